@@ -0,0 +1,260 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ju4n97/relic/internal/backend"
+	"github.com/ju4n97/relic/internal/backend/llama"
+	"github.com/ju4n97/relic/internal/mapsafe"
+	"github.com/ju4n97/relic/internal/model"
+	"github.com/ju4n97/relic/internal/service"
+	inferencev2 "github.com/ju4n97/relic/sdk-go/pb/inference/v2"
+)
+
+// roleNames maps the chat roles to the names backends use.
+var roleNames = map[inferencev2.Role]string{
+	inferencev2.Role_ROLE_SYSTEM:    "system",
+	inferencev2.Role_ROLE_USER:      "user",
+	inferencev2.Role_ROLE_ASSISTANT: "assistant",
+	inferencev2.Role_ROLE_TOOL:      "tool",
+	inferencev2.Role_ROLE_DEVELOPER: "developer",
+}
+
+// ChatServer implements inferencev2.ChatServiceServer.
+type ChatServer struct {
+	inferencev2.UnimplementedChatServiceServer
+	service *service.LLM
+	models  *model.Registry
+}
+
+// NewChatServer creates a new ChatServer instance.
+func NewChatServer(svc *service.LLM, models *model.Registry) *ChatServer {
+	return &ChatServer{
+		service: svc,
+		models:  models,
+	}
+}
+
+// Chat generates the reply of the assistant.
+func (s *ChatServer) Chat(ctx context.Context, req *inferencev2.ChatRequest) (*inferencev2.ChatResponse, error) {
+	breq, err := buildChatRequest(req)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+	}
+
+	resp, err := s.service.Generate(ctx, llama.BackendName, req.ModelId, req.Profile, breq)
+	if err != nil {
+		return nil, mapBackendError(err)
+	}
+
+	var sb strings.Builder
+	if _, err := io.Copy(&sb, resp.Output); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read output: %v", err)
+	}
+
+	out := &inferencev2.ChatResponse{
+		Message: &inferencev2.ChatMessage{
+			Role:    inferencev2.Role_ROLE_ASSISTANT,
+			Content: sb.String(),
+		},
+		Metadata: buildResponseMetadata(resp.Metadata, resolveModel(s.models, model.TypeLLM, req.ModelId)),
+	}
+	if resp.Metadata != nil {
+		if completion, ok := chatCompletionOf(resp.Metadata.BackendSpecific); ok {
+			out.FinishReason = completion.FinishReason()
+			out.Timings = buildTimings(completion.Timings)
+		}
+	}
+
+	return out, nil
+}
+
+// ChatStream generates the reply of the assistant, streaming it as it is produced.
+// The last chunk carries the metadata of the reply.
+func (s *ChatServer) ChatStream(req *inferencev2.ChatRequest, stream inferencev2.ChatService_ChatStreamServer) error {
+	ctx := stream.Context()
+
+	breq, err := buildChatRequest(req)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+	}
+
+	start := time.Now()
+
+	chunks, err := s.service.GenerateStream(ctx, llama.BackendName, req.ModelId, req.Profile, breq)
+	if err != nil {
+		return mapBackendError(err)
+	}
+
+	for chunk := range chunks {
+		if chunk.Error != nil {
+			return status.Errorf(codes.Unknown, "inference error: %v", chunk.Error)
+		}
+
+		out := &inferencev2.ChatChunk{Delta: string(chunk.Data), Done: chunk.Done}
+		if chunk.Done {
+			out.Metadata = buildResponseMetadata(&backend.ResponseMetadata{
+				Provider:        llama.BackendName,
+				Timestamp:       time.Now(),
+				DurationSeconds: time.Since(start).Seconds(),
+				Usage:           chunk.Usage,
+			}, resolveModel(s.models, model.TypeLLM, req.ModelId))
+			if completion, ok := chatCompletionOf(chunk.BackendSpecific); ok {
+				out.FinishReason = completion.FinishReason()
+				out.Timings = buildTimings(completion.Timings)
+			}
+		}
+
+		if err := stream.Send(out); err != nil {
+			return status.Errorf(codes.Internal, "failed to send chunk: %v", err)
+		}
+
+		if chunk.Done {
+			break
+		}
+	}
+
+	return nil
+}
+
+// buildChatRequest converts a chat request to a backend request.
+func buildChatRequest(req *inferencev2.ChatRequest) (*backend.Request, error) {
+	if len(req.Messages) == 0 {
+		return nil, errors.New("messages is required")
+	}
+
+	messages, err := buildMessages(req.Messages)
+	if err != nil {
+		return nil, err
+	}
+
+	// The sampling parameters take precedence over the passed through ones.
+	parameters, err := parseParameters(req.Parameters)
+	if err != nil {
+		return nil, fmt.Errorf("parameters: %w", err)
+	}
+	maps.Copy(parameters, samplingParameters(req.Sampling))
+
+	return &backend.Request{
+		Messages:   messages,
+		Parameters: parameters,
+	}, nil
+}
+
+// buildMessages converts chat messages to backend messages.
+func buildMessages(msgs []*inferencev2.ChatMessage) ([]backend.Message, error) {
+	messages := make([]backend.Message, 0, len(msgs))
+	for i, msg := range msgs {
+		role, ok := roleNames[msg.Role]
+		if !ok {
+			return nil, fmt.Errorf("messages[%d]: unknown role %s", i, msg.Role)
+		}
+		messages = append(messages, backend.Message{Role: role, Content: msg.Content})
+	}
+
+	return messages, nil
+}
+
+// samplingParameters returns the backend parameters of the sampling options that
+// are set.
+func samplingParameters(p *inferencev2.SamplingParams) map[string]any {
+	parameters := map[string]any{}
+	if p == nil {
+		return parameters
+	}
+
+	if p.MaxTokens != nil {
+		parameters["n_predict"] = int(*p.MaxTokens)
+	}
+	if p.Temperature != nil {
+		parameters["temperature"] = *p.Temperature
+	}
+	if p.TopK != nil {
+		parameters["top_k"] = int(*p.TopK)
+	}
+	if p.TopP != nil {
+		parameters["top_p"] = *p.TopP
+	}
+	if p.MinP != nil {
+		parameters["min_p"] = *p.MinP
+	}
+	if p.RepeatPenalty != nil {
+		parameters["repeat_penalty"] = *p.RepeatPenalty
+	}
+	if p.PresencePenalty != nil {
+		parameters["presence_penalty"] = *p.PresencePenalty
+	}
+	if p.FrequencyPenalty != nil {
+		parameters["frequency_penalty"] = *p.FrequencyPenalty
+	}
+
+	return parameters
+}
+
+// chatCompletionOf returns the llama-server response a reply was built from.
+func chatCompletionOf(backendSpecific map[string]any) (llama.ChatCompletionResponse, bool) {
+	completion, ok := backendSpecific["response"].(llama.ChatCompletionResponse)
+	return completion, ok
+}
+
+// buildTimings converts the timings llama-server reports to protobuf, or returns
+// nil if it reported none.
+func buildTimings(timings map[string]any) *inferencev2.Timings {
+	if len(timings) == 0 {
+		return nil
+	}
+
+	return &inferencev2.Timings{
+		PromptSeconds:            mapsafe.Get(timings, "prompt_ms", 0.0) / 1000,
+		GenerationSeconds:        mapsafe.Get(timings, "predicted_ms", 0.0) / 1000,
+		PromptTokensPerSecond:    mapsafe.Get(timings, "prompt_per_second", 0.0),
+		GeneratedTokensPerSecond: mapsafe.Get(timings, "predicted_per_second", 0.0),
+	}
+}
+
+// buildResponseMetadata converts backend metadata to the typed metadata of the
+// v2 services.
+func buildResponseMetadata(meta *backend.ResponseMetadata, modelID string) *inferencev2.ResponseMetadata {
+	if meta == nil {
+		return nil
+	}
+
+	metadata := &inferencev2.ResponseMetadata{
+		Backend:         meta.Provider,
+		ModelId:         modelID,
+		Timestamp:       timestamppb.New(meta.Timestamp),
+		DurationSeconds: meta.DurationSeconds,
+	}
+	if u := meta.Usage; u != nil {
+		metadata.Usage = &inferencev2.Usage{
+			PromptTokens:    int32(u.PromptTokens),
+			GeneratedTokens: int32(u.GeneratedTokens),
+			TokensPerSecond: u.TokensPerSecond,
+			AudioSeconds:    u.AudioSeconds,
+			Characters:      int32(u.Characters),
+		}
+	}
+
+	return metadata
+}
+
+// resolveModel returns the ID of the model a request for the service names, or
+// "" if it cannot be resolved.
+func resolveModel(models *model.Registry, service model.Type, name string) string {
+	modelID, err := models.Routes().Resolve(service, name)
+	if err != nil {
+		return ""
+	}
+
+	return modelID
+}
@@ -0,0 +1,237 @@
+package grpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	relicgrpc "github.com/ju4n97/relic/api/grpc"
+	"github.com/ju4n97/relic/internal/backend/llama/llamatest"
+	"github.com/ju4n97/relic/internal/config"
+	"github.com/ju4n97/relic/internal/service"
+	inferencev2 "github.com/ju4n97/relic/sdk-go/pb/inference/v2"
+)
+
+// completion is the reply of the stub llama-server to chat completions.
+const completion = `{
+	"choices": [{"index": 0, "message": {"role": "assistant", "content": "Hello!"}, "finish_reason": "length"}],
+	"usage": {"prompt_tokens": 12, "completion_tokens": 3, "total_tokens": 15},
+	"timings": {"prompt_n": 12, "prompt_ms": 250, "prompt_per_second": 48, "predicted_n": 3, "predicted_ms": 1500, "predicted_per_second": 2}
+}`
+
+// newChatClient returns a client of a ChatServer whose llama.cpp backend is
+// served by a stub llama-server with the handlers of mux.
+func newChatClient(t *testing.T, mux *http.ServeMux) inferencev2.ChatServiceClient {
+	t.Helper()
+
+	manager := newManager(t, map[string]config.ModelConfig{
+		"qwen": {Type: "llm", Backend: "llama.cpp", Aliases: []string{"chat"}},
+	})
+	backends := newBackends(t, llamatest.NewBackend(t, mux))
+	models := manager.Registry()
+
+	conn := dial(t, func(server *grpc.Server) {
+		inferencev2.RegisterChatServiceServer(server, relicgrpc.NewChatServer(service.NewLLM(backends, models), models))
+	})
+
+	return inferencev2.NewChatServiceClient(conn)
+}
+
+func TestChatServer_Chat(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(completion))
+	})
+	client := newChatClient(t, mux)
+
+	resp, err := client.Chat(context.Background(), &inferencev2.ChatRequest{
+		ModelId:  "chat",
+		Messages: []*inferencev2.ChatMessage{{Role: inferencev2.Role_ROLE_USER, Content: "Hi"}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, inferencev2.Role_ROLE_ASSISTANT, resp.Message.Role)
+	assert.Equal(t, "Hello!", resp.Message.Content)
+	assert.Equal(t, "length", resp.FinishReason)
+
+	require.NotNil(t, resp.Timings)
+	assert.InDelta(t, 0.25, resp.Timings.PromptSeconds, 1e-9)
+	assert.InDelta(t, 1.5, resp.Timings.GenerationSeconds, 1e-9)
+	assert.InDelta(t, 48, resp.Timings.PromptTokensPerSecond, 1e-9)
+	assert.InDelta(t, 2, resp.Timings.GeneratedTokensPerSecond, 1e-9)
+
+	require.NotNil(t, resp.Metadata)
+	assert.Equal(t, "qwen", resp.Metadata.ModelId)
+	assert.Equal(t, "llama.cpp", resp.Metadata.Backend)
+	require.NotNil(t, resp.Metadata.Usage)
+	assert.EqualValues(t, 12, resp.Metadata.Usage.PromptTokens)
+	assert.EqualValues(t, 3, resp.Metadata.Usage.GeneratedTokens)
+}
+
+func TestChatServer_Chat_Parameters(t *testing.T) {
+	var body map[string]any
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_, _ = w.Write([]byte(completion))
+	})
+	client := newChatClient(t, mux)
+
+	parameters, err := structpb.NewStruct(map[string]any{"cache_prompt": false, "seed": 42, "n_predict": 1, "id_slot": 3})
+	require.NoError(t, err)
+
+	_, err = client.Chat(context.Background(), &inferencev2.ChatRequest{
+		Messages:   []*inferencev2.ChatMessage{{Role: inferencev2.Role_ROLE_USER, Content: "Hi"}},
+		Sampling:   &inferencev2.SamplingParams{MaxTokens: proto.Int32(64)},
+		Parameters: parameters,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, false, body["cache_prompt"], "parameters without a field are passed through")
+	assert.EqualValues(t, 42, body["seed"], "parameters without a field are passed through")
+	assert.EqualValues(t, 64, body["n_predict"], "sampling parameters take precedence")
+	assert.EqualValues(t, -1, body["id_slot"], "the slot is picked by the backend")
+}
+
+func TestChatServer_Chat_Request(t *testing.T) {
+	var body struct {
+		Messages []struct {
+			Role    string `json:"role"`
+			Content string `json:"content"`
+		} `json:"messages"`
+		NPredict         int     `json:"n_predict"`
+		Temperature      float64 `json:"temperature"`
+		TopK             int     `json:"top_k"`
+		TopP             float64 `json:"top_p"`
+		MinP             float64 `json:"min_p"`
+		RepeatPenalty    float64 `json:"repeat_penalty"`
+		PresencePenalty  float64 `json:"presence_penalty"`
+		FrequencyPenalty float64 `json:"frequency_penalty"`
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_, _ = w.Write([]byte(completion))
+	})
+	client := newChatClient(t, mux)
+
+	_, err := client.Chat(context.Background(), &inferencev2.ChatRequest{
+		Messages: []*inferencev2.ChatMessage{
+			{Role: inferencev2.Role_ROLE_SYSTEM, Content: "Be brief."},
+			{Role: inferencev2.Role_ROLE_DEVELOPER, Content: "Answer in English."},
+			{Role: inferencev2.Role_ROLE_USER, Content: "Weather?"},
+			{Role: inferencev2.Role_ROLE_ASSISTANT, Content: "Let me check."},
+			{Role: inferencev2.Role_ROLE_TOOL, Content: "Sunny"},
+		},
+		Sampling: &inferencev2.SamplingParams{
+			MaxTokens:        proto.Int32(64),
+			Temperature:      proto.Float64(0.3),
+			TopK:             proto.Int32(20),
+			TopP:             proto.Float64(0.8),
+			MinP:             proto.Float64(0.1),
+			RepeatPenalty:    proto.Float64(1.2),
+			PresencePenalty:  proto.Float64(0.5),
+			FrequencyPenalty: proto.Float64(0.25),
+		},
+	})
+	require.NoError(t, err)
+
+	var roles []string
+	for _, msg := range body.Messages {
+		roles = append(roles, msg.Role)
+	}
+	assert.Equal(t, []string{"system", "developer", "user", "assistant", "tool"}, roles)
+	assert.Equal(t, "Sunny", body.Messages[4].Content)
+
+	assert.Equal(t, 64, body.NPredict)
+	assert.InDelta(t, 0.3, body.Temperature, 1e-9)
+	assert.Equal(t, 20, body.TopK)
+	assert.InDelta(t, 0.8, body.TopP, 1e-9)
+	assert.InDelta(t, 0.1, body.MinP, 1e-9)
+	assert.InDelta(t, 1.2, body.RepeatPenalty, 1e-9)
+	assert.InDelta(t, 0.5, body.PresencePenalty, 1e-9)
+	assert.InDelta(t, 0.25, body.FrequencyPenalty, 1e-9)
+}
+
+func TestChatServer_ChatStream(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, event := range []string{
+			`{"choices": [{"index": 0, "delta": {"content": "Hel"}}]}`,
+			`{"choices": [{"index": 0, "delta": {"content": "lo!"}}]}`,
+			`{"choices": [{"index": 0, "delta": {}, "finish_reason": "stop"}], "usage": {"prompt_tokens": 12, "completion_tokens": 2}, "timings": {"predicted_ms": 500, "predicted_per_second": 4}}`,
+		} {
+			_, _ = io.WriteString(w, "data: "+event+"\n\n")
+		}
+	})
+	client := newChatClient(t, mux)
+
+	stream, err := client.ChatStream(context.Background(), &inferencev2.ChatRequest{
+		ModelId:  "chat",
+		Messages: []*inferencev2.ChatMessage{{Role: inferencev2.Role_ROLE_USER, Content: "Hi"}},
+	})
+	require.NoError(t, err)
+
+	var (
+		text string
+		last *inferencev2.ChatChunk
+	)
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		text += chunk.Delta
+		last = chunk
+	}
+
+	assert.Equal(t, "Hello!", text)
+	require.NotNil(t, last)
+	assert.True(t, last.Done)
+	assert.Equal(t, "stop", last.FinishReason)
+	require.NotNil(t, last.Timings)
+	assert.InDelta(t, 0.5, last.Timings.GenerationSeconds, 1e-9)
+	require.NotNil(t, last.Metadata)
+	assert.Equal(t, "qwen", last.Metadata.ModelId)
+	assert.EqualValues(t, 2, last.Metadata.Usage.GeneratedTokens)
+}
+
+func TestChatServer_Chat_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /chat/completions", func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	})
+	client := newChatClient(t, mux)
+
+	hi := []*inferencev2.ChatMessage{{Role: inferencev2.Role_ROLE_USER, Content: "Hi"}}
+	tests := []struct {
+		name string
+		req  *inferencev2.ChatRequest
+		want codes.Code
+	}{
+		{"no messages", &inferencev2.ChatRequest{}, codes.InvalidArgument},
+		{"unspecified role", &inferencev2.ChatRequest{Messages: []*inferencev2.ChatMessage{{Content: "Hi"}}}, codes.InvalidArgument},
+		{"unknown model", &inferencev2.ChatRequest{ModelId: "missing", Messages: hi}, codes.NotFound},
+		{"unknown profile", &inferencev2.ChatRequest{Profile: "missing", Messages: hi}, codes.InvalidArgument},
+		{"backend failure", &inferencev2.ChatRequest{Messages: hi}, codes.Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.Chat(context.Background(), tt.req)
+			assert.Equal(t, tt.want, status.Code(err), err)
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ju4n97/relic/internal/backend/llama"
+	"github.com/ju4n97/relic/internal/model"
+	"github.com/ju4n97/relic/internal/service"
+	inferencev2 "github.com/ju4n97/relic/sdk-go/pb/inference/v2"
+)
+
+// EmbeddingServer implements inferencev2.EmbeddingServiceServer.
+type EmbeddingServer struct {
+	inferencev2.UnimplementedEmbeddingServiceServer
+	service *service.Embedding
+	models  *model.Registry
+}
+
+// NewEmbeddingServer creates a new EmbeddingServer instance.
+func NewEmbeddingServer(svc *service.Embedding, models *model.Registry) *EmbeddingServer {
+	return &EmbeddingServer{
+		service: svc,
+		models:  models,
+	}
+}
+
+// Embed computes the embedding of each input.
+func (s *EmbeddingServer) Embed(ctx context.Context, req *inferencev2.EmbedRequest) (*inferencev2.EmbedResponse, error) {
+	if len(req.Inputs) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid request: inputs is required")
+	}
+
+	vectors, metadata, err := s.service.Embed(ctx, llama.BackendName, req.ModelId, req.Profile, req.Inputs)
+	if err != nil {
+		return nil, mapBackendError(err)
+	}
+
+	embeddings := make([]*inferencev2.Embedding, 0, len(vectors))
+	for i, vector := range vectors {
+		embeddings = append(embeddings, &inferencev2.Embedding{
+			Index:  int32(i),
+			Values: vector,
+		})
+	}
+
+	return &inferencev2.EmbedResponse{
+		Embeddings: embeddings,
+		Metadata:   buildResponseMetadata(metadata, resolveModel(s.models, model.TypeLLM, req.ModelId)),
+	}, nil
+}
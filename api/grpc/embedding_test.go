@@ -0,0 +1,69 @@
+package grpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	relicgrpc "github.com/ju4n97/relic/api/grpc"
+	"github.com/ju4n97/relic/internal/backend/llama/llamatest"
+	"github.com/ju4n97/relic/internal/config"
+	"github.com/ju4n97/relic/internal/service"
+	inferencev2 "github.com/ju4n97/relic/sdk-go/pb/inference/v2"
+)
+
+func TestEmbeddingServer_Embed(t *testing.T) {
+	var inputs []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/embeddings", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Input []string `json:"input"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		inputs = body.Input
+
+		// Out of order, as the server may answer.
+		_, _ = w.Write([]byte(`{
+			"data": [{"index": 1, "embedding": [0.3, 0.4]}, {"index": 0, "embedding": [0.1, 0.2]}],
+			"usage": {"prompt_tokens": 7}
+		}`))
+	})
+
+	manager := newManager(t, map[string]config.ModelConfig{
+		"qwen": {Type: "llm", Backend: "llama.cpp"},
+	})
+	backends := newBackends(t, llamatest.NewBackend(t, mux))
+	models := manager.Registry()
+
+	conn := dial(t, func(server *grpc.Server) {
+		inferencev2.RegisterEmbeddingServiceServer(server, relicgrpc.NewEmbeddingServer(service.NewEmbedding(backends, models), models))
+	})
+	client := inferencev2.NewEmbeddingServiceClient(conn)
+	ctx := context.Background()
+
+	resp, err := client.Embed(ctx, &inferencev2.EmbedRequest{Inputs: []string{"first", "second"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"first", "second"}, inputs)
+	require.Len(t, resp.Embeddings, 2)
+	for i, want := range [][]float32{{0.1, 0.2}, {0.3, 0.4}} {
+		assert.EqualValues(t, i, resp.Embeddings[i].Index)
+		assert.Equal(t, want, resp.Embeddings[i].Values)
+	}
+	require.NotNil(t, resp.Metadata)
+	assert.Equal(t, "qwen", resp.Metadata.ModelId)
+	assert.EqualValues(t, 7, resp.Metadata.Usage.PromptTokens)
+
+	_, err = client.Embed(ctx, &inferencev2.EmbedRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.Embed(ctx, &inferencev2.EmbedRequest{ModelId: "missing", Inputs: []string{"first"}})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
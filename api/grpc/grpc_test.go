@@ -0,0 +1,104 @@
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/ju4n97/relic/internal/backend"
+	"github.com/ju4n97/relic/internal/config"
+	"github.com/ju4n97/relic/internal/model"
+)
+
+// newManager returns a model manager with a cached model of each config, keyed
+// by model ID and assigned to the service of its type.
+func newManager(t *testing.T, models map[string]config.ModelConfig) *model.Manager {
+	t.Helper()
+	t.Setenv("RELIC_MODELS_PATH", "")
+
+	modelsDir := t.TempDir()
+	cfg := &config.Config{
+		Storage: config.StorageConfig{ModelsDir: modelsDir},
+		Models:  map[string]config.ModelConfig{},
+	}
+
+	for modelID, modelConfig := range models {
+		path := filepath.Join(modelsDir, "org", modelID, modelID+".bin")
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(modelID), 0o644))
+
+		modelConfig.SetHuggingFaceSource(config.HuggingFaceSource{Repo: "org/" + modelID, Include: []string{modelID + ".bin"}})
+		cfg.Models[modelID] = modelConfig
+
+		switch modelConfig.Type {
+		case "llm":
+			cfg.Services.LLM.Models = append(cfg.Services.LLM.Models, modelID)
+		case "stt":
+			cfg.Services.STT.Models = append(cfg.Services.STT.Models, modelID)
+		case "tts":
+			cfg.Services.TTS.Models = append(cfg.Services.TTS.Models, modelID)
+		}
+	}
+
+	manager := model.NewManager(model.WithOffline(true))
+	t.Cleanup(manager.Close)
+	require.NoError(t, manager.LoadModelsFromConfig(context.Background(), cfg))
+
+	return manager
+}
+
+// fakeBackend is a backend answering requests with infer.
+type fakeBackend struct {
+	infer    func(req *backend.Request) (*backend.Response, error)
+	provider string
+}
+
+func (b *fakeBackend) Provider() string { return b.provider }
+
+func (b *fakeBackend) Infer(_ context.Context, req *backend.Request) (*backend.Response, error) {
+	return b.infer(req)
+}
+
+func (b *fakeBackend) Close() error { return nil }
+
+// newBackends returns a registry of backends.
+func newBackends(t *testing.T, backends ...backend.Backend) *backend.Registry {
+	t.Helper()
+
+	registry := backend.NewRegistry()
+	for _, b := range backends {
+		require.NoError(t, registry.Register(b))
+	}
+
+	return registry
+}
+
+// dial serves the services registered by register over an in-memory listener
+// and returns a connection to them.
+func dial(t *testing.T, register func(*grpc.Server)) *grpc.ClientConn {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	register(server)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
@@ -5,16 +5,24 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/ju4n97/relic/internal/auth"
 	"github.com/ju4n97/relic/internal/backend"
+	"github.com/ju4n97/relic/internal/config"
+	"github.com/ju4n97/relic/internal/limits"
 	"github.com/ju4n97/relic/internal/model"
+	"github.com/ju4n97/relic/internal/service"
+	"github.com/ju4n97/relic/internal/tracing"
 	inferencev1 "github.com/ju4n97/relic/sdk-go/pb/inference/v1"
 )
 
@@ -44,20 +52,26 @@ func (s *InferenceServer) Infer(ctx context.Context, req *inferencev1.InferenceR
 		return nil, status.Errorf(codes.NotFound, "backend not found: %s", req.Provider)
 	}
 
-	m, ok := s.models.Get(req.ModelId)
-	if !ok {
-		return nil, status.Errorf(codes.NotFound, "model not found: %s", req.ModelId)
+	m, err := s.acquire(ctx, req)
+	if errors.Is(err, model.ErrNotFound) {
+		return nil, modelNotFound(fmt.Sprintf("model not found: %s", req.ModelId))
 	}
+	if err != nil {
+		return nil, mapBackendError(err)
+	}
+	defer m.Release()
 
 	parameters, err := parseParameters(req.Parameters)
 	if err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "failed to parse parameters: %v", err)
 	}
 
-	breq := &backend.Request{
-		ModelPath:  m.Path,
+	breq, err := service.BackendRequest(m, req.Profile, &backend.Request{
 		Input:      bytes.NewReader(req.Input),
 		Parameters: parameters,
+	})
+	if err != nil {
+		return nil, mapBackendError(err)
 	}
 
 	resp, err := b.Infer(ctx, breq)
@@ -99,20 +113,26 @@ func (s *InferenceServer) InferStream(stream inferencev1.InferenceService_InferS
 		return status.Errorf(codes.Unimplemented, "backend %s does not support streaming", req.Provider)
 	}
 
-	m, ok := s.models.Get(req.ModelId)
-	if !ok {
-		return status.Errorf(codes.NotFound, "model not found: %s", req.ModelId)
+	m, err := s.acquire(ctx, req)
+	if errors.Is(err, model.ErrNotFound) {
+		return modelNotFound(fmt.Sprintf("model not found: %s", req.ModelId))
 	}
+	if err != nil {
+		return mapBackendError(err)
+	}
+	defer m.Release()
 
 	parameters, err := parseParameters(req.Parameters)
 	if err != nil {
 		return status.Errorf(codes.InvalidArgument, "failed to parse parameters: %v", err)
 	}
 
-	breq := &backend.Request{
-		ModelPath:  m.Path,
+	breq, err := service.BackendRequest(m, req.Profile, &backend.Request{
 		Input:      bytes.NewReader(req.Input),
 		Parameters: parameters,
+	})
+	if err != nil {
+		return mapBackendError(err)
 	}
 
 	chunkChan, err := sb.InferStream(ctx, breq)
@@ -148,15 +168,43 @@ func (s *InferenceServer) InferStream(stream inferencev1.InferenceService_InferS
 	return nil
 }
 
+// acquire returns the model a request should use. The service is derived from
+// the provider, so an empty model ID selects the default model of that service.
+// Callers must release the instance once done. The resolved model is recorded on
+// the span of the call.
+func (s *InferenceServer) acquire(ctx context.Context, req *inferencev1.InferenceRequest) (*model.Instance, error) {
+	service := model.Type(config.BackendType(req.Provider))
+
+	m, err := s.models.Acquire(service, req.ModelId)
+	if err != nil {
+		return nil, err
+	}
+	trace.SpanFromContext(ctx).SetAttributes(
+		tracing.Service.String(string(service)),
+		tracing.Backend.String(req.Provider),
+		tracing.ModelID.String(m.ID),
+		tracing.Profile.String(req.Profile),
+		tracing.InputBytes.Int(len(req.Input)),
+	)
+
+	if m.Config.Backend != req.Provider {
+		m.Release()
+		return nil, status.Errorf(codes.InvalidArgument, "model %s runs on %s, not %s", m.ID, m.Config.Backend, req.Provider)
+	}
+
+	if err := auth.CheckModel(ctx, string(service), m.ID); err != nil {
+		m.Release()
+		return nil, err
+	}
+
+	return m, nil
+}
+
 // validateInferenceRequest validates the inference request.
 func validateInferenceRequest(req *inferencev1.InferenceRequest) error {
 	if req.Provider == "" {
 		return errors.New("provider is required")
 	}
-	if req.ModelId == "" {
-		return errors.New("model_id is required")
-	}
-
 	return nil
 }
 
@@ -205,6 +253,27 @@ func parseParameters(params *structpb.Struct) (map[string]any, error) {
 	return native, nil
 }
 
+// ReasonModelNotFound is the reason of the ErrorInfo detail of NOT_FOUND errors
+// for models the server does not know or does not serve, which tells them apart
+// from other missing resources, e.g. backends or pull jobs.
+const ReasonModelNotFound = "MODEL_NOT_FOUND"
+
+// errorDomain is the domain of the ErrorInfo details of the server.
+const errorDomain = "relic"
+
+// modelNotFound returns a NOT_FOUND status error for a missing model, with an
+// ErrorInfo detail of reason ReasonModelNotFound.
+func modelNotFound(msg string) error {
+	st := status.New(codes.NotFound, msg)
+
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: ReasonModelNotFound, Domain: errorDomain})
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
 // mapBackendError converts backend errors to appropriate gRPC status codes.
 func mapBackendError(err error) error {
 	if err == nil {
@@ -214,8 +283,18 @@ func mapBackendError(err error) error {
 	switch {
 	case errors.Is(err, backend.ErrNotFound):
 		return status.Error(codes.NotFound, err.Error())
-	case errors.Is(err, model.ErrNotFound):
-		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, model.ErrNotFound), errors.Is(err, model.ErrNotAssigned):
+		return modelNotFound(err.Error())
+	case errors.Is(err, model.ErrNoDefault), errors.Is(err, model.ErrBackendMismatch), errors.Is(err, model.ErrProfileNotFound):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, model.ErrNotCached):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, model.ErrUnavailable):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, auth.ErrForbidden):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, limits.ErrRateLimited), errors.Is(err, limits.ErrQuotaExceeded):
+		return status.Convert(err).Err()
 	default:
 		if _, ok := status.FromError(err); ok {
 			return err
@@ -0,0 +1,177 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ju4n97/relic/internal/model"
+	inferencev1 "github.com/ju4n97/relic/sdk-go/pb/inference/v1"
+)
+
+// ModelServer implements inferencev1.ModelServiceServer.
+type ModelServer struct {
+	inferencev1.UnimplementedModelServiceServer
+	manager *model.Manager
+}
+
+// NewModelServer creates a new ModelServer instance.
+func NewModelServer(manager *model.Manager) *ModelServer {
+	return &ModelServer{
+		manager: manager,
+	}
+}
+
+// ListModels lists configured models and their status.
+func (s *ModelServer) ListModels(ctx context.Context, req *inferencev1.ListModelsRequest) (*inferencev1.ListModelsResponse, error) {
+	instances := sortedInstances(s.manager.Registry())
+
+	models := make([]*inferencev1.ModelInfo, 0, len(instances))
+	for _, instance := range instances {
+		info := &inferencev1.ModelInfo{
+			Id:      instance.ID,
+			Type:    instance.Config.Type,
+			Backend: instance.Config.Backend,
+			Status:  string(instance.Status),
+			Error:   instance.Error,
+			Tags:    instance.Config.Tags,
+			Order:   int32(instance.Config.Order),
+		}
+		if instance.Job != nil {
+			info.Pull = buildPullProgress(instance.Job.Status())
+		}
+		for _, profile := range instance.Profiles {
+			info.Profiles = append(info.Profiles, &inferencev1.ProfileInfo{
+				Name:        profile.Name,
+				DisplayName: profile.DisplayName,
+				Description: profile.Description,
+				ComputeType: profile.ComputeType,
+			})
+		}
+
+		models = append(models, info)
+	}
+
+	return &inferencev1.ListModelsResponse{Models: models}, nil
+}
+
+// PullModel starts (or attaches to) a model download and streams its progress.
+func (s *ModelServer) PullModel(req *inferencev1.PullModelRequest, stream inferencev1.ModelService_PullModelServer) error {
+	if req.ModelId == "" {
+		return status.Error(codes.InvalidArgument, "invalid request: model_id is required")
+	}
+
+	ctx := stream.Context()
+
+	job, err := s.manager.Pull(ctx, req.ModelId)
+	if err != nil {
+		return mapModelError(err)
+	}
+
+	updates, unsubscribe := job.Subscribe()
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case update, ok := <-updates:
+			if !ok {
+				return nil
+			}
+
+			if err := stream.Send(buildPullProgress(update)); err != nil {
+				return status.Errorf(codes.Internal, "failed to send progress: %v", err)
+			}
+		}
+	}
+}
+
+// CancelPull cancels a running download.
+func (s *ModelServer) CancelPull(ctx context.Context, req *inferencev1.CancelPullRequest) (*inferencev1.CancelPullResponse, error) {
+	job, ok := s.manager.PullJob(req.JobId)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "pull job not found: %s", req.JobId)
+	}
+
+	job.Cancel()
+
+	select {
+	case <-job.Done():
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+
+	return &inferencev1.CancelPullResponse{Pull: buildPullProgress(job.Status())}, nil
+}
+
+// sortedInstances returns the models of a registry sorted by type, display order
+// and ID.
+func sortedInstances(registry *model.Registry) []*model.Instance {
+	instances := registry.List()
+	slices.SortFunc(instances, func(a, b *model.Instance) int {
+		if c := strings.Compare(a.Config.Type, b.Config.Type); c != 0 {
+			return c
+		}
+		if a.Config.Order != b.Config.Order {
+			return a.Config.Order - b.Config.Order
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return instances
+}
+
+// buildPullProgress converts a pull job status to protobuf.
+func buildPullProgress(s model.PullStatus) *inferencev1.PullProgress {
+	progress := &inferencev1.PullProgress{
+		JobId:              s.ID,
+		ModelId:            s.ModelID,
+		State:              string(s.State),
+		BytesDone:          s.BytesDone,
+		BytesTotal:         s.BytesTotal,
+		RateBytesPerSecond: s.RateBytesPerSec,
+		EtaSeconds:         s.ETASeconds,
+		Error:              s.Error,
+		Files:              make([]*inferencev1.FileProgress, 0, len(s.Files)),
+	}
+
+	if s.StartedAt != nil {
+		progress.StartedAt = timestamppb.New(*s.StartedAt)
+	}
+	if s.FinishedAt != nil {
+		progress.FinishedAt = timestamppb.New(*s.FinishedAt)
+	}
+
+	for _, f := range s.Files {
+		progress.Files = append(progress.Files, &inferencev1.FileProgress{
+			Path:       f.Path,
+			State:      string(f.State),
+			BytesDone:  f.BytesDone,
+			BytesTotal: f.BytesTotal,
+		})
+	}
+
+	return progress
+}
+
+// mapModelError converts model manager errors to appropriate gRPC status codes.
+func mapModelError(err error) error {
+	switch {
+	case errors.Is(err, model.ErrNotFound):
+		return modelNotFound(err.Error())
+	case errors.Is(err, model.ErrPullNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, model.ErrOffline), errors.Is(err, model.ErrNotCached), errors.Is(err, model.ErrPullInProgress):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, model.ErrNoConfig):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "model error: %v", err)
+	}
+}
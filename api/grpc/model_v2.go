@@ -0,0 +1,220 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ju4n97/relic/internal/config/source"
+	"github.com/ju4n97/relic/internal/model"
+	inferencev2 "github.com/ju4n97/relic/sdk-go/pb/inference/v2"
+)
+
+var (
+	// modelTypes maps the model types to protobuf.
+	modelTypes = map[model.Type]inferencev2.ModelType{
+		model.TypeLLM: inferencev2.ModelType_MODEL_TYPE_LLM,
+		model.TypeNLU: inferencev2.ModelType_MODEL_TYPE_NLU,
+		model.TypeSTT: inferencev2.ModelType_MODEL_TYPE_STT,
+		model.TypeTTS: inferencev2.ModelType_MODEL_TYPE_TTS,
+	}
+
+	// modelStatuses maps the model statuses to protobuf.
+	modelStatuses = map[model.Status]inferencev2.ModelStatus{
+		model.StatusUnloaded:    inferencev2.ModelStatus_MODEL_STATUS_UNLOADED,
+		model.StatusLoading:     inferencev2.ModelStatus_MODEL_STATUS_LOADING,
+		model.StatusLoaded:      inferencev2.ModelStatus_MODEL_STATUS_LOADED,
+		model.StatusFailed:      inferencev2.ModelStatus_MODEL_STATUS_FAILED,
+		model.StatusUnloading:   inferencev2.ModelStatus_MODEL_STATUS_UNLOADING,
+		model.StatusDownloading: inferencev2.ModelStatus_MODEL_STATUS_DOWNLOADING,
+		model.StatusNotCached:   inferencev2.ModelStatus_MODEL_STATUS_NOT_CACHED,
+	}
+
+	// pullStates maps the download job states to protobuf.
+	pullStates = map[model.PullState]inferencev2.PullState{
+		model.PullStateQueued:    inferencev2.PullState_PULL_STATE_QUEUED,
+		model.PullStateRunning:   inferencev2.PullState_PULL_STATE_RUNNING,
+		model.PullStateCompleted: inferencev2.PullState_PULL_STATE_COMPLETED,
+		model.PullStateFailed:    inferencev2.PullState_PULL_STATE_FAILED,
+		model.PullStateCanceled:  inferencev2.PullState_PULL_STATE_CANCELED,
+	}
+
+	// fileStates maps the download file states to protobuf.
+	fileStates = map[source.FileState]inferencev2.FileState{
+		source.FileStatePending:     inferencev2.FileState_FILE_STATE_PENDING,
+		source.FileStateDownloading: inferencev2.FileState_FILE_STATE_DOWNLOADING,
+		source.FileStateCompleted:   inferencev2.FileState_FILE_STATE_COMPLETED,
+	}
+)
+
+// ModelServerV2 implements inferencev2.ModelServiceServer.
+type ModelServerV2 struct {
+	inferencev2.UnimplementedModelServiceServer
+	manager *model.Manager
+}
+
+// NewModelServerV2 creates a new ModelServerV2 instance.
+func NewModelServerV2(manager *model.Manager) *ModelServerV2 {
+	return &ModelServerV2{
+		manager: manager,
+	}
+}
+
+// ListModels lists configured models and their status.
+func (s *ModelServerV2) ListModels(ctx context.Context, req *inferencev2.ListModelsRequest) (*inferencev2.ListModelsResponse, error) {
+	registry := s.manager.Registry()
+
+	var models []*inferencev2.Model
+	for _, instance := range sortedInstances(registry) {
+		info := buildModel(registry, instance)
+		if req.Type != inferencev2.ModelType_MODEL_TYPE_UNSPECIFIED && info.Type != req.Type {
+			continue
+		}
+
+		models = append(models, info)
+	}
+
+	return &inferencev2.ListModelsResponse{Models: models}, nil
+}
+
+// GetModel describes a model and its status.
+func (s *ModelServerV2) GetModel(ctx context.Context, req *inferencev2.GetModelRequest) (*inferencev2.Model, error) {
+	registry := s.manager.Registry()
+
+	instance, ok := registry.Get(req.ModelId)
+	if !ok {
+		return nil, modelNotFound(fmt.Sprintf("model not found: %s", req.ModelId))
+	}
+
+	return buildModel(registry, instance), nil
+}
+
+// PullModel starts (or attaches to) a model download and streams its progress.
+func (s *ModelServerV2) PullModel(req *inferencev2.PullModelRequest, stream inferencev2.ModelService_PullModelServer) error {
+	if req.ModelId == "" {
+		return status.Error(codes.InvalidArgument, "invalid request: model_id is required")
+	}
+
+	ctx := stream.Context()
+
+	job, err := s.manager.Pull(ctx, req.ModelId)
+	if err != nil {
+		return mapModelError(err)
+	}
+
+	updates, unsubscribe := job.Subscribe()
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case update, ok := <-updates:
+			if !ok {
+				return nil
+			}
+
+			if err := stream.Send(buildPullProgressV2(update)); err != nil {
+				return status.Errorf(codes.Internal, "failed to send progress: %v", err)
+			}
+		}
+	}
+}
+
+// CancelPull cancels a running download.
+func (s *ModelServerV2) CancelPull(ctx context.Context, req *inferencev2.CancelPullRequest) (*inferencev2.CancelPullResponse, error) {
+	job, ok := s.manager.PullJob(req.JobId)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "pull job not found: %s", req.JobId)
+	}
+
+	job.Cancel()
+
+	select {
+	case <-job.Done():
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+
+	return &inferencev2.CancelPullResponse{Pull: buildPullProgressV2(job.Status())}, nil
+}
+
+// RemoveModel removes the cached files of a model.
+func (s *ModelServerV2) RemoveModel(ctx context.Context, req *inferencev2.RemoveModelRequest) (*inferencev2.RemoveModelResponse, error) {
+	if req.ModelId == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid request: model_id is required")
+	}
+
+	result, err := s.manager.RemoveModel(ctx, req.ModelId)
+	if err != nil {
+		return nil, mapModelError(err)
+	}
+
+	return &inferencev2.RemoveModelResponse{
+		RemovedFiles: result.Removed,
+		FreedBytes:   result.FreedBytes,
+	}, nil
+}
+
+// buildModel converts a model instance to protobuf.
+func buildModel(registry *model.Registry, instance *model.Instance) *inferencev2.Model {
+	info := &inferencev2.Model{
+		Id:      instance.ID,
+		Type:    modelTypes[model.Type(instance.Config.Type)],
+		Backend: instance.Config.Backend,
+		Status:  modelStatuses[instance.Status],
+		Error:   instance.Error,
+		Tags:    instance.Config.Tags,
+		Order:   int32(instance.Config.Order),
+		Aliases: registry.Routes().Aliases(instance.ID),
+	}
+	if instance.Job != nil {
+		info.Pull = buildPullProgressV2(instance.Job.Status())
+	}
+	for _, profile := range instance.Profiles {
+		info.Profiles = append(info.Profiles, &inferencev2.Profile{
+			Name:        profile.Name,
+			DisplayName: profile.DisplayName,
+			Description: profile.Description,
+			ComputeType: profile.ComputeType,
+		})
+	}
+
+	return info
+}
+
+// buildPullProgressV2 converts a pull job status to protobuf.
+func buildPullProgressV2(s model.PullStatus) *inferencev2.PullProgress {
+	progress := &inferencev2.PullProgress{
+		JobId:              s.ID,
+		ModelId:            s.ModelID,
+		State:              pullStates[s.State],
+		BytesDone:          s.BytesDone,
+		BytesTotal:         s.BytesTotal,
+		RateBytesPerSecond: s.RateBytesPerSec,
+		EtaSeconds:         s.ETASeconds,
+		Error:              s.Error,
+		Files:              make([]*inferencev2.FileProgress, 0, len(s.Files)),
+	}
+
+	if s.StartedAt != nil {
+		progress.StartedAt = timestamppb.New(*s.StartedAt)
+	}
+	if s.FinishedAt != nil {
+		progress.FinishedAt = timestamppb.New(*s.FinishedAt)
+	}
+
+	for _, f := range s.Files {
+		progress.Files = append(progress.Files, &inferencev2.FileProgress{
+			Path:       f.Path,
+			State:      fileStates[f.State],
+			BytesDone:  f.BytesDone,
+			BytesTotal: f.BytesTotal,
+		})
+	}
+
+	return progress
+}
@@ -0,0 +1,134 @@
+package grpc_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	relicgrpc "github.com/ju4n97/relic/api/grpc"
+	"github.com/ju4n97/relic/internal/config"
+	inferencev2 "github.com/ju4n97/relic/sdk-go/pb/inference/v2"
+)
+
+// newModelClient returns a client of a ModelServerV2 with an llm and an stt model.
+func newModelClient(t *testing.T) inferencev2.ModelServiceClient {
+	t.Helper()
+
+	manager := newManager(t, map[string]config.ModelConfig{
+		"qwen":    {Type: "llm", Backend: "llama.cpp", Aliases: []string{"chat"}, Tags: []string{"fast"}},
+		"whisper": {Type: "stt", Backend: "whisper.cpp"},
+	})
+
+	conn := dial(t, func(server *grpc.Server) {
+		inferencev2.RegisterModelServiceServer(server, relicgrpc.NewModelServerV2(manager))
+	})
+
+	return inferencev2.NewModelServiceClient(conn)
+}
+
+func TestModelServerV2_ListModels(t *testing.T) {
+	client := newModelClient(t)
+	ctx := context.Background()
+
+	resp, err := client.ListModels(ctx, &inferencev2.ListModelsRequest{})
+	require.NoError(t, err)
+	var ids []string
+	for _, m := range resp.Models {
+		ids = append(ids, m.Id)
+	}
+	assert.ElementsMatch(t, []string{"qwen", "whisper"}, ids)
+
+	resp, err = client.ListModels(ctx, &inferencev2.ListModelsRequest{Type: inferencev2.ModelType_MODEL_TYPE_STT})
+	require.NoError(t, err)
+	require.Len(t, resp.Models, 1)
+	assert.Equal(t, "whisper", resp.Models[0].Id)
+	assert.Equal(t, inferencev2.ModelType_MODEL_TYPE_STT, resp.Models[0].Type)
+}
+
+func TestModelServerV2_GetModel(t *testing.T) {
+	client := newModelClient(t)
+	ctx := context.Background()
+
+	m, err := client.GetModel(ctx, &inferencev2.GetModelRequest{ModelId: "qwen"})
+	require.NoError(t, err)
+	assert.Equal(t, inferencev2.ModelType_MODEL_TYPE_LLM, m.Type)
+	assert.Equal(t, "llama.cpp", m.Backend)
+	assert.Equal(t, []string{"chat"}, m.Aliases)
+	assert.Equal(t, []string{"fast"}, m.Tags)
+	assert.NotEqual(t, inferencev2.ModelStatus_MODEL_STATUS_NOT_CACHED, m.Status)
+
+	_, err = client.GetModel(ctx, &inferencev2.GetModelRequest{ModelId: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	details := status.Convert(err).Details()
+	require.Len(t, details, 1)
+	require.IsType(t, &errdetails.ErrorInfo{}, details[0])
+	assert.Equal(t, relicgrpc.ReasonModelNotFound, details[0].(*errdetails.ErrorInfo).Reason)
+}
+
+func TestModelServerV2_PullModel_Cached(t *testing.T) {
+	client := newModelClient(t)
+
+	stream, err := client.PullModel(context.Background(), &inferencev2.PullModelRequest{ModelId: "qwen"})
+	require.NoError(t, err)
+
+	progress, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "qwen", progress.ModelId)
+	assert.Equal(t, inferencev2.PullState_PULL_STATE_COMPLETED, progress.State)
+
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestModelServerV2_Errors(t *testing.T) {
+	client := newModelClient(t)
+	ctx := context.Background()
+
+	recv := func(req *inferencev2.PullModelRequest) error {
+		stream, err := client.PullModel(ctx, req)
+		if err != nil {
+			return err
+		}
+		_, err = stream.Recv()
+		return err
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+		want codes.Code
+	}{
+		{"pull without model", func() error {
+			return recv(&inferencev2.PullModelRequest{})
+		}, codes.InvalidArgument},
+		{"pull unknown model", func() error {
+			return recv(&inferencev2.PullModelRequest{ModelId: "missing"})
+		}, codes.NotFound},
+		{"cancel unknown job", func() error {
+			_, err := client.CancelPull(ctx, &inferencev2.CancelPullRequest{JobId: "missing"})
+			return err
+		}, codes.NotFound},
+		{"remove without model", func() error {
+			_, err := client.RemoveModel(ctx, &inferencev2.RemoveModelRequest{})
+			return err
+		}, codes.InvalidArgument},
+		{"remove unknown model", func() error {
+			_, err := client.RemoveModel(ctx, &inferencev2.RemoveModelRequest{ModelId: "missing"})
+			return err
+		}, codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			assert.Equal(t, tt.want, status.Code(err), err)
+		})
+	}
+}
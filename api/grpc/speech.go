@@ -0,0 +1,206 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ju4n97/relic/internal/backend"
+	"github.com/ju4n97/relic/internal/backend/piper"
+	"github.com/ju4n97/relic/internal/backend/whisper"
+	"github.com/ju4n97/relic/internal/model"
+	"github.com/ju4n97/relic/internal/service"
+	inferencev2 "github.com/ju4n97/relic/sdk-go/pb/inference/v2"
+)
+
+// SpeechToTextServer implements inferencev2.SpeechToTextServiceServer.
+type SpeechToTextServer struct {
+	inferencev2.UnimplementedSpeechToTextServiceServer
+	service *service.STT
+	models  *model.Registry
+}
+
+// NewSpeechToTextServer creates a new SpeechToTextServer instance.
+func NewSpeechToTextServer(svc *service.STT, models *model.Registry) *SpeechToTextServer {
+	return &SpeechToTextServer{
+		service: svc,
+		models:  models,
+	}
+}
+
+// Transcribe transcribes audio.
+func (s *SpeechToTextServer) Transcribe(ctx context.Context, req *inferencev2.TranscribeRequest) (*inferencev2.TranscribeResponse, error) {
+	if len(req.Audio) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid request: audio is required")
+	}
+	if err := checkAudioFormat(req.Format); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+	}
+
+	parameters, err := parseParameters(req.Parameters)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid request: parameters: %v", err)
+	}
+	parameters["language"] = req.Language
+	parameters["prompt"] = req.Prompt
+	parameters["translate"] = req.Translate
+	if req.Temperature != nil {
+		parameters["temperature"] = *req.Temperature
+	}
+	if req.BeamSize != nil {
+		parameters["beam_size"] = int(*req.BeamSize)
+	}
+	if req.BestOf != nil {
+		parameters["best_of"] = int(*req.BestOf)
+	}
+
+	resp, err := s.service.Transcribe(ctx, whisper.BackendName, req.ModelId, req.Profile, &backend.Request{
+		Input:      bytes.NewReader(req.Audio),
+		Parameters: parameters,
+	})
+	if err != nil {
+		return nil, mapBackendError(err)
+	}
+
+	var sb strings.Builder
+	if _, err := io.Copy(&sb, resp.Output); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read output: %v", err)
+	}
+
+	out := &inferencev2.TranscribeResponse{
+		Text:     sb.String(),
+		Language: req.Language,
+		Metadata: buildResponseMetadata(resp.Metadata, resolveModel(s.models, model.TypeSTT, req.ModelId)),
+	}
+	if resp.Metadata != nil && resp.Metadata.Usage != nil {
+		out.DurationSeconds = resp.Metadata.Usage.AudioSeconds
+	}
+	if transcription, ok := transcriptionOf(resp.Metadata); ok {
+		if transcription.DetectedLanguage != "" {
+			out.Language = transcription.DetectedLanguage
+		}
+		out.Segments = buildSegments(transcription.Segments)
+	}
+
+	return out, nil
+}
+
+// transcriptionOf returns the whisper.cpp response a transcription was built from.
+func transcriptionOf(meta *backend.ResponseMetadata) (whisper.TranscriptionResponse, bool) {
+	if meta == nil {
+		return whisper.TranscriptionResponse{}, false
+	}
+
+	transcription, ok := meta.BackendSpecific["response"].(whisper.TranscriptionResponse)
+	return transcription, ok
+}
+
+// buildSegments converts whisper.cpp segments to protobuf.
+func buildSegments(segments []whisper.TranscriptSegment) []*inferencev2.Segment {
+	out := make([]*inferencev2.Segment, 0, len(segments))
+	for _, segment := range segments {
+		words := make([]*inferencev2.Word, 0, len(segment.Words))
+		for _, word := range segment.Words {
+			words = append(words, &inferencev2.Word{
+				Word:         word.Word,
+				StartSeconds: word.Start,
+				EndSeconds:   word.End,
+				Probability:  word.Probability,
+			})
+		}
+
+		out = append(out, &inferencev2.Segment{
+			Id:           int32(segment.ID),
+			StartSeconds: segment.Start,
+			EndSeconds:   segment.End,
+			Text:         segment.Text,
+			Words:        words,
+			AvgLogprob:   segment.AvgLogprob,
+			NoSpeechProb: segment.NoSpeechProb,
+		})
+	}
+
+	return out
+}
+
+// TextToSpeechServer implements inferencev2.TextToSpeechServiceServer.
+type TextToSpeechServer struct {
+	inferencev2.UnimplementedTextToSpeechServiceServer
+	service *service.TTS
+	models  *model.Registry
+}
+
+// NewTextToSpeechServer creates a new TextToSpeechServer instance.
+func NewTextToSpeechServer(svc *service.TTS, models *model.Registry) *TextToSpeechServer {
+	return &TextToSpeechServer{
+		service: svc,
+		models:  models,
+	}
+}
+
+// Synthesize synthesizes speech from text.
+func (s *TextToSpeechServer) Synthesize(ctx context.Context, req *inferencev2.SynthesizeRequest) (*inferencev2.SynthesizeResponse, error) {
+	if req.Text == "" {
+		return nil, status.Error(codes.InvalidArgument, "invalid request: text is required")
+	}
+	if err := checkAudioFormat(req.Format); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+	}
+
+	parameters := map[string]any{}
+	if req.SpeakerId != nil {
+		parameters["speaker_id"] = int(*req.SpeakerId)
+	}
+	if req.LengthScale != nil {
+		parameters["length_scale"] = *req.LengthScale
+	}
+	if req.NoiseScale != nil {
+		parameters["noise_scale"] = *req.NoiseScale
+	}
+	if req.NoiseW != nil {
+		parameters["noise_w"] = *req.NoiseW
+	}
+	if req.SentenceSilence != nil {
+		parameters["sentence_silence"] = *req.SentenceSilence
+	}
+
+	resp, err := s.service.Synthesize(ctx, piper.BackendName, req.ModelId, req.Profile, &backend.Request{
+		Input:      strings.NewReader(req.Text),
+		Parameters: parameters,
+	})
+	if err != nil {
+		return nil, mapBackendError(err)
+	}
+
+	audio, err := io.ReadAll(resp.Output)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to read output: %v", err)
+	}
+
+	out := &inferencev2.SynthesizeResponse{
+		Audio:    audio,
+		Format:   inferencev2.AudioFormat_AUDIO_FORMAT_WAV,
+		Metadata: buildResponseMetadata(resp.Metadata, resolveModel(s.models, model.TypeTTS, req.ModelId)),
+	}
+	if resp.Metadata != nil && resp.Metadata.Usage != nil {
+		out.DurationSeconds = resp.Metadata.Usage.AudioSeconds
+	}
+
+	return out, nil
+}
+
+// checkAudioFormat returns an error if the backends cannot handle an audio format.
+// Only WAV is supported for now.
+func checkAudioFormat(format inferencev2.AudioFormat) error {
+	switch format {
+	case inferencev2.AudioFormat_AUDIO_FORMAT_UNSPECIFIED, inferencev2.AudioFormat_AUDIO_FORMAT_WAV:
+		return nil
+	default:
+		return errors.New("unsupported audio format " + format.String())
+	}
+}
@@ -0,0 +1,197 @@
+package grpc_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	relicgrpc "github.com/ju4n97/relic/api/grpc"
+	"github.com/ju4n97/relic/internal/backend"
+	"github.com/ju4n97/relic/internal/backend/piper"
+	"github.com/ju4n97/relic/internal/backend/whisper"
+	"github.com/ju4n97/relic/internal/config"
+	"github.com/ju4n97/relic/internal/service"
+	inferencev2 "github.com/ju4n97/relic/sdk-go/pb/inference/v2"
+)
+
+// newSpeechClients returns clients of the speech servers, whose whisper.cpp and
+// piper backends answer with the given functions.
+func newSpeechClients(t *testing.T, transcribe, synthesize func(*backend.Request) (*backend.Response, error)) (inferencev2.SpeechToTextServiceClient, inferencev2.TextToSpeechServiceClient) {
+	t.Helper()
+
+	manager := newManager(t, map[string]config.ModelConfig{
+		"whisper": {Type: "stt", Backend: whisper.BackendName, Aliases: []string{"asr"}},
+		"voice":   {Type: "tts", Backend: piper.BackendName},
+	})
+	backends := newBackends(t,
+		&fakeBackend{provider: whisper.BackendName, infer: transcribe},
+		&fakeBackend{provider: piper.BackendName, infer: synthesize},
+	)
+	models := manager.Registry()
+
+	conn := dial(t, func(server *grpc.Server) {
+		inferencev2.RegisterSpeechToTextServiceServer(server, relicgrpc.NewSpeechToTextServer(service.NewSTT(backends, models), models))
+		inferencev2.RegisterTextToSpeechServiceServer(server, relicgrpc.NewTextToSpeechServer(service.NewTTS(backends, models), models))
+	})
+
+	return inferencev2.NewSpeechToTextServiceClient(conn), inferencev2.NewTextToSpeechServiceClient(conn)
+}
+
+func TestSpeechToTextServer_Transcribe(t *testing.T) {
+	var parameters map[string]any
+	stt, _ := newSpeechClients(t, func(req *backend.Request) (*backend.Response, error) {
+		parameters = req.Parameters
+		return &backend.Response{
+			Output: strings.NewReader("Hola mundo"),
+			Metadata: &backend.ResponseMetadata{
+				Provider:  whisper.BackendName,
+				Timestamp: time.Now(),
+				Usage:     &backend.Usage{AudioSeconds: 2.5},
+				BackendSpecific: map[string]any{"response": whisper.TranscriptionResponse{
+					DetectedLanguage: "es",
+					Segments: []whisper.TranscriptSegment{{
+						ID:           0,
+						Start:        0.1,
+						End:          2.4,
+						Text:         "Hola mundo",
+						AvgLogprob:   -0.2,
+						NoSpeechProb: 0.01,
+						Words: []whisper.TranscriptionSegmentWord{
+							{Word: "Hola", Start: 0.1, End: 0.9, Probability: 0.98},
+							{Word: "mundo", Start: 1.0, End: 2.4, Probability: 0.95},
+						},
+					}},
+				}},
+			},
+		}, nil
+	}, nil)
+
+	extra, err := structpb.NewStruct(map[string]any{"no_timestamps": true})
+	require.NoError(t, err)
+
+	resp, err := stt.Transcribe(context.Background(), &inferencev2.TranscribeRequest{
+		ModelId:    "asr",
+		Audio:      []byte("RIFF"),
+		Prompt:     "Saludo",
+		BeamSize:   proto.Int32(5),
+		Parameters: extra,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Hola mundo", resp.Text)
+	assert.Equal(t, "es", resp.Language, "the detected language is reported")
+	assert.InDelta(t, 2.5, resp.DurationSeconds, 1e-9)
+
+	require.Len(t, resp.Segments, 1)
+	segment := resp.Segments[0]
+	assert.InDelta(t, 0.1, segment.StartSeconds, 1e-9)
+	assert.InDelta(t, 2.4, segment.EndSeconds, 1e-9)
+	assert.InDelta(t, -0.2, segment.AvgLogprob, 1e-9)
+	assert.InDelta(t, 0.01, segment.NoSpeechProb, 1e-9)
+	require.Len(t, segment.Words, 2)
+	assert.Equal(t, "mundo", segment.Words[1].Word)
+	assert.InDelta(t, 1.0, segment.Words[1].StartSeconds, 1e-9)
+	assert.InDelta(t, 0.95, segment.Words[1].Probability, 1e-9)
+
+	require.NotNil(t, resp.Metadata)
+	assert.Equal(t, "whisper", resp.Metadata.ModelId)
+	assert.InDelta(t, 2.5, resp.Metadata.Usage.AudioSeconds, 1e-9)
+
+	assert.Equal(t, "Saludo", parameters["prompt"])
+	assert.Equal(t, 5, parameters["beam_size"])
+	assert.Equal(t, true, parameters["no_timestamps"])
+	assert.NotContains(t, parameters, "temperature", "unset options keep the backend defaults")
+}
+
+func TestTextToSpeechServer_Synthesize(t *testing.T) {
+	var (
+		text       string
+		parameters map[string]any
+	)
+	_, tts := newSpeechClients(t, nil, func(req *backend.Request) (*backend.Response, error) {
+		input, _ := io.ReadAll(req.Input)
+		text, parameters = string(input), req.Parameters
+
+		return &backend.Response{
+			Output: strings.NewReader("RIFF...."),
+			Metadata: &backend.ResponseMetadata{
+				Provider:  piper.BackendName,
+				Timestamp: time.Now(),
+				Usage:     &backend.Usage{AudioSeconds: 1.5, Characters: 5},
+			},
+		}, nil
+	})
+
+	resp, err := tts.Synthesize(context.Background(), &inferencev2.SynthesizeRequest{
+		Text:        "Hello",
+		SpeakerId:   proto.Int32(3),
+		LengthScale: proto.Float64(1.2),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte("RIFF...."), resp.Audio)
+	assert.Equal(t, inferencev2.AudioFormat_AUDIO_FORMAT_WAV, resp.Format)
+	assert.InDelta(t, 1.5, resp.DurationSeconds, 1e-9)
+	require.NotNil(t, resp.Metadata)
+	assert.Equal(t, "voice", resp.Metadata.ModelId)
+	assert.EqualValues(t, 5, resp.Metadata.Usage.Characters)
+
+	assert.Equal(t, "Hello", text)
+	assert.Equal(t, map[string]any{"speaker_id": 3, "length_scale": 1.2}, parameters)
+}
+
+func TestSpeechServers_Errors(t *testing.T) {
+	failed := func(*backend.Request) (*backend.Response, error) {
+		return nil, status.Error(codes.ResourceExhausted, "busy")
+	}
+	stt, tts := newSpeechClients(t, failed, failed)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		call func() error
+		want codes.Code
+	}{
+		{"no audio", func() error {
+			_, err := stt.Transcribe(ctx, &inferencev2.TranscribeRequest{})
+			return err
+		}, codes.InvalidArgument},
+		{"unknown audio format", func() error {
+			_, err := stt.Transcribe(ctx, &inferencev2.TranscribeRequest{Audio: []byte("RIFF"), Format: inferencev2.AudioFormat(7)})
+			return err
+		}, codes.InvalidArgument},
+		{"unknown stt model", func() error {
+			_, err := stt.Transcribe(ctx, &inferencev2.TranscribeRequest{ModelId: "voice", Audio: []byte("RIFF")})
+			return err
+		}, codes.NotFound},
+		{"stt backend status", func() error {
+			_, err := stt.Transcribe(ctx, &inferencev2.TranscribeRequest{Audio: []byte("RIFF")})
+			return err
+		}, codes.ResourceExhausted},
+		{"no text", func() error {
+			_, err := tts.Synthesize(ctx, &inferencev2.SynthesizeRequest{})
+			return err
+		}, codes.InvalidArgument},
+		{"unknown tts model", func() error {
+			_, err := tts.Synthesize(ctx, &inferencev2.SynthesizeRequest{ModelId: "missing", Text: "Hi"})
+			return err
+		}, codes.NotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			assert.Equal(t, tt.want, status.Code(err), err)
+		})
+	}
+}
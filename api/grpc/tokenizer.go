@@ -0,0 +1,126 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ju4n97/relic/internal/backend/llama"
+	"github.com/ju4n97/relic/internal/model"
+	"github.com/ju4n97/relic/internal/service"
+	inferencev2 "github.com/ju4n97/relic/sdk-go/pb/inference/v2"
+)
+
+// TokenizerServer implements inferencev2.TokenizerServiceServer.
+type TokenizerServer struct {
+	inferencev2.UnimplementedTokenizerServiceServer
+	service *service.Tokenizer
+	models  *model.Registry
+}
+
+// NewTokenizerServer creates a new TokenizerServer instance.
+func NewTokenizerServer(svc *service.Tokenizer, models *model.Registry) *TokenizerServer {
+	return &TokenizerServer{
+		service: svc,
+		models:  models,
+	}
+}
+
+// Tokenize tokenizes text, or a chat conversation.
+func (s *TokenizerServer) Tokenize(ctx context.Context, req *inferencev2.TokenizeRequest) (*inferencev2.TokenizeResponse, error) {
+	if req.Text != "" && len(req.Messages) > 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid request: text and messages are mutually exclusive")
+	}
+
+	messages, err := buildMessages(req.Messages)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+	}
+
+	tokenization, metadata, err := s.service.Tokenize(ctx, llama.BackendName, req.ModelId, req.Profile, req.Text, messages, service.TokenizeOptions{
+		AddSpecial: req.AddSpecial,
+		WithPieces: req.WithPieces,
+	})
+	if err != nil {
+		return nil, mapBackendError(err)
+	}
+
+	return &inferencev2.TokenizeResponse{
+		Tokens:        int32s(tokenization.Tokens),
+		Pieces:        tokenization.Pieces,
+		Prompt:        tokenization.Prompt,
+		ContextLength: int32(tokenization.ContextLength),
+		Metadata:      buildResponseMetadata(metadata, resolveModel(s.models, model.TypeLLM, req.ModelId)),
+	}, nil
+}
+
+// Detokenize converts tokens back to text.
+func (s *TokenizerServer) Detokenize(ctx context.Context, req *inferencev2.DetokenizeRequest) (*inferencev2.DetokenizeResponse, error) {
+	tokens := make([]int, len(req.Tokens))
+	for i, token := range req.Tokens {
+		tokens[i] = int(token)
+	}
+
+	text, metadata, err := s.service.Detokenize(ctx, llama.BackendName, req.ModelId, req.Profile, tokens)
+	if err != nil {
+		return nil, mapBackendError(err)
+	}
+
+	return &inferencev2.DetokenizeResponse{
+		Text:     text,
+		Metadata: buildResponseMetadata(metadata, resolveModel(s.models, model.TypeLLM, req.ModelId)),
+	}, nil
+}
+
+// ApplyTemplate formats a chat conversation with the chat template of the model.
+func (s *TokenizerServer) ApplyTemplate(ctx context.Context, req *inferencev2.ApplyTemplateRequest) (*inferencev2.ApplyTemplateResponse, error) {
+	if len(req.Messages) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid request: messages is required")
+	}
+
+	messages, err := buildMessages(req.Messages)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+	}
+
+	prompt, metadata, err := s.service.ApplyTemplate(ctx, llama.BackendName, req.ModelId, req.Profile, messages)
+	if err != nil {
+		return nil, mapBackendError(err)
+	}
+
+	return &inferencev2.ApplyTemplateResponse{
+		Prompt:   prompt,
+		Metadata: buildResponseMetadata(metadata, resolveModel(s.models, model.TypeLLM, req.ModelId)),
+	}, nil
+}
+
+// CountTokens counts the tokens of a chat conversation with the chat template
+// applied, and reports the context length of the model.
+func (s *TokenizerServer) CountTokens(ctx context.Context, req *inferencev2.CountTokensRequest) (*inferencev2.CountTokensResponse, error) {
+	messages, err := buildMessages(req.Messages)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid request: %v", err)
+	}
+
+	tokenization, metadata, err := s.service.Tokenize(ctx, llama.BackendName, req.ModelId, req.Profile, "", messages, service.TokenizeOptions{})
+	if err != nil {
+		return nil, mapBackendError(err)
+	}
+
+	return &inferencev2.CountTokensResponse{
+		Tokens:        int32(len(tokenization.Tokens)),
+		ContextLength: int32(tokenization.ContextLength),
+		Metadata:      buildResponseMetadata(metadata, resolveModel(s.models, model.TypeLLM, req.ModelId)),
+	}, nil
+}
+
+// int32s converts token IDs to protobuf.
+func int32s(values []int) []int32 {
+	out := make([]int32, len(values))
+	for i, v := range values {
+		out[i] = int32(v)
+	}
+
+	return out
+}
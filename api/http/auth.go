@@ -0,0 +1,62 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/ju4n97/relic/internal/auth"
+	"github.com/ju4n97/relic/internal/config"
+)
+
+// securityScheme is the name of the API key security scheme of the OpenAPI document.
+const securityScheme = "apiKey"
+
+// Security requirements of the operations, by the scope they require.
+var (
+	inferenceSecurity = []map[string][]string{{securityScheme: {config.ScopeInference}}}
+	adminSecurity     = []map[string][]string{{securityScheme: {config.ScopeAdmin}}}
+)
+
+// Authorize rejects requests to operations whose key lacks the scopes listed in
+// the security requirements of the operation, with 401 when no key was presented
+// and 403 otherwise. The key itself is authenticated by the auth middleware of
+// the router. Nothing is enforced while the authenticator has no key configured.
+// It must be called before the operations are registered.
+func Authorize(api huma.API, authenticator *auth.Authenticator) {
+	oapi := api.OpenAPI()
+	if oapi.Components.SecuritySchemes == nil {
+		oapi.Components.SecuritySchemes = map[string]*huma.SecurityScheme{}
+	}
+	oapi.Components.SecuritySchemes[securityScheme] = &huma.SecurityScheme{
+		Type:        "http",
+		Scheme:      "bearer",
+		Description: "API key sent as a bearer token, or in the X-API-Key header.",
+	}
+
+	api.UseMiddleware(func(ctx huma.Context, next func(huma.Context)) {
+		op := ctx.Operation()
+		if !authenticator.Enabled() || len(op.Security) == 0 {
+			next(ctx)
+			return
+		}
+
+		key, ok := auth.KeyFromContext(ctx.Context())
+		if !ok {
+			ctx.SetHeader("WWW-Authenticate", "Bearer")
+			_ = huma.WriteErr(api, ctx, http.StatusUnauthorized, auth.ErrMissingKey.Error())
+			return
+		}
+
+		for _, requirement := range op.Security {
+			for _, scope := range requirement[securityScheme] {
+				if !key.HasScope(scope) {
+					_ = huma.WriteErr(api, ctx, http.StatusForbidden, "API key lacks the "+scope+" scope")
+					return
+				}
+			}
+		}
+
+		next(ctx)
+	})
+}
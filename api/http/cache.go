@@ -0,0 +1,129 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/ju4n97/relic/internal/cache"
+	"github.com/ju4n97/relic/internal/model"
+)
+
+type (
+	// CacheReportOutput is the huma output for the GetCacheReport operation.
+	CacheReportOutput struct {
+		Body *cache.Report
+	}
+
+	// CollectGarbageInput is the huma input for the CollectGarbage operation.
+	CollectGarbageInput struct {
+		DryRun bool `query:"dry_run" doc:"Only list the files that would be removed"`
+	}
+
+	// CollectGarbageOutput is the huma output for the CollectGarbage operation.
+	CollectGarbageOutput struct {
+		Body *cache.GCResult
+	}
+
+	// RemoveModelInput is the huma input for the RemoveModel operation.
+	RemoveModelInput struct {
+		ModelID string `path:"model_id" doc:"ID of the model whose files are removed"`
+	}
+
+	// RemoveModelOutput is the huma output for the RemoveModel operation.
+	RemoveModelOutput struct {
+		Body *cache.GCResult
+	}
+)
+
+// CacheHandler handles HTTP requests for the models cache.
+type CacheHandler struct {
+	manager *model.Manager
+}
+
+// NewCacheHandler creates a new CacheHandler instance.
+func NewCacheHandler(api huma.API, manager *model.Manager) *CacheHandler {
+	h := &CacheHandler{manager: manager}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-cache-report",
+		Method:      http.MethodGet,
+		Path:        "/cache",
+		Summary:     "Report the disk usage of the models cache",
+		Tags:        []string{"cache"},
+		Security:    adminSecurity,
+	}, h.handleGetCacheReport)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "collect-cache-garbage",
+		Method:      http.MethodPost,
+		Path:        "/cache/gc",
+		Summary:     "Remove cached files no configured model references",
+		Tags:        []string{"cache"},
+		Security:    adminSecurity,
+	}, h.handleCollectGarbage)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "remove-cached-model",
+		Method:      http.MethodDelete,
+		Path:        "/cache/{model_id}",
+		Summary:     "Remove the cached files of a model, keeping those other models use",
+		Tags:        []string{"cache"},
+		Security:    adminSecurity,
+	}, h.handleRemoveModel)
+
+	return h
+}
+
+// handleGetCacheReport handles the GetCacheReport operation.
+func (h *CacheHandler) handleGetCacheReport(ctx context.Context, input *struct{}) (*CacheReportOutput, error) {
+	report, err := h.manager.CacheReport(ctx)
+	if err != nil {
+		if errors.Is(err, model.ErrNoConfig) {
+			return nil, huma.Error503ServiceUnavailable("no config loaded", err)
+		}
+		return nil, huma.Error500InternalServerError("failed to build cache report", err)
+	}
+
+	return &CacheReportOutput{Body: report}, nil
+}
+
+// handleCollectGarbage handles the CollectGarbage operation.
+func (h *CacheHandler) handleCollectGarbage(ctx context.Context, input *CollectGarbageInput) (*CollectGarbageOutput, error) {
+	result, err := h.manager.CollectGarbage(ctx, input.DryRun)
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrNoConfig):
+			return nil, huma.Error503ServiceUnavailable("no config loaded", err)
+		case errors.Is(err, model.ErrPullInProgress):
+			return nil, huma.Error409Conflict("cannot collect garbage while models are downloading", err)
+		default:
+			return nil, huma.Error500InternalServerError("failed to collect garbage", err)
+		}
+	}
+
+	return &CollectGarbageOutput{Body: result}, nil
+}
+
+// handleRemoveModel handles the RemoveModel operation.
+func (h *CacheHandler) handleRemoveModel(ctx context.Context, input *RemoveModelInput) (*RemoveModelOutput, error) {
+	result, err := h.manager.RemoveModel(ctx, input.ModelID)
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrNoConfig):
+			return nil, huma.Error503ServiceUnavailable("no config loaded", err)
+		case errors.Is(err, model.ErrNotFound):
+			return nil, huma.Error404NotFound("model not found", err)
+		case errors.Is(err, model.ErrNotCached):
+			return nil, huma.Error409Conflict("model is not cached", err)
+		case errors.Is(err, model.ErrPullInProgress):
+			return nil, huma.Error409Conflict("cannot remove a model while it is downloading", err)
+		default:
+			return nil, huma.Error500InternalServerError("failed to remove model", err)
+		}
+	}
+
+	return &RemoveModelOutput{Body: result}, nil
+}
@@ -0,0 +1,39 @@
+package http
+
+import (
+	"errors"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/ju4n97/relic/internal/auth"
+	"github.com/ju4n97/relic/internal/limits"
+	"github.com/ju4n97/relic/internal/model"
+)
+
+// modelError converts an error raised while selecting the model of a request, or
+// admitting the request against the limits, into an HTTP error. It returns nil if
+// err is related to neither.
+func modelError(err error) error {
+	switch {
+	case errors.Is(err, model.ErrNotFound):
+		return huma.Error404NotFound("model not found", err)
+	case errors.Is(err, model.ErrNotAssigned):
+		return huma.Error404NotFound("model not enabled for this service", err)
+	case errors.Is(err, model.ErrNoDefault):
+		return huma.Error400BadRequest("model_id is required, the service has no default model", err)
+	case errors.Is(err, model.ErrBackendMismatch):
+		return huma.Error400BadRequest("model is served by a different backend", err)
+	case errors.Is(err, model.ErrProfileNotFound):
+		return huma.Error400BadRequest("profile not available for this model", err)
+	case errors.Is(err, model.ErrUnavailable):
+		return huma.Error503ServiceUnavailable("model not available", err)
+	case errors.Is(err, auth.ErrForbidden):
+		return huma.Error403Forbidden("API key may not use this model", err)
+	case errors.Is(err, limits.ErrRateLimited):
+		return huma.Error429TooManyRequests("rate limit exceeded", err)
+	case errors.Is(err, limits.ErrQuotaExceeded):
+		return huma.Error429TooManyRequests("daily quota exceeded", err)
+	default:
+		return nil
+	}
+}
@@ -2,26 +2,34 @@ package http
 
 import (
 	"context"
-	"errors"
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/sse"
 
 	"github.com/ju4n97/relic/internal/backend"
 	"github.com/ju4n97/relic/internal/backend/llama"
-	"github.com/ju4n97/relic/internal/model"
 	"github.com/ju4n97/relic/internal/service"
 )
 
 type (
 	// GenerateRequestDTO is the request body for the Generate operation.
 	GenerateRequestDTO struct {
-		Parameters map[string]any `json:"parameters,omitempty"`
-		ModelID    string         `json:"model_id" minLength:"1"`
-		Prompt     string         `json:"prompt" maxLength:"4096" minLength:"1"`
+		Parameters  map[string]any `json:"parameters,omitempty"`
+		ModelID     string         `json:"model_id,omitempty" doc:"Model ID or alias, defaults to the service default model"`
+		Profile     string         `json:"profile,omitempty" doc:"Compute profile, defaults to the first profile of the model"`
+		Prompt      string         `json:"prompt,omitempty" maxLength:"4096" doc:"Prompt, sent as a single user message. Either prompt or messages is required"`
+		Messages    []MessageDTO   `json:"messages,omitempty" doc:"Chat conversation, used instead of prompt"`
+		PromptCache string         `json:"prompt_cache,omitempty" doc:"Prompt cache whose prefix the conversation starts with. Its model and profile are used unless given"`
+	}
+
+	// MessageDTO is a message of the conversation of a Generate operation.
+	MessageDTO struct {
+		Role    string `json:"role" enum:"system,user,assistant,tool,developer"`
+		Content string `json:"content"`
 	}
 
 	// GenerateResponseDTO is the response body for the Generate operation.
@@ -49,20 +57,22 @@ type (
 
 	// StreamEvent is the huma event for the GenerateStream operation.
 	StreamEvent struct {
-		Done  bool   `json:"done,omitempty"`
-		Text  string `json:"text,omitempty"`
-		Error string `json:"error,omitempty"`
+		Metadata *backend.ResponseMetadata `json:"metadata,omitempty" doc:"Set on the last event"`
+		Done     bool                      `json:"done,omitempty"`
+		Text     string                    `json:"text,omitempty"`
+		Error    string                    `json:"error,omitempty"`
 	}
 )
 
 // LLMHandler handles HTTP requests for LLM.
 type LLMHandler struct {
 	service *service.LLM
+	caches  *service.PromptCaches
 }
 
 // NewLLMHandler creates a new LLMHandler instance.
-func NewLLMHandler(api huma.API, svc *service.LLM) *LLMHandler {
-	h := &LLMHandler{service: svc}
+func NewLLMHandler(api huma.API, svc *service.LLM, caches *service.PromptCaches) *LLMHandler {
+	h := &LLMHandler{service: svc, caches: caches}
 
 	huma.Register(api, huma.Operation{
 		OperationID:   "generate",
@@ -70,6 +80,7 @@ func NewLLMHandler(api huma.API, svc *service.LLM) *LLMHandler {
 		Path:          "/llm",
 		Summary:       "Generate text from a prompt",
 		Tags:          []string{"llm"},
+		Security:      inferenceSecurity,
 		DefaultStatus: http.StatusOK,
 	}, h.handleGenerate)
 
@@ -79,6 +90,7 @@ func NewLLMHandler(api huma.API, svc *service.LLM) *LLMHandler {
 		Path:        "/llm/stream",
 		Summary:     "Generate stream of text from a prompt (SSE)",
 		Tags:        []string{"llm"},
+		Security:    inferenceSecurity,
 	}, map[string]any{
 		"message": StreamEvent{},
 	}, h.handleGenerateStream)
@@ -90,18 +102,21 @@ func NewLLMHandler(api huma.API, svc *service.LLM) *LLMHandler {
 func (h *LLMHandler) handleGenerate(ctx context.Context, input *GenerateInput) (*GenerateOutput, error) {
 	provider := llama.BackendName
 
+	req, modelID, profile, err := h.buildGenerateRequest(&input.Body)
+	if err != nil {
+		return nil, err
+	}
+
 	resp, err := h.service.Generate(
 		ctx,
 		provider,
-		input.Body.ModelID,
-		&backend.Request{
-			Input:      strings.NewReader(input.Body.Prompt),
-			Parameters: input.Body.Parameters,
-		},
+		modelID,
+		profile,
+		req,
 	)
 	if err != nil {
-		if errors.Is(err, model.ErrNotFound) {
-			return nil, huma.Error404NotFound("model not found", err)
+		if modelErr := modelError(err); modelErr != nil {
+			return nil, modelErr
 		}
 		return nil, huma.Error500InternalServerError("failed to generate", err)
 	}
@@ -123,14 +138,20 @@ func (h *LLMHandler) handleGenerate(ctx context.Context, input *GenerateInput) (
 func (h *LLMHandler) handleGenerateStream(ctx context.Context, input *GenerateStreamInput, send sse.Sender) {
 	provider := llama.BackendName
 
+	req, modelID, profile, err := h.buildGenerateRequest(&input.Body)
+	if err != nil {
+		_ = send.Data(StreamEvent{Error: err.Error()})
+		return
+	}
+
+	start := time.Now()
+
 	stream, err := h.service.GenerateStream(
 		ctx,
 		provider,
-		input.Body.ModelID,
-		&backend.Request{
-			Input:      strings.NewReader(input.Body.Prompt),
-			Parameters: input.Body.Parameters,
-		},
+		modelID,
+		profile,
+		req,
 	)
 	if err != nil {
 		_ = send.Data(StreamEvent{Error: err.Error()})
@@ -142,7 +163,7 @@ func (h *LLMHandler) handleGenerateStream(ctx context.Context, input *GenerateSt
 		case <-ctx.Done():
 			return
 		case chunk, ok := <-stream:
-			if !ok || chunk.Done {
+			if !ok {
 				_ = send.Data(StreamEvent{Done: true})
 				return
 			}
@@ -152,7 +173,67 @@ func (h *LLMHandler) handleGenerateStream(ctx context.Context, input *GenerateSt
 				return
 			}
 
+			if chunk.Done {
+				_ = send.Data(StreamEvent{
+					Done: true,
+					Metadata: &backend.ResponseMetadata{
+						Provider:        provider,
+						Timestamp:       time.Now(),
+						DurationSeconds: time.Since(start).Seconds(),
+						Usage:           chunk.Usage,
+						BackendSpecific: chunk.BackendSpecific,
+					},
+				})
+				return
+			}
+
 			_ = send.Data(StreamEvent{Text: string(chunk.Data)})
 		}
 	}
 }
+
+// buildGenerateRequest converts the body of a generate request to a backend
+// request, and returns it with the model and the profile it is served with. With
+// a prompt cache, the conversation starts with its prefix.
+func (h *LLMHandler) buildGenerateRequest(body *GenerateRequestDTO) (*backend.Request, string, string, error) {
+	req, err := buildConversation(body)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	if body.PromptCache == "" {
+		return req, body.ModelID, body.Profile, nil
+	}
+
+	modelID, profile, err := h.caches.Apply(body.PromptCache, body.ModelID, body.Profile, req)
+	if err != nil {
+		return nil, "", "", promptCacheError(err, "failed to apply prompt cache")
+	}
+
+	return req, modelID, profile, nil
+}
+
+// buildConversation converts the body of a generate request to a backend
+// request, from either its prompt or its messages.
+func buildConversation(body *GenerateRequestDTO) (*backend.Request, error) {
+	switch {
+	case body.Prompt != "" && len(body.Messages) > 0:
+		return nil, huma.Error400BadRequest("prompt and messages are mutually exclusive")
+	case len(body.Messages) > 0:
+		return &backend.Request{Messages: buildMessages(body.Messages), Parameters: body.Parameters}, nil
+	case body.Prompt != "":
+		return &backend.Request{Input: strings.NewReader(body.Prompt), Parameters: body.Parameters}, nil
+	default:
+		return nil, huma.Error400BadRequest("prompt or messages is required")
+	}
+}
+
+// buildMessages converts the messages of a request to backend messages.
+func buildMessages(msgs []MessageDTO) []backend.Message {
+	messages := make([]backend.Message, len(msgs))
+	for i, msg := range msgs {
+		messages[i] = backend.Message{Role: msg.Role, Content: msg.Content}
+	}
+
+	return messages
+}
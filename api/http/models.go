@@ -0,0 +1,280 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/sse"
+
+	"github.com/ju4n97/relic/internal/model"
+)
+
+type (
+	// ModelDTO describes a configured model and its current status.
+	ModelDTO struct {
+		Pull     *model.PullStatus `json:"pull,omitempty"`
+		ID       string            `json:"id"`
+		Type     string            `json:"type"`
+		Backend  string            `json:"backend"`
+		Status   model.Status      `json:"status"`
+		Error    string            `json:"error,omitempty"`
+		Tags     []string          `json:"tags,omitempty"`
+		Aliases  []string          `json:"aliases,omitempty"`
+		Profiles []model.Profile   `json:"profiles,omitempty"` // The first one is used when a request names no profile
+		Order    int               `json:"order"`
+		Default  bool              `json:"default"` // Used by its service when a request names no model
+	}
+)
+
+type (
+	// ListModelsOutput is the huma output for the ListModels operation.
+	ListModelsOutput struct {
+		Body []ModelDTO
+	}
+
+	// ModelInput is the huma input for operations on a single model.
+	ModelInput struct {
+		ModelID string `path:"model_id"`
+	}
+
+	// ModelOutput is the huma output for operations on a single model.
+	ModelOutput struct {
+		Body ModelDTO
+	}
+
+	// ListPullsOutput is the huma output for the ListPulls operation.
+	ListPullsOutput struct {
+		Body []model.PullStatus
+	}
+
+	// PullInput is the huma input for operations on a single pull job.
+	PullInput struct {
+		JobID string `path:"job_id"`
+	}
+
+	// PullOutput is the huma output for operations on a single pull job.
+	PullOutput struct {
+		Body model.PullStatus
+	}
+)
+
+// ModelHandler handles HTTP requests for model management.
+type ModelHandler struct {
+	manager *model.Manager
+}
+
+// NewModelHandler creates a new ModelHandler instance.
+func NewModelHandler(api huma.API, manager *model.Manager) *ModelHandler {
+	h := &ModelHandler{manager: manager}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-models",
+		Method:      http.MethodGet,
+		Path:        "/models",
+		Summary:     "List configured models and their status",
+		Tags:        []string{"models"},
+		Security:    inferenceSecurity,
+	}, h.handleListModels)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-model",
+		Method:      http.MethodGet,
+		Path:        "/models/{model_id}",
+		Summary:     "Get a configured model and its status",
+		Tags:        []string{"models"},
+		Security:    inferenceSecurity,
+	}, h.handleGetModel)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "pull-model",
+		Method:        http.MethodPost,
+		Path:          "/models/{model_id}/pull",
+		Summary:       "Start downloading a model in the background",
+		Tags:          []string{"models"},
+		Security:      adminSecurity,
+		DefaultStatus: http.StatusAccepted,
+	}, h.handlePullModel)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-pulls",
+		Method:      http.MethodGet,
+		Path:        "/pulls",
+		Summary:     "List model download jobs",
+		Tags:        []string{"models"},
+		Security:    adminSecurity,
+	}, h.handleListPulls)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-pull",
+		Method:      http.MethodGet,
+		Path:        "/pulls/{job_id}",
+		Summary:     "Get the progress of a model download job",
+		Tags:        []string{"models"},
+		Security:    adminSecurity,
+	}, h.handleGetPull)
+
+	sse.Register(api, huma.Operation{
+		OperationID: "pull-events",
+		Method:      http.MethodGet,
+		Path:        "/pulls/{job_id}/events",
+		Summary:     "Stream the progress of a model download job (SSE)",
+		Tags:        []string{"models"},
+		Security:    adminSecurity,
+	}, map[string]any{
+		"progress": model.PullStatus{},
+	}, h.handlePullEvents)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "cancel-pull",
+		Method:      http.MethodDelete,
+		Path:        "/pulls/{job_id}",
+		Summary:     "Cancel a running model download job",
+		Tags:        []string{"models"},
+		Security:    adminSecurity,
+	}, h.handleCancelPull)
+
+	return h
+}
+
+// handleListModels handles the list-models operation.
+func (h *ModelHandler) handleListModels(ctx context.Context, input *struct{}) (*ListModelsOutput, error) {
+	instances := h.manager.Registry().List()
+	slices.SortFunc(instances, func(a, b *model.Instance) int {
+		if c := strings.Compare(a.Config.Type, b.Config.Type); c != 0 {
+			return c
+		}
+		if a.Config.Order != b.Config.Order {
+			return a.Config.Order - b.Config.Order
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	routes := h.manager.Registry().Routes()
+	models := make([]ModelDTO, 0, len(instances))
+	for _, instance := range instances {
+		models = append(models, newModelDTO(instance, routes))
+	}
+
+	return &ListModelsOutput{Body: models}, nil
+}
+
+// handleGetModel handles the get-model operation.
+func (h *ModelHandler) handleGetModel(ctx context.Context, input *ModelInput) (*ModelOutput, error) {
+	instance, ok := h.manager.Registry().Get(input.ModelID)
+	if !ok {
+		return nil, huma.Error404NotFound("model not found")
+	}
+
+	return &ModelOutput{Body: newModelDTO(instance, h.manager.Registry().Routes())}, nil
+}
+
+// handlePullModel handles the pull-model operation.
+func (h *ModelHandler) handlePullModel(ctx context.Context, input *ModelInput) (*PullOutput, error) {
+	job, err := h.manager.Pull(ctx, input.ModelID)
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrNotFound):
+			return nil, huma.Error404NotFound("model not found", err)
+		case errors.Is(err, model.ErrOffline):
+			return nil, huma.Error409Conflict("cannot pull models in offline mode", err)
+		default:
+			return nil, huma.Error500InternalServerError("failed to pull model", err)
+		}
+	}
+
+	return &PullOutput{Body: job.Status()}, nil
+}
+
+// handleListPulls handles the list-pulls operation.
+func (h *ModelHandler) handleListPulls(ctx context.Context, input *struct{}) (*ListPullsOutput, error) {
+	jobs := h.manager.PullJobs()
+
+	pulls := make([]model.PullStatus, 0, len(jobs))
+	for _, job := range jobs {
+		pulls = append(pulls, job.Status())
+	}
+
+	return &ListPullsOutput{Body: pulls}, nil
+}
+
+// handleGetPull handles the get-pull operation.
+func (h *ModelHandler) handleGetPull(ctx context.Context, input *PullInput) (*PullOutput, error) {
+	job, ok := h.manager.PullJob(input.JobID)
+	if !ok {
+		return nil, huma.Error404NotFound("pull job not found")
+	}
+
+	return &PullOutput{Body: job.Status()}, nil
+}
+
+// handlePullEvents handles the pull-events operation.
+func (h *ModelHandler) handlePullEvents(ctx context.Context, input *PullInput, send sse.Sender) {
+	job, ok := h.manager.PullJob(input.JobID)
+	if !ok {
+		_ = send.Data(model.PullStatus{ID: input.JobID, Error: model.ErrPullNotFound.Error()})
+		return
+	}
+
+	updates, unsubscribe := job.Subscribe()
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case update, ok := <-updates:
+			if !ok {
+				return
+			}
+
+			if err := send.Data(update); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// handleCancelPull handles the cancel-pull operation.
+func (h *ModelHandler) handleCancelPull(ctx context.Context, input *PullInput) (*PullOutput, error) {
+	job, ok := h.manager.PullJob(input.JobID)
+	if !ok {
+		return nil, huma.Error404NotFound("pull job not found")
+	}
+
+	job.Cancel()
+
+	select {
+	case <-job.Done():
+	case <-ctx.Done():
+		return nil, huma.Error504GatewayTimeout("timed out waiting for the download to stop", ctx.Err())
+	}
+
+	return &PullOutput{Body: job.Status()}, nil
+}
+
+// newModelDTO converts a model instance to its DTO.
+func newModelDTO(instance *model.Instance, routes *model.Routes) ModelDTO {
+	dto := ModelDTO{
+		ID:       instance.ID,
+		Type:     instance.Config.Type,
+		Backend:  instance.Config.Backend,
+		Status:   instance.Status,
+		Error:    instance.Error,
+		Tags:     instance.Config.Tags,
+		Aliases:  routes.Aliases(instance.ID),
+		Profiles: instance.Profiles,
+		Order:    instance.Config.Order,
+		Default:  routes.Default(model.Type(instance.Config.Type)) == instance.ID,
+	}
+
+	if instance.Job != nil {
+		status := instance.Job.Status()
+		dto.Pull = &status
+	}
+
+	return dto
+}
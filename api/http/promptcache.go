@@ -0,0 +1,183 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/ju4n97/relic/internal/backend"
+	"github.com/ju4n97/relic/internal/backend/llama"
+	"github.com/ju4n97/relic/internal/promptcache"
+	"github.com/ju4n97/relic/internal/service"
+)
+
+type (
+	// CreatePromptCacheRequestDTO is the request body for the CreatePromptCache operation.
+	CreatePromptCacheRequestDTO struct {
+		Name         string `json:"name" pattern:"^[a-z0-9][a-z0-9_-]*$" doc:"Name requests use the cache by"`
+		Model        string `json:"model" minLength:"1" doc:"Model ID or alias the cache is built with"`
+		Profile      string `json:"profile,omitempty" doc:"Compute profile, defaults to the first profile of the model"`
+		SystemPrompt string `json:"system_prompt" minLength:"1" doc:"System prompt the requests using the cache start with"`
+		Warm         bool   `json:"warm,omitempty" doc:"Process and persist the prefix right away instead of on first use"`
+	}
+)
+
+type (
+	// CreatePromptCacheInput is the huma input for the CreatePromptCache operation.
+	CreatePromptCacheInput struct {
+		Body CreatePromptCacheRequestDTO
+	}
+
+	// PromptCacheInput is the huma input for the operations on a prompt cache.
+	PromptCacheInput struct {
+		Name string `path:"name"`
+	}
+
+	// PromptCacheOutput is the huma output for the CreatePromptCache and GetPromptCache operations.
+	PromptCacheOutput struct {
+		Body promptcache.Cache
+	}
+
+	// ListPromptCachesOutput is the huma output for the ListPromptCaches operation.
+	ListPromptCachesOutput struct {
+		Body []promptcache.Cache
+	}
+
+	// WarmPromptCacheOutput is the huma output for the WarmPromptCache operation.
+	WarmPromptCacheOutput struct {
+		Body *backend.PromptCacheStatus
+	}
+)
+
+// PromptCacheHandler handles HTTP requests for prompt caches.
+type PromptCacheHandler struct {
+	service *service.PromptCaches
+}
+
+// NewPromptCacheHandler creates a new PromptCacheHandler instance.
+func NewPromptCacheHandler(api huma.API, svc *service.PromptCaches) *PromptCacheHandler {
+	h := &PromptCacheHandler{service: svc}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-prompt-caches",
+		Method:      http.MethodGet,
+		Path:        "/prompt-caches",
+		Summary:     "List the prompt caches",
+		Tags:        []string{"prompt-caches"},
+		Security:    inferenceSecurity,
+	}, h.handleListPromptCaches)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-prompt-cache",
+		Method:        http.MethodPost,
+		Path:          "/prompt-caches",
+		Summary:       "Create a prompt cache",
+		Tags:          []string{"prompt-caches"},
+		Security:      adminSecurity,
+		DefaultStatus: http.StatusCreated,
+	}, h.handleCreatePromptCache)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-prompt-cache",
+		Method:      http.MethodGet,
+		Path:        "/prompt-caches/{name}",
+		Summary:     "Get a prompt cache",
+		Tags:        []string{"prompt-caches"},
+		Security:    inferenceSecurity,
+	}, h.handleGetPromptCache)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-prompt-cache",
+		Method:        http.MethodDelete,
+		Path:          "/prompt-caches/{name}",
+		Summary:       "Delete a prompt cache created through the API",
+		Tags:          []string{"prompt-caches"},
+		Security:      adminSecurity,
+		DefaultStatus: http.StatusNoContent,
+	}, h.handleDeletePromptCache)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "warm-prompt-cache",
+		Method:      http.MethodPost,
+		Path:        "/prompt-caches/{name}/warm",
+		Summary:     "Process and persist the prefix of a prompt cache",
+		Tags:        []string{"prompt-caches"},
+		Security:    adminSecurity,
+	}, h.handleWarmPromptCache)
+
+	return h
+}
+
+// handleListPromptCaches handles the list-prompt-caches operation.
+func (h *PromptCacheHandler) handleListPromptCaches(ctx context.Context, input *struct{}) (*ListPromptCachesOutput, error) {
+	return &ListPromptCachesOutput{Body: h.service.List()}, nil
+}
+
+// handleCreatePromptCache handles the create-prompt-cache operation.
+func (h *PromptCacheHandler) handleCreatePromptCache(ctx context.Context, input *CreatePromptCacheInput) (*PromptCacheOutput, error) {
+	cache, err := h.service.Create(ctx, llama.BackendName, promptcache.Cache{
+		Name:         input.Body.Name,
+		Model:        input.Body.Model,
+		Profile:      input.Body.Profile,
+		SystemPrompt: input.Body.SystemPrompt,
+	}, input.Body.Warm)
+	if err != nil {
+		return nil, promptCacheError(err, "failed to create prompt cache")
+	}
+
+	return &PromptCacheOutput{Body: cache}, nil
+}
+
+// handleGetPromptCache handles the get-prompt-cache operation.
+func (h *PromptCacheHandler) handleGetPromptCache(ctx context.Context, input *PromptCacheInput) (*PromptCacheOutput, error) {
+	cache, err := h.service.Get(input.Name)
+	if err != nil {
+		return nil, promptCacheError(err, "failed to get prompt cache")
+	}
+
+	return &PromptCacheOutput{Body: cache}, nil
+}
+
+// handleDeletePromptCache handles the delete-prompt-cache operation.
+func (h *PromptCacheHandler) handleDeletePromptCache(ctx context.Context, input *PromptCacheInput) (*struct{}, error) {
+	if err := h.service.Delete(input.Name); err != nil {
+		return nil, promptCacheError(err, "failed to delete prompt cache")
+	}
+
+	return nil, nil
+}
+
+// handleWarmPromptCache handles the warm-prompt-cache operation.
+func (h *PromptCacheHandler) handleWarmPromptCache(ctx context.Context, input *PromptCacheInput) (*WarmPromptCacheOutput, error) {
+	status, err := h.service.Warm(ctx, llama.BackendName, input.Name)
+	if err != nil {
+		return nil, promptCacheError(err, "failed to warm prompt cache")
+	}
+
+	return &WarmPromptCacheOutput{Body: status}, nil
+}
+
+// promptCacheError converts an error raised by the prompt caches service into an
+// HTTP error, with msg describing the failed operation otherwise.
+func promptCacheError(err error, msg string) error {
+	switch {
+	case errors.Is(err, promptcache.ErrNotFound):
+		return huma.Error404NotFound("prompt cache not found", err)
+	case errors.Is(err, promptcache.ErrExists):
+		return huma.Error409Conflict("prompt cache already exists", err)
+	case errors.Is(err, promptcache.ErrDeclared):
+		return huma.Error409Conflict("prompt cache is declared in the config", err)
+	case errors.Is(err, promptcache.ErrInvalidName):
+		return huma.Error400BadRequest("invalid prompt cache name", err)
+	case errors.Is(err, promptcache.ErrModelMismatch):
+		return huma.Error400BadRequest("model_id is not the model of the prompt cache", err)
+	}
+
+	if modelErr := modelError(err); modelErr != nil {
+		return modelErr
+	}
+
+	return huma.Error500InternalServerError(msg, err)
+}
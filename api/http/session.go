@@ -0,0 +1,346 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/sse"
+
+	"github.com/ju4n97/relic/internal/backend"
+	"github.com/ju4n97/relic/internal/backend/llama"
+	"github.com/ju4n97/relic/internal/service"
+	"github.com/ju4n97/relic/internal/session"
+)
+
+type (
+	// CreateSessionRequestDTO is the request body for the CreateSession operation.
+	CreateSessionRequestDTO struct {
+		Parameters   map[string]any `json:"parameters,omitempty" doc:"Generation parameters of every turn"`
+		ModelID      string         `json:"model_id,omitempty" doc:"Model ID or alias, defaults to the service default model"`
+		Profile      string         `json:"profile,omitempty" doc:"Compute profile, defaults to the first profile of the model"`
+		SystemPrompt string         `json:"system_prompt,omitempty" doc:"System prompt of the conversation"`
+		TTL          string         `json:"ttl,omitempty" doc:"Idle time after which the session expires, e.g. 30m. Defaults to sessions.ttl of the config"`
+	}
+
+	// SessionSummaryDTO describes a session without its messages.
+	SessionSummaryDTO struct {
+		CreatedAt time.Time `json:"created_at"`
+		UpdatedAt time.Time `json:"updated_at"`
+		ExpiresAt time.Time `json:"expires_at"`
+		ID        string    `json:"id"`
+		ModelID   string    `json:"model_id"`
+		Profile   string    `json:"profile,omitempty"`
+		Messages  int       `json:"messages" doc:"Messages kept, without the system prompt"`
+		Turns     int       `json:"turns" doc:"Replies generated"`
+	}
+
+	// SendMessageRequestDTO is the request body for the SendMessage operation.
+	SendMessageRequestDTO struct {
+		Parameters map[string]any `json:"parameters,omitempty" doc:"Generation parameters of this turn, overriding those of the session"`
+		Content    string         `json:"content" minLength:"1" doc:"User message"`
+	}
+
+	// SendMessageResponseDTO is the response body for the SendMessage operation.
+	SendMessageResponseDTO struct {
+		Metadata  *backend.ResponseMetadata `json:"metadata,omitempty"`
+		SessionID string                    `json:"session_id"`
+		Text      string                    `json:"text" doc:"Reply of the assistant"`
+		Turns     int                       `json:"turns" doc:"Replies generated in the session, this one included"`
+	}
+)
+
+type (
+	// CreateSessionInput is the huma input for the CreateSession operation.
+	CreateSessionInput struct {
+		Body CreateSessionRequestDTO
+	}
+
+	// SessionInput is the huma input for the operations on a session.
+	SessionInput struct {
+		SessionID string `path:"session_id"`
+	}
+
+	// SessionOutput is the huma output for the CreateSession and GetSession operations.
+	SessionOutput struct {
+		Body *session.Session
+	}
+
+	// ListSessionsOutput is the huma output for the ListSessions operation.
+	ListSessionsOutput struct {
+		Body []SessionSummaryDTO
+	}
+
+	// ExportSessionInput is the huma input for the ExportSession operation.
+	ExportSessionInput struct {
+		SessionID string `path:"session_id"`
+		Format    string `query:"format" enum:"json,text" default:"json" doc:"json for the session document, text for a plain transcript"`
+	}
+
+	// SendMessageInput is the huma input for the SendMessage and SendMessageStream operations.
+	SendMessageInput struct {
+		SessionID string `path:"session_id"`
+		Body      SendMessageRequestDTO
+	}
+
+	// SendMessageOutput is the huma output for the SendMessage operation.
+	SendMessageOutput struct {
+		Body SendMessageResponseDTO
+	}
+)
+
+// SessionHandler handles HTTP requests for chat sessions.
+type SessionHandler struct {
+	service *service.Sessions
+}
+
+// NewSessionHandler creates a new SessionHandler instance.
+func NewSessionHandler(api huma.API, svc *service.Sessions) *SessionHandler {
+	h := &SessionHandler{service: svc}
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-session",
+		Method:        http.MethodPost,
+		Path:          "/sessions",
+		Summary:       "Create a chat session kept on the server",
+		Tags:          []string{"sessions"},
+		Security:      inferenceSecurity,
+		DefaultStatus: http.StatusCreated,
+	}, h.handleCreateSession)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-sessions",
+		Method:      http.MethodGet,
+		Path:        "/sessions",
+		Summary:     "List the chat sessions of the API key",
+		Tags:        []string{"sessions"},
+		Security:    inferenceSecurity,
+	}, h.handleListSessions)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-session",
+		Method:      http.MethodGet,
+		Path:        "/sessions/{session_id}",
+		Summary:     "Get a chat session with its history",
+		Tags:        []string{"sessions"},
+		Security:    inferenceSecurity,
+	}, h.handleGetSession)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-session",
+		Method:        http.MethodDelete,
+		Path:          "/sessions/{session_id}",
+		Summary:       "Delete a chat session",
+		Tags:          []string{"sessions"},
+		Security:      inferenceSecurity,
+		DefaultStatus: http.StatusNoContent,
+	}, h.handleDeleteSession)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "export-session",
+		Method:      http.MethodGet,
+		Path:        "/sessions/{session_id}/export",
+		Summary:     "Export the history of a chat session",
+		Tags:        []string{"sessions"},
+		Security:    inferenceSecurity,
+	}, h.handleExportSession)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "send-session-message",
+		Method:      http.MethodPost,
+		Path:        "/sessions/{session_id}/messages",
+		Summary:     "Send a user message to a chat session and get the reply",
+		Tags:        []string{"sessions"},
+		Security:    inferenceSecurity,
+	}, h.handleSendMessage)
+
+	sse.Register(api, huma.Operation{
+		OperationID: "send-session-message-stream",
+		Method:      http.MethodPost,
+		Path:        "/sessions/{session_id}/messages/stream",
+		Summary:     "Send a user message to a chat session and stream the reply (SSE)",
+		Tags:        []string{"sessions"},
+		Security:    inferenceSecurity,
+	}, map[string]any{
+		"message": StreamEvent{},
+	}, h.handleSendMessageStream)
+
+	return h
+}
+
+// handleCreateSession handles the create-session operation.
+func (h *SessionHandler) handleCreateSession(ctx context.Context, input *CreateSessionInput) (*SessionOutput, error) {
+	var ttl time.Duration
+	if input.Body.TTL != "" {
+		var err error
+		if ttl, err = time.ParseDuration(input.Body.TTL); err != nil || ttl <= 0 {
+			return nil, huma.Error400BadRequest("ttl must be a positive duration, e.g. 30m")
+		}
+	}
+
+	sess, err := h.service.Create(ctx, llama.BackendName, service.CreateSessionOptions{
+		Parameters:   input.Body.Parameters,
+		ModelID:      input.Body.ModelID,
+		Profile:      input.Body.Profile,
+		SystemPrompt: input.Body.SystemPrompt,
+		TTL:          ttl,
+	})
+	if err != nil {
+		return nil, sessionError(err, "failed to create session")
+	}
+
+	return &SessionOutput{Body: sess}, nil
+}
+
+// handleListSessions handles the list-sessions operation.
+func (h *SessionHandler) handleListSessions(ctx context.Context, input *struct{}) (*ListSessionsOutput, error) {
+	sessions, err := h.service.List(ctx)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("failed to list sessions", err)
+	}
+
+	summaries := make([]SessionSummaryDTO, 0, len(sessions))
+	for _, sess := range sessions {
+		summaries = append(summaries, SessionSummaryDTO{
+			CreatedAt: sess.CreatedAt,
+			UpdatedAt: sess.UpdatedAt,
+			ExpiresAt: sess.ExpiresAt,
+			ID:        sess.ID,
+			ModelID:   sess.ModelID,
+			Profile:   sess.Profile,
+			Messages:  len(sess.Messages),
+			Turns:     sess.Turns,
+		})
+	}
+
+	return &ListSessionsOutput{Body: summaries}, nil
+}
+
+// handleGetSession handles the get-session operation.
+func (h *SessionHandler) handleGetSession(ctx context.Context, input *SessionInput) (*SessionOutput, error) {
+	sess, err := h.service.Get(ctx, input.SessionID)
+	if err != nil {
+		return nil, sessionError(err, "failed to get session")
+	}
+
+	return &SessionOutput{Body: sess}, nil
+}
+
+// handleDeleteSession handles the delete-session operation.
+func (h *SessionHandler) handleDeleteSession(ctx context.Context, input *SessionInput) (*struct{}, error) {
+	if err := h.service.Delete(ctx, input.SessionID); err != nil {
+		return nil, sessionError(err, "failed to delete session")
+	}
+
+	return nil, nil
+}
+
+// handleExportSession handles the export-session operation.
+func (h *SessionHandler) handleExportSession(ctx context.Context, input *ExportSessionInput) (*huma.StreamResponse, error) {
+	sess, err := h.service.Get(ctx, input.SessionID)
+	if err != nil {
+		return nil, sessionError(err, "failed to export session")
+	}
+
+	contentType, filename, data := "application/json", sess.ID+".json", []byte(nil)
+	if input.Format == "text" {
+		contentType, filename, data = "text/plain; charset=utf-8", sess.ID+".txt", []byte(sess.Transcript())
+	} else if data, err = json.MarshalIndent(sess, "", "  "); err != nil {
+		return nil, huma.Error500InternalServerError("failed to encode session", err)
+	}
+
+	return &huma.StreamResponse{
+		Body: func(ctx huma.Context) {
+			ctx.SetHeader("Content-Type", contentType)
+			ctx.SetHeader("Content-Disposition", `attachment; filename="`+filename+`"`)
+
+			if _, err := ctx.BodyWriter().Write(data); err != nil {
+				slog.Error("Failed to write session export", "error", err)
+			}
+		},
+	}, nil
+}
+
+// handleSendMessage handles the send-session-message operation.
+func (h *SessionHandler) handleSendMessage(ctx context.Context, input *SendMessageInput) (*SendMessageOutput, error) {
+	reply, err := h.service.Send(ctx, input.SessionID, input.Body.Content, input.Body.Parameters)
+	if err != nil {
+		return nil, sessionError(err, "failed to generate reply")
+	}
+
+	return &SendMessageOutput{
+		Body: SendMessageResponseDTO{
+			SessionID: reply.Session.ID,
+			Text:      reply.Text,
+			Turns:     reply.Session.Turns,
+			Metadata:  reply.Metadata,
+		},
+	}, nil
+}
+
+// handleSendMessageStream handles the send-session-message-stream operation.
+func (h *SessionHandler) handleSendMessageStream(ctx context.Context, input *SendMessageInput, send sse.Sender) {
+	start := time.Now()
+
+	stream, err := h.service.SendStream(ctx, input.SessionID, input.Body.Content, input.Body.Parameters)
+	if err != nil {
+		_ = send.Data(StreamEvent{Error: err.Error()})
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case chunk, ok := <-stream:
+			if !ok {
+				_ = send.Data(StreamEvent{Done: true})
+				return
+			}
+
+			if chunk.Error != nil {
+				_ = send.Data(StreamEvent{Error: chunk.Error.Error()})
+				return
+			}
+
+			if len(chunk.Data) > 0 {
+				_ = send.Data(StreamEvent{Text: string(chunk.Data)})
+			}
+
+			if chunk.Done {
+				_ = send.Data(StreamEvent{
+					Done: true,
+					Metadata: &backend.ResponseMetadata{
+						Provider:        llama.BackendName,
+						Timestamp:       time.Now(),
+						DurationSeconds: time.Since(start).Seconds(),
+						Usage:           chunk.Usage,
+						BackendSpecific: chunk.BackendSpecific,
+					},
+				})
+				return
+			}
+		}
+	}
+}
+
+// sessionError converts an error raised by the sessions service into an HTTP
+// error, with msg describing the failed operation otherwise.
+func sessionError(err error, msg string) error {
+	switch {
+	case errors.Is(err, session.ErrNotFound):
+		return huma.Error404NotFound("session not found", err)
+	case errors.Is(err, session.ErrBusy):
+		return huma.Error409Conflict("session is busy with another turn", err)
+	}
+
+	if modelErr := modelError(err); modelErr != nil {
+		return modelErr
+	}
+
+	return huma.Error500InternalServerError(msg, err)
+}
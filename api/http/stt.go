@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"io"
 	"net/http"
 
@@ -12,7 +11,6 @@ import (
 
 	"github.com/ju4n97/relic/internal/backend"
 	"github.com/ju4n97/relic/internal/backend/whisper"
-	"github.com/ju4n97/relic/internal/model"
 	"github.com/ju4n97/relic/internal/service"
 )
 
@@ -20,7 +18,8 @@ type (
 	// TranscribeRequestDTO is the request body for the Transcribe operation.
 	TranscribeRequestDTO struct {
 		AudioFile  huma.FormFile `contentType:"audio/*,application/octet-stream" form:"file" required:"true"`
-		ModelID    string        `form:"model_id" minLength:"1" required:"true"`
+		ModelID    string        `form:"model_id" doc:"Model ID or alias, defaults to the service default model"`
+		Profile    string        `form:"profile" doc:"Compute profile, defaults to the first profile of the model"`
 		Parameters string        `form:"parameters"` // JSON-encoded optional parameters
 	}
 
@@ -58,6 +57,7 @@ func NewSTTHandler(api huma.API, svc *service.STT) *STTHandler {
 		Path:          "/stt",
 		Summary:       "Transcribe speech from an audio file",
 		Tags:          []string{"stt"},
+		Security:      inferenceSecurity,
 		DefaultStatus: http.StatusOK,
 	}, h.handleTranscribe)
 
@@ -91,14 +91,15 @@ func (h *STTHandler) handleTranscribe(ctx context.Context, input *TranscribeInpu
 		ctx,
 		provider,
 		formData.ModelID,
+		formData.Profile,
 		&backend.Request{
 			Input:      bytes.NewReader(audioBytes),
 			Parameters: parameters,
 		},
 	)
 	if err != nil {
-		if errors.Is(err, model.ErrNotFound) {
-			return nil, huma.Error404NotFound("model not found", err)
+		if modelErr := modelError(err); modelErr != nil {
+			return nil, modelErr
 		}
 		return nil, huma.Error500InternalServerError("failed to transcribe", err)
 	}
@@ -0,0 +1,36 @@
+package http
+
+import (
+	"context"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// Timeout cancels the requests to the operations of the API that run longer than
+// d. Operations streaming server-sent events are left out: they last as long as
+// what they report, e.g. a generation or a multi-gigabyte download.
+func Timeout(api huma.API, d time.Duration) {
+	api.UseMiddleware(func(ctx huma.Context, next func(huma.Context)) {
+		if streams(ctx.Operation()) {
+			next(ctx)
+			return
+		}
+
+		timeoutCtx, cancel := context.WithTimeout(ctx.Context(), d)
+		defer cancel()
+
+		next(huma.WithContext(ctx, timeoutCtx))
+	})
+}
+
+// streams reports whether an operation streams server-sent events.
+func streams(op *huma.Operation) bool {
+	for _, resp := range op.Responses {
+		if _, ok := resp.Content["text/event-stream"]; ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,41 @@
+package http_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/danielgtaylor/huma/v2/sse"
+	"github.com/stretchr/testify/assert"
+
+	relichttp "github.com/ju4n97/relic/api/http"
+)
+
+func TestTimeout(t *testing.T) {
+	t.Parallel()
+
+	_, api := humatest.New(t)
+	relichttp.Timeout(api, time.Minute)
+
+	var unaryDeadline, streamDeadline bool
+	huma.Get(api, "/unary", func(ctx context.Context, _ *struct{}) (*struct{}, error) {
+		_, unaryDeadline = ctx.Deadline()
+		return nil, nil
+	})
+	sse.Register(api, huma.Operation{
+		OperationID: "stream",
+		Method:      http.MethodGet,
+		Path:        "/stream",
+	}, map[string]any{"message": struct{}{}}, func(ctx context.Context, _ *struct{}, send sse.Sender) {
+		_, streamDeadline = ctx.Deadline()
+	})
+
+	assert.Equal(t, http.StatusNoContent, api.Get("/unary").Code)
+	assert.True(t, unaryDeadline, "operations must time out")
+
+	assert.Equal(t, http.StatusOK, api.Get("/stream").Code)
+	assert.False(t, streamDeadline, "streams must not time out")
+}
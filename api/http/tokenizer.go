@@ -0,0 +1,256 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/ju4n97/relic/internal/backend"
+	"github.com/ju4n97/relic/internal/backend/llama"
+	"github.com/ju4n97/relic/internal/service"
+)
+
+type (
+	// TokenizeRequestDTO is the request body for the Tokenize operation.
+	TokenizeRequestDTO struct {
+		ModelID    string       `json:"model_id,omitempty" doc:"Model ID or alias, defaults to the service default model"`
+		Profile    string       `json:"profile,omitempty" doc:"Compute profile, defaults to the first profile of the model"`
+		Text       string       `json:"text,omitempty" doc:"Text to tokenize, unless messages are given"`
+		Messages   []MessageDTO `json:"messages,omitempty" doc:"Chat conversation, tokenized with the chat template of the model applied"`
+		AddSpecial bool         `json:"add_special,omitempty" doc:"Add the special tokens of the model, e.g. BOS, to text. Always set for messages"`
+		WithPieces bool         `json:"with_pieces,omitempty" doc:"Return the text of each token"`
+	}
+
+	// TokenizeResponseDTO is the response body for the Tokenize operation.
+	TokenizeResponseDTO struct {
+		Metadata      *backend.ResponseMetadata `json:"metadata,omitempty"`
+		Prompt        string                    `json:"prompt" doc:"Text tokenized, the chat template applied for messages"`
+		Tokens        []int                     `json:"tokens"`
+		Pieces        []string                  `json:"pieces,omitempty" doc:"Text of each token, when requested"`
+		ContextLength int                       `json:"context_length" doc:"Tokens a request can hold, prompt and reply"`
+	}
+
+	// DetokenizeRequestDTO is the request body for the Detokenize operation.
+	DetokenizeRequestDTO struct {
+		ModelID string `json:"model_id,omitempty" doc:"Model ID or alias, defaults to the service default model"`
+		Profile string `json:"profile,omitempty" doc:"Compute profile, defaults to the first profile of the model"`
+		Tokens  []int  `json:"tokens"`
+	}
+
+	// DetokenizeResponseDTO is the response body for the Detokenize operation.
+	DetokenizeResponseDTO struct {
+		Metadata *backend.ResponseMetadata `json:"metadata,omitempty"`
+		Text     string                    `json:"text"`
+	}
+
+	// ChatTemplateRequestDTO is the request body for the ApplyTemplate and
+	// CountTokens operations.
+	ChatTemplateRequestDTO struct {
+		ModelID  string       `json:"model_id,omitempty" doc:"Model ID or alias, defaults to the service default model"`
+		Profile  string       `json:"profile,omitempty" doc:"Compute profile, defaults to the first profile of the model"`
+		Messages []MessageDTO `json:"messages,omitempty" doc:"Chat conversation"`
+	}
+
+	// ApplyTemplateResponseDTO is the response body for the ApplyTemplate operation.
+	ApplyTemplateResponseDTO struct {
+		Metadata *backend.ResponseMetadata `json:"metadata,omitempty"`
+		Prompt   string                    `json:"prompt" doc:"Prompt the model is given for the conversation"`
+	}
+
+	// CountTokensResponseDTO is the response body for the CountTokens operation.
+	CountTokensResponseDTO struct {
+		Metadata      *backend.ResponseMetadata `json:"metadata,omitempty"`
+		Tokens        int                       `json:"tokens" doc:"Tokens of the conversation with the chat template applied"`
+		ContextLength int                       `json:"context_length" doc:"Tokens a request can hold, prompt and reply"`
+	}
+)
+
+type (
+	// TokenizeInput is the huma input for the Tokenize operation.
+	TokenizeInput struct {
+		Body TokenizeRequestDTO
+	}
+
+	// TokenizeOutput is the huma output for the Tokenize operation.
+	TokenizeOutput struct {
+		Body TokenizeResponseDTO
+	}
+
+	// DetokenizeInput is the huma input for the Detokenize operation.
+	DetokenizeInput struct {
+		Body DetokenizeRequestDTO
+	}
+
+	// DetokenizeOutput is the huma output for the Detokenize operation.
+	DetokenizeOutput struct {
+		Body DetokenizeResponseDTO
+	}
+
+	// ChatTemplateInput is the huma input for the ApplyTemplate and CountTokens
+	// operations.
+	ChatTemplateInput struct {
+		Body ChatTemplateRequestDTO
+	}
+
+	// ApplyTemplateOutput is the huma output for the ApplyTemplate operation.
+	ApplyTemplateOutput struct {
+		Body ApplyTemplateResponseDTO
+	}
+
+	// CountTokensOutput is the huma output for the CountTokens operation.
+	CountTokensOutput struct {
+		Body CountTokensResponseDTO
+	}
+)
+
+// TokenizerHandler handles HTTP requests for the tokenizers and chat templates
+// of the LLM models.
+type TokenizerHandler struct {
+	service *service.Tokenizer
+}
+
+// NewTokenizerHandler creates a new TokenizerHandler instance.
+func NewTokenizerHandler(api huma.API, svc *service.Tokenizer) *TokenizerHandler {
+	h := &TokenizerHandler{service: svc}
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "tokenize",
+		Method:        "POST",
+		Path:          "/llm/tokenize",
+		Summary:       "Tokenize text or a chat conversation",
+		Tags:          []string{"llm"},
+		Security:      inferenceSecurity,
+		DefaultStatus: http.StatusOK,
+	}, h.handleTokenize)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "detokenize",
+		Method:        "POST",
+		Path:          "/llm/detokenize",
+		Summary:       "Convert tokens back to text",
+		Tags:          []string{"llm"},
+		Security:      inferenceSecurity,
+		DefaultStatus: http.StatusOK,
+	}, h.handleDetokenize)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "apply-template",
+		Method:        "POST",
+		Path:          "/llm/apply-template",
+		Summary:       "Format a chat conversation with the chat template of the model",
+		Tags:          []string{"llm"},
+		Security:      inferenceSecurity,
+		DefaultStatus: http.StatusOK,
+	}, h.handleApplyTemplate)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "count-tokens",
+		Method:        "POST",
+		Path:          "/llm/count-tokens",
+		Summary:       "Count the tokens of a chat conversation",
+		Description:   "Counts the tokens of a chat conversation with the chat template of the model applied, and reports the context length of the model. Without messages, only the context length is read.",
+		Tags:          []string{"llm"},
+		Security:      inferenceSecurity,
+		DefaultStatus: http.StatusOK,
+	}, h.handleCountTokens)
+
+	return h
+}
+
+// handleTokenize handles the tokenize operation.
+func (h *TokenizerHandler) handleTokenize(ctx context.Context, input *TokenizeInput) (*TokenizeOutput, error) {
+	if input.Body.Text != "" && len(input.Body.Messages) > 0 {
+		return nil, huma.Error400BadRequest("text and messages are mutually exclusive")
+	}
+
+	tokenization, metadata, err := h.service.Tokenize(
+		ctx,
+		llama.BackendName,
+		input.Body.ModelID,
+		input.Body.Profile,
+		input.Body.Text,
+		buildMessages(input.Body.Messages),
+		service.TokenizeOptions{AddSpecial: input.Body.AddSpecial, WithPieces: input.Body.WithPieces},
+	)
+	if err != nil {
+		return nil, tokenizerError(err, "failed to tokenize")
+	}
+
+	return &TokenizeOutput{
+		Body: TokenizeResponseDTO{
+			Metadata:      metadata,
+			Prompt:        tokenization.Prompt,
+			Tokens:        tokenization.Tokens,
+			Pieces:        tokenization.Pieces,
+			ContextLength: tokenization.ContextLength,
+		},
+	}, nil
+}
+
+// handleDetokenize handles the detokenize operation.
+func (h *TokenizerHandler) handleDetokenize(ctx context.Context, input *DetokenizeInput) (*DetokenizeOutput, error) {
+	text, metadata, err := h.service.Detokenize(ctx, llama.BackendName, input.Body.ModelID, input.Body.Profile, input.Body.Tokens)
+	if err != nil {
+		return nil, tokenizerError(err, "failed to detokenize")
+	}
+
+	return &DetokenizeOutput{
+		Body: DetokenizeResponseDTO{
+			Metadata: metadata,
+			Text:     text,
+		},
+	}, nil
+}
+
+// handleApplyTemplate handles the apply-template operation.
+func (h *TokenizerHandler) handleApplyTemplate(ctx context.Context, input *ChatTemplateInput) (*ApplyTemplateOutput, error) {
+	if len(input.Body.Messages) == 0 {
+		return nil, huma.Error400BadRequest("messages is required")
+	}
+
+	prompt, metadata, err := h.service.ApplyTemplate(ctx, llama.BackendName, input.Body.ModelID, input.Body.Profile, buildMessages(input.Body.Messages))
+	if err != nil {
+		return nil, tokenizerError(err, "failed to apply chat template")
+	}
+
+	return &ApplyTemplateOutput{
+		Body: ApplyTemplateResponseDTO{
+			Metadata: metadata,
+			Prompt:   prompt,
+		},
+	}, nil
+}
+
+// handleCountTokens handles the count-tokens operation.
+func (h *TokenizerHandler) handleCountTokens(ctx context.Context, input *ChatTemplateInput) (*CountTokensOutput, error) {
+	tokenization, metadata, err := h.service.Tokenize(
+		ctx,
+		llama.BackendName,
+		input.Body.ModelID,
+		input.Body.Profile,
+		"",
+		buildMessages(input.Body.Messages),
+		service.TokenizeOptions{},
+	)
+	if err != nil {
+		return nil, tokenizerError(err, "failed to count tokens")
+	}
+
+	return &CountTokensOutput{
+		Body: CountTokensResponseDTO{
+			Metadata:      metadata,
+			Tokens:        len(tokenization.Tokens),
+			ContextLength: tokenization.ContextLength,
+		},
+	}, nil
+}
+
+// tokenizerError converts an error of the tokenizer service into an HTTP error.
+func tokenizerError(err error, msg string) error {
+	if modelErr := modelError(err); modelErr != nil {
+		return modelErr
+	}
+
+	return huma.Error500InternalServerError(msg, err)
+}
@@ -0,0 +1,187 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2/humatest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	relichttp "github.com/ju4n97/relic/api/http"
+	"github.com/ju4n97/relic/internal/backend"
+	"github.com/ju4n97/relic/internal/backend/llama"
+	"github.com/ju4n97/relic/internal/backend/llama/llamatest"
+	"github.com/ju4n97/relic/internal/config"
+	"github.com/ju4n97/relic/internal/model"
+	"github.com/ju4n97/relic/internal/service"
+)
+
+// newTokenizerAPI returns an API serving the tokenizer operations with a cached
+// LLM model, qwen, and a stub llama-server with the handlers of mux.
+func newTokenizerAPI(t *testing.T, mux *http.ServeMux) humatest.TestAPI {
+	t.Helper()
+	t.Setenv("RELIC_MODELS_PATH", "")
+
+	b := llamatest.NewBackend(t, mux)
+	backends := backend.NewRegistry()
+	require.NoError(t, backends.Register(b))
+
+	modelsDir := t.TempDir()
+	path := filepath.Join(modelsDir, "org", "qwen", "qwen.bin")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte("qwen"), 0o644))
+
+	modelConfig := config.ModelConfig{Type: "llm", Backend: llama.BackendName}
+	modelConfig.SetHuggingFaceSource(config.HuggingFaceSource{Repo: "org/qwen", Include: []string{"qwen.bin"}})
+	cfg := &config.Config{
+		Storage: config.StorageConfig{ModelsDir: modelsDir},
+		Models:  map[string]config.ModelConfig{"qwen": modelConfig},
+	}
+	cfg.Services.LLM.Models = []string{"qwen"}
+
+	manager := model.NewManager(model.WithOffline(true))
+	t.Cleanup(manager.Close)
+	require.NoError(t, manager.LoadModelsFromConfig(context.Background(), cfg))
+
+	_, api := humatest.New(t)
+	relichttp.NewTokenizerHandler(api, service.NewTokenizer(backends, manager.Registry()))
+
+	return api
+}
+
+func TestTokenizerHandler_Tokenize(t *testing.T) {
+	var requests []llama.TokenizeRequest
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /tokenize", func(w http.ResponseWriter, r *http.Request) {
+		var req llama.TokenizeRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		requests = append(requests, req)
+
+		if req.WithPieces {
+			_, _ = io.WriteString(w, `{"tokens": [{"id": 1, "piece": "<s>"}, {"id": 9, "piece": "Hi"}]}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"tokens": [9]}`)
+	})
+	api := newTokenizerAPI(t, mux)
+
+	resp := api.Post("/llm/tokenize", map[string]any{"text": "Hi"})
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	var body relichttp.TokenizeResponseDTO
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "Hi", body.Prompt)
+	assert.Equal(t, []int{9}, body.Tokens)
+	assert.Nil(t, body.Pieces)
+	assert.Equal(t, 4096, body.ContextLength)
+	require.NotNil(t, body.Metadata)
+	assert.Equal(t, llama.BackendName, body.Metadata.Provider)
+
+	resp = api.Post("/llm/tokenize", map[string]any{"text": "Hi", "add_special": true, "with_pieces": true})
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	body = relichttp.TokenizeResponseDTO{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, []int{1, 9}, body.Tokens)
+	assert.Equal(t, []string{"<s>", "Hi"}, body.Pieces)
+
+	assert.Equal(t, []llama.TokenizeRequest{
+		{Content: "Hi"},
+		{Content: "Hi", AddSpecial: true, WithPieces: true},
+	}, requests, "add_special and with_pieces reach llama-server")
+}
+
+func TestTokenizerHandler_Tokenize_TextAndMessages(t *testing.T) {
+	api := newTokenizerAPI(t, http.NewServeMux())
+
+	resp := api.Post("/llm/tokenize", map[string]any{
+		"text":     "Hi",
+		"messages": []map[string]any{{"role": "user", "content": "Hi"}},
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+}
+
+func TestTokenizerHandler_ApplyTemplate(t *testing.T) {
+	var templateReq llama.ApplyTemplateRequest
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /apply-template", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&templateReq)
+		_, _ = io.WriteString(w, `{"prompt": "<|system|>Be brief.<|user|>Hi<|assistant|>"}`)
+	})
+	api := newTokenizerAPI(t, mux)
+
+	resp := api.Post("/llm/apply-template", map[string]any{
+		"model_id": "qwen",
+		"messages": []map[string]any{
+			{"role": "system", "content": "Be brief."},
+			{"role": "user", "content": "Hi"},
+		},
+	})
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	var body relichttp.ApplyTemplateResponseDTO
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "<|system|>Be brief.<|user|>Hi<|assistant|>", body.Prompt)
+	assert.Equal(t, []llama.ChatMessage{
+		{Role: "system", Content: "Be brief."},
+		{Role: "user", Content: "Hi"},
+	}, templateReq.Messages)
+
+	resp = api.Post("/llm/apply-template", map[string]any{"model_id": "qwen"})
+	assert.Equal(t, http.StatusBadRequest, resp.Code, "messages are required")
+
+	resp = api.Post("/llm/apply-template", map[string]any{
+		"model_id": "missing",
+		"messages": []map[string]any{{"role": "user", "content": "Hi"}},
+	})
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func TestTokenizerHandler_CountTokens(t *testing.T) {
+	var tokenizeReq llama.TokenizeRequest
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /apply-template", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `{"prompt": "<|user|>Hi<|assistant|>"}`)
+	})
+	mux.HandleFunc("POST /tokenize", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&tokenizeReq)
+		_, _ = io.WriteString(w, `{"tokens": [1, 2, 3, 4]}`)
+	})
+	api := newTokenizerAPI(t, mux)
+
+	resp := api.Post("/llm/count-tokens", map[string]any{
+		"messages": []map[string]any{{"role": "user", "content": "Hi"}},
+	})
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	var body relichttp.CountTokensResponseDTO
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, 4, body.Tokens)
+	assert.Equal(t, 4096, body.ContextLength)
+	assert.Equal(t, llama.TokenizeRequest{Content: "<|user|>Hi<|assistant|>", AddSpecial: true}, tokenizeReq,
+		"the templated conversation is tokenized with the special tokens")
+}
+
+func TestTokenizerHandler_Detokenize(t *testing.T) {
+	var detokenizeReq llama.DetokenizeRequest
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /detokenize", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&detokenizeReq)
+		_, _ = io.WriteString(w, `{"content": "Hi there"}`)
+	})
+	api := newTokenizerAPI(t, mux)
+
+	resp := api.Post("/llm/detokenize", map[string]any{"tokens": []int{9, 10}})
+	require.Equal(t, http.StatusOK, resp.Code, resp.Body.String())
+
+	var body relichttp.DetokenizeResponseDTO
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "Hi there", body.Text)
+	assert.Equal(t, []int{9, 10}, detokenizeReq.Tokens)
+}
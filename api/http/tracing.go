@@ -0,0 +1,31 @@
+package http
+
+import (
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceOperations names the span of each request handled by the API after its
+// operation, e.g. "POST /v1/llm", and records the operation ID. The span itself
+// is created by the HTTP tracing middleware of the router.
+func TraceOperations(api huma.API) {
+	api.UseMiddleware(func(ctx huma.Context, next func(huma.Context)) {
+		span := trace.SpanFromContext(ctx.Context())
+
+		op := ctx.Operation()
+		route := op.Path
+		if rctx := chi.RouteContext(ctx.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			route = rctx.RoutePattern()
+		}
+
+		span.SetName(op.Method + " " + route)
+		span.SetAttributes(
+			attribute.String("http.route", route),
+			attribute.String("relic.operation", op.OperationID),
+		)
+
+		next(ctx)
+	})
+}
@@ -2,7 +2,6 @@ package http
 
 import (
 	"context"
-	"errors"
 	"io"
 	"log/slog"
 	"net/http"
@@ -12,7 +11,6 @@ import (
 
 	"github.com/ju4n97/relic/internal/backend"
 	"github.com/ju4n97/relic/internal/backend/piper"
-	"github.com/ju4n97/relic/internal/model"
 	"github.com/ju4n97/relic/internal/service"
 )
 
@@ -20,7 +18,8 @@ type (
 	// SynthesizeRequestDTO is the request body for the Synthesize operation.
 	SynthesizeRequestDTO struct {
 		Parameters map[string]any `json:"parameters,omitempty"`
-		ModelID    string         `json:"model_id" minLength:"1"`
+		ModelID    string         `json:"model_id,omitempty" doc:"Model ID or alias, defaults to the service default model"`
+		Profile    string         `json:"profile,omitempty" doc:"Compute profile, defaults to the first profile of the model"`
 		Text       string         `json:"text" maxLength:"4096" minLength:"1"`
 	}
 )
@@ -47,6 +46,7 @@ func NewTTSHandler(api huma.API, svc *service.TTS) *TTSHandler {
 		Path:          "/tts",
 		Summary:       "Synthesize speech from a text",
 		Tags:          []string{"tts"},
+		Security:      inferenceSecurity,
 		DefaultStatus: http.StatusOK,
 	}, h.handleSynthesize)
 
@@ -61,14 +61,15 @@ func (h *TTSHandler) handleSynthesize(ctx context.Context, input *SynthesizeInpu
 		ctx,
 		provider,
 		input.Body.ModelID,
+		input.Body.Profile,
 		&backend.Request{
 			Input:      strings.NewReader(input.Body.Text),
 			Parameters: input.Body.Parameters,
 		},
 	)
 	if err != nil {
-		if errors.Is(err, model.ErrNotFound) {
-			return nil, huma.Error404NotFound("model not found", err)
+		if modelErr := modelError(err); modelErr != nil {
+			return nil, modelErr
 		}
 		return nil, huma.Error500InternalServerError("failed to synthesize", err)
 	}
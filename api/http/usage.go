@@ -0,0 +1,74 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/ju4n97/relic/internal/limits"
+)
+
+type (
+	// UsageInput is the huma input for the GetUsage operation.
+	UsageInput struct {
+		Client string `query:"client" doc:"Client identity, e.g. key:ops, cert:voice-agent or ip:10.0.0.7"`
+		Model  string `query:"model"  doc:"Model ID"`
+		From   string `query:"from"   doc:"First UTC day, inclusive" format:"date"`
+		To     string `query:"to"     doc:"Last UTC day, inclusive"  format:"date"`
+	}
+
+	// UsageOutput is the huma output for the GetUsage operation.
+	UsageOutput struct {
+		Body UsageResponseDTO
+	}
+
+	// UsageResponseDTO is the usage recorded in the ledger.
+	UsageResponseDTO struct {
+		Entries []limits.Entry `json:"entries" doc:"Usage per UTC day, client and model"`
+		Total   limits.Tally   `json:"total"   doc:"Sum of the entries"`
+	}
+)
+
+// UsageHandler handles HTTP requests for the usage ledger.
+type UsageHandler struct {
+	limiter *limits.Limiter
+}
+
+// NewUsageHandler creates a new UsageHandler instance.
+func NewUsageHandler(api huma.API, limiter *limits.Limiter) *UsageHandler {
+	h := &UsageHandler{limiter: limiter}
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-usage",
+		Method:      http.MethodGet,
+		Path:        "/usage",
+		Summary:     "Report the inference usage per client and model",
+		Tags:        []string{"usage"},
+		Security:    adminSecurity,
+	}, h.handleGetUsage)
+
+	return h
+}
+
+// handleGetUsage handles the GetUsage operation.
+func (h *UsageHandler) handleGetUsage(ctx context.Context, input *UsageInput) (*UsageOutput, error) {
+	entries := h.limiter.Ledger().Entries(limits.Filter{
+		Client: input.Client,
+		Model:  input.Model,
+		From:   input.From,
+		To:     input.To,
+	})
+
+	var total limits.Tally
+	for _, entry := range entries {
+		total.Add(&entry.Tally)
+	}
+
+	return &UsageOutput{
+		Body: UsageResponseDTO{
+			Entries: entries,
+			Total:   total,
+		},
+	}, nil
+}
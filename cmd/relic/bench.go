@@ -0,0 +1,252 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ju4n97/relic/internal/bench"
+	"github.com/ju4n97/relic/internal/model"
+	"github.com/ju4n97/relic/internal/service"
+)
+
+// defaultBenchPrompt is the prompt of the LLM benchmark when none is given.
+const defaultBenchPrompt = "Explain how a hash map works, including how collisions are handled."
+
+// defaultBenchText is the text of the TTS benchmark when none is given.
+const defaultBenchText = "The quick brown fox jumps over the lazy dog. Pack my box with five dozen liquor jugs."
+
+// reportWriters write a benchmark report in each output format.
+var reportWriters = map[string]func(*bench.Report, io.Writer) error{
+	"table": (*bench.Report).WriteTable,
+	"json":  (*bench.Report).WriteJSON,
+	"csv":   (*bench.Report).WriteCSV,
+}
+
+// runBench runs the bench subcommand and returns the process exit code.
+//
+//	relic bench <llm|stt|tts> [flags] [model...]  Measure the latency and
+//	                                              throughput of models, side by
+//	                                              side when several are given.
+func runBench(args []string) int {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		fmt.Fprintln(os.Stderr, "usage: relic bench <llm|stt|tts> [flags] [model...]")
+		return 2
+	}
+	task := model.Type(args[0])
+	if task != model.TypeLLM && task != model.TypeSTT && task != model.TypeTTS {
+		fmt.Fprintf(os.Stderr, "relic: unknown benchmark %q, want llm, stt or tts\n", task)
+		return 2
+	}
+
+	fs := flag.NewFlagSet("bench "+args[0], flag.ContinueOnError)
+	configs := addConfigFlags(fs)
+	backends := addBackendFlags(fs)
+	flagProfiles := fs.String("profile", "", "Comma-separated compute profiles each model is measured with (default: the model's)")
+	flagConcurrency := fs.String("concurrency", "1", "Comma-separated numbers of requests in flight at once, each measured in turn")
+	flagRequests := fs.Int("requests", 10, "Requests measured per model, profile and concurrency")
+	flagWarmup := fs.Int("warmup", 1, "Requests sent before measuring, e.g. to load the model")
+	flagFormat := fs.String("format", "table", "Output format: table, json or csv")
+	flagOutput := fs.String("o", "-", "File the report is written to, standard output with \"-\"")
+	flagPrompt := fs.String("prompt", defaultBenchPrompt, "Prompt of the llm benchmark")
+	flagPromptFile := fs.String("prompt-file", "", "File holding the prompt of the llm benchmark, overrides --prompt")
+	flagMaxTokens := fs.Int("max-tokens", 128, "Tokens generated per reply by the llm benchmark, unlimited when 0")
+	flagAudio := fs.String("audio", "", "WAV file, or directory of WAV files, transcribed by the stt benchmark; a .txt file next to each is its reference transcript")
+	flagLanguage := fs.String("language", "", "Language of the audio of the stt benchmark, detected when empty")
+	flagText := fs.String("text", defaultBenchText, "Text synthesized by the tts benchmark")
+	flagTextFile := fs.String("text-file", "", "File with a text per line synthesized in turn by the tts benchmark, overrides --text")
+	modelIDs, err := parseArgs(fs, args[1:])
+	if err != nil {
+		return 2
+	}
+
+	write, ok := reportWriters[*flagFormat]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "relic: unknown format %q, want table, json or csv\n", *flagFormat)
+		return 2
+	}
+
+	concurrency, err := parseConcurrency(*flagConcurrency)
+	if err != nil {
+		printError(err)
+		return 2
+	}
+	if task == model.TypeSTT && *flagAudio == "" {
+		fmt.Fprintln(os.Stderr, "relic: the stt benchmark needs --audio")
+		return 2
+	}
+
+	quietLogs()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	cfg, err := configs.load()
+	if err != nil {
+		printError(err)
+		return 1
+	}
+
+	local, err := startLocalModels(ctx, cfg, backends)
+	if err != nil {
+		printError(err)
+		return 1
+	}
+	defer local.close()
+
+	var workload bench.Workload
+	switch task {
+	case model.TypeLLM:
+		prompt := *flagPrompt
+		if *flagPromptFile != "" {
+			data, err := os.ReadFile(*flagPromptFile)
+			if err != nil {
+				printError(err)
+				return 1
+			}
+			prompt = string(data)
+		}
+		workload = &bench.LLM{
+			Service:   service.NewLLM(local.backends, local.registry()),
+			Prompts:   []string{prompt},
+			MaxTokens: *flagMaxTokens,
+		}
+	case model.TypeSTT:
+		inputs, err := readBenchAudio(*flagAudio)
+		if err != nil {
+			printError(err)
+			return 1
+		}
+		workload = &bench.STT{
+			Service:  service.NewSTT(local.backends, local.registry()),
+			Inputs:   inputs,
+			Language: *flagLanguage,
+		}
+	default:
+		texts := []string{*flagText}
+		if *flagTextFile != "" {
+			if texts, err = readLines(*flagTextFile); err != nil {
+				printError(err)
+				return 1
+			}
+		}
+		workload = &bench.TTS{
+			Service: service.NewTTS(local.backends, local.registry()),
+			Texts:   texts,
+		}
+	}
+
+	if len(modelIDs) == 0 {
+		modelIDs = []string{local.registry().Routes().Default(task)}
+	}
+
+	report := &bench.Report{StartedAt: time.Now()}
+	for _, modelID := range modelIDs {
+		for _, profile := range strings.Split(*flagProfiles, ",") {
+			target := bench.Target{Model: modelID, Profile: strings.TrimSpace(profile)}
+
+			for _, c := range concurrency {
+				fmt.Fprintf(os.Stderr, "Benchmarking %s with %d requests at concurrency %d...\n", target, *flagRequests, c)
+
+				result, err := bench.Run(ctx, workload, target, bench.Options{
+					Requests:    *flagRequests,
+					Concurrency: c,
+					Warmup:      *flagWarmup,
+				})
+				if err != nil {
+					if errors.Is(err, context.Canceled) {
+						return 130
+					}
+					printError(err)
+					return 1
+				}
+				report.Results = append(report.Results, result)
+			}
+		}
+	}
+
+	if err := writeOutput(*flagOutput, func(w io.Writer) error { return write(report, w) }); err != nil {
+		printError(err)
+		return 1
+	}
+
+	return 0
+}
+
+// parseConcurrency parses a comma-separated list of concurrency levels.
+func parseConcurrency(list string) ([]int, error) {
+	var levels []int
+	for _, field := range strings.Split(list, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid concurrency %q, want positive numbers", field)
+		}
+		levels = append(levels, n)
+	}
+
+	return levels, nil
+}
+
+// readBenchAudio reads a WAV file, or the WAV files of a directory, and their
+// reference transcripts, the .txt files of the same name.
+func readBenchAudio(path string) ([]bench.Audio, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		if files, err = filepath.Glob(filepath.Join(path, "*.wav")); err != nil {
+			return nil, err
+		}
+		if len(files) == 0 {
+			return nil, fmt.Errorf("no WAV files in %s", path)
+		}
+	}
+
+	inputs := make([]bench.Audio, 0, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		reference, err := os.ReadFile(strings.TrimSuffix(file, filepath.Ext(file)) + ".txt")
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+
+		inputs = append(inputs, bench.Audio{Name: filepath.Base(file), Data: data, Reference: string(reference)})
+	}
+
+	return inputs, nil
+}
+
+// readLines reads the non-empty lines of a file.
+func readLines(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	for line := range strings.Lines(string(data)) {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("%s is empty", path)
+	}
+
+	return lines, nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ju4n97/relic/internal/cache"
+	"github.com/ju4n97/relic/internal/model"
+)
+
+// runCache runs the cache subcommand and returns the process exit code.
+//
+//	relic cache [--json]              Report the disk usage of the models cache.
+//	relic cache gc [--dry-run] [--json] Remove files no configured model references.
+func runCache(args []string) int {
+	gc := len(args) > 0 && args[0] == "gc"
+	if gc {
+		args = args[1:]
+	}
+
+	fs := flag.NewFlagSet("cache", flag.ContinueOnError)
+	flags := addConfigFlags(fs)
+	flagJSON := fs.Bool("json", false, "Print the result as JSON")
+	flagDryRun := fs.Bool("dry-run", false, "Only list the files gc would remove")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	cfg, err := flags.load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "relic: %v\n", err)
+		return 1
+	}
+
+	ctx := context.Background()
+
+	if !gc {
+		report, err := model.CacheReport(ctx, cfg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "relic: %v\n", err)
+			return 1
+		}
+
+		if *flagJSON {
+			return printJSON(report)
+		}
+		printCacheReport(os.Stdout, report)
+		return 0
+	}
+
+	result, err := model.CollectGarbage(ctx, cfg, *flagDryRun)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "relic: %v\n", err)
+		return 1
+	}
+
+	if *flagJSON {
+		return printJSON(result)
+	}
+	printGCResult(os.Stdout, result)
+	return 0
+}
+
+// printCacheReport prints a cache report as a table.
+func printCacheReport(w io.Writer, report *cache.Report) {
+	fmt.Fprintf(w, "Models directory: %s\n", report.ModelsDir)
+	if report.MaxBytes > 0 {
+		fmt.Fprintf(w, "Total size:       %s of %s\n", cache.FormatBytes(report.TotalBytes), cache.FormatBytes(report.MaxBytes))
+	} else {
+		fmt.Fprintf(w, "Total size:       %s\n", cache.FormatBytes(report.TotalBytes))
+	}
+	fmt.Fprintf(w, "Unreferenced:     %s in %d files\n\n", cache.FormatBytes(report.UnreferencedBytes), len(report.UnreferencedFiles))
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "MODEL\tSIZE\tFILES\tCONFIGURED\tSHARED\tLAST USED")
+	for _, usage := range report.Models {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%t\t%t\t%s\n",
+			usage.ModelID,
+			cache.FormatBytes(usage.SizeBytes),
+			usage.Files,
+			usage.Configured,
+			usage.Shared,
+			usage.LastUsed.Format(time.DateTime),
+		)
+	}
+	_ = tw.Flush()
+}
+
+// printGCResult prints the outcome of a garbage collection.
+func printGCResult(w io.Writer, result *cache.GCResult) {
+	verb := "Removed"
+	if result.DryRun {
+		verb = "Would remove"
+	}
+
+	for _, file := range result.Removed {
+		fmt.Fprintf(w, "%s %s\n", verb, file)
+	}
+	fmt.Fprintf(w, "%s %d files, %s\n", verb, len(result.Removed), cache.FormatBytes(result.FreedBytes))
+}
+
+// printJSON prints v as indented JSON and returns the process exit code.
+func printJSON(v any) int {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		fmt.Fprintf(os.Stderr, "relic: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
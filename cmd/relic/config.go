@@ -0,0 +1,154 @@
+package main
+
+import (
+	"cmp"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/ju4n97/relic/internal/config"
+)
+
+// configFlags are the flags selecting the config files, shared by the commands.
+type configFlags struct {
+	path    *string
+	schema  *string
+	overlay *string
+}
+
+// addConfigFlags registers the config flags on a flag set.
+func addConfigFlags(fs *flag.FlagSet) *configFlags {
+	return &configFlags{
+		path:    fs.String("config", path.Join(config.DefaultConfigPath(), "config.yaml"), "Path to config file"),
+		schema:  fs.String("schema", "", "Path to a schema file overriding the embedded one"),
+		overlay: fs.String("overlay", "", "Path to a config file merged on top of the config (default: <config>.<RELIC_ENV>.yaml if it exists)"),
+	}
+}
+
+// overlays returns the config files to merge on top of the config.
+func (f *configFlags) overlays() []string {
+	if overlay := cmp.Or(*f.overlay, config.DefaultOverlayPath(*f.path)); overlay != "" {
+		return []string{overlay}
+	}
+
+	return nil
+}
+
+// load loads and validates the config.
+func (f *configFlags) load() (*config.Config, error) {
+	return config.LoadAndValidate(*f.path, *f.schema, f.overlays()...)
+}
+
+// runConfig runs the config subcommand and returns the process exit code.
+//
+//	relic config validate [--json]                Report every problem of the config.
+//	relic config print [--json] [--show-secrets]  Print the effective config.
+func runConfig(args []string) int {
+	if len(args) == 0 || (args[0] != "validate" && args[0] != "print") {
+		fmt.Fprintln(os.Stderr, "usage: relic config validate|print [flags]")
+		return 2
+	}
+	command, args := args[0], args[1:]
+
+	fs := flag.NewFlagSet("config "+command, flag.ContinueOnError)
+	flags := addConfigFlags(fs)
+	flagJSON := fs.Bool("json", false, "Print the result as JSON")
+	flagShowSecrets := fs.Bool("show-secrets", false, "Print secrets such as access tokens instead of masking them")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if command == "validate" {
+		issues, err := config.Check(*flags.path, *flags.schema, flags.overlays()...)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "relic: %v\n", err)
+			return 1
+		}
+
+		if *flagJSON {
+			if code := printJSON(issues); code != 0 {
+				return code
+			}
+		} else {
+			printIssues(os.Stdout, issues)
+		}
+
+		for _, issue := range issues {
+			if !issue.Warning {
+				return 1
+			}
+		}
+		return 0
+	}
+
+	cfg, err := flags.load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "relic: %v\n", err)
+		return 1
+	}
+
+	cfg = cfg.Effective()
+	if !*flagShowSecrets {
+		cfg = cfg.Redacted()
+	}
+
+	if *flagJSON {
+		return printJSON(cfg)
+	}
+
+	enc := yaml.NewEncoder(os.Stdout)
+	enc.SetIndent(2)
+	if err := errors.Join(enc.Encode(cfg), enc.Close()); err != nil {
+		fmt.Fprintf(os.Stderr, "relic: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// printIssues prints config issues, one per line, followed by a summary.
+func printIssues(w io.Writer, issues []config.Issue) {
+	var errorCount, warningCount int
+	for _, issue := range issues {
+		if issue.Position != nil {
+			position := *issue.Position
+			position.File = relativePath(position.File)
+			issue.Position = &position
+		}
+		fmt.Fprintln(w, issue)
+
+		if issue.Warning {
+			warningCount++
+		} else {
+			errorCount++
+		}
+	}
+
+	if len(issues) == 0 {
+		fmt.Fprintln(w, "Config is valid")
+		return
+	}
+	fmt.Fprintf(w, "%d errors, %d warnings\n", errorCount, warningCount)
+}
+
+// relativePath returns path relative to the working directory when it is below it.
+func relativePath(path string) string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return path
+	}
+
+	rel, err := filepath.Rel(wd, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+
+	return rel
+}
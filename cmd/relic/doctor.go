@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ju4n97/relic/internal/backend/llama"
+	"github.com/ju4n97/relic/internal/backend/piper"
+	"github.com/ju4n97/relic/internal/backend/whisper"
+	"github.com/ju4n97/relic/internal/config"
+	"github.com/ju4n97/relic/internal/doctor"
+)
+
+// statusMarks are the marks of the check statuses in the doctor output.
+var statusMarks = map[doctor.Status]string{
+	doctor.StatusOK:      "ok",
+	doctor.StatusWarning: "warn",
+	doctor.StatusFailed:  "FAIL",
+	doctor.StatusSkipped: "skip",
+}
+
+// runDoctor runs the doctor subcommand and returns the process exit code, 1 if
+// any check failed.
+//
+//	relic doctor [flags]  Check the backend binaries, ports, models directory,
+//	                      config and cached models, and suggest fixes.
+func runDoctor(args []string) int {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	configs := addConfigFlags(fs)
+	backends := addBackendFlags(fs)
+	flagHTTPPort := fs.Int("http-port", config.DefaultHTTPPort(), "HTTP port the server listens on")
+	flagGRPCPort := fs.Int("grpc-port", config.DefaultGRPCPort(), "gRPC port the server listens on")
+	flagJSON := fs.Bool("json", false, "Print the result as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	quietLogs()
+
+	d := doctor.New(
+		doctor.WithConfig(*configs.path, *configs.schema, configs.overlays()...),
+		doctor.WithBinary(llama.BackendName, *backends.llama, "llama-bin"),
+		doctor.WithBinary(whisper.BackendName, *backends.whisper, "whisper-bin"),
+		doctor.WithBinary(piper.BackendName, *backends.piper, "piper-bin"),
+		doctor.WithPort("HTTP", *flagHTTPPort, "http-port"),
+		doctor.WithPort("gRPC", *flagGRPCPort, "grpc-port"),
+		doctor.WithPort(llama.BackendName, llama.BackendPort, ""),
+		doctor.WithPort(whisper.BackendName, whisper.BackendPort, ""),
+	)
+	report := d.Run(context.Background())
+
+	if *flagJSON {
+		if code := printJSON(report); code != 0 {
+			return code
+		}
+	} else {
+		printDoctorReport(os.Stdout, report)
+	}
+
+	if report.Failed() {
+		return 1
+	}
+	return 0
+}
+
+// printDoctorReport prints the checks of a report by group, each problem
+// followed by its fix, and a summary.
+func printDoctorReport(w io.Writer, report *doctor.Report) {
+	group := ""
+	for _, check := range report.Checks {
+		if check.Group != group {
+			if group != "" {
+				fmt.Fprintln(w)
+			}
+			group = check.Group
+			fmt.Fprintln(w, group)
+		}
+
+		fmt.Fprintf(w, "  %-4s  %s", statusMarks[check.Status], check.Name)
+		if check.Detail != "" {
+			fmt.Fprintf(w, ": %s", check.Detail)
+		}
+		fmt.Fprintln(w)
+		if check.Fix != "" {
+			fmt.Fprintf(w, "        → %s\n", check.Fix)
+		}
+	}
+
+	fmt.Fprintf(w, "\n%d ok, %d warnings, %d failed\n",
+		report.Count(doctor.StatusOK),
+		report.Count(doctor.StatusWarning),
+		report.Count(doctor.StatusFailed),
+	)
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/grpc"
+
+	"github.com/ju4n97/relic/internal/auth"
+	"github.com/ju4n97/relic/internal/backend"
+	"github.com/ju4n97/relic/internal/backend/llama"
+	"github.com/ju4n97/relic/internal/backend/piper"
+	"github.com/ju4n97/relic/internal/backend/whisper"
+	"github.com/ju4n97/relic/internal/config"
+	"github.com/ju4n97/relic/internal/health"
+	"github.com/ju4n97/relic/internal/metrics"
+	"github.com/ju4n97/relic/internal/model"
+	relic "github.com/ju4n97/relic/sdk-go"
+)
+
+// backendFlags are the flags selecting the backend binaries, shared by the
+// commands that run models.
+type backendFlags struct {
+	llama        *string
+	whisper      *string
+	piper        *string
+	llamaServers *int
+}
+
+// addBackendFlags registers the backend flags on a flag set.
+func addBackendFlags(fs *flag.FlagSet) *backendFlags {
+	return &backendFlags{
+		llama:        fs.String("llama-bin", "./bin/llama-server-cuda", "Path to llama"),
+		whisper:      fs.String("whisper-bin", "./bin/whisper-server-cuda", "Path to whisper"),
+		piper:        fs.String("piper-bin", "./bin/piper-cpu/piper", "Path to piper"),
+		llamaServers: fs.Int("llama-servers", llama.DefaultMaxServers, "Number of llama-server processes run at once"),
+	}
+}
+
+// binaries returns the health checker options of the backend binaries.
+func (f *backendFlags) binaries() []health.Option {
+	return []health.Option{
+		health.WithBinary(llama.BackendName, *f.llama),
+		health.WithBinary(whisper.BackendName, *f.whisper),
+		health.WithBinary(piper.BackendName, *f.piper),
+	}
+}
+
+// register creates the backends and registers them wrapped by wrap, e.g. with
+// metrics. llama.cpp persists prompt caches in slotSavePath, if set. Backends
+// that cannot be created are logged and skipped.
+func (f *backendFlags) register(backends *backend.Registry, serverManager *backend.ServerManager, slotSavePath string, wrap func(backend.Backend) backend.Backend) {
+	create := []struct {
+		name string
+		new  func() (backend.Backend, error)
+	}{
+		{"Llama", func() (backend.Backend, error) {
+			return llama.NewBackend(*f.llama, serverManager,
+				llama.WithSlotSavePath(slotSavePath), llama.WithMaxServers(*f.llamaServers))
+		}},
+		{"Whisper", func() (backend.Backend, error) { return whisper.NewBackend(*f.whisper, serverManager) }},
+		{"Piper", func() (backend.Backend, error) { return piper.NewBackend(*f.piper) }},
+	}
+
+	for _, c := range create {
+		b, err := c.new()
+		if err != nil {
+			slog.Error("Failed to create "+c.name+" backend", "error", err)
+			continue
+		}
+		if err := backends.Register(wrap(b)); err != nil {
+			slog.Error("Failed to register "+c.name+" backend", "error", err)
+		}
+	}
+}
+
+// remoteFlags are the flags selecting the server the model commands use instead
+// of the local models.
+type remoteFlags struct {
+	server *string
+	apiKey *string
+	tls    *bool
+	rootCA *string
+}
+
+// addRemoteFlags registers the remote flags on a flag set.
+func addRemoteFlags(fs *flag.FlagSet) *remoteFlags {
+	return &remoteFlags{
+		server: fs.String("server", config.DefaultServer(), "gRPC address of a relic server to use instead of the local models, e.g. localhost:50051"),
+		apiKey: fs.String("api-key", config.DefaultAPIKey(), "API key sent to the server"),
+		tls:    fs.Bool("tls", false, "Connect to the server over TLS"),
+		rootCA: fs.String("tls-ca", "", "CA bundle the server certificate is verified with, implies --tls"),
+	}
+}
+
+// enabled reports whether a server was selected.
+func (f *remoteFlags) enabled() bool {
+	return *f.server != ""
+}
+
+// dial returns a client of the selected server.
+func (f *remoteFlags) dial() (*relic.Client, error) {
+	var opts []relic.ClientOption
+	if *f.apiKey != "" {
+		opts = append(opts, relic.WithAPIKey(*f.apiKey))
+	}
+	if *f.tls {
+		opts = append(opts, relic.WithTLS())
+	}
+	if *f.rootCA != "" {
+		opts = append(opts, relic.WithRootCA(*f.rootCA))
+	}
+
+	return relic.NewClient(*f.server, opts...)
+}
+
+// connect returns a client of the selected server or, without one, of a local
+// server started for the models of the config, and a function releasing it.
+func connect(ctx context.Context, remote *remoteFlags, configs *configFlags, backends *backendFlags) (*relic.Client, func(), error) {
+	if remote.enabled() {
+		client, err := remote.dial()
+		if err != nil {
+			return nil, nil, err
+		}
+		return client, func() { _ = client.Close() }, nil
+	}
+
+	cfg, err := configs.load()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	local, err := startLocalServer(ctx, cfg, backends)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client, err := relic.NewClient(local.address())
+	if err != nil {
+		local.close()
+		return nil, nil, err
+	}
+
+	return client, func() {
+		_ = client.Close()
+		local.close()
+	}, nil
+}
+
+// localModels are the models of a config and the backends that run them,
+// started in-process for the model commands.
+type localModels struct {
+	serverManager *backend.ServerManager
+	modelManager  *model.Manager
+	backends      *backend.Registry
+	checker       *health.Checker
+}
+
+// startLocalModels loads the cached models of a config and creates the backends.
+// Models are never downloaded, see the pull command.
+func startLocalModels(ctx context.Context, cfg *config.Config, backendFlags *backendFlags) (*localModels, error) {
+	serverManager := backend.NewServerManager()
+	modelManager := model.NewManager(
+		model.WithOffline(true),
+		model.WithOnUnload(func(instance *model.Instance) {
+			serverManager.StopServersWithArg(instance.Path)
+		}),
+	)
+
+	m := &localModels{
+		serverManager: serverManager,
+		modelManager:  modelManager,
+		backends:      backend.NewRegistry(),
+	}
+
+	if err := modelManager.LoadModelsFromConfig(ctx, cfg); err != nil {
+		m.close()
+		return nil, err
+	}
+
+	backendFlags.register(m.backends, serverManager, "", func(b backend.Backend) backend.Backend { return b })
+	m.checker = health.NewChecker(modelManager.Registry(), backendFlags.binaries()...)
+
+	return m, nil
+}
+
+// registry returns the registry of the models.
+func (m *localModels) registry() *model.Registry {
+	return m.modelManager.Registry()
+}
+
+// close stops the backends and the processes they started.
+func (m *localModels) close() {
+	if err := m.backends.Close(); err != nil {
+		slog.Error("Failed to close backends", "error", err)
+	}
+	m.serverManager.StopAll()
+	m.modelManager.Close()
+}
+
+// localServer serves the gRPC services of the local models on a unix socket, so
+// that the model commands reach them through the SDK as they would a server.
+type localServer struct {
+	models *localModels
+	server *grpc.Server
+	dir    string
+}
+
+// startLocalServer starts serving the cached models of a config.
+func startLocalServer(ctx context.Context, cfg *config.Config, backendFlags *backendFlags) (*localServer, error) {
+	models, err := startLocalModels(ctx, cfg, backendFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &localServer{
+		models: models,
+		server: buildGRPCServer(models.backends, models.modelManager, metrics.New(), auth.NewAuthenticator(), models.checker, nil),
+	}
+
+	dir, err := os.MkdirTemp("", "relic-")
+	if err != nil {
+		s.close()
+		return nil, fmt.Errorf("failed to create socket directory: %w", err)
+	}
+	s.dir = dir
+
+	var lc net.ListenConfig
+	listener, err := lc.Listen(ctx, "unix", s.socket())
+	if err != nil {
+		s.close()
+		return nil, fmt.Errorf("failed to listen on %s: %w", s.socket(), err)
+	}
+
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			slog.Error("Local gRPC server error", "error", err)
+		}
+	}()
+
+	return s, nil
+}
+
+// socket returns the path of the unix socket the server listens on.
+func (s *localServer) socket() string {
+	return filepath.Join(s.dir, "relic.sock")
+}
+
+// address returns the gRPC address of the server.
+func (s *localServer) address() string {
+	return "unix://" + s.socket()
+}
+
+// close stops the server and the local models.
+func (s *localServer) close() {
+	s.server.Stop()
+	s.models.close()
+	if s.dir != "" {
+		_ = os.RemoveAll(s.dir)
+	}
+}
+
+// parseArgs parses the flags of a command, which may come before or after its
+// arguments, and returns the arguments.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+
+		rest := fs.Args()
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...), nil // Only arguments follow "--"
+		}
+
+		args = rest
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// quietLogs drops the logs, so that they do not get in the way of the output of
+// the model commands. Their errors are reported by the commands.
+func quietLogs() {
+	slog.SetDefault(slog.New(slog.DiscardHandler))
+}
+
+// printError prints the error of a command to stderr. Errors of the SDK already
+// carry the "relic: " prefix.
+func printError(err error) {
+	msg := err.Error()
+	if !strings.HasPrefix(msg, "relic: ") {
+		msg = "relic: " + msg
+	}
+
+	fmt.Fprintln(os.Stderr, msg)
+}
@@ -9,7 +9,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
-	"path"
+	"strings"
 	"syscall"
 	"time"
 
@@ -19,37 +19,122 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 	"github.com/go-chi/httplog/v3"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 
 	relicgrpc "github.com/ju4n97/relic/api/grpc"
 	relichttp "github.com/ju4n97/relic/api/http"
+	"github.com/ju4n97/relic/internal/auth"
 	"github.com/ju4n97/relic/internal/backend"
-	"github.com/ju4n97/relic/internal/backend/llama"
-	"github.com/ju4n97/relic/internal/backend/piper"
-	"github.com/ju4n97/relic/internal/backend/whisper"
 	"github.com/ju4n97/relic/internal/config"
 	"github.com/ju4n97/relic/internal/env"
+	"github.com/ju4n97/relic/internal/health"
+	"github.com/ju4n97/relic/internal/limits"
 	"github.com/ju4n97/relic/internal/logger"
+	"github.com/ju4n97/relic/internal/metrics"
 	"github.com/ju4n97/relic/internal/model"
 	"github.com/ju4n97/relic/internal/service"
+	"github.com/ju4n97/relic/internal/tlsconfig"
+	"github.com/ju4n97/relic/internal/tracing"
 	inferencev1 "github.com/ju4n97/relic/sdk-go/pb/inference/v1"
+	inferencev2 "github.com/ju4n97/relic/sdk-go/pb/inference/v2"
 )
 
+const (
+	// healthCheckInterval is how often the readiness of the backends and models
+	// is checked, for the gRPC health service.
+	healthCheckInterval = 10 * time.Second
+
+	// sessionSweepInterval is how often expired chat sessions are deleted.
+	sessionSweepInterval = 10 * time.Minute
+
+	// httpRequestTimeout is how long the HTTP operations may run, streams aside.
+	httpRequestTimeout = 60 * time.Second
+)
+
+// usage describes the commands.
+const usage = `usage: relic <command> [flags] [arguments]
+
+Commands:
+  serve                 Run the HTTP and gRPC servers (the default)
+  pull <model>          Download a model
+  ls                    List the configured and cached models
+  rm <model>            Remove the cached files of a model
+  run <model>           Chat with a model
+  transcribe <file>     Transcribe a WAV file
+  say <text>            Synthesize speech to a WAV file
+  bench <task> [model]  Measure the latency and throughput of models
+  cache                 Report or collect the models cache
+  config                Validate or print the config
+  doctor                Diagnose the environment and suggest fixes
+
+Run "relic <command> -h" for the flags of a command. The model commands use the
+local models unless --server or RELIC_SERVER selects a server.
+`
+
 func main() {
+	// Flags without a command run the server, as before there were commands.
+	if len(os.Args) < 2 || strings.HasPrefix(os.Args[1], "-") {
+		os.Exit(runServe(os.Args[1:]))
+	}
+
+	command, args := os.Args[1], os.Args[2:]
+	switch command {
+	case "serve":
+		os.Exit(runServe(args))
+	case "pull":
+		os.Exit(runPull(args))
+	case "ls", "list":
+		os.Exit(runList(args))
+	case "rm", "remove":
+		os.Exit(runRemove(args))
+	case "run":
+		os.Exit(runRun(args))
+	case "transcribe":
+		os.Exit(runTranscribe(args))
+	case "say":
+		os.Exit(runSay(args))
+	case "bench":
+		os.Exit(runBench(args))
+	case "doctor":
+		os.Exit(runDoctor(args))
+	case "cache":
+		os.Exit(runCache(args))
+	case "config":
+		os.Exit(runConfig(args))
+	case "help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprintf(os.Stderr, "relic: unknown command %q\n\n%s", command, usage)
+		os.Exit(2)
+	}
+}
+
+// runServe runs the serve subcommand and returns the process exit code.
+//
+//	relic serve [flags]  Run the HTTP and gRPC servers until interrupted.
+func runServe(args []string) int {
 	ctx := context.Background()
 
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
 	var (
-		flagHTTPPort   = flag.Int("http-port", config.DefaultHTTPPort(), "HTTP port to listen on")
-		flagGRPCPort   = flag.Int("grpc-port", config.DefaultGRPCPort(), "gRPC port to listen on")
-		flagConfigPath = flag.String("config", path.Join(config.DefaultConfigPath(), "config.yaml"), "Path to config file")
-		flagSchemaPath = flag.String("schema", path.Join(config.DefaultConfigPath(), "relic.v1.schema.json"), "Path to schema file")
-		flagLlamaBin   = flag.String("llama-bin", "./bin/llama-server-cuda", "Path to llama")
-		flagWhisperBin = flag.String("whisper-bin", "./bin/whisper-server-cuda", "Path to whisper")
-		flagPiperBin   = flag.String("piper-bin", "./bin/piper-cpu/piper", "Path to piper")
+		flagHTTPPort = fs.Int("http-port", config.DefaultHTTPPort(), "HTTP port to listen on")
+		flagGRPCPort = fs.Int("grpc-port", config.DefaultGRPCPort(), "gRPC port to listen on")
+		flagConfig   = addConfigFlags(fs)
+		flagBackends = addBackendFlags(fs)
+		flagOffline  = fs.Bool("offline", config.DefaultOffline(), "Only use locally cached models, never download")
+		flagTLSCert  = fs.String("tls-cert", config.DefaultTLSCertFile(), "TLS certificate of the HTTP and gRPC servers, serving plaintext when empty")
+		flagTLSKey   = fs.String("tls-key", config.DefaultTLSKeyFile(), "TLS private key of the HTTP and gRPC servers")
+		flagTLSCA    = fs.String("tls-client-ca", config.DefaultTLSClientCAFile(), "CA bundle client certificates must chain to, enabling mutual TLS")
 	)
-	flag.Parse()
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
 
 	environment := env.FromEnv()
 
@@ -60,31 +145,57 @@ func main() {
 		),
 	)
 
-	modelManager := model.NewManager()
-
-	watcher, err := config.NewWatcher(*flagConfigPath, *flagSchemaPath, func(cfg *config.Config, err error) {
-		if err != nil {
-			slog.Error("Failed to reload config", "error", err)
-			return
+	shutdownTracing, err := tracing.Setup(ctx)
+	if err != nil {
+		slog.Error("Failed to set up tracing", "error", err)
+		return 1
+	}
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			slog.Error("Failed to flush traces", "error", err)
 		}
+	}()
 
-		if err := modelManager.LoadModelsFromConfig(ctx, cfg); err != nil {
-			slog.Error("Failed to load models from config", "error", err)
-			return
+	var tlsServer *tlsconfig.Server
+	if tlsOptions := (tlsconfig.Options{CertFile: *flagTLSCert, KeyFile: *flagTLSKey, ClientCAFile: *flagTLSCA}); tlsOptions.Enabled() {
+		tlsServer, err = tlsconfig.NewServer(tlsOptions)
+		if err != nil {
+			slog.Error("Failed to load TLS certificates", "error", err)
+			return 1
 		}
-	})
-	if err != nil {
-		slog.Error("Failed to create config watcher", "error", err)
-		return
+		slog.Info("TLS enabled", "cert", tlsOptions.CertFile, "mutual_tls", tlsServer.MutualTLS())
 	}
 
-	cfg := watcher.Snapshot()
-	if err := modelManager.LoadModelsFromConfig(ctx, cfg); err != nil {
-		slog.Error("Failed to load models from config", "error", err)
-		return
+	metricsRegistry := metrics.New()
+
+	serverManager := backend.NewServerManager(backend.WithOnStart(metricsRegistry.ServerStarted))
+	defer serverManager.StopAll()
+	metricsRegistry.WatchServers(serverManager)
+
+	modelManager := model.NewManager(
+		model.WithOffline(*flagOffline),
+		model.WithOnPullFinished(metricsRegistry.PullFinished),
+		model.WithOnUnload(func(instance *model.Instance) {
+			serverManager.StopServersWithArg(instance.Path)
+		}),
+	)
+	defer modelManager.Close()
+	if *flagOffline {
+		slog.Info("Offline mode enabled, models will only be resolved from the local cache")
 	}
 
-	slog.Info("Config loaded successfully", "config", *flagConfigPath, "schema", *flagSchemaPath)
+	authenticator := auth.NewAuthenticator()
+
+	checker := health.NewChecker(modelManager.Registry(), flagBackends.binaries()...)
+
+	limiter := limits.New()
+	defer func() {
+		if err := limiter.Close(); err != nil {
+			slog.Error("Failed to flush usage ledger", "error", err)
+		}
+	}()
 
 	backends := backend.NewRegistry()
 	defer func() {
@@ -93,33 +204,84 @@ func main() {
 		}
 	}()
 
-	serverManager := backend.NewServerManager()
-	defer serverManager.StopAll()
+	sessions := service.NewSessions(service.NewLLM(backends, modelManager.Registry()), modelManager.Registry())
+	promptCaches := service.NewPromptCaches(service.NewLLM(backends, modelManager.Registry()), modelManager.Registry())
+	defer func() {
+		if err := sessions.Close(); err != nil {
+			slog.Error("Failed to close session database", "error", err)
+		}
+	}()
+
+	watcher, err := config.NewWatcher(*flagConfig.path, *flagConfig.schema, func(cfg *config.Config, err error) {
+		if err != nil {
+			slog.Error("Failed to reload config, keeping the previous one", "error", err)
+			return
+		}
+
+		if err := modelManager.LoadModelsFromConfig(ctx, cfg); err != nil {
+			slog.Error("Failed to apply reloaded config, keeping the previous one", "error", err)
+			return
+		}
+		checker.Check()
+
+		if err := authenticator.Update(&cfg.Auth); err != nil {
+			slog.Error("Failed to apply reloaded API keys, keeping the previous ones", "error", err)
+		}
 
-	backendLlama, err := llama.NewBackend(*flagLlamaBin, serverManager)
+		if err := limiter.Update(cfg); err != nil {
+			slog.Error("Failed to apply reloaded limits, keeping the previous ones", "error", err)
+		}
+
+		if err := sessions.Update(cfg); err != nil {
+			slog.Error("Failed to apply reloaded sessions settings, keeping the previous ones", "error", err)
+		}
+
+		if err := promptCaches.Update(cfg); err != nil {
+			slog.Error("Failed to apply reloaded prompt caches, keeping the previous ones", "error", err)
+		}
+	}, config.WithOverlays(flagConfig.overlays()...))
 	if err != nil {
-		slog.Error("Failed to create Llama backend", "error", err)
+		slog.Error("Failed to create config watcher", "error", err)
+		return 1
 	}
-	if err := backends.Register(backendLlama); err != nil {
-		slog.Error("Failed to register Llama backend", "error", err)
+
+	metricsRegistry.CountConfigReloads(watcher.ReloadCount)
+
+	cfg := watcher.Snapshot()
+	if err := modelManager.LoadModelsFromConfig(ctx, cfg); err != nil {
+		slog.Error("Failed to load models from config", "error", err)
+		return 1
 	}
 
-	backendWhisper, err := whisper.NewBackend(*flagWhisperBin, serverManager)
-	if err != nil {
-		slog.Error("Failed to create Whisper backend", "error", err)
+	if err := authenticator.Update(&cfg.Auth); err != nil {
+		slog.Error("Failed to load API keys from config", "error", err)
+		return 1
 	}
-	if err := backends.Register(backendWhisper); err != nil {
-		slog.Error("Failed to register Whisper backend", "error", err)
+	if authenticator.Enabled() {
+		slog.Info("API key authentication enabled", "keys", len(cfg.Auth.AllKeys()))
 	}
 
-	backendPiper, err := piper.NewBackend(*flagPiperBin)
-	if err != nil {
-		slog.Error("Failed to create Piper backend", "error", err)
+	if err := limiter.Update(cfg); err != nil {
+		slog.Error("Failed to load limits from config", "error", err)
+		return 1
+	}
+
+	if err := sessions.Update(cfg); err != nil {
+		slog.Error("Failed to open session database", "error", err)
+		return 1
 	}
-	if err := backends.Register(backendPiper); err != nil {
-		slog.Error("Failed to register Piper backend", "error", err)
+
+	if err := promptCaches.Update(cfg); err != nil {
+		slog.Error("Failed to load prompt caches", "error", err)
+		return 1
 	}
 
+	slog.Info("Config loaded successfully", "config", *flagConfig.path, "files", watcher.Files())
+
+	flagBackends.register(backends, serverManager, cfg.PromptCacheDir(), func(b backend.Backend) backend.Backend {
+		return limiter.Backend(metricsRegistry.Backend(backend.Traced(b)))
+	})
+
 	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
@@ -128,8 +290,19 @@ func main() {
 
 	g, ctx := errgroup.WithContext(ctx)
 
-	httpServer := buildHTTPServer(*flagHTTPPort, backends, modelManager.Registry())
-	grpcServer := buildGRPCServer(backends, modelManager.Registry())
+	httpServer := buildHTTPServer(*flagHTTPPort, backends, modelManager, metricsRegistry, authenticator, limiter, sessions, promptCaches, checker, tlsServer)
+	grpcServer := buildGRPCServer(backends, modelManager, metricsRegistry, authenticator, checker, tlsServer)
+	checker.Check()
+
+	g.Go(func() error {
+		checker.Run(ctx, healthCheckInterval)
+		return nil
+	})
+
+	g.Go(func() error {
+		sessions.Run(ctx, sessionSweepInterval)
+		return nil
+	})
 
 	g.Go(func() error {
 		slog.Info("Starting HTTP server", "port", *flagHTTPPort)
@@ -146,6 +319,8 @@ func main() {
 	}
 
 	slog.Info("Shutting down...")
+
+	return 0
 }
 
 // runHTTPServer runs the HTTP server.
@@ -160,13 +335,20 @@ func runHTTPServer(ctx context.Context, server *http.Server) error {
 		}
 	}()
 
+	scheme := "http"
+	listen := server.ListenAndServe
+	if server.TLSConfig != nil {
+		scheme = "https"
+		listen = func() error { return server.ListenAndServeTLS("", "") }
+	}
+
 	slog.Info("Server starting",
 		"protocol", "HTTP",
-		"address", "http://localhost"+server.Addr,
-		"docs_v1", fmt.Sprintf("http://localhost%s/v1/docs", server.Addr),
+		"address", scheme+"://localhost"+server.Addr,
+		"docs_v1", fmt.Sprintf("%s://localhost%s/v1/docs", scheme, server.Addr),
 	)
 
-	if err := server.ListenAndServe(); err != http.ErrServerClosed {
+	if err := listen(); err != http.ErrServerClosed {
 		if ctx.Err() == nil {
 			return fmt.Errorf("HTTP server error: %w", err)
 		}
@@ -206,51 +388,109 @@ func runGRPCServer(ctx context.Context, server *grpc.Server, port int) error {
 }
 
 // buildHTTPServer builds the HTTP server.
-func buildHTTPServer(port int, backends *backend.Registry, models *model.Registry) *http.Server {
+func buildHTTPServer(port int, backends *backend.Registry, modelManager *model.Manager, metricsRegistry *metrics.Metrics, authenticator *auth.Authenticator, limiter *limits.Limiter, sessions *service.Sessions, promptCaches *service.PromptCaches, checker *health.Checker, tlsServer *tlsconfig.Server) *http.Server {
+	models := modelManager.Registry()
+
 	router := buildHTTPRouter()
+	router.Use(
+		otelhttp.NewMiddleware("relic.http", otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return r.Method
+		})),
+		metricsRegistry.HTTPMiddleware,
+		tlsconfig.Middleware,
+		authenticator.Middleware,
+		limits.Middleware,
+	)
 
 	router.Get("/", func(w http.ResponseWriter, r *http.Request) {
 		_, _ = w.Write([]byte("relic HTTP service is running."))
 	})
 
-	router.Get("/health", func(w http.ResponseWriter, r *http.Request) {
-		_, _ = w.Write([]byte("ok"))
-	})
+	// Liveness and readiness probes, open to requests without a key.
+	router.Get("/health", health.LiveHandler)
+	router.Get("/health/live", health.LiveHandler)
+	router.Get("/health/ready", checker.ReadyHandler)
+
+	router.With(authenticator.RequireScope(config.ScopeMetrics)).Handle("/metrics", metricsRegistry.Handler())
 
 	router.Route("/v1", func(r chi.Router) {
 		cfg := huma.DefaultConfig("RELIC", "1.0.0")
 		cfg.Servers = []*huma.Server{{URL: "/v1"}}
 		api := humachi.New(r, cfg)
+		relichttp.TraceOperations(api)
+		relichttp.Timeout(api, httpRequestTimeout)
+		relichttp.Authorize(api, authenticator)
 
 		llm := service.NewLLM(backends, models)
 		stt := service.NewSTT(backends, models)
 		tts := service.NewTTS(backends, models)
 
-		relichttp.NewLLMHandler(api, llm)
+		relichttp.NewLLMHandler(api, llm, promptCaches)
+		relichttp.NewTokenizerHandler(api, service.NewTokenizer(backends, models))
 		relichttp.NewSTTHandler(api, stt)
 		relichttp.NewTTSHandler(api, tts)
+		relichttp.NewModelHandler(api, modelManager)
+		relichttp.NewCacheHandler(api, modelManager)
+		relichttp.NewUsageHandler(api, limiter)
+		relichttp.NewSessionHandler(api, sessions)
+		relichttp.NewPromptCacheHandler(api, promptCaches)
 	})
 
-	return &http.Server{
-		Addr:    fmt.Sprintf(":%d", port),
-		Handler: router,
+	server := &http.Server{
+		Addr:        fmt.Sprintf(":%d", port),
+		Handler:     router,
+		ConnContext: limits.ConnContext,
 	}
+	if tlsServer != nil {
+		server.TLSConfig = tlsServer.Config()
+	}
+
+	return server
 }
 
 // buildGRPCServer builds the gRPC server.
-func buildGRPCServer(backends *backend.Registry, models *model.Registry) *grpc.Server {
-	server := grpc.NewServer(
+func buildGRPCServer(backends *backend.Registry, modelManager *model.Manager, metricsRegistry *metrics.Metrics, authenticator *auth.Authenticator, checker *health.Checker, tlsServer *tlsconfig.Server) *grpc.Server {
+	var opts []grpc.ServerOption
+	if tlsServer != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsServer.Config())))
+	}
+
+	server := grpc.NewServer(append(opts,
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		// Clients ping every 30 seconds by default while calls run, to notice
+		// servers that went away during long generations.
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{MinTime: 10 * time.Second}),
 		grpc.ChainUnaryInterceptor(
+			metricsRegistry.UnaryServerInterceptor(),
+			authenticator.UnaryServerInterceptor(),
+			limits.UnaryServerInterceptor(),
 			unaryLoggingInterceptor(),
 		),
 		grpc.ChainStreamInterceptor(
+			metricsRegistry.StreamServerInterceptor(),
+			authenticator.StreamServerInterceptor(),
+			limits.StreamServerInterceptor(),
 			streamLoggingInterceptor(),
 		),
-	)
+	)...)
 
-	inferenceServer := relicgrpc.NewInferenceServer(backends, models)
+	inferenceServer := relicgrpc.NewInferenceServer(backends, modelManager.Registry())
 	inferencev1.RegisterInferenceServiceServer(server, inferenceServer)
 
+	modelServer := relicgrpc.NewModelServer(modelManager)
+	inferencev1.RegisterModelServiceServer(server, modelServer)
+
+	// The typed v2 services, served alongside v1.
+	models := modelManager.Registry()
+	inferencev2.RegisterChatServiceServer(server, relicgrpc.NewChatServer(service.NewLLM(backends, models), models))
+	inferencev2.RegisterSpeechToTextServiceServer(server, relicgrpc.NewSpeechToTextServer(service.NewSTT(backends, models), models))
+	inferencev2.RegisterTextToSpeechServiceServer(server, relicgrpc.NewTextToSpeechServer(service.NewTTS(backends, models), models))
+	inferencev2.RegisterEmbeddingServiceServer(server, relicgrpc.NewEmbeddingServer(service.NewEmbedding(backends, models), models))
+	inferencev2.RegisterTokenizerServiceServer(server, relicgrpc.NewTokenizerServer(service.NewTokenizer(backends, models), models))
+	inferencev2.RegisterModelServiceServer(server, relicgrpc.NewModelServerV2(modelManager))
+
+	checker.NewGRPCServer().Register(server)
+
 	// Enable reflection for development (allows using grpcurl, grpcui, etc.)
 	if env.FromEnv() == env.EnvDevelopment {
 		reflection.Register(server)
@@ -266,7 +506,7 @@ func buildHTTPRouter() *chi.Mux {
 	router.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"https://*", "http://*"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", auth.HeaderAPIKey},
 		ExposedHeaders:   []string{"*"},
 		AllowCredentials: false,
 		MaxAge:           300,
@@ -291,7 +531,6 @@ func buildHTTPRouter() *chi.Mux {
 		}),
 		middleware.Recoverer,
 		middleware.Compress(5),
-		middleware.Timeout(60*time.Second),
 	)
 	return router
 }
@@ -313,12 +552,16 @@ func unaryLoggingInterceptor() grpc.UnaryServerInterceptor {
 			slog.ErrorContext(ctx, "gRPC unary call failed",
 				"method", info.FullMethod,
 				"duration", duration,
+				"api_key", keyName(ctx),
+				"client_identity", tlsconfig.IdentityFromContext(ctx),
 				"error", err,
 			)
 		} else {
 			slog.InfoContext(ctx, "gRPC unary call",
 				"method", info.FullMethod,
 				"duration", duration,
+				"api_key", keyName(ctx),
+				"client_identity", tlsconfig.IdentityFromContext(ctx),
 			)
 		}
 
@@ -345,6 +588,8 @@ func streamLoggingInterceptor() grpc.StreamServerInterceptor {
 				"duration", duration,
 				"is_client_stream", info.IsClientStream,
 				"is_server_stream", info.IsServerStream,
+				"api_key", keyName(ss.Context()),
+				"client_identity", tlsconfig.IdentityFromContext(ss.Context()),
 				"error", err,
 			)
 		} else {
@@ -353,9 +598,20 @@ func streamLoggingInterceptor() grpc.StreamServerInterceptor {
 				"duration", duration,
 				"is_client_stream", info.IsClientStream,
 				"is_server_stream", info.IsServerStream,
+				"api_key", keyName(ss.Context()),
+				"client_identity", tlsconfig.IdentityFromContext(ss.Context()),
 			)
 		}
 
 		return err
 	}
 }
+
+// keyName returns the name of the API key a call was authenticated with, or an
+// empty string when authentication is disabled.
+func keyName(ctx context.Context) string {
+	if key, ok := auth.KeyFromContext(ctx); ok {
+		return key.Name
+	}
+	return ""
+}
@@ -0,0 +1,345 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ju4n97/relic/internal/cache"
+	"github.com/ju4n97/relic/internal/model"
+	relic "github.com/ju4n97/relic/sdk-go"
+)
+
+// listedModel is a model listed by the ls subcommand.
+type listedModel struct {
+	ID         string   `json:"id"`
+	Type       string   `json:"type,omitempty"`
+	Backend    string   `json:"backend,omitempty"`
+	Status     string   `json:"status"`
+	Aliases    []string `json:"aliases,omitempty"`
+	SizeBytes  int64    `json:"size_bytes,omitempty"` // Only known for the local models
+	Configured bool     `json:"configured"`
+}
+
+// runPull runs the pull subcommand and returns the process exit code.
+//
+//	relic pull [flags] <model>  Download a model, showing its progress.
+func runPull(args []string) int {
+	fs := flag.NewFlagSet("pull", flag.ContinueOnError)
+	flags := addConfigFlags(fs)
+	remote := addRemoteFlags(fs)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return 2
+	}
+	if len(positional) != 1 {
+		fmt.Fprintln(os.Stderr, "usage: relic pull [flags] <model>")
+		return 2
+	}
+	modelID := positional[0]
+
+	quietLogs()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	progress := &progressLine{w: os.Stderr, modelID: modelID}
+	if remote.enabled() {
+		err = pullRemote(ctx, remote, modelID, progress)
+	} else {
+		err = pullLocal(ctx, flags, modelID, progress)
+	}
+	progress.finish()
+
+	if err != nil {
+		printError(err)
+		return 1
+	}
+
+	fmt.Printf("Pulled %s\n", modelID)
+	return 0
+}
+
+// pullLocal downloads a model of the local config.
+func pullLocal(ctx context.Context, flags *configFlags, modelID string, progress *progressLine) error {
+	cfg, err := flags.load()
+	if err != nil {
+		return err
+	}
+
+	_, err = model.Pull(ctx, cfg, modelID, func(s model.PullStatus) {
+		progress.print(s.BytesDone, s.BytesTotal, s.RateBytesPerSec, time.Duration(s.ETASeconds*float64(time.Second)))
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", modelID, err)
+	}
+
+	return nil
+}
+
+// pullRemote downloads a model on the server.
+func pullRemote(ctx context.Context, remote *remoteFlags, modelID string, progress *progressLine) error {
+	client, err := remote.dial()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	return client.PullModel(ctx, modelID, func(p relic.PullProgress) {
+		progress.print(p.BytesDone, p.BytesTotal, p.RateBytesPerSecond, p.ETA)
+	})
+}
+
+// progressLine prints the progress of a download on a single line.
+type progressLine struct {
+	w       io.Writer
+	modelID string
+	printed bool
+}
+
+// print replaces the line with the current progress.
+func (p *progressLine) print(done, total int64, rate float64, eta time.Duration) {
+	line := fmt.Sprintf("%s: %s", p.modelID, cache.FormatBytes(done))
+	if total > 0 {
+		line = fmt.Sprintf("%s: %3d%% %s / %s", p.modelID, done*100/total, cache.FormatBytes(done), cache.FormatBytes(total))
+	}
+	if rate > 0 {
+		line += fmt.Sprintf("  %s/s", cache.FormatBytes(int64(rate)))
+	}
+	if eta > 0 {
+		line += fmt.Sprintf("  ETA %s", eta.Round(time.Second))
+	}
+
+	fmt.Fprintf(p.w, "\r\033[K%s", line)
+	p.printed = true
+}
+
+// finish ends the line, if anything was printed.
+func (p *progressLine) finish() {
+	if p.printed {
+		fmt.Fprintln(p.w)
+	}
+}
+
+// runList runs the ls subcommand and returns the process exit code.
+//
+//	relic ls [--json]  List the configured models, their status and size, and
+//	                   the cached models that are no longer configured.
+func runList(args []string) int {
+	fs := flag.NewFlagSet("ls", flag.ContinueOnError)
+	flags := addConfigFlags(fs)
+	remote := addRemoteFlags(fs)
+	flagJSON := fs.Bool("json", false, "Print the result as JSON")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	quietLogs()
+
+	ctx := context.Background()
+
+	var (
+		models []listedModel
+		err    error
+	)
+	if remote.enabled() {
+		models, err = listRemote(ctx, remote)
+	} else {
+		models, err = listLocal(ctx, flags)
+	}
+	if err != nil {
+		printError(err)
+		return 1
+	}
+
+	if *flagJSON {
+		return printJSON(models)
+	}
+	printModels(os.Stdout, models)
+	return 0
+}
+
+// listLocal lists the models of the local config and the models cache.
+func listLocal(ctx context.Context, flags *configFlags) ([]listedModel, error) {
+	cfg, err := flags.load()
+	if err != nil {
+		return nil, err
+	}
+
+	manager := model.NewManager(model.WithOffline(true))
+	defer manager.Close()
+	if err := manager.LoadModelsFromConfig(ctx, cfg); err != nil {
+		return nil, err
+	}
+
+	report, err := manager.CacheReport(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sizes := map[string]int64{}
+	for _, usage := range report.Models {
+		sizes[usage.ModelID] = usage.SizeBytes
+	}
+
+	registry := manager.Registry()
+
+	var models []listedModel
+	for modelID, modelConfig := range cfg.Models {
+		// Models no service uses are not in the registry, only their files tell.
+		cached := sizes[modelID] > 0
+		if instance, ok := registry.Get(modelID); ok {
+			cached = instance.Path != ""
+		}
+		status := "not_cached"
+		if cached {
+			status = "cached"
+		}
+
+		models = append(models, listedModel{
+			ID:         modelID,
+			Type:       modelConfig.Type,
+			Backend:    modelConfig.Backend,
+			Status:     status,
+			Aliases:    registry.Routes().Aliases(modelID),
+			SizeBytes:  sizes[modelID],
+			Configured: true,
+		})
+	}
+	sortModels(models)
+
+	for _, usage := range report.Models {
+		if !usage.Configured && usage.SizeBytes > 0 {
+			models = append(models, listedModel{ID: usage.ModelID, Status: "cached", SizeBytes: usage.SizeBytes})
+		}
+	}
+
+	return models, nil
+}
+
+// listRemote lists the models configured on the server.
+func listRemote(ctx context.Context, remote *remoteFlags) ([]listedModel, error) {
+	client, err := remote.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = client.Close() }()
+
+	remoteModels, err := client.ListModels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make([]listedModel, 0, len(remoteModels))
+	for _, m := range remoteModels {
+		models = append(models, listedModel{
+			ID:         m.ID,
+			Type:       m.Type,
+			Backend:    m.Backend,
+			Status:     m.Status,
+			Aliases:    m.Aliases,
+			Configured: true,
+		})
+	}
+
+	return models, nil
+}
+
+// sortModels sorts models by type, then ID.
+func sortModels(models []listedModel) {
+	slices.SortFunc(models, func(a, b listedModel) int {
+		return cmp.Or(strings.Compare(a.Type, b.Type), strings.Compare(a.ID, b.ID))
+	})
+}
+
+// printModels prints models as a table.
+func printModels(w io.Writer, models []listedModel) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "MODEL\tTYPE\tBACKEND\tSTATUS\tSIZE\tALIASES")
+	for _, m := range models {
+		size := "-"
+		if m.SizeBytes > 0 {
+			size = cache.FormatBytes(m.SizeBytes)
+		}
+		status := m.Status
+		if !m.Configured {
+			status += " (not configured)"
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			m.ID,
+			cmp.Or(m.Type, "-"),
+			cmp.Or(m.Backend, "-"),
+			status,
+			size,
+			strings.Join(m.Aliases, ", "),
+		)
+	}
+	_ = tw.Flush()
+}
+
+// runRemove runs the rm subcommand and returns the process exit code.
+//
+//	relic rm [flags] <model>...  Remove the cached files of models, keeping
+//	                             those other models use.
+func runRemove(args []string) int {
+	fs := flag.NewFlagSet("rm", flag.ContinueOnError)
+	flags := addConfigFlags(fs)
+	remote := addRemoteFlags(fs)
+	modelIDs, err := parseArgs(fs, args)
+	if err != nil {
+		return 2
+	}
+	if len(modelIDs) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: relic rm [flags] <model>...")
+		return 2
+	}
+
+	quietLogs()
+
+	ctx := context.Background()
+
+	remove := func(modelID string) (int64, error) {
+		cfg, err := flags.load()
+		if err != nil {
+			return 0, err
+		}
+		result, err := model.RemoveModel(ctx, cfg, modelID)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", modelID, err)
+		}
+		return result.FreedBytes, nil
+	}
+	if remote.enabled() {
+		client, err := remote.dial()
+		if err != nil {
+			printError(err)
+			return 1
+		}
+		defer func() { _ = client.Close() }()
+
+		remove = func(modelID string) (int64, error) {
+			return client.RemoveModel(ctx, modelID)
+		}
+	}
+
+	code := 0
+	for _, modelID := range modelIDs {
+		freed, err := remove(modelID)
+		if err != nil {
+			printError(err)
+			code = 1
+			continue
+		}
+		fmt.Printf("Removed %s, freed %s\n", modelID, cache.FormatBytes(freed))
+	}
+
+	return code
+}
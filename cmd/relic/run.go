@@ -0,0 +1,193 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	relic "github.com/ju4n97/relic/sdk-go"
+)
+
+// replHelp describes the commands of the chat.
+const replHelp = `Commands:
+  /clear  Forget the conversation
+  /bye    Exit (or Ctrl-D)
+Ctrl-C stops the reply being generated.
+`
+
+// runRun runs the run subcommand and returns the process exit code.
+//
+//	relic run [flags] [model] [prompt]  Chat with a model, or reply to a single
+//	                                    prompt. The LLM default model is used
+//	                                    when no model is given.
+func runRun(args []string) int {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	configs := addConfigFlags(fs)
+	backends := addBackendFlags(fs)
+	remote := addRemoteFlags(fs)
+	flagProfile := fs.String("profile", "", "Compute profile the model is served with")
+	flagSystem := fs.String("system", "", "System prompt")
+	flagMaxTokens := fs.Int("max-tokens", 0, "Maximum number of tokens of each reply, unlimited when 0")
+	flagTemperature := fs.Float64("temperature", 0, "Sampling temperature (default: the model's)")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return 2
+	}
+
+	opts := []relic.Option{relic.WithProfile(*flagProfile)}
+	if len(positional) > 0 {
+		opts = append(opts, relic.WithModelID(positional[0]))
+	}
+	if *flagMaxTokens > 0 {
+		opts = append(opts, relic.WithMaxTokens(*flagMaxTokens))
+	}
+	if isSet(fs, "temperature") {
+		opts = append(opts, relic.WithTemperature(*flagTemperature))
+	}
+
+	var messages []relic.Message
+	if *flagSystem != "" {
+		messages = append(messages, relic.NewSystemMessage(*flagSystem))
+	}
+
+	quietLogs()
+
+	ctx := context.Background()
+
+	client, release, err := connect(ctx, remote, configs, backends)
+	if err != nil {
+		printError(err)
+		return 1
+	}
+	defer release()
+
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+
+	if len(positional) > 1 {
+		messages = append(messages, relic.NewUserMessage(strings.Join(positional[1:], " ")))
+		if _, err := streamReply(ctx, client, messages, opts, interrupts, os.Stdout); err != nil {
+			printError(err)
+			return 1
+		}
+		return 0
+	}
+
+	return chat(ctx, client, messages, opts, interrupts)
+}
+
+// chat runs an interactive chat until the user exits, and returns the process
+// exit code. Each reply is streamed as it is generated.
+func chat(ctx context.Context, client *relic.Client, messages []relic.Message, opts []relic.Option, interrupts <-chan os.Signal) int {
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+
+		scanner := bufio.NewScanner(os.Stdin)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	system := len(messages)
+
+	fmt.Println(`Send a message, or "/?" for help.`)
+	for {
+		fmt.Print(">>> ")
+
+		var line string
+		select {
+		case l, ok := <-lines:
+			if !ok {
+				fmt.Println()
+				return 0
+			}
+			line = strings.TrimSpace(l)
+		case <-interrupts:
+			fmt.Println()
+			return 0
+		}
+
+		switch line {
+		case "":
+			continue
+		case "/bye", "/exit":
+			return 0
+		case "/clear":
+			messages = messages[:system]
+			fmt.Println("Conversation cleared.")
+			continue
+		case "/?", "/help":
+			fmt.Print(replHelp)
+			continue
+		}
+
+		messages = append(messages, relic.NewUserMessage(line))
+
+		reply, err := streamReply(ctx, client, messages, opts, interrupts, os.Stdout)
+		if err != nil {
+			// The turn is dropped, so that it can be retried.
+			messages = messages[:len(messages)-1]
+			if errors.Is(err, context.Canceled) {
+				fmt.Println("\n(interrupted)")
+			} else {
+				fmt.Println()
+				printError(err)
+			}
+			continue
+		}
+
+		messages = append(messages, relic.NewAssistantMessage(reply))
+	}
+}
+
+// streamReply prints the reply to messages as it is generated, and returns it.
+// An interrupt stops the generation with context.Canceled.
+func streamReply(ctx context.Context, client *relic.Client, messages []relic.Message, opts []relic.Option, interrupts <-chan os.Signal, w io.Writer) (string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		select {
+		case <-interrupts:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	var reply strings.Builder
+	for chunk := range client.GenerateStream(ctx, messages, opts...) {
+		if chunk.Error != nil {
+			if ctx.Err() != nil {
+				return "", context.Canceled
+			}
+			return "", chunk.Error
+		}
+
+		fmt.Fprint(w, chunk.Content)
+		reply.WriteString(chunk.Content)
+	}
+	fmt.Fprintln(w)
+
+	return reply.String(), nil
+}
+
+// isSet reports whether a flag was given on the command line.
+func isSet(fs *flag.FlagSet, name string) bool {
+	set := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+
+	return set
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	relic "github.com/ju4n97/relic/sdk-go"
+)
+
+// transcriptJSON is the JSON output of the transcribe subcommand.
+type transcriptJSON struct {
+	Text            string        `json:"text"`
+	Language        string        `json:"language,omitempty"`
+	DurationSeconds float64       `json:"duration_seconds"`
+	Segments        []segmentJSON `json:"segments"`
+}
+
+// segmentJSON is a segment of the JSON output of the transcribe subcommand.
+type segmentJSON struct {
+	Start float64 `json:"start_seconds"`
+	End   float64 `json:"end_seconds"`
+	Text  string  `json:"text"`
+}
+
+// runTranscribe runs the transcribe subcommand and returns the process exit code.
+//
+//	relic transcribe [flags] <file.wav>  Transcribe a WAV file, or standard
+//	                                     input with "-", as text, SRT or JSON.
+func runTranscribe(args []string) int {
+	fs := flag.NewFlagSet("transcribe", flag.ContinueOnError)
+	configs := addConfigFlags(fs)
+	backends := addBackendFlags(fs)
+	remote := addRemoteFlags(fs)
+	flagModel := fs.String("model", "", "Model ID or alias (default: the STT default model)")
+	flagProfile := fs.String("profile", "", "Compute profile the model is served with")
+	flagFormat := fs.String("format", "text", "Output format: text, srt or json")
+	flagOutput := fs.String("o", "-", "File the transcript is written to, standard output with \"-\"")
+	flagLanguage := fs.String("language", "", "Language of the audio, detected when empty")
+	flagTranslate := fs.Bool("translate", false, "Translate the transcript to English")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return 2
+	}
+	if len(positional) != 1 {
+		fmt.Fprintln(os.Stderr, "usage: relic transcribe [flags] <file.wav>")
+		return 2
+	}
+
+	write, ok := transcriptWriters[*flagFormat]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "relic: unknown format %q, want text, srt or json\n", *flagFormat)
+		return 2
+	}
+
+	audio, err := readInput(positional[0])
+	if err != nil {
+		printError(err)
+		return 1
+	}
+
+	opts := []relic.Option{relic.WithModelID(*flagModel), relic.WithProfile(*flagProfile)}
+	if *flagLanguage != "" {
+		opts = append(opts, relic.WithLanguage(*flagLanguage))
+	}
+	if *flagTranslate {
+		opts = append(opts, relic.WithParameter("translate", true))
+	}
+
+	quietLogs()
+
+	ctx := context.Background()
+
+	client, release, err := connect(ctx, remote, configs, backends)
+	if err != nil {
+		printError(err)
+		return 1
+	}
+	defer release()
+
+	transcript, err := client.Transcribe(ctx, audio, opts...)
+	if err != nil {
+		printError(err)
+		return 1
+	}
+
+	if err := writeOutput(*flagOutput, func(w io.Writer) error { return write(w, transcript) }); err != nil {
+		printError(err)
+		return 1
+	}
+
+	return 0
+}
+
+// transcriptWriters write a transcript in each output format.
+var transcriptWriters = map[string]func(io.Writer, *relic.Transcript) error{
+	"text": writeText,
+	"srt":  writeSRT,
+	"json": writeTranscriptJSON,
+}
+
+// writeText writes the text of a transcript.
+func writeText(w io.Writer, transcript *relic.Transcript) error {
+	_, err := fmt.Fprintln(w, strings.TrimSpace(transcript.Text))
+	return err
+}
+
+// writeSRT writes a transcript as SubRip subtitles, one per segment. Without
+// segments, the whole text is a single subtitle.
+func writeSRT(w io.Writer, transcript *relic.Transcript) error {
+	segments := transcript.Segments
+	if len(segments) == 0 {
+		segments = []relic.Segment{{Text: transcript.Text, End: transcript.Duration}}
+	}
+
+	for i, segment := range segments {
+		_, err := fmt.Fprintf(w, "%d\n%s --> %s\n%s\n\n",
+			i+1,
+			srtTimestamp(segment.Start),
+			srtTimestamp(segment.End),
+			strings.TrimSpace(segment.Text),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// srtTimestamp formats a time offset as HH:MM:SS,mmm.
+func srtTimestamp(d time.Duration) string {
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d,%03d", ms/3_600_000, ms/60_000%60, ms/1000%60, ms%1000)
+}
+
+// writeTranscriptJSON writes a transcript as JSON.
+func writeTranscriptJSON(w io.Writer, transcript *relic.Transcript) error {
+	out := transcriptJSON{
+		Text:            strings.TrimSpace(transcript.Text),
+		Language:        transcript.Language,
+		DurationSeconds: transcript.Duration.Seconds(),
+		Segments:        make([]segmentJSON, len(transcript.Segments)),
+	}
+	for i, segment := range transcript.Segments {
+		out.Segments[i] = segmentJSON{
+			Start: segment.Start.Seconds(),
+			End:   segment.End.Seconds(),
+			Text:  strings.TrimSpace(segment.Text),
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// runSay runs the say subcommand and returns the process exit code.
+//
+//	relic say [flags] <text>  Synthesize speech to a WAV file. The text is read
+//	                          from standard input when not given.
+func runSay(args []string) int {
+	fs := flag.NewFlagSet("say", flag.ContinueOnError)
+	configs := addConfigFlags(fs)
+	backends := addBackendFlags(fs)
+	remote := addRemoteFlags(fs)
+	flagModel := fs.String("model", "", "Model ID or alias (default: the TTS default model)")
+	flagProfile := fs.String("profile", "", "Compute profile the model is served with")
+	flagOutput := fs.String("o", "out.wav", "File the WAV audio is written to, standard output with \"-\"")
+	flagSpeaker := fs.Int("speaker", 0, "Speaker of multi-speaker voices")
+	flagLengthScale := fs.Float64("length-scale", 1, "Speaking rate, slower above 1")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return 2
+	}
+
+	text := strings.Join(positional, " ")
+	if len(positional) == 0 {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "relic: failed to read text: %v\n", err)
+			return 1
+		}
+		text = string(data)
+	}
+	if strings.TrimSpace(text) == "" {
+		fmt.Fprintln(os.Stderr, "usage: relic say [flags] <text>")
+		return 2
+	}
+
+	opts := []relic.Option{relic.WithModelID(*flagModel), relic.WithProfile(*flagProfile)}
+	if isSet(fs, "speaker") {
+		opts = append(opts, relic.WithSpeaker(*flagSpeaker))
+	}
+	if isSet(fs, "length-scale") {
+		opts = append(opts, relic.WithParameter("length_scale", *flagLengthScale))
+	}
+
+	quietLogs()
+
+	ctx := context.Background()
+
+	client, release, err := connect(ctx, remote, configs, backends)
+	if err != nil {
+		printError(err)
+		return 1
+	}
+	defer release()
+
+	audio, err := client.SynthesizeSpeech(ctx, text, opts...)
+	if err != nil {
+		printError(err)
+		return 1
+	}
+
+	err = writeOutput(*flagOutput, func(w io.Writer) error {
+		_, err := w.Write(audio)
+		return err
+	})
+	if err != nil {
+		printError(err)
+		return 1
+	}
+
+	if *flagOutput != "-" {
+		fmt.Fprintf(os.Stderr, "Wrote %s\n", *flagOutput)
+	}
+	return 0
+}
+
+// readInput reads a file, or standard input with "-".
+func readInput(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+
+	return os.ReadFile(path)
+}
+
+// writeOutput writes to a file, or to standard output with "-".
+func writeOutput(path string, write func(io.Writer) error) error {
+	if path == "-" {
+		return write(os.Stdout)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"path"
+	"slices"
+	"sync/atomic"
+
+	"github.com/ju4n97/relic/internal/config"
+)
+
+// Key is an authenticated API key.
+type Key struct {
+	Name     string
+	Scopes   []string
+	Services []string // Services the key may use, all when empty
+	Models   []string // Glob patterns of the models the key may use, all when empty
+}
+
+// HasScope reports whether the key grants a scope. The admin scope grants every scope.
+func (k *Key) HasScope(scope string) bool {
+	return slices.Contains(k.Scopes, config.ScopeAdmin) || slices.Contains(k.Scopes, scope)
+}
+
+// AllowsModel reports whether the key may use a model of a service.
+func (k *Key) AllowsModel(service, modelID string) bool {
+	if len(k.Services) > 0 && !slices.Contains(k.Services, service) {
+		return false
+	}
+
+	if len(k.Models) == 0 {
+		return true
+	}
+
+	for _, pattern := range k.Models {
+		if ok, _ := path.Match(pattern, modelID); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Authenticator checks API keys against the keys of the config.
+// Authentication is disabled while no key is configured.
+type Authenticator struct {
+	keys atomic.Pointer[map[[sha256.Size]byte]*Key] // Keyed by the digest of the key
+}
+
+// NewAuthenticator creates an Authenticator without keys, see Update.
+func NewAuthenticator() *Authenticator {
+	a := &Authenticator{}
+	a.keys.Store(&map[[sha256.Size]byte]*Key{})
+
+	return a
+}
+
+// Update replaces the accepted keys with those of the config, e.g. on reload.
+// On error the previous keys are kept.
+func (a *Authenticator) Update(cfg *config.AuthConfig) error {
+	keys := map[[sha256.Size]byte]*Key{}
+	for _, keyConfig := range cfg.AllKeys() {
+		digest, err := config.ParseKeyHash(keyConfig.Hash)
+		if err != nil {
+			return fmt.Errorf("auth: API key %q: %w", keyConfig.Name, err)
+		}
+
+		keys[digest] = &Key{
+			Name:     keyConfig.Name,
+			Scopes:   keyConfig.Scopes,
+			Services: keyConfig.Services,
+			Models:   keyConfig.Models,
+		}
+	}
+	a.keys.Store(&keys)
+
+	return nil
+}
+
+// Enabled reports whether requests must present an API key.
+func (a *Authenticator) Enabled() bool {
+	return len(*a.keys.Load()) > 0
+}
+
+// Authenticate returns the key matching token.
+func (a *Authenticator) Authenticate(token string) (*Key, error) {
+	if token == "" {
+		return nil, ErrMissingKey
+	}
+
+	key, ok := (*a.keys.Load())[sha256.Sum256([]byte(token))]
+	if !ok {
+		return nil, ErrInvalidKey
+	}
+
+	return key, nil
+}
+
+// keyContextKey is the context key of the authenticated key.
+type keyContextKey struct{}
+
+// WithKey returns a copy of ctx carrying the authenticated key.
+func WithKey(ctx context.Context, key *Key) context.Context {
+	return context.WithValue(ctx, keyContextKey{}, key)
+}
+
+// KeyFromContext returns the key a request was authenticated with.
+func KeyFromContext(ctx context.Context) (*Key, bool) {
+	key, ok := ctx.Value(keyContextKey{}).(*Key)
+	return key, ok
+}
+
+// CheckModel returns ErrForbidden if the key of the request may not use a model
+// of a service. Requests without a key are allowed: they only get this far when
+// authentication is disabled.
+func CheckModel(ctx context.Context, service, modelID string) error {
+	key, ok := KeyFromContext(ctx)
+	if !ok || key.AllowsModel(service, modelID) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: key %q may not use %s model %s", ErrForbidden, key.Name, service, modelID)
+}
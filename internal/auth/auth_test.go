@@ -0,0 +1,180 @@
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/ju4n97/relic/internal/auth"
+	"github.com/ju4n97/relic/internal/config"
+	inferencev1 "github.com/ju4n97/relic/sdk-go/pb/inference/v1"
+)
+
+func newAuthenticator(t *testing.T) *auth.Authenticator {
+	t.Helper()
+
+	authenticator := auth.NewAuthenticator()
+	require.NoError(t, authenticator.Update(&config.AuthConfig{
+		Keys: []config.APIKeyConfig{
+			{Name: "ops", Hash: config.HashKey("admin-key"), Scopes: []string{config.ScopeAdmin}},
+			{Name: "app", Hash: config.HashKey("app-key"), Scopes: []string{config.ScopeInference}, Services: []string{"llm"}, Models: []string{"qwen*"}},
+		},
+	}))
+
+	return authenticator
+}
+
+func TestAuthenticator_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	authenticator := newAuthenticator(t)
+	require.True(t, authenticator.Enabled())
+
+	key, err := authenticator.Authenticate("app-key")
+	require.NoError(t, err)
+	assert.Equal(t, "app", key.Name)
+	assert.True(t, key.HasScope(config.ScopeInference))
+	assert.False(t, key.HasScope(config.ScopeAdmin))
+
+	admin, err := authenticator.Authenticate("admin-key")
+	require.NoError(t, err)
+	assert.True(t, admin.HasScope(config.ScopeInference), "admin grants every scope")
+
+	_, err = authenticator.Authenticate("wrong")
+	require.ErrorIs(t, err, auth.ErrInvalidKey)
+
+	_, err = authenticator.Authenticate("")
+	require.ErrorIs(t, err, auth.ErrMissingKey)
+
+	require.NoError(t, authenticator.Update(&config.AuthConfig{}))
+	assert.False(t, authenticator.Enabled())
+}
+
+func TestCheckModel(t *testing.T) {
+	t.Parallel()
+
+	key, err := newAuthenticator(t).Authenticate("app-key")
+	require.NoError(t, err)
+	ctx := auth.WithKey(context.Background(), key)
+
+	require.NoError(t, auth.CheckModel(ctx, "llm", "qwen3-4b"))
+	require.ErrorIs(t, auth.CheckModel(ctx, "llm", "llama-3"), auth.ErrForbidden)
+	require.ErrorIs(t, auth.CheckModel(ctx, "stt", "qwen-asr"), auth.ErrForbidden)
+	require.NoError(t, auth.CheckModel(context.Background(), "stt", "whisper"), "requests without a key are not restricted")
+}
+
+func TestAuthenticator_Middleware(t *testing.T) {
+	t.Parallel()
+
+	var got string
+	handler := newAuthenticator(t).Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if key, ok := auth.KeyFromContext(r.Context()); ok {
+			got = key.Name
+		}
+	}))
+
+	tests := []struct {
+		name   string
+		header map[string]string
+		status int
+		key    string
+	}{
+		{name: "bearer token", header: map[string]string{"Authorization": "Bearer app-key"}, status: http.StatusOK, key: "app"},
+		{name: "api key header", header: map[string]string{auth.HeaderAPIKey: "admin-key"}, status: http.StatusOK, key: "ops"},
+		{name: "no key", status: http.StatusOK},
+		{name: "invalid key", header: map[string]string{"Authorization": "Bearer wrong"}, status: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		got = ""
+		req := httptest.NewRequest(http.MethodGet, "/v1/models", nil)
+		for name, value := range tt.header {
+			req.Header.Set(name, value)
+		}
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, tt.status, rec.Code, tt.name)
+		assert.Equal(t, tt.key, got, tt.name)
+	}
+}
+
+func TestAuthenticator_RequireScope(t *testing.T) {
+	t.Parallel()
+
+	authenticator := auth.NewAuthenticator()
+	require.NoError(t, authenticator.Update(&config.AuthConfig{
+		Keys: []config.APIKeyConfig{
+			{Name: "ops", Hash: config.HashKey("admin-key"), Scopes: []string{config.ScopeAdmin}},
+			{Name: "app", Hash: config.HashKey("app-key"), Scopes: []string{config.ScopeInference}},
+			{Name: "prometheus", Hash: config.HashKey("metrics-key"), Scopes: []string{config.ScopeMetrics}},
+		},
+	}))
+	handler := authenticator.Middleware(authenticator.RequireScope(config.ScopeMetrics)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})))
+
+	tests := []struct {
+		name   string
+		token  string
+		status int
+	}{
+		{"metrics scope", "metrics-key", http.StatusOK},
+		{"admin scope", "admin-key", http.StatusOK},
+		{"other scope", "app-key", http.StatusForbidden},
+		{"no key", "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		if tt.token != "" {
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+		}
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, tt.status, rec.Code, tt.name)
+	}
+
+	rec := httptest.NewRecorder()
+	auth.NewAuthenticator().RequireScope(config.ScopeMetrics)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})).
+		ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rec.Code, "metrics are open without keys")
+}
+
+func TestAuthenticator_UnaryServerInterceptor(t *testing.T) {
+	t.Parallel()
+
+	interceptor := newAuthenticator(t).UnaryServerInterceptor()
+	handler := func(ctx context.Context, _ any) (any, error) {
+		key, _ := auth.KeyFromContext(ctx)
+		return key.Name, nil
+	}
+
+	call := func(method string, md metadata.MD) (any, error) {
+		ctx := metadata.NewIncomingContext(context.Background(), md)
+		return interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+	}
+
+	resp, err := call(inferencev1.InferenceService_Infer_FullMethodName, metadata.Pairs("authorization", "Bearer app-key"))
+	require.NoError(t, err)
+	assert.Equal(t, "app", resp)
+
+	_, err = call(inferencev1.ModelService_PullModel_FullMethodName, metadata.Pairs("x-api-key", "app-key"))
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	resp, err = call(inferencev1.ModelService_PullModel_FullMethodName, metadata.Pairs("x-api-key", "admin-key"))
+	require.NoError(t, err)
+	assert.Equal(t, "ops", resp)
+
+	_, err = call(inferencev1.InferenceService_Infer_FullMethodName, metadata.MD{})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -0,0 +1,10 @@
+package auth
+
+import "errors"
+
+// Error definitions for the auth package.
+var (
+	ErrMissingKey = errors.New("API key required")
+	ErrInvalidKey = errors.New("invalid API key")
+	ErrForbidden  = errors.New("API key not allowed")
+)
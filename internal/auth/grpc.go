@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/ju4n97/relic/internal/config"
+	inferencev1 "github.com/ju4n97/relic/sdk-go/pb/inference/v1"
+	inferencev2 "github.com/ju4n97/relic/sdk-go/pb/inference/v2"
+)
+
+// methodScopes are the scopes of the gRPC methods that do not require the
+// inference scope.
+var methodScopes = map[string]string{
+	inferencev1.ModelService_PullModel_FullMethodName:   config.ScopeAdmin,
+	inferencev1.ModelService_CancelPull_FullMethodName:  config.ScopeAdmin,
+	inferencev2.ModelService_PullModel_FullMethodName:   config.ScopeAdmin,
+	inferencev2.ModelService_CancelPull_FullMethodName:  config.ScopeAdmin,
+	inferencev2.ModelService_RemoveModel_FullMethodName: config.ScopeAdmin,
+}
+
+// publicMethods are the gRPC methods open to calls without a key, so
+// orchestrators can probe the server.
+var publicMethods = map[string]bool{
+	healthpb.Health_Check_FullMethodName: true,
+	healthpb.Health_Watch_FullMethodName: true,
+	healthpb.Health_List_FullMethodName:  true,
+}
+
+// UnaryServerInterceptor authenticates the API key of unary calls, see authorize.
+func (a *Authenticator) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := a.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor authenticates the API key of streaming calls, see authorize.
+func (a *Authenticator) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authorize(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &keyServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authorize authenticates the API key sent in the "authorization" metadata as a
+// bearer token, or in "x-api-key", checks it grants the scope of the method and
+// returns a copy of ctx carrying it. Nothing is enforced while no key is configured,
+// nor for the health service.
+func (a *Authenticator) authorize(ctx context.Context, method string) (context.Context, error) {
+	if !a.Enabled() || publicMethods[method] {
+		return ctx, nil
+	}
+
+	key, err := a.Authenticate(tokenFromMetadata(ctx))
+	if err != nil {
+		slog.WarnContext(ctx, "gRPC call rejected", "method", method, "error", err)
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	scope, ok := methodScopes[method]
+	if !ok {
+		scope = config.ScopeInference
+	}
+	if !key.HasScope(scope) {
+		err := errors.New("API key lacks the " + scope + " scope")
+		slog.WarnContext(ctx, "gRPC call rejected", "method", method, "api_key", key.Name, "error", err)
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	return WithKey(ctx, key), nil
+}
+
+// tokenFromMetadata returns the API key sent in the metadata of a call.
+func tokenFromMetadata(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if values := md.Get("authorization"); len(values) > 0 {
+		scheme, token, ok := strings.Cut(values[0], " ")
+		if ok && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+	}
+
+	if values := md.Get(strings.ToLower(HeaderAPIKey)); len(values) > 0 {
+		return strings.TrimSpace(values[0])
+	}
+
+	return ""
+}
+
+// keyServerStream overrides the context of a server stream with the one carrying
+// the authenticated key.
+type keyServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context implements grpc.ServerStream.
+func (s *keyServerStream) Context() context.Context {
+	return s.ctx
+}
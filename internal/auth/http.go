@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/httplog/v3"
+)
+
+// HeaderAPIKey is the header an API key may be sent in instead of Authorization.
+const HeaderAPIKey = "X-API-Key"
+
+// Middleware authenticates the API key of a request, sent as a bearer token or in
+// the X-API-Key header, and attaches it to the request context and logs. Requests
+// with an unknown key are rejected with 401. Requests without a key are passed
+// through: the operations decide whether they require one.
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := TokenFromHeader(r.Header)
+		if token == "" || !a.Enabled() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		key, err := a.Authenticate(token)
+		if err != nil {
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"title":"Unauthorized","status":401,"detail":"invalid API key"}`))
+			return
+		}
+
+		ctx := WithKey(r.Context(), key)
+		httplog.SetAttrs(ctx, slog.String("api_key", key.Name))
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequireScope returns a middleware that, once keys are defined, rejects requests
+// without a key granting scope: with 401 without a key, and with 403 with a key
+// lacking it. It runs after Middleware, which authenticates the key.
+func (a *Authenticator) RequireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !a.Enabled() {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key, ok := KeyFromContext(r.Context())
+			switch {
+			case !ok:
+				w.Header().Set("Content-Type", "application/problem+json")
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"title":"Unauthorized","status":401,"detail":"` + ErrMissingKey.Error() + `"}`))
+			case !key.HasScope(scope):
+				w.Header().Set("Content-Type", "application/problem+json")
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"title":"Forbidden","status":403,"detail":"API key lacks the ` + scope + ` scope"}`))
+			default:
+				next.ServeHTTP(w, r)
+			}
+		})
+	}
+}
+
+// TokenFromHeader returns the API key sent in the Authorization header as a bearer
+// token, or else in the X-API-Key header.
+func TokenFromHeader(header http.Header) string {
+	if authorization := header.Get("Authorization"); authorization != "" {
+		scheme, token, ok := strings.Cut(authorization, " ")
+		if ok && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+	}
+
+	return strings.TrimSpace(header.Get(HeaderAPIKey))
+}
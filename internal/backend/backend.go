@@ -2,6 +2,8 @@ package backend
 
 import (
 	"context"
+	"crypto/sha256"
+	"fmt"
 	"io"
 	"time"
 )
@@ -26,11 +28,116 @@ type StreamingBackend interface {
 	InferStream(ctx context.Context, req *Request) (<-chan StreamChunk, error)
 }
 
+// Task selects what a backend does with a request.
+type Task string
+
+const (
+	// TaskDefault is the main task of the backend, e.g. text generation for
+	// llama.cpp or speech recognition for whisper.cpp.
+	TaskDefault Task = ""
+
+	// TaskEmbedding computes text embeddings. Input is a JSON array of strings
+	// and Output a JSON array with the embedding of each of them, in order.
+	TaskEmbedding Task = "embedding"
+
+	// TaskTokenize tokenizes text with the tokenizer of the model. Input is the
+	// text, or Messages are formatted with the chat template of the model first.
+	// Output is a JSON Tokenization.
+	TaskTokenize Task = "tokenize"
+
+	// TaskDetokenize converts tokens back to text. Input is a JSON array of token
+	// IDs and Output the text.
+	TaskDetokenize Task = "detokenize"
+
+	// TaskApplyTemplate formats Messages with the chat template of the model.
+	// Output is the prompt the model is given for them.
+	TaskApplyTemplate Task = "apply_template"
+
+	// TaskWarmPromptCache processes the messages of the PromptCache of the
+	// request and persists their KV cache. Output is a JSON PromptCacheStatus.
+	TaskWarmPromptCache Task = "warm_prompt_cache"
+)
+
+// Inference reports whether requests of the task run inference: generation,
+// speech recognition and synthesis, and embeddings. The tokenizer tasks and
+// warming prompt caches are utilities serving other requests.
+func (t Task) Inference() bool {
+	switch t {
+	case TaskTokenize, TaskDetokenize, TaskApplyTemplate, TaskWarmPromptCache:
+		return false
+	default:
+		return true
+	}
+}
+
+// PromptCache is a named prompt prefix, e.g. a long system prompt, whose KV cache
+// backends keep in a slot and persist so that requests starting with it skip
+// processing it, even after a restart.
+type PromptCache struct {
+	Name     string
+	Messages []Message // Prefix of the conversation of the requests using the cache
+}
+
+// FileName returns the file the KV cache of the prefix is persisted in, for a
+// model file. It changes with the messages or the model, so that a stale cache is
+// never restored. Names of prompt caches are valid file names.
+func (c *PromptCache) FileName(modelPath string) string {
+	h := sha256.New()
+	_, _ = io.WriteString(h, modelPath)
+	for _, msg := range c.Messages {
+		_, _ = fmt.Fprintf(h, "\x00%s\x00%s", msg.Role, msg.Content)
+	}
+
+	return fmt.Sprintf("%s.%x.bin", c.Name, h.Sum(nil)[:8])
+}
+
+// PromptCacheStatus reports how a prompt cache served a request, in the
+// "prompt_cache" entry of the backend-specific metadata.
+type PromptCacheStatus struct {
+	Name         string `json:"name"`
+	Slot         int    `json:"slot"`                    // Slot the cache is pinned to
+	Restored     bool   `json:"restored,omitempty"`      // Restored from disk before the request
+	Saved        bool   `json:"saved,omitempty"`         // Persisted after the request
+	Hit          bool   `json:"hit"`                     // Prompt tokens were reused from the cache
+	CachedTokens int    `json:"cached_tokens,omitempty"` // Prompt tokens reused from the cache
+}
+
+// Tokenization is the output of TaskTokenize.
+type Tokenization struct {
+	Prompt        string   `json:"prompt"` // Text tokenized, the chat template applied for messages
+	Tokens        []int    `json:"tokens"`
+	Pieces        []string `json:"pieces,omitempty"` // Text of each token, when requested
+	ContextLength int      `json:"context_length"`   // Tokens a request can hold, prompt and reply
+}
+
 // Request encapsulates all parameters for an inference call.
 type Request struct {
-	Input      io.Reader
-	Parameters map[string]any
-	ModelPath  string
+	Input       io.Reader
+	Parameters  map[string]any
+	Messages    []Message // Chat conversation, used instead of Input by chat backends
+	Task        Task
+	ModelID     string // Model the request is served with, for logs and metrics
+	ModelPath   string
+	PromptCache *PromptCache  // Prefix of Messages kept in a persisted cache, if any
+	CacheKey    string        // Requests with the same key share a prompt prefix, e.g. the turns of a chat session
+	Launch      LaunchOptions // Options the backend server is started with
+}
+
+// Message is a message of a chat conversation.
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// LaunchOptions holds the options a backend is started with, taken from the
+// profile a request is served with. Zero values keep the backend defaults.
+// Backends ignore the options they do not support.
+type LaunchOptions struct {
+	GPULayers   *int
+	Threads     int
+	ContextSize int
+	BatchSize   int
+	Parallel    int // Requests served at once
 }
 
 // Response contains the result of an inference operation.
@@ -46,6 +153,7 @@ type Response struct {
 type ResponseMetadata struct {
 	Timestamp       time.Time      `json:"timestamp"`
 	BackendSpecific map[string]any `json:"backend_specific,omitempty"`
+	Usage           *Usage         `json:"usage,omitempty"`
 	Provider        string         `json:"provider"`
 	Model           string         `json:"model"`
 	DurationSeconds float64        `json:"inference_time_seconds"`
@@ -54,7 +162,20 @@ type ResponseMetadata struct {
 
 // StreamChunk represents a single chunk in a streaming response.
 type StreamChunk struct {
-	Error error  `json:"error,omitempty"`
-	Data  []byte `json:"data,omitempty"`
-	Done  bool   `json:"done,omitempty"`
+	Error           error          `json:"error,omitempty"`
+	Usage           *Usage         `json:"usage,omitempty"`            // Set on the last chunk when the backend reports it
+	BackendSpecific map[string]any `json:"backend_specific,omitempty"` // Set on the last chunk, as in ResponseMetadata
+	Data            []byte         `json:"data,omitempty"`
+	Done            bool           `json:"done,omitempty"`
+}
+
+// Usage describes the work done to serve a request. Backends fill the fields
+// that apply to them and leave the others zero.
+type Usage struct {
+	PromptTokens    int     `json:"prompt_tokens,omitempty"`
+	CachedTokens    int     `json:"cached_tokens,omitempty"` // Prompt tokens reused from the KV cache, included in PromptTokens
+	GeneratedTokens int     `json:"generated_tokens,omitempty"`
+	TokensPerSecond float64 `json:"tokens_per_second,omitempty"`
+	AudioSeconds    float64 `json:"audio_seconds,omitempty"` // Audio transcribed or produced
+	Characters      int     `json:"characters,omitempty"`    // Text synthesized
 }
@@ -9,7 +9,13 @@ import (
 	"log/slog"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ju4n97/relic/internal/tracing"
 )
 
 // CommandRunner is the interface for running commands.
@@ -87,6 +93,11 @@ func NewExecutorWithRunner(binaryPath string, timeout time.Duration, runner Comm
 
 // Execute runs the command and returns output.
 func (e *Executor) Execute(ctx context.Context, args []string, stdin io.Reader) (stdout, stderr []byte, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "exec "+filepath.Base(e.binaryPath), trace.WithAttributes(
+		attribute.String("process.executable.path", e.binaryPath),
+	))
+	defer func() { tracing.End(span, err) }()
+
 	ctx, cancel := context.WithTimeout(ctx, e.timeout)
 	defer cancel()
 
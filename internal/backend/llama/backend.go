@@ -5,12 +5,17 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"hash/fnv"
 	"io"
 	"net/http"
+	"os"
 	"strconv"
 	"time"
 
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
 	"github.com/ju4n97/relic/internal/backend"
 	"github.com/ju4n97/relic/internal/mapsafe"
 )
@@ -27,8 +32,9 @@ const (
 type Backend struct {
 	serverManager *backend.ServerManager
 	client        *http.Client
+	servers       serverPool
 	binPath       string
-	port          int
+	slotSavePath  string // Directory prompt caches are persisted in, if any
 }
 
 // ChatMessage represents a single message in a chat conversation.
@@ -49,6 +55,54 @@ type ChatCompletionRequest struct {
 	RepeatPenalty    float64       `json:"repeat_penalty,omitempty"`
 	PresencePenalty  float64       `json:"presence_penalty,omitempty"`
 	FrequencyPenalty float64       `json:"frequency_penalty,omitempty"`
+	CachePrompt      bool          `json:"cache_prompt"` // Reuse the KV cache of the slot for the common prompt prefix
+	IDSlot           int           `json:"id_slot"`      // Slot serving the request, -1 lets the server pick
+
+	Extra map[string]any `json:"-"` // Parameters without a field, e.g. seed, sent as they are
+}
+
+// chatParameters are the parameters of a request read into the fields of a
+// ChatCompletionRequest, or by relic. The others are passed through. id_slot is
+// dropped: the backend picks the slots, to track the prompt caches they hold.
+var chatParameters = map[string]bool{
+	"messages":          true,
+	"system_prompt":     true,
+	"stream":            true,
+	"n_predict":         true,
+	"temperature":       true,
+	"top_k":             true,
+	"top_p":             true,
+	"min_p":             true,
+	"repeat_penalty":    true,
+	"presence_penalty":  true,
+	"frequency_penalty": true,
+	"cache_prompt":      true,
+	"id_slot":           true,
+}
+
+// MarshalJSON implements json.Marshaler. The passed through parameters are added
+// to the fields.
+func (r ChatCompletionRequest) MarshalJSON() ([]byte, error) {
+	type fields ChatCompletionRequest
+	data, err := json.Marshal(fields(r))
+	if err != nil || len(r.Extra) == 0 {
+		return data, err
+	}
+
+	body := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, err
+	}
+	for name, value := range r.Extra {
+		if _, ok := body[name]; ok {
+			continue
+		}
+		if body[name], err = json.Marshal(value); err != nil {
+			return nil, fmt.Errorf("parameter %s: %w", name, err)
+		}
+	}
+
+	return json.Marshal(body)
 }
 
 // ChatCompletionResponse is a response from the llama-server API.
@@ -89,21 +143,55 @@ type Usage struct {
 	TotalTokens      int `json:"total_tokens"`
 }
 
+// Option is a function that configures a Backend.
+type Option func(*Backend)
+
+// WithPort sets the port the first llama-server listens on, BackendPort by
+// default. The others listen on free ports.
+func WithPort(port int) Option {
+	return func(b *Backend) {
+		b.servers.port = port
+	}
+}
+
 // NewBackend creates a new Backend instance.
-func NewBackend(binPath string, serverManager *backend.ServerManager) (backend.StreamingBackend, error) {
-	return &Backend{
+func NewBackend(binPath string, serverManager *backend.ServerManager, opts ...Option) (backend.StreamingBackend, error) {
+	b := &Backend{
 		binPath:       binPath,
 		serverManager: serverManager,
 		client: &http.Client{
-			Timeout: 2 * time.Minute,
+			// Calls to the server are traced as children of the request span.
+			Transport: otelhttp.NewTransport(http.DefaultTransport),
+			Timeout:   2 * time.Minute,
 		},
-		port: BackendPort,
-	}, nil
+		servers: serverPool{
+			newPort: freePort,
+			port:    BackendPort,
+			max:     DefaultMaxServers,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(b)
+	}
+
+	if b.slotSavePath != "" {
+		if err := os.MkdirAll(b.slotSavePath, 0o755); err != nil {
+			return nil, fmt.Errorf("manager: failed to create slot save path: %w", err)
+		}
+	}
+
+	return b, nil
 }
 
 // Close implements backend.Backend.
 func (b *Backend) Close() error {
-	return b.serverManager.StopServer(BackendName, b.port)
+	var errs []error
+	for _, port := range b.servers.ports() {
+		errs = append(errs, b.serverManager.StopServer(BackendName, port))
+	}
+
+	return errors.Join(errs...)
 }
 
 // Provider implements backend.Backend.
@@ -111,69 +199,51 @@ func (b *Backend) Provider() string {
 	return BackendName
 }
 
-// Infer implements backend.Backend.
+// Infer implements backend.Backend. It generates the reply to a chat or, for
+// backend.TaskEmbedding, computes embeddings. The tokenizer tasks are served by
+// the tokenizer of the model. Requests with a prompt cache are served by its slot.
 func (b *Backend) Infer(ctx context.Context, req *backend.Request) (*backend.Response, error) {
-	args := []string{
-		"--model", req.ModelPath,
-		"--host", "127.0.0.1",
-		"--port", strconv.Itoa(b.port),
+	switch req.Task {
+	case backend.TaskEmbedding:
+		return b.embed(ctx, req)
+	case backend.TaskTokenize, backend.TaskDetokenize, backend.TaskApplyTemplate:
+		return b.tokenize(ctx, req)
+	case backend.TaskWarmPromptCache:
+		return b.warmPromptCache(ctx, req)
 	}
 
-	if err := b.serverManager.StartServer(backend.ServerConfig{
-		Name:       BackendName,
-		BinPath:    b.binPath,
-		Args:       args,
-		Port:       b.port,
-		HealthPath: "/health",
-	}); err != nil {
-		return nil, fmt.Errorf("manager: failed to start server: %w", err)
-	}
-
-	prompt, err := io.ReadAll(req.Input)
+	srv, release, err := b.acquire(req)
 	if err != nil {
-		return nil, fmt.Errorf("manager: failed to read input: %w", err)
+		return nil, err
 	}
+	defer release()
 
-	const shouldStream = false
-	completionReq := b.buildChatCompletionRequest(req, string(prompt), shouldStream)
-
-	jsonData, err := json.Marshal(completionReq)
+	prompt, err := readPrompt(req)
 	if err != nil {
-		return nil, fmt.Errorf("manager: failed to marshal request: %w", err)
+		return nil, err
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx,
-		http.MethodPost,
-		fmt.Sprintf("http://localhost:%d/chat/completions", b.port),
-		bytes.NewReader(jsonData),
-	)
-	if err != nil {
-		return nil, fmt.Errorf("manager: failed to create request: %w", err)
-	}
-	httpReq.Header.Set("Content-Type", "application/json")
+	const shouldStream = false
+	slot, status := b.preparePromptCache(ctx, srv, req)
+	completionReq := b.buildChatCompletionRequest(req, prompt, shouldStream)
+	completionReq.IDSlot = slot
 
 	start := time.Now()
 
-	resp, err := b.client.Do(httpReq)
+	completionResp, err := b.complete(ctx, srv, completionReq)
 	if err != nil {
-		return nil, fmt.Errorf("manager: failed to execute request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
 	elapsed := time.Since(start).Seconds()
 
-	if resp.StatusCode != http.StatusOK {
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("manager: failed to read response body: %w", err)
-		}
-
-		return nil, fmt.Errorf("manager: request failed with status code %d: %s", resp.StatusCode, body)
+	usage := usageOf(completionResp)
+	backendSpecific := map[string]any{
+		"response": *completionResp,
 	}
-
-	var completionResp ChatCompletionResponse
-	if err := json.NewDecoder(resp.Body).Decode(&completionResp); err != nil {
-		return nil, fmt.Errorf("manager: failed to decode response: %w", err)
+	if status != nil {
+		b.finishPromptCache(ctx, srv, req, status, usage)
+		backendSpecific["prompt_cache"] = status
 	}
 
 	content := ""
@@ -189,38 +259,45 @@ func (b *Backend) Infer(ctx context.Context, req *backend.Request) (*backend.Res
 			Timestamp:       time.Now(),
 			DurationSeconds: elapsed,
 			OutputSizeBytes: int64(len(content)),
-			BackendSpecific: map[string]any{
-				"response": completionResp,
-			},
+			Usage:           usage,
+			BackendSpecific: backendSpecific,
 		},
 	}, nil
 }
 
-// InferStream implements backend.StreamingBackend.
-func (b *Backend) InferStream(ctx context.Context, req *backend.Request) (<-chan backend.StreamChunk, error) {
-	args := []string{
-		"--model", req.ModelPath,
-		"--host", "127.0.0.1",
-		"--port", strconv.Itoa(b.port),
+// complete sends a non-streaming chat completion request to llama-server.
+func (b *Backend) complete(ctx context.Context, srv *server, completionReq *ChatCompletionRequest) (*ChatCompletionResponse, error) {
+	var completionResp ChatCompletionResponse
+	if err := b.post(ctx, srv, "/chat/completions", completionReq, &completionResp); err != nil {
+		return nil, err
 	}
 
-	if err := b.serverManager.StartServer(backend.ServerConfig{
-		Name:       BackendName,
-		BinPath:    b.binPath,
-		Args:       args,
-		Port:       b.port,
-		HealthPath: "/health",
-	}); err != nil {
-		return nil, fmt.Errorf("manager: failed to start server: %w", err)
+	return &completionResp, nil
+}
+
+// InferStream implements backend.StreamingBackend.
+func (b *Backend) InferStream(ctx context.Context, req *backend.Request) (<-chan backend.StreamChunk, error) {
+	srv, release, err := b.acquire(req)
+	if err != nil {
+		return nil, err
 	}
+	// The server is released by the streaming goroutine once the stream ends.
+	streaming := false
+	defer func() {
+		if !streaming {
+			release()
+		}
+	}()
 
-	prompt, err := io.ReadAll(req.Input)
+	prompt, err := readPrompt(req)
 	if err != nil {
-		return nil, fmt.Errorf("manager: failed to read input: %w", err)
+		return nil, err
 	}
 
 	const shouldStream = true
-	completionReq := b.buildChatCompletionRequest(req, string(prompt), shouldStream)
+	slot, status := b.preparePromptCache(ctx, srv, req)
+	completionReq := b.buildChatCompletionRequest(req, prompt, shouldStream)
+	completionReq.IDSlot = slot
 
 	jsonData, err := json.Marshal(completionReq)
 	if err != nil {
@@ -229,7 +306,7 @@ func (b *Backend) InferStream(ctx context.Context, req *backend.Request) (<-chan
 
 	httpReq, err := http.NewRequestWithContext(ctx,
 		http.MethodPost,
-		fmt.Sprintf("http://localhost:%d/chat/completions", b.port),
+		fmt.Sprintf("http://localhost:%d/chat/completions", srv.port),
 		bytes.NewReader(jsonData),
 	)
 	if err != nil {
@@ -252,7 +329,9 @@ func (b *Backend) InferStream(ctx context.Context, req *backend.Request) (<-chan
 
 	chunks := make(chan backend.StreamChunk)
 
+	streaming = true
 	go func() {
+		defer release()
 		defer close(chunks)
 		defer resp.Body.Close()
 
@@ -307,7 +386,20 @@ func (b *Backend) InferStream(ctx context.Context, req *backend.Request) (<-chan
 				}
 
 				if completionResp.Choices[0].FinishReason != nil {
-					chunks <- backend.StreamChunk{Done: true}
+					usage := usageOf(&completionResp)
+					backendSpecific := map[string]any{
+						"response": completionResp,
+					}
+					if status != nil {
+						b.finishPromptCache(ctx, srv, req, status, usage)
+						backendSpecific["prompt_cache"] = status
+					}
+
+					chunks <- backend.StreamChunk{
+						Usage:           usage,
+						BackendSpecific: backendSpecific,
+						Done:            true,
+					}
 					return
 				}
 			}
@@ -317,6 +409,86 @@ func (b *Backend) InferStream(ctx context.Context, req *backend.Request) (<-chan
 	return chunks, nil
 }
 
+// FinishReason returns why the generation of the first choice stopped, e.g.
+// "stop" or "length", or "" if it did not.
+func (r *ChatCompletionResponse) FinishReason() string {
+	if len(r.Choices) == 0 || r.Choices[0].FinishReason == nil {
+		return ""
+	}
+
+	return *r.Choices[0].FinishReason
+}
+
+// usageOf returns the usage reported in a response. llama-server reports the
+// token counts and generation speed in its timings; usage is used as a fallback.
+func usageOf(resp *ChatCompletionResponse) *backend.Usage {
+	usage := &backend.Usage{
+		PromptTokens:    resp.Usage.PromptTokens,
+		GeneratedTokens: resp.Usage.CompletionTokens,
+	}
+
+	if n := mapsafe.Get(resp.Timings, "prompt_n", 0); n > 0 {
+		usage.PromptTokens = n
+	}
+	if n := mapsafe.Get(resp.Timings, "predicted_n", 0); n > 0 {
+		usage.GeneratedTokens = n
+	}
+	usage.CachedTokens = mapsafe.Get(resp.Timings, "cache_n", 0)
+	usage.TokensPerSecond = mapsafe.Get(resp.Timings, "predicted_per_second", 0.0)
+
+	return usage
+}
+
+// buildServerArgs builds the llama-server command-line arguments, but the port.
+// Requests with different arguments, e.g. another profile, are served by
+// different servers.
+func (b *Backend) buildServerArgs(req *backend.Request) []string {
+	args := []string{
+		"--model", req.ModelPath,
+		"--host", "127.0.0.1",
+	}
+
+	launch := req.Launch
+	if launch.Threads > 0 {
+		args = append(args, "--threads", strconv.Itoa(launch.Threads))
+	}
+	if launch.ContextSize > 0 {
+		args = append(args, "--ctx-size", strconv.Itoa(launch.ContextSize))
+	}
+	if launch.BatchSize > 0 {
+		args = append(args, "--batch-size", strconv.Itoa(launch.BatchSize))
+	}
+	if launch.Parallel > 0 {
+		args = append(args, "--parallel", strconv.Itoa(launch.Parallel))
+	}
+	if launch.GPULayers != nil {
+		args = append(args, "--n-gpu-layers", strconv.Itoa(*launch.GPULayers))
+	}
+	if req.Task == backend.TaskEmbedding {
+		args = append(args, "--embeddings")
+	}
+	if b.slotSavePath != "" {
+		args = append(args, "--slot-save-path", b.slotSavePath)
+	}
+
+	return args
+}
+
+// readPrompt reads the prompt of a request. Chat requests carry messages
+// instead, and no input.
+func readPrompt(req *backend.Request) (string, error) {
+	if req.Input == nil {
+		return "", nil
+	}
+
+	prompt, err := io.ReadAll(req.Input)
+	if err != nil {
+		return "", fmt.Errorf("manager: failed to read input: %w", err)
+	}
+
+	return string(prompt), nil
+}
+
 // buildChatCompletionRequest builds a ChatCompletionRequest from a backend.Request.
 func (b *Backend) buildChatCompletionRequest(req *backend.Request, prompt string, stream bool) *ChatCompletionRequest {
 	p := req.Parameters
@@ -326,7 +498,11 @@ func (b *Backend) buildChatCompletionRequest(req *backend.Request, prompt string
 
 	messages := []ChatMessage{}
 
-	if messagesJSON, ok := p["messages"].(string); ok && messagesJSON != "" {
+	if len(req.Messages) > 0 {
+		for _, msg := range req.Messages {
+			messages = append(messages, ChatMessage(msg))
+		}
+	} else if messagesJSON, ok := p["messages"].(string); ok && messagesJSON != "" {
 		var chatMsgs []map[string]string
 
 		if err := json.Unmarshal([]byte(messagesJSON), &chatMsgs); err == nil {
@@ -358,5 +534,38 @@ func (b *Backend) buildChatCompletionRequest(req *backend.Request, prompt string
 		RepeatPenalty:    mapsafe.Get(p, "repeat_penalty", 1.1),
 		PresencePenalty:  mapsafe.Get(p, "presence_penalty", 0.0),
 		FrequencyPenalty: mapsafe.Get(p, "frequency_penalty", 0.0),
+		CachePrompt:      mapsafe.Get(p, "cache_prompt", true),
+		IDSlot:           slotOf(req),
+		Extra:            extraParameters(p),
 	}
 }
+
+// slotOf returns the slot requests with the cache key of a request are pinned
+// to, so that their shared prompt prefix stays in the KV cache of the slot, or -1
+// to let the server pick the slot with the most similar prompt.
+func slotOf(req *backend.Request) int {
+	if req.CacheKey == "" || req.Launch.Parallel < 2 {
+		return -1
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(req.CacheKey))
+
+	return int(h.Sum32() % uint32(req.Launch.Parallel))
+}
+
+// extraParameters returns the parameters without a field of ChatCompletionRequest.
+func extraParameters(p map[string]any) map[string]any {
+	var extra map[string]any
+	for name, value := range p {
+		if chatParameters[name] {
+			continue
+		}
+		if extra == nil {
+			extra = map[string]any{}
+		}
+		extra[name] = value
+	}
+
+	return extra
+}
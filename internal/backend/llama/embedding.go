@@ -0,0 +1,111 @@
+package llama
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ju4n97/relic/internal/backend"
+)
+
+// EmbeddingRequest is a request to the embeddings endpoint of llama-server.
+type EmbeddingRequest struct {
+	Input          []string `json:"input"`
+	EncodingFormat string   `json:"encoding_format"`
+}
+
+// EmbeddingResponse is a response from the embeddings endpoint of llama-server.
+type EmbeddingResponse struct {
+	Model string          `json:"model,omitempty"`
+	Data  []EmbeddingData `json:"data"`
+	Usage Usage           `json:"usage"`
+}
+
+// EmbeddingData is the embedding of a single input.
+type EmbeddingData struct {
+	Embedding []float32 `json:"embedding"`
+	Index     int       `json:"index"`
+}
+
+// embed computes the embeddings of the inputs of a backend.TaskEmbedding request,
+// with a server started in embedding mode, next to the one serving chats.
+func (b *Backend) embed(ctx context.Context, req *backend.Request) (*backend.Response, error) {
+	var inputs []string
+	if err := json.NewDecoder(req.Input).Decode(&inputs); err != nil {
+		return nil, fmt.Errorf("manager: failed to decode embedding inputs: %w", err)
+	}
+
+	srv, release, err := b.acquire(req)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	jsonData, err := json.Marshal(&EmbeddingRequest{Input: inputs, EncodingFormat: "float"})
+	if err != nil {
+		return nil, fmt.Errorf("manager: failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx,
+		http.MethodPost,
+		fmt.Sprintf("http://localhost:%d/v1/embeddings", srv.port),
+		bytes.NewReader(jsonData),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("manager: failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	start := time.Now()
+
+	resp, err := b.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("manager: failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	elapsed := time.Since(start).Seconds()
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("manager: failed to read response body: %w", err)
+		}
+
+		return nil, fmt.Errorf("manager: request failed with status code %d: %s", resp.StatusCode, body)
+	}
+
+	var embeddingResp EmbeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&embeddingResp); err != nil {
+		return nil, fmt.Errorf("manager: failed to decode response: %w", err)
+	}
+
+	vectors := make([][]float32, len(inputs))
+	for _, data := range embeddingResp.Data {
+		if data.Index < 0 || data.Index >= len(vectors) {
+			return nil, fmt.Errorf("manager: embedding index %d out of range", data.Index)
+		}
+		vectors[data.Index] = data.Embedding
+	}
+
+	output, err := json.Marshal(vectors)
+	if err != nil {
+		return nil, fmt.Errorf("manager: failed to marshal embeddings: %w", err)
+	}
+
+	return &backend.Response{
+		Output: bytes.NewReader(output),
+		Metadata: &backend.ResponseMetadata{
+			Provider:        b.Provider(),
+			Model:           req.ModelPath,
+			Timestamp:       time.Now(),
+			DurationSeconds: elapsed,
+			OutputSizeBytes: int64(len(output)),
+			Usage:           &backend.Usage{PromptTokens: embeddingResp.Usage.PromptTokens},
+		},
+	}, nil
+}
@@ -0,0 +1,50 @@
+// Package llamatest serves llama.cpp backends from a stub llama-server in tests.
+package llamatest
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ju4n97/relic/internal/backend"
+	"github.com/ju4n97/relic/internal/backend/llama"
+)
+
+// NewBackend returns a llama.cpp backend served by a stub llama-server with the
+// handlers of mux, and the health and props endpoints. The backend starts a
+// placeholder process instead of llama-server, and reaches the stub on its port.
+func NewBackend(t testing.TB, mux *http.ServeMux) backend.Backend {
+	t.Helper()
+
+	mux.HandleFunc("GET /health", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("GET /props", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `{"default_generation_settings": {"n_ctx": 4096}}`)
+	})
+	stub := httptest.NewServer(mux)
+	t.Cleanup(stub.Close)
+
+	stubURL, err := url.Parse(stub.URL)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(stubURL.Port())
+	require.NoError(t, err)
+
+	bin := filepath.Join(t.TempDir(), "llama-server")
+	require.NoError(t, os.WriteFile(bin, []byte("#!/bin/sh\nexec sleep 300\n"), 0o755))
+
+	serverManager := backend.NewServerManager()
+	t.Cleanup(serverManager.StopAll)
+
+	b, err := llama.NewBackend(bin, serverManager, llama.WithPort(port))
+	require.NoError(t, err)
+
+	return b
+}
@@ -0,0 +1,133 @@
+package llama
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ju4n97/relic/internal/backend"
+)
+
+// DefaultMaxServers is how many llama-server processes run at once by default,
+// e.g. one for chat and one for embeddings.
+const DefaultMaxServers = 2
+
+// WithMaxServers sets how many llama-server processes run at once, one per set
+// of launch options, DefaultMaxServers by default. Beyond it, the least recently
+// used server is replaced.
+func WithMaxServers(n int) Option {
+	return func(b *Backend) {
+		b.servers.max = max(n, 1)
+	}
+}
+
+// server is a llama-server process of the backend, started with a set of launch
+// options on its own port.
+type server struct {
+	slots slotCaches
+	key   string // Launch options, without the port
+	port  int
+}
+
+// serverPool assigns a server to each set of launch options, so that requests
+// with different options, e.g. chat and embeddings or two profiles, do not
+// restart each other's server.
+type serverPool struct {
+	newPort func() (int, error) // Picks the port of a server beyond the first
+	servers []*server           // Least recently used first
+	port    int                 // Port of the first server
+	max     int
+	mu      sync.Mutex
+}
+
+// get returns the server of a set of launch options and marks it as the most
+// recently used. Once the pool is full, a new server takes the port of the least
+// recently used one, which the ServerManager restarts with the new options once
+// its in-flight requests are done.
+func (p *serverPool) get(key string) (*server, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, srv := range p.servers {
+		if srv.key == key {
+			p.servers = append(slices.Delete(p.servers, i, i+1), srv)
+			return srv, nil
+		}
+	}
+
+	var port int
+	switch {
+	case len(p.servers) >= p.max:
+		port = p.servers[0].port
+		p.servers = p.servers[1:]
+	case !slices.ContainsFunc(p.servers, func(srv *server) bool { return srv.port == p.port }):
+		port = p.port
+	default:
+		var err error
+		if port, err = p.newPort(); err != nil {
+			return nil, fmt.Errorf("manager: failed to pick a port: %w", err)
+		}
+	}
+
+	srv := &server{
+		slots: slotCaches{held: map[int]string{}},
+		key:   key,
+		port:  port,
+	}
+	p.servers = append(p.servers, srv)
+
+	return srv, nil
+}
+
+// ports returns the ports of the servers.
+func (p *serverPool) ports() []int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ports := make([]int, len(p.servers))
+	for i, srv := range p.servers {
+		ports[i] = srv.port
+	}
+
+	return ports
+}
+
+// acquire returns the server of the launch options of a request, running, with
+// the request registered on it. The returned function must be called once the
+// request is done.
+func (b *Backend) acquire(req *backend.Request) (*server, func(), error) {
+	args := b.buildServerArgs(req)
+
+	srv, err := b.servers.get(strings.Join(args, "\x00"))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	release, err := b.serverManager.Acquire(backend.ServerConfig{
+		Name:       BackendName,
+		Model:      req.ModelID,
+		BinPath:    b.binPath,
+		Args:       append(args, "--port", strconv.Itoa(srv.port)),
+		Port:       srv.port,
+		HealthPath: "/health",
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("manager: failed to start server: %w", err)
+	}
+
+	return srv, release, nil
+}
+
+// freePort returns a port that is free on the loopback interface.
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer listener.Close()
+
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
@@ -0,0 +1,46 @@
+package llama
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerPool_Get(t *testing.T) {
+	next := 9000
+	pool := serverPool{
+		newPort: func() (int, error) {
+			next++
+			return next, nil
+		},
+		port: 8081,
+		max:  2,
+	}
+
+	chat, err := pool.get("chat")
+	require.NoError(t, err)
+	assert.Equal(t, 8081, chat.port, "the first server takes the port of the backend")
+
+	embed, err := pool.get("embed")
+	require.NoError(t, err)
+	assert.Equal(t, 9001, embed.port)
+
+	again, err := pool.get("chat")
+	require.NoError(t, err)
+	assert.Same(t, chat, again, "the options of a running server reuse it")
+
+	// The pool is full: the least recently used server, embed, is replaced.
+	profile, err := pool.get("profile")
+	require.NoError(t, err)
+	assert.Equal(t, 9001, profile.port)
+	assert.ElementsMatch(t, []int{8081, 9001}, pool.ports())
+
+	again, err = pool.get("chat")
+	require.NoError(t, err)
+	assert.Same(t, chat, again)
+
+	embed, err = pool.get("embed")
+	require.NoError(t, err)
+	assert.Equal(t, 9001, embed.port, "profile was the least recently used")
+}
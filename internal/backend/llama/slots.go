@@ -0,0 +1,222 @@
+package llama
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ju4n97/relic/internal/backend"
+)
+
+// WithSlotSavePath sets the directory llama-server saves the KV caches of the
+// prompt caches to, and restores them from after a restart. Without it prompt
+// caches are kept in the slots only, and lost when the server stops.
+func WithSlotSavePath(dir string) Option {
+	return func(b *Backend) {
+		b.slotSavePath = dir
+	}
+}
+
+// slotCaches tracks the prompt caches held by the slots of the running server.
+type slotCaches struct {
+	held   map[int]string // Slot to the file of the prompt cache it holds
+	starts int            // Start of the server the slots belong to
+	next   int            // Slot the next request pinned to none is tried on first
+	mu     sync.Mutex
+}
+
+// SlotActionRequest is a request to save or restore the KV cache of a slot.
+type SlotActionRequest struct {
+	Filename string `json:"filename"`
+}
+
+// pinnedSlot returns the slot a prompt cache is pinned to. Every cache has a
+// slot, even when the server has a single one.
+func pinnedSlot(name string, parallel int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+
+	return int(h.Sum32() % uint32(max(parallel, 1)))
+}
+
+// preparePromptCache returns the slot a request must be served by, -1 to let
+// the server pick, and the status of its prompt cache, after restoring the KV
+// cache of the prefix in the slot from disk if the slot does not hold it yet.
+// Requests without a prompt cache return a nil status, and forget the cache of
+// the slot they overwrite.
+func (b *Backend) preparePromptCache(ctx context.Context, srv *server, req *backend.Request) (int, *backend.PromptCacheStatus) {
+	srv.slots.mu.Lock()
+	defer srv.slots.mu.Unlock()
+
+	b.syncSlotsLocked(srv)
+
+	if req.PromptCache == nil {
+		slot := slotOf(req)
+		switch {
+		case slot >= 0:
+			delete(srv.slots.held, slot)
+		case req.Launch.Parallel < 2:
+			delete(srv.slots.held, 0)
+		default:
+			// The server would pick any slot, possibly one holding a prompt cache.
+			slot = srv.slots.freeSlotLocked(req.Launch.Parallel)
+		}
+		return slot, nil
+	}
+
+	status := &backend.PromptCacheStatus{
+		Name: req.PromptCache.Name,
+		Slot: pinnedSlot(req.PromptCache.Name, req.Launch.Parallel),
+	}
+
+	file := req.PromptCache.FileName(req.ModelPath)
+	if srv.slots.held[status.Slot] == file || b.slotSavePath == "" {
+		return status.Slot, status
+	}
+	if _, err := os.Stat(filepath.Join(b.slotSavePath, file)); err != nil {
+		return status.Slot, status
+	}
+
+	if err := b.slotAction(ctx, srv, status.Slot, "restore", file); err != nil {
+		// The prefix is processed again instead.
+		slog.Warn("Failed to restore prompt cache", "name", status.Name, "slot", status.Slot, "error", err)
+		return status.Slot, status
+	}
+	srv.slots.held[status.Slot] = file
+	status.Restored = true
+
+	return status.Slot, status
+}
+
+// freeSlotLocked returns the slot of a request pinned to none: one holding no
+// prompt cache, taken in turn so that such requests are spread over the slots.
+// When every slot holds one, the cache of the returned slot is forgotten.
+// Callers must hold the lock.
+func (s *slotCaches) freeSlotLocked(parallel int) int {
+	for range parallel {
+		slot := s.next % parallel
+		s.next = slot + 1
+		if _, ok := s.held[slot]; !ok {
+			return slot
+		}
+	}
+
+	slot := s.next % parallel
+	s.next = slot + 1
+	delete(s.held, slot)
+
+	return slot
+}
+
+// finishPromptCache records that the slot of a request served with a prompt
+// cache holds its prefix, and saves the KV cache of the slot if the prefix was
+// not persisted yet. Files persisted for previous prefixes of the cache are
+// removed.
+func (b *Backend) finishPromptCache(ctx context.Context, srv *server, req *backend.Request, status *backend.PromptCacheStatus, usage *backend.Usage) {
+	status.CachedTokens = usage.CachedTokens
+	status.Hit = usage.CachedTokens > 0
+
+	file := req.PromptCache.FileName(req.ModelPath)
+
+	srv.slots.mu.Lock()
+	defer srv.slots.mu.Unlock()
+
+	b.syncSlotsLocked(srv)
+	srv.slots.held[status.Slot] = file
+
+	if b.slotSavePath == "" {
+		return
+	}
+	if _, err := os.Stat(filepath.Join(b.slotSavePath, file)); err == nil {
+		return
+	}
+
+	if err := b.slotAction(ctx, srv, status.Slot, "save", file); err != nil {
+		slog.Warn("Failed to save prompt cache", "name", status.Name, "slot", status.Slot, "error", err)
+		return
+	}
+	status.Saved = true
+
+	stale, _ := filepath.Glob(filepath.Join(b.slotSavePath, req.PromptCache.Name+".*.bin"))
+	for _, path := range stale {
+		if filepath.Base(path) == file {
+			continue
+		}
+		if err := os.Remove(path); err != nil {
+			slog.Warn("Failed to remove stale prompt cache", "file", path, "error", err)
+		}
+	}
+}
+
+// syncSlotsLocked forgets the prompt caches held by the slots of a server if it
+// was restarted since they were recorded. Callers must hold the lock.
+func (b *Backend) syncSlotsLocked(srv *server) {
+	if starts := b.serverManager.Starts(BackendName, srv.port); starts != srv.slots.starts {
+		srv.slots.starts = starts
+		srv.slots.held = map[int]string{}
+	}
+}
+
+// slotAction saves or restores the KV cache of a slot to or from a file of the
+// slot save path.
+func (b *Backend) slotAction(ctx context.Context, srv *server, slot int, action, file string) error {
+	var resp map[string]any
+	return b.post(ctx, srv, fmt.Sprintf("/slots/%d?action=%s", slot, action), &SlotActionRequest{Filename: file}, &resp)
+}
+
+// warmPromptCache serves backend.TaskWarmPromptCache: it makes the slot of the
+// prompt cache of a request hold its prefix, restored from disk or processed,
+// and persists it.
+func (b *Backend) warmPromptCache(ctx context.Context, req *backend.Request) (*backend.Response, error) {
+	if req.PromptCache == nil {
+		return nil, fmt.Errorf("manager: %s requires a prompt cache", backend.TaskWarmPromptCache)
+	}
+
+	srv, release, err := b.acquire(req)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	start := time.Now()
+	_, status := b.preparePromptCache(ctx, srv, req)
+
+	completionReq := b.buildChatCompletionRequest(&backend.Request{Messages: req.PromptCache.Messages}, "", false)
+	completionReq.IDSlot = status.Slot
+	completionReq.CachePrompt = true
+	completionReq.NPredict = 1 // Zero is omitted, and the server would generate its default
+
+	completionResp, err := b.complete(ctx, srv, completionReq)
+	if err != nil {
+		return nil, err
+	}
+	usage := usageOf(completionResp)
+	b.finishPromptCache(ctx, srv, req, status, usage)
+
+	output, err := json.Marshal(status)
+	if err != nil {
+		return nil, fmt.Errorf("manager: failed to encode prompt cache status: %w", err)
+	}
+
+	return &backend.Response{
+		Output: bytes.NewReader(output),
+		Metadata: &backend.ResponseMetadata{
+			Provider:        b.Provider(),
+			Model:           req.ModelPath,
+			Timestamp:       time.Now(),
+			DurationSeconds: time.Since(start).Seconds(),
+			OutputSizeBytes: int64(len(output)),
+			Usage:           usage,
+			BackendSpecific: map[string]any{
+				"prompt_cache": status,
+			},
+		},
+	}, nil
+}
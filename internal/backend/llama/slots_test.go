@@ -0,0 +1,22 @@
+package llama
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlotCaches_FreeSlot(t *testing.T) {
+	slots := slotCaches{held: map[int]string{1: "system.bin"}}
+
+	var picked []int
+	for range 4 {
+		picked = append(picked, slots.freeSlotLocked(3))
+	}
+	assert.Equal(t, []int{0, 2, 0, 2}, picked, "the slot holding a prompt cache is skipped")
+	assert.Equal(t, map[int]string{1: "system.bin"}, slots.held)
+
+	slots = slotCaches{held: map[int]string{0: "a.bin", 1: "b.bin"}}
+	assert.Equal(t, 0, slots.freeSlotLocked(2), "with every slot held, they are taken in turn")
+	assert.Equal(t, map[int]string{1: "b.bin"}, slots.held, "the overwritten cache is forgotten")
+}
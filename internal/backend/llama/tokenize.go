@@ -0,0 +1,267 @@
+package llama
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ju4n97/relic/internal/backend"
+	"github.com/ju4n97/relic/internal/mapsafe"
+)
+
+// TokenizeRequest is a request to the tokenize endpoint of llama-server.
+type TokenizeRequest struct {
+	Content    string `json:"content"`
+	AddSpecial bool   `json:"add_special"`
+	WithPieces bool   `json:"with_pieces"`
+}
+
+// TokenizeResponse is a response from the tokenize endpoint of llama-server.
+// Tokens are IDs, or TokenPiece objects when pieces are requested.
+type TokenizeResponse struct {
+	Tokens []json.RawMessage `json:"tokens"`
+}
+
+// TokenPiece is a token with its text. The piece is a string, or an array of
+// bytes when the token is not valid UTF-8 on its own.
+type TokenPiece struct {
+	Piece json.RawMessage `json:"piece"`
+	ID    int             `json:"id"`
+}
+
+// DetokenizeRequest is a request to the detokenize endpoint of llama-server.
+type DetokenizeRequest struct {
+	Tokens []int `json:"tokens"`
+}
+
+// DetokenizeResponse is a response from the detokenize endpoint of llama-server.
+type DetokenizeResponse struct {
+	Content string `json:"content"`
+}
+
+// ApplyTemplateRequest is a request to the apply-template endpoint of llama-server.
+type ApplyTemplateRequest struct {
+	Messages []ChatMessage `json:"messages"`
+}
+
+// ApplyTemplateResponse is a response from the apply-template endpoint of llama-server.
+type ApplyTemplateResponse struct {
+	Prompt string `json:"prompt"`
+}
+
+// PropsResponse is the part of the props of llama-server relic reads.
+type PropsResponse struct {
+	DefaultGenerationSettings struct {
+		NCtx int `json:"n_ctx"` // Context of a slot, i.e. of a request
+	} `json:"default_generation_settings"`
+}
+
+// tokenize serves the backend.TaskTokenize, backend.TaskDetokenize and
+// backend.TaskApplyTemplate requests with the tokenizer of the running model.
+func (b *Backend) tokenize(ctx context.Context, req *backend.Request) (*backend.Response, error) {
+	srv, release, err := b.acquire(req)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	start := time.Now()
+
+	var output []byte
+	switch req.Task {
+	case backend.TaskTokenize:
+		tokenization, err := b.tokenizeInput(ctx, srv, req)
+		if err != nil {
+			return nil, err
+		}
+		if output, err = json.Marshal(tokenization); err != nil {
+			return nil, fmt.Errorf("manager: failed to marshal tokens: %w", err)
+		}
+	case backend.TaskDetokenize:
+		var tokens []int
+		if err := json.NewDecoder(req.Input).Decode(&tokens); err != nil {
+			return nil, fmt.Errorf("manager: failed to decode tokens: %w", err)
+		}
+
+		var detokenizeResp DetokenizeResponse
+		if err := b.post(ctx, srv, "/detokenize", &DetokenizeRequest{Tokens: tokens}, &detokenizeResp); err != nil {
+			return nil, err
+		}
+		output = []byte(detokenizeResp.Content)
+	case backend.TaskApplyTemplate:
+		prompt, err := b.applyTemplate(ctx, srv, req.Messages)
+		if err != nil {
+			return nil, err
+		}
+		output = []byte(prompt)
+	default:
+		return nil, fmt.Errorf("manager: unsupported task %q", req.Task)
+	}
+
+	return &backend.Response{
+		Output: bytes.NewReader(output),
+		Metadata: &backend.ResponseMetadata{
+			Provider:        b.Provider(),
+			Model:           req.ModelPath,
+			Timestamp:       time.Now(),
+			DurationSeconds: time.Since(start).Seconds(),
+			OutputSizeBytes: int64(len(output)),
+		},
+	}, nil
+}
+
+// tokenizeInput tokenizes the input of a request, or its messages formatted
+// with the chat template. Messages are tokenized with the special tokens, as
+// llama-server does for chat completions; text only when add_special is set.
+func (b *Backend) tokenizeInput(ctx context.Context, srv *server, req *backend.Request) (*backend.Tokenization, error) {
+	tokenizeReq := &TokenizeRequest{
+		AddSpecial: mapsafe.Get(req.Parameters, "add_special", false),
+		WithPieces: mapsafe.Get(req.Parameters, "with_pieces", false),
+	}
+	if len(req.Messages) > 0 {
+		prompt, err := b.applyTemplate(ctx, srv, req.Messages)
+		if err != nil {
+			return nil, err
+		}
+		tokenizeReq.Content = prompt
+		tokenizeReq.AddSpecial = true
+	} else {
+		prompt, err := readPrompt(req)
+		if err != nil {
+			return nil, err
+		}
+		tokenizeReq.Content = prompt
+	}
+
+	var tokenizeResp TokenizeResponse
+	if err := b.post(ctx, srv, "/tokenize", tokenizeReq, &tokenizeResp); err != nil {
+		return nil, err
+	}
+
+	tokenization := &backend.Tokenization{
+		Prompt: tokenizeReq.Content,
+		Tokens: make([]int, len(tokenizeResp.Tokens)),
+	}
+	if tokenizeReq.WithPieces {
+		tokenization.Pieces = make([]string, len(tokenizeResp.Tokens))
+	}
+	for i, raw := range tokenizeResp.Tokens {
+		if !tokenizeReq.WithPieces {
+			if err := json.Unmarshal(raw, &tokenization.Tokens[i]); err != nil {
+				return nil, fmt.Errorf("manager: failed to decode token: %w", err)
+			}
+			continue
+		}
+
+		var token TokenPiece
+		if err := json.Unmarshal(raw, &token); err != nil {
+			return nil, fmt.Errorf("manager: failed to decode token: %w", err)
+		}
+		tokenization.Tokens[i] = token.ID
+		tokenization.Pieces[i] = token.text()
+	}
+
+	var props PropsResponse
+	if err := b.get(ctx, srv, "/props", &props); err != nil {
+		return nil, err
+	}
+	tokenization.ContextLength = props.DefaultGenerationSettings.NCtx
+
+	return tokenization, nil
+}
+
+// text returns the text of a token, with the bytes that are not valid UTF-8 on
+// their own replaced.
+func (t *TokenPiece) text() string {
+	var s string
+	if err := json.Unmarshal(t.Piece, &s); err == nil {
+		return s
+	}
+
+	var b []byte
+	var ints []int
+	if err := json.Unmarshal(t.Piece, &ints); err == nil {
+		for _, n := range ints {
+			b = append(b, byte(n))
+		}
+	}
+
+	return string(bytes.ToValidUTF8(b, []byte("\uFFFD")))
+}
+
+// applyTemplate formats messages with the chat template of the model.
+func (b *Backend) applyTemplate(ctx context.Context, srv *server, messages []backend.Message) (string, error) {
+	templateReq := &ApplyTemplateRequest{Messages: make([]ChatMessage, len(messages))}
+	for i, msg := range messages {
+		templateReq.Messages[i] = ChatMessage(msg)
+	}
+
+	var templateResp ApplyTemplateResponse
+	if err := b.post(ctx, srv, "/apply-template", templateReq, &templateResp); err != nil {
+		return "", err
+	}
+
+	return templateResp.Prompt, nil
+}
+
+// post sends a JSON request to an endpoint of llama-server and decodes its response.
+func (b *Backend) post(ctx context.Context, srv *server, path string, in, out any) error {
+	jsonData, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("manager: failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx,
+		http.MethodPost,
+		fmt.Sprintf("http://localhost:%d%s", srv.port, path),
+		bytes.NewReader(jsonData),
+	)
+	if err != nil {
+		return fmt.Errorf("manager: failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	return b.do(httpReq, out)
+}
+
+// get reads an endpoint of llama-server.
+func (b *Backend) get(ctx context.Context, srv *server, path string, out any) error {
+	httpReq, err := http.NewRequestWithContext(ctx,
+		http.MethodGet,
+		fmt.Sprintf("http://localhost:%d%s", srv.port, path),
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("manager: failed to create request: %w", err)
+	}
+
+	return b.do(httpReq, out)
+}
+
+// do executes a request to llama-server and decodes its JSON response.
+func (b *Backend) do(httpReq *http.Request, out any) error {
+	resp, err := b.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("manager: failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("manager: failed to read response body: %w", err)
+		}
+
+		return fmt.Errorf("manager: request failed with status code %d: %s", resp.StatusCode, body)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("manager: failed to decode response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package llama_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ju4n97/relic/internal/backend"
+	"github.com/ju4n97/relic/internal/backend/llama"
+	"github.com/ju4n97/relic/internal/backend/llama/llamatest"
+)
+
+// tokenize serves a tokenize request with b and decodes its tokenization.
+func tokenize(t *testing.T, b backend.Backend, req *backend.Request) *backend.Tokenization {
+	t.Helper()
+
+	req.ModelID, req.ModelPath, req.Task = "qwen", "/models/qwen.gguf", backend.TaskTokenize
+	resp, err := b.Infer(context.Background(), req)
+	require.NoError(t, err)
+
+	var tokenization backend.Tokenization
+	require.NoError(t, json.NewDecoder(resp.Output).Decode(&tokenization))
+
+	return &tokenization
+}
+
+func TestBackend_Tokenize(t *testing.T) {
+	var requests []llama.TokenizeRequest
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /tokenize", func(w http.ResponseWriter, r *http.Request) {
+		var req llama.TokenizeRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		requests = append(requests, req)
+
+		if req.WithPieces {
+			// A piece that is not valid UTF-8 on its own comes as bytes.
+			_, _ = io.WriteString(w, `{"tokens": [{"id": 1, "piece": "Hi"}, {"id": 2, "piece": [240, 159]}]}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"tokens": [1, 2]}`)
+	})
+	b := llamatest.NewBackend(t, mux)
+
+	tokenization := tokenize(t, b, &backend.Request{Input: strings.NewReader("Hi")})
+	assert.Equal(t, &backend.Tokenization{Prompt: "Hi", Tokens: []int{1, 2}, ContextLength: 4096}, tokenization)
+
+	tokenization = tokenize(t, b, &backend.Request{
+		Input:      strings.NewReader("Hi"),
+		Parameters: map[string]any{"add_special": true, "with_pieces": true},
+	})
+	assert.Equal(t, []int{1, 2}, tokenization.Tokens)
+	assert.Equal(t, []string{"Hi", "�"}, tokenization.Pieces)
+
+	assert.Equal(t, []llama.TokenizeRequest{
+		{Content: "Hi"},
+		{Content: "Hi", AddSpecial: true, WithPieces: true},
+	}, requests)
+}
+
+func TestBackend_Tokenize_Messages(t *testing.T) {
+	var (
+		templateReq llama.ApplyTemplateRequest
+		tokenizeReq llama.TokenizeRequest
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /apply-template", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&templateReq)
+		_, _ = io.WriteString(w, `{"prompt": "<|user|>Hi<|assistant|>"}`)
+	})
+	mux.HandleFunc("POST /tokenize", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&tokenizeReq)
+		_, _ = io.WriteString(w, `{"tokens": [1, 2, 3]}`)
+	})
+	b := llamatest.NewBackend(t, mux)
+
+	tokenization := tokenize(t, b, &backend.Request{
+		Messages:   []backend.Message{{Role: "user", Content: "Hi"}},
+		Parameters: map[string]any{"add_special": false},
+	})
+
+	assert.Equal(t, []llama.ChatMessage{{Role: "user", Content: "Hi"}}, templateReq.Messages)
+	assert.Equal(t, llama.TokenizeRequest{Content: "<|user|>Hi<|assistant|>", AddSpecial: true}, tokenizeReq,
+		"messages are tokenized with the special tokens")
+	assert.Equal(t, "<|user|>Hi<|assistant|>", tokenization.Prompt)
+	assert.Equal(t, []int{1, 2, 3}, tokenization.Tokens)
+}
+
+func TestBackend_ApplyTemplate(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /apply-template", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `{"prompt": "<|user|>Hi<|assistant|>"}`)
+	})
+	b := llamatest.NewBackend(t, mux)
+
+	resp, err := b.Infer(context.Background(), &backend.Request{
+		ModelID:   "qwen",
+		ModelPath: "/models/qwen.gguf",
+		Task:      backend.TaskApplyTemplate,
+		Messages:  []backend.Message{{Role: "user", Content: "Hi"}},
+	})
+	require.NoError(t, err)
+
+	prompt, err := io.ReadAll(resp.Output)
+	require.NoError(t, err)
+	assert.Equal(t, "<|user|>Hi<|assistant|>", string(prompt))
+	assert.Equal(t, llama.BackendName, resp.Metadata.Provider)
+}
+
+func TestBackend_Tokenize_Error(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /apply-template", func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "this model has no chat template", http.StatusBadRequest)
+	})
+	b := llamatest.NewBackend(t, mux)
+
+	_, err := b.Infer(context.Background(), &backend.Request{
+		ModelID:   "qwen",
+		ModelPath: "/models/qwen.gguf",
+		Task:      backend.TaskApplyTemplate,
+		Messages:  []backend.Message{{Role: "user", Content: "Hi"}},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status code 400: this model has no chat template")
+}
@@ -4,12 +4,14 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/ju4n97/relic/internal/backend"
 )
@@ -59,8 +61,13 @@ func (b *Backend) Infer(ctx context.Context, req *backend.Request) (*backend.Res
 
 	args := b.buildArgs(req, outputFile)
 
+	text, err := io.ReadAll(req.Input)
+	if err != nil {
+		return nil, fmt.Errorf("manager: failed to read input: %w", err)
+	}
+
 	// Piper reads text from stdin
-	stdout, stderr, err := b.executor.Execute(ctx, args, req.Input)
+	stdout, stderr, err := b.executor.Execute(ctx, args, bytes.NewReader(text))
 	if err != nil {
 		return nil, fmt.Errorf("manager: execution failed: %w\nstderr: %s", err, stderr)
 	}
@@ -71,6 +78,11 @@ func (b *Backend) Infer(ctx context.Context, req *backend.Request) (*backend.Res
 		return nil, fmt.Errorf("manager: failed to read audio file: %w", err)
 	}
 
+	usage := &backend.Usage{Characters: utf8.RuneCount(text)}
+	if seconds, ok := backend.WAVDuration(audioData); ok {
+		usage.AudioSeconds = seconds
+	}
+
 	return &backend.Response{
 		Output: bytes.NewReader(audioData),
 		Metadata: &backend.ResponseMetadata{
@@ -78,6 +90,7 @@ func (b *Backend) Infer(ctx context.Context, req *backend.Request) (*backend.Res
 			Model:           req.ModelPath,
 			Timestamp:       time.Now(),
 			OutputSizeBytes: int64(len(audioData)),
+			Usage:           usage,
 			BackendSpecific: map[string]any{
 				"stdout": string(stdout),
 				"stderr": string(stderr),
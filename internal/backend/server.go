@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"os"
 	"os/exec"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 )
@@ -14,19 +16,54 @@ import (
 // ServerManager manages server processes.
 type ServerManager struct {
 	servers map[string]*ServerProcess
+	starts  map[string]*ProcessStatus // Every server started so far, keyed like servers
+	onStart func(ServerConfig, time.Duration)
+	drained *sync.Cond // Signaled whenever a request on a server is released
 	mu      sync.RWMutex
 }
 
+// ServerManagerOption is a function that configures the ServerManager.
+type ServerManagerOption func(*ServerManager)
+
+// WithOnStart sets a function called once a server is ready, with the time it
+// took to start, e.g. to record how long loading a model takes.
+func WithOnStart(fn func(cfg ServerConfig, elapsed time.Duration)) ServerManagerOption {
+	return func(sm *ServerManager) {
+		sm.onStart = fn
+	}
+}
+
 // ServerProcess represents a server running process.
 type ServerProcess struct {
-	cmd    *exec.Cmd
-	cancel context.CancelFunc
+	cmd      *exec.Cmd
+	cancel   context.CancelFunc
+	exited   chan struct{} // Closed once the process has exited
+	ready    chan struct{} // Closed once the server is ready or failed to start
+	args     []string      // Launch options the process was started with
+	inflight int
+}
+
+// ProcessStatus describes a backend server started by the ServerManager.
+type ProcessStatus struct {
+	Name     string `json:"name"`
+	Model    string `json:"model,omitempty"` // Model of the last start
+	Port     int    `json:"port"`
+	PID      int    `json:"pid,omitempty"` // Zero if the server is not running
+	Starts   int    `json:"starts"`
+	Running  bool   `json:"running"`
+	InFlight int    `json:"in_flight"`
+}
+
+// Restarts returns how many times the server was started again after its first start.
+func (ps ProcessStatus) Restarts() int {
+	return max(ps.Starts-1, 0)
 }
 
 // ServerConfig defines how to start and check a backend server.
 type ServerConfig struct {
 	Env          map[string]string
 	Name         string
+	Model        string // Model the server loads, for logs and metrics
 	BinPath      string
 	HealthPath   string
 	Args         []string
@@ -35,24 +72,135 @@ type ServerConfig struct {
 }
 
 // NewServerManager initializes a ServerManager.
-func NewServerManager() *ServerManager {
-	return &ServerManager{
+func NewServerManager(opts ...ServerManagerOption) *ServerManager {
+	sm := &ServerManager{
 		servers: map[string]*ServerProcess{},
+		starts:  map[string]*ProcessStatus{},
 	}
+	sm.drained = sync.NewCond(&sm.mu)
+
+	for _, opt := range opts {
+		opt(sm)
+	}
+
+	return sm
 }
 
 // StartServer starts a backend server based on a generic configuration.
+// A server already running with the same launch options is reused; one running
+// with different options is restarted once its in-flight requests are done.
 func (sm *ServerManager) StartServer(cfg ServerConfig) error {
+	release, err := sm.Acquire(cfg)
+	if err != nil {
+		return err
+	}
+	release()
+
+	return nil
+}
+
+// Acquire ensures a server is running with the given configuration and registers
+// a request on it. The returned function must be called once the request is done.
+// If the server runs with different launch options, Acquire waits for its
+// in-flight requests to finish and restarts it. The lock is not held while a
+// server starts, so that other servers and requests are not held up by it.
+func (sm *ServerManager) Acquire(cfg ServerConfig) (func(), error) {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
 	key := fmt.Sprintf("%s-%d", cfg.Name, cfg.Port)
-	if _, exists := sm.servers[key]; exists {
-		return nil // Already running
+	for {
+		srv, exists := sm.servers[key]
+		if !exists {
+			break
+		}
+
+		if srv.starting() {
+			sm.drained.Wait()
+			continue
+		}
+
+		if srv.done() {
+			slog.Warn("Restarting server, process exited", "name", cfg.Name, "port", cfg.Port)
+			srv.cancel()
+			delete(sm.servers, key)
+			break
+		}
+
+		if slices.Equal(srv.args, cfg.Args) {
+			srv.inflight++
+			return sm.releaser(srv), nil
+		}
+
+		if srv.inflight == 0 {
+			slog.Info("Restarting server, launch options changed", "name", cfg.Name, "port", cfg.Port)
+			sm.stopLocked(key, srv)
+			break
+		}
+
+		sm.drained.Wait()
+	}
+
+	srv, err := sm.startLocked(key, cfg)
+	if err != nil {
+		return nil, err
 	}
 
+	return sm.releaser(srv), nil
+}
+
+// StopServersWithArg stops the servers launched with the given argument (e.g. a
+// model path) once their in-flight requests are done.
+func (sm *ServerManager) StopServersWithArg(arg string) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	for {
+		var (
+			key     string
+			matched *ServerProcess
+		)
+		for k, srv := range sm.servers {
+			if slices.Contains(srv.args, arg) {
+				key, matched = k, srv
+				break
+			}
+		}
+
+		if matched == nil {
+			return
+		}
+
+		if matched.inflight > 0 {
+			sm.drained.Wait()
+			continue
+		}
+
+		sm.stopLocked(key, matched)
+	}
+}
+
+// releaser returns a function releasing a request on the server, at most once.
+func (sm *ServerManager) releaser(srv *ServerProcess) func() {
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			sm.mu.Lock()
+			defer sm.mu.Unlock()
+
+			srv.inflight--
+			sm.drained.Broadcast()
+		})
+	}
+}
+
+// startLocked starts a server process and registers a request on it once it is
+// ready. The server is registered as starting right away, and the lock is
+// released while waiting for it to become ready. Callers must hold the lock.
+func (sm *ServerManager) startLocked(key string, cfg ServerConfig) (*ServerProcess, error) {
 	if info, err := os.Stat(cfg.BinPath); err != nil || info.IsDir() {
-		return fmt.Errorf("manager: failed to start %s server: %w", cfg.Name, err)
+		return nil, fmt.Errorf("manager: failed to start %s server: %w", cfg.Name, err)
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -67,11 +215,36 @@ func (sm *ServerManager) StartServer(cfg ServerConfig) error {
 		cmd.Env = append(cmd.Env, env...)
 	}
 
+	start := time.Now()
 	if err := cmd.Start(); err != nil {
 		cancel()
-		return fmt.Errorf("manager: failed to start %s server: %w", cfg.Name, err)
+		return nil, fmt.Errorf("manager: failed to start %s server: %w", cfg.Name, err)
 	}
 
+	// Reap the process whenever it exits, whether it was stopped or crashed.
+	exited := make(chan struct{})
+	go func() {
+		_ = cmd.Wait()
+		close(exited)
+	}()
+
+	// The request of the caller keeps the server from being stopped for a
+	// change of launch options or an unload while it starts.
+	srv := &ServerProcess{
+		cmd:      cmd,
+		cancel:   cancel,
+		exited:   exited,
+		ready:    make(chan struct{}),
+		args:     slices.Clone(cfg.Args),
+		inflight: 1,
+	}
+	sm.servers[key] = srv
+
+	defer func() {
+		close(srv.ready)
+		sm.drained.Broadcast()
+	}()
+
 	baseURL := fmt.Sprintf("http://localhost:%d", cfg.Port)
 
 	healthPath := cfg.HealthPath
@@ -84,21 +257,94 @@ func (sm *ServerManager) StartServer(cfg ServerConfig) error {
 		timeout = 10 * time.Second
 	}
 
-	if err := sm.waitForServer(ctx, baseURL+healthPath, timeout); err != nil {
-		cancel()
-		if err := cmd.Process.Kill(); err != nil {
-			slog.Error("Failed to kill server process", "error", err)
+	sm.mu.Unlock()
+	err := sm.waitForServer(ctx, baseURL+healthPath, timeout)
+	sm.mu.Lock()
+
+	// The server may have been stopped while it was starting.
+	if sm.servers[key] != srv {
+		return nil, fmt.Errorf("manager: %s server stopped while starting", cfg.Name)
+	}
+
+	if err != nil {
+		sm.stopLocked(key, srv)
+		return nil, fmt.Errorf("manager: %s server did not become ready: %w", cfg.Name, err)
+	}
+
+	elapsed := time.Since(start)
+
+	status, ok := sm.starts[key]
+	if !ok {
+		status = &ProcessStatus{Name: cfg.Name, Port: cfg.Port}
+		sm.starts[key] = status
+	}
+	status.Model = cfg.Model
+	status.Starts++
+
+	if sm.onStart != nil {
+		sm.onStart(cfg, elapsed)
+	}
+
+	slog.Info("Server started", "name", cfg.Name, "port", cfg.Port, "model", cfg.Model, "elapsed", elapsed)
+	return srv, nil
+}
+
+// Processes returns the status of every server started so far, sorted by name and port.
+func (sm *ServerManager) Processes() []ProcessStatus {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	processes := make([]ProcessStatus, 0, len(sm.starts))
+	for key, status := range sm.starts {
+		process := *status
+		if srv, ok := sm.servers[key]; ok && !srv.starting() && !srv.done() {
+			process.Running = true
+			process.PID = srv.cmd.Process.Pid
+			process.InFlight = srv.inflight
 		}
-		return fmt.Errorf("manager: %s server did not become ready: %w", cfg.Name, err)
+		processes = append(processes, process)
 	}
 
-	sm.servers[key] = &ServerProcess{
-		cmd:    cmd,
-		cancel: cancel,
+	slices.SortFunc(processes, func(a, b ProcessStatus) int {
+		if a.Name != b.Name {
+			return strings.Compare(a.Name, b.Name)
+		}
+		return a.Port - b.Port
+	})
+
+	return processes
+}
+
+// Starts returns how many times a server was started so far. It changes
+// whenever the server restarts, losing the state it held in memory.
+func (sm *ServerManager) Starts(name string, port int) int {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	if status, ok := sm.starts[fmt.Sprintf("%s-%d", name, port)]; ok {
+		return status.Starts
 	}
+	return 0
+}
 
-	slog.Info("Server started", "name", cfg.Name, "port", cfg.Port)
-	return nil
+// starting reports whether the server is waiting to become ready.
+func (srv *ServerProcess) starting() bool {
+	select {
+	case <-srv.ready:
+		return false
+	default:
+		return true
+	}
+}
+
+// done reports whether the server process has exited.
+func (srv *ServerProcess) done() bool {
+	select {
+	case <-srv.exited:
+		return true
+	default:
+		return false
+	}
 }
 
 // StopServer terminates a backend server.
@@ -112,14 +358,19 @@ func (sm *ServerManager) StopServer(name string, port int) error {
 		return fmt.Errorf("server %s-%d not found", name, port)
 	}
 
+	sm.stopLocked(key, srv)
+	return nil
+}
+
+// stopLocked kills a server process. Callers must hold the lock.
+func (sm *ServerManager) stopLocked(key string, srv *ServerProcess) {
 	srv.cancel()
 	if err := srv.cmd.Process.Kill(); err != nil {
 		slog.Error("Failed to kill server process", "error", err)
 	}
 
 	delete(sm.servers, key)
-	slog.Info("Server stopped", "name", name, "port", port)
-	return nil
+	slog.Info("Server stopped", "server", key)
 }
 
 // StopAll terminates all running servers.
@@ -144,6 +395,10 @@ func (sm *ServerManager) waitForServer(ctx context.Context, url string, timeout
 	deadline := time.Now().Add(timeout)
 
 	for time.Now().Before(deadline) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
 		if err != nil {
 			return fmt.Errorf("failed to create request: %w", err)
@@ -0,0 +1,79 @@
+package backend_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ju4n97/relic/internal/backend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerManager_Acquire_DoesNotBlockWhileStarting(t *testing.T) {
+	var ready atomic.Bool
+	health := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if !ready.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer health.Close()
+
+	healthURL, err := url.Parse(health.URL)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(healthURL.Port())
+	require.NoError(t, err)
+
+	bin := filepath.Join(t.TempDir(), "server")
+	require.NoError(t, os.WriteFile(bin, []byte("#!/bin/sh\nexec sleep 300\n"), 0o755))
+
+	sm := backend.NewServerManager()
+	defer sm.StopAll()
+
+	cfg := backend.ServerConfig{Name: "stub", BinPath: bin, Port: port, ReadyTimeout: 10 * time.Second}
+
+	var wg sync.WaitGroup
+	releases := make([]func(), 2)
+	errs := make([]error, 2)
+	for i := range releases {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			releases[i], errs[i] = sm.Acquire(cfg)
+		}()
+	}
+
+	// The server is starting: the manager answers without waiting for it.
+	time.Sleep(100 * time.Millisecond)
+	answered := make(chan []backend.ProcessStatus)
+	go func() { answered <- sm.Processes() }()
+	select {
+	case processes := <-answered:
+		assert.Empty(t, processes)
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("Processes blocked while a server was starting")
+	}
+
+	ready.Store(true)
+	wg.Wait()
+
+	for i, release := range releases {
+		require.NoError(t, errs[i])
+		release()
+	}
+	assert.Equal(t, 1, sm.Starts("stub", port), "concurrent requests share the start")
+
+	processes := sm.Processes()
+	require.Len(t, processes, 1)
+	assert.True(t, processes[0].Running)
+	assert.Zero(t, processes[0].InFlight)
+}
@@ -0,0 +1,146 @@
+package backend
+
+import (
+	"context"
+	"io"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ju4n97/relic/internal/tracing"
+)
+
+// tracedBackend creates a span for each request served by a backend.
+type tracedBackend struct {
+	Backend
+}
+
+// tracedStreamingBackend creates a span for each request served by a streaming backend.
+type tracedStreamingBackend struct {
+	*tracedBackend
+	streaming StreamingBackend
+}
+
+// Traced wraps a backend to create a span for each request it serves, named after
+// the backend, e.g. "llama.cpp.Infer". The wrapper implements StreamingBackend
+// when the backend does, so it can be registered in place of the backend.
+func Traced(b Backend) Backend {
+	tb := &tracedBackend{Backend: b}
+	if sb, ok := b.(StreamingBackend); ok {
+		return &tracedStreamingBackend{tracedBackend: tb, streaming: sb}
+	}
+
+	return tb
+}
+
+// Infer implements Backend.
+func (b *tracedBackend) Infer(ctx context.Context, req *Request) (*Response, error) {
+	ctx, span, req, input := b.start(ctx, "Infer", req)
+
+	resp, err := b.Backend.Infer(ctx, req)
+
+	span.SetAttributes(tracing.InputBytes.Int64(input.n))
+	if resp != nil && resp.Metadata != nil {
+		span.SetAttributes(tracing.OutputBytes.Int64(resp.Metadata.OutputSizeBytes))
+		span.SetAttributes(resp.Metadata.Usage.attributes()...)
+	}
+	tracing.End(span, err)
+
+	return resp, err
+}
+
+// InferStream implements StreamingBackend. The span ends with the stream.
+func (b *tracedStreamingBackend) InferStream(ctx context.Context, req *Request) (<-chan StreamChunk, error) {
+	ctx, span, req, input := b.start(ctx, "InferStream", req)
+
+	chunks, err := b.streaming.InferStream(ctx, req)
+	if err != nil {
+		tracing.End(span, err)
+		return nil, err
+	}
+
+	out := make(chan StreamChunk)
+	go func() {
+		defer close(out)
+
+		var (
+			output int
+			errEnd error
+		)
+		for chunk := range chunks {
+			output += len(chunk.Data)
+			if chunk.Usage != nil {
+				span.SetAttributes(chunk.Usage.attributes()...)
+			}
+			if chunk.Error != nil {
+				errEnd = chunk.Error
+			}
+			out <- chunk
+		}
+
+		span.SetAttributes(
+			tracing.InputBytes.Int64(input.n),
+			tracing.OutputBytes.Int(output),
+		)
+		tracing.End(span, errEnd)
+	}()
+
+	return out, nil
+}
+
+// start starts the span of a request and returns a copy of the request counting
+// the input bytes the backend reads.
+func (b *tracedBackend) start(ctx context.Context, operation string, req *Request) (context.Context, trace.Span, *Request, *countingReader) {
+	provider := b.Provider()
+	ctx, span := tracing.Tracer().Start(ctx, provider+"."+operation, trace.WithAttributes(
+		tracing.Backend.String(provider),
+		tracing.ModelID.String(req.ModelID),
+	))
+
+	input := &countingReader{r: req.Input}
+	traced := *req
+	if req.Input != nil {
+		traced.Input = input
+	}
+
+	return ctx, span, &traced, input
+}
+
+// attributes returns the span attributes describing the usage.
+func (u *Usage) attributes() []attribute.KeyValue {
+	if u == nil {
+		return nil
+	}
+
+	var attrs []attribute.KeyValue
+	if u.PromptTokens > 0 {
+		attrs = append(attrs, tracing.PromptTokens.Int(u.PromptTokens))
+	}
+	if u.GeneratedTokens > 0 {
+		attrs = append(attrs, tracing.GeneratedTokens.Int(u.GeneratedTokens))
+	}
+	if u.TokensPerSecond > 0 {
+		attrs = append(attrs, tracing.TokensPerSecond.Float64(u.TokensPerSecond))
+	}
+	if u.AudioSeconds > 0 {
+		attrs = append(attrs, tracing.AudioSeconds.Float64(u.AudioSeconds))
+	}
+	if u.Characters > 0 {
+		attrs = append(attrs, tracing.Characters.Int(u.Characters))
+	}
+
+	return attrs
+}
+
+// countingReader counts the bytes read from a reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+// Read implements io.Reader.
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,75 @@
+package backend_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/ju4n97/relic/internal/backend"
+	"github.com/ju4n97/relic/internal/tracing"
+)
+
+// echoBackend returns its input and reports it as generated tokens.
+type echoBackend struct {
+	err error
+}
+
+func (echoBackend) Provider() string { return "echo" }
+
+func (b echoBackend) Infer(_ context.Context, req *backend.Request) (*backend.Response, error) {
+	input, _ := io.ReadAll(req.Input)
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	return &backend.Response{
+		Output: bytes.NewReader(input),
+		Metadata: &backend.ResponseMetadata{
+			OutputSizeBytes: int64(len(input)),
+			Usage:           &backend.Usage{GeneratedTokens: 3},
+		},
+	}, nil
+}
+
+func (echoBackend) Close() error { return nil }
+
+func TestTraced(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	shutdown, err := tracing.Setup(t.Context(), tracing.WithExporter(exporter))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = shutdown(t.Context()) })
+
+	b := backend.Traced(echoBackend{})
+	_, isStreaming := b.(backend.StreamingBackend)
+	assert.False(t, isStreaming)
+
+	_, err = b.Infer(t.Context(), &backend.Request{ModelID: "qwen", Input: bytes.NewReader([]byte("hello"))})
+	require.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "echo.Infer", spans[0].Name)
+	assert.Subset(t, spans[0].Attributes, []attribute.KeyValue{
+		tracing.Backend.String("echo"),
+		tracing.ModelID.String("qwen"),
+		tracing.InputBytes.Int64(5),
+		tracing.OutputBytes.Int64(5),
+		tracing.GeneratedTokens.Int(3),
+	})
+
+	exporter.Reset()
+	_, err = backend.Traced(echoBackend{err: errors.New("boom")}).Infer(t.Context(), &backend.Request{Input: bytes.NewReader(nil)})
+	require.Error(t, err)
+
+	spans = exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status.Code)
+}
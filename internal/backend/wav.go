@@ -0,0 +1,44 @@
+package backend
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// WAVDuration returns the duration in seconds of a WAV file. It returns false if
+// the data is not a PCM WAV file it can read.
+func WAVDuration(data []byte) (float64, bool) {
+	if len(data) < 12 || !bytes.Equal(data[0:4], []byte("RIFF")) || !bytes.Equal(data[8:12], []byte("WAVE")) {
+		return 0, false
+	}
+
+	var byteRate uint32
+	for offset := 12; offset+8 <= len(data); {
+		id := data[offset : offset+4]
+		size := int(binary.LittleEndian.Uint32(data[offset+4 : offset+8]))
+		body := offset + 8
+
+		switch {
+		case bytes.Equal(id, []byte("fmt ")):
+			if size < 16 || body+16 > len(data) {
+				return 0, false
+			}
+			byteRate = binary.LittleEndian.Uint32(data[body+8 : body+12])
+
+		case bytes.Equal(id, []byte("data")):
+			if byteRate == 0 {
+				return 0, false
+			}
+			// Streamed files may not know their size; use what was written.
+			if body+size > len(data) {
+				size = len(data) - body
+			}
+			return float64(size) / float64(byteRate), true
+		}
+
+		// Chunks are padded to an even size.
+		offset = body + size + size%2
+	}
+
+	return 0, false
+}
@@ -0,0 +1,38 @@
+package backend_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ju4n97/relic/internal/backend"
+)
+
+// wav builds a 16-bit mono WAV file holding the given number of samples.
+func wav(sampleRate, samples int) []byte {
+	data := make([]byte, 44+samples*2)
+	copy(data[0:], "RIFF")
+	binary.LittleEndian.PutUint32(data[4:], uint32(36+samples*2))
+	copy(data[8:], "WAVEfmt ")
+	binary.LittleEndian.PutUint32(data[16:], 16)
+	binary.LittleEndian.PutUint16(data[20:], 1)
+	binary.LittleEndian.PutUint16(data[22:], 1)
+	binary.LittleEndian.PutUint32(data[24:], uint32(sampleRate))
+	binary.LittleEndian.PutUint32(data[28:], uint32(sampleRate*2))
+	binary.LittleEndian.PutUint16(data[32:], 2)
+	binary.LittleEndian.PutUint16(data[34:], 16)
+	copy(data[36:], "data")
+	binary.LittleEndian.PutUint32(data[40:], uint32(samples*2))
+
+	return data
+}
+
+func TestWAVDuration(t *testing.T) {
+	seconds, ok := backend.WAVDuration(wav(16000, 24000))
+	assert.True(t, ok)
+	assert.InDelta(t, 1.5, seconds, 1e-9)
+
+	_, ok = backend.WAVDuration([]byte("ID3 not a wav file"))
+	assert.False(t, ok)
+}
@@ -11,6 +11,8 @@ import (
 	"strconv"
 	"time"
 
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
 	"github.com/ju4n97/relic/internal/backend"
 	"github.com/ju4n97/relic/internal/mapsafe"
 )
@@ -40,6 +42,22 @@ type TranscriptionRequest struct {
 	BestOf       int     `json:"best_of,omitempty"`
 	Translate    bool    `json:"translate,omitempty"`
 	NoTimestamps bool    `json:"no_timestamps,omitempty"`
+
+	Extra map[string]any `json:"-"` // Parameters without a field, sent as form fields
+}
+
+// transcriptionParameters are the parameters of a request read into the fields
+// of a TranscriptionRequest, or set by relic. The others are passed through.
+var transcriptionParameters = map[string]bool{
+	"file":            true,
+	"response_format": true,
+	"language":        true,
+	"temperature":     true,
+	"translate":       true,
+	"no_timestamps":   true,
+	"prompt":          true,
+	"beam_size":       true,
+	"best_of":         true,
 }
 
 // TranscriptionResponse represents a response from the whisper-server API.
@@ -82,7 +100,9 @@ func NewBackend(binPath string, serverManager *backend.ServerManager) (backend.B
 		binPath:       binPath,
 		serverManager: serverManager,
 		client: &http.Client{
-			Timeout: 5 * time.Minute,
+			// Calls to the server are traced as children of the request span.
+			Transport: otelhttp.NewTransport(http.DefaultTransport),
+			Timeout:   5 * time.Minute,
 		},
 		port: BackendPort,
 	}, nil
@@ -105,16 +125,22 @@ func (b *Backend) Infer(ctx context.Context, req *backend.Request) (*backend.Res
 		"--port", strconv.Itoa(b.port),
 		"--host", "127.0.0.1",
 	}
+	if req.Launch.Threads > 0 {
+		args = append(args, "--threads", strconv.Itoa(req.Launch.Threads))
+	}
 
-	if err := b.serverManager.StartServer(backend.ServerConfig{
+	release, err := b.serverManager.Acquire(backend.ServerConfig{
 		Name:       BackendName,
+		Model:      req.ModelID,
 		BinPath:    b.binPath,
 		Args:       args,
 		Port:       b.port,
 		HealthPath: "/",
-	}); err != nil {
+	})
+	if err != nil {
 		return nil, fmt.Errorf("manager: failed to start server: %w", err)
 	}
+	defer release()
 
 	audioData, err := io.ReadAll(req.Input)
 	if err != nil {
@@ -185,6 +211,7 @@ func (b *Backend) Infer(ctx context.Context, req *backend.Request) (*backend.Res
 			Timestamp:       time.Now(),
 			DurationSeconds: elapsed,
 			OutputSizeBytes: int64(len(transcriptionResp.Text)),
+			Usage:           &backend.Usage{AudioSeconds: audioSeconds(audioData, &transcriptionResp)},
 			BackendSpecific: map[string]any{
 				"response": transcriptionResp,
 			},
@@ -192,6 +219,16 @@ func (b *Backend) Infer(ctx context.Context, req *backend.Request) (*backend.Res
 	}, nil
 }
 
+// audioSeconds returns the duration of the transcribed audio, read from the WAV
+// header or, for other formats, from the duration reported by the server.
+func audioSeconds(audio []byte, resp *TranscriptionResponse) float64 {
+	if seconds, ok := backend.WAVDuration(audio); ok {
+		return seconds
+	}
+
+	return resp.Duration
+}
+
 // buildTranscriptionRequest builds a TranscriptionRequest from a backend.Request.
 func (b *Backend) buildTranscriptionRequest(req *backend.Request) *TranscriptionRequest {
 	p := req.Parameters
@@ -207,7 +244,24 @@ func (b *Backend) buildTranscriptionRequest(req *backend.Request) *Transcription
 		Prompt:       mapsafe.Get(p, "prompt", ""),
 		BeamSize:     mapsafe.Get(p, "beam_size", -1),
 		BestOf:       mapsafe.Get(p, "best_of", 2),
+		Extra:        extraParameters(p),
+	}
+}
+
+// extraParameters returns the parameters without a field of TranscriptionRequest.
+func extraParameters(p map[string]any) map[string]any {
+	var extra map[string]any
+	for name, value := range p {
+		if transcriptionParameters[name] {
+			continue
+		}
+		if extra == nil {
+			extra = map[string]any{}
+		}
+		extra[name] = value
 	}
+
+	return extra
 }
 
 // addTranscriptionParams adds transcription parameters to the multipart writer.
@@ -232,6 +286,19 @@ func (b *Backend) addTranscriptionParams(w *multipart.Writer, req *Transcription
 		params["prompt"] = req.Prompt
 	}
 
+	for name, value := range req.Extra {
+		if s, ok := value.(string); ok {
+			params[name] = s
+			continue
+		}
+
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("manager: failed to encode parameter %s: %w", name, err)
+		}
+		params[name] = string(data)
+	}
+
 	for key, value := range params {
 		if err := w.WriteField(key, value); err != nil {
 			return fmt.Errorf("manager: failed to write field %s: %w", key, err)
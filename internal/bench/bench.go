@@ -0,0 +1,133 @@
+// Package bench measures the latency and throughput of models by sending them
+// workloads through the service layer.
+package bench
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ju4n97/relic/internal/model"
+)
+
+// Target is a model, and the compute profile it is served with, a benchmark runs
+// against.
+type Target struct {
+	Model   string `json:"model"`
+	Profile string `json:"profile,omitempty"` // Empty for the model default
+}
+
+// String returns the model, followed by "@profile" when a profile is set.
+func (t Target) String() string {
+	if t.Profile == "" {
+		return t.Model
+	}
+
+	return t.Model + "@" + t.Profile
+}
+
+// Sample is the measurement of a single request. Workloads fill the fields that
+// apply to them.
+type Sample struct {
+	Latency         time.Duration // Until the whole output was received
+	FirstOutput     time.Duration // Until the first token or audio was received
+	PromptTokens    int
+	GeneratedTokens int
+	AudioSeconds    float64 // Audio transcribed or produced
+	WordErrors      int     // Substituted, deleted and inserted words of a transcript
+	ReferenceWords  int     // Words of the reference transcript, 0 without one
+}
+
+// Workload is the kind of requests a benchmark sends.
+type Workload interface {
+	// Task returns the service the requests are sent to.
+	Task() model.Type
+
+	// Do sends the i-th request of a run to a target and measures it.
+	Do(ctx context.Context, target Target, i int) (Sample, error)
+
+	// Metrics summarizes the samples of a run that took wall to complete.
+	Metrics(samples []Sample, wall time.Duration) []Metric
+}
+
+// Options configure a run.
+type Options struct {
+	Requests    int // Requests measured, at least 1
+	Concurrency int // Requests in flight at once, at least 1
+	Warmup      int // Requests sent one after the other before measuring, e.g. to load the model
+}
+
+// Run sends a workload to a target and summarizes its measurements. Failed
+// requests are counted; the run fails if every request does.
+func Run(ctx context.Context, w Workload, target Target, opts Options) (*Result, error) {
+	if opts.Requests < 1 {
+		return nil, fmt.Errorf("%w: requests must be at least 1", ErrInvalidOptions)
+	}
+	if opts.Concurrency < 1 {
+		return nil, fmt.Errorf("%w: concurrency must be at least 1", ErrInvalidOptions)
+	}
+
+	for i := range opts.Warmup {
+		if _, err := w.Do(ctx, target, i); err != nil {
+			return nil, fmt.Errorf("bench: warmup of %s failed: %w", target, err)
+		}
+	}
+
+	var (
+		samples  = make([]Sample, 0, opts.Requests)
+		errs     int
+		firstErr error
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+	)
+
+	next := make(chan int)
+	start := time.Now()
+	for range min(opts.Concurrency, opts.Requests) {
+		wg.Go(func() {
+			for i := range next {
+				sample, err := w.Do(ctx, target, opts.Warmup+i)
+
+				mu.Lock()
+				if err != nil {
+					errs++
+					if firstErr == nil {
+						firstErr = err
+					}
+				} else {
+					samples = append(samples, sample)
+				}
+				mu.Unlock()
+			}
+		})
+	}
+
+send:
+	for i := range opts.Requests {
+		select {
+		case next <- i:
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(next)
+	wg.Wait()
+	wall := time.Since(start)
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("bench: %s: %w: %w", target, ErrAllFailed, firstErr)
+	}
+
+	return &Result{
+		Task:        w.Task(),
+		Target:      target,
+		Concurrency: opts.Concurrency,
+		Requests:    opts.Requests,
+		Errors:      errs,
+		Metrics:     w.Metrics(samples, wall),
+	}, nil
+}
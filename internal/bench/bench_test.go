@@ -0,0 +1,171 @@
+package bench_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ju4n97/relic/internal/bench"
+	"github.com/ju4n97/relic/internal/model"
+)
+
+// fakeWorkload fails the requests whose index is in fail and counts the others.
+type fakeWorkload struct {
+	fail     map[int]bool
+	inFlight int
+	peak     int
+	mu       sync.Mutex
+}
+
+func (w *fakeWorkload) Task() model.Type { return model.TypeLLM }
+
+func (w *fakeWorkload) Do(_ context.Context, _ bench.Target, i int) (bench.Sample, error) {
+	w.mu.Lock()
+	w.inFlight++
+	w.peak = max(w.peak, w.inFlight)
+	w.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	w.mu.Lock()
+	w.inFlight--
+	w.mu.Unlock()
+
+	if w.fail[i] {
+		return bench.Sample{}, errors.New("boom")
+	}
+
+	return bench.Sample{Latency: time.Duration(i+1) * time.Millisecond}, nil
+}
+
+func (w *fakeWorkload) Metrics(samples []bench.Sample, _ time.Duration) []bench.Metric {
+	return []bench.Metric{{Key: "samples", Name: "Samples", Value: float64(len(samples))}}
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	w := &fakeWorkload{fail: map[int]bool{1: true}}
+	target := bench.Target{Model: "qwen", Profile: "gpu"}
+
+	result, err := bench.Run(t.Context(), w, target, bench.Options{Requests: 8, Concurrency: 4, Warmup: 1})
+	require.NoError(t, err)
+
+	assert.Equal(t, model.TypeLLM, result.Task)
+	assert.Equal(t, "qwen@gpu", result.Target.String())
+	assert.Equal(t, 8, result.Requests)
+	assert.Equal(t, 1, result.Errors, "index 1 is the first measured request after the warmup")
+	assert.Equal(t, []bench.Metric{{Key: "samples", Name: "Samples", Value: 7}}, result.Metrics)
+	assert.Equal(t, 4, w.peak)
+}
+
+func TestRun_Errors(t *testing.T) {
+	t.Parallel()
+
+	w := &fakeWorkload{fail: map[int]bool{0: true, 1: true}}
+
+	_, err := bench.Run(t.Context(), w, bench.Target{Model: "qwen"}, bench.Options{Requests: 2, Concurrency: 1})
+	require.ErrorIs(t, err, bench.ErrAllFailed)
+
+	_, err = bench.Run(t.Context(), w, bench.Target{Model: "qwen"}, bench.Options{Requests: 1, Concurrency: 1, Warmup: 1})
+	require.Error(t, err, "a failed warmup stops the run")
+
+	_, err = bench.Run(t.Context(), w, bench.Target{Model: "qwen"}, bench.Options{Requests: 1})
+	require.ErrorIs(t, err, bench.ErrInvalidOptions)
+}
+
+func TestWordErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		reference  string
+		hypothesis string
+		errors     int
+		words      int
+	}{
+		{"identical", "The cat sat.", "the cat sat", 0, 3},
+		{"substitution", "the cat sat", "the bat sat", 1, 3},
+		{"deletion", "the cat sat down", "the cat down", 1, 4},
+		{"insertion", "the cat sat", "the black cat sat", 1, 3},
+		{"apostrophes", "Don't stop", "don't stop", 0, 2},
+		{"empty hypothesis", "the cat", "", 2, 2},
+		{"empty reference", "", "noise", 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			errs, words := bench.WordErrors(tt.reference, tt.hypothesis)
+			assert.Equal(t, tt.errors, errs)
+			assert.Equal(t, tt.words, words)
+		})
+	}
+}
+
+func TestReport(t *testing.T) {
+	t.Parallel()
+
+	report := &bench.Report{Results: []*bench.Result{
+		{
+			Task: model.TypeLLM, Target: bench.Target{Model: "qwen", Profile: "q4"}, Concurrency: 1, Requests: 10,
+			Metrics: []bench.Metric{
+				{Key: "ttft_p50_ms", Name: "Time to first token p50", Unit: "ms", Value: 200},
+				{Key: "decode_tokens_per_second", Name: "Decoding", Unit: "tok/s", Value: 40, HigherIsBetter: true},
+			},
+		},
+		{
+			Task: model.TypeLLM, Target: bench.Target{Model: "qwen", Profile: "q8"}, Concurrency: 1, Requests: 10, Errors: 1,
+			Metrics: []bench.Metric{
+				{Key: "ttft_p50_ms", Name: "Time to first token p50", Unit: "ms", Value: 250},
+				{Key: "decode_tokens_per_second", Name: "Decoding", Unit: "tok/s", Value: 30, HigherIsBetter: true},
+			},
+		},
+	}}
+
+	var table bytes.Buffer
+	require.NoError(t, report.WriteTable(&table))
+	assert.Contains(t, table.String(), "qwen@q4 c=1")
+	assert.Contains(t, table.String(), "CHANGE")
+	assert.Regexp(t, `Time to first token p50\s+200.00 ms\s+250.00 ms\s+\+25.0% worse`, table.String())
+	assert.Regexp(t, `Decoding\s+40.00 tok/s\s+30.00 tok/s\s+-25.0% worse`, table.String())
+
+	var out bytes.Buffer
+	require.NoError(t, report.WriteCSV(&out))
+	records, err := csv.NewReader(&out).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"task", "model", "profile", "concurrency", "requests", "errors", "ttft_p50_ms", "decode_tokens_per_second"},
+		{"llm", "qwen", "q4", "1", "10", "0", "200", "40"},
+		{"llm", "qwen", "q8", "1", "10", "1", "250", "30"},
+	}, records)
+}
+
+func TestLLM_Metrics(t *testing.T) {
+	t.Parallel()
+
+	samples := []bench.Sample{
+		{FirstOutput: 100 * time.Millisecond, Latency: 1100 * time.Millisecond, PromptTokens: 50, GeneratedTokens: 21},
+		{FirstOutput: 300 * time.Millisecond, Latency: 2300 * time.Millisecond, PromptTokens: 150, GeneratedTokens: 41},
+	}
+
+	metrics := map[string]float64{}
+	for _, m := range (&bench.LLM{}).Metrics(samples, 4*time.Second) {
+		metrics[m.Key] = m.Value
+	}
+
+	assert.InDelta(t, 200, metrics["ttft_p50_ms"], 0.001)
+	assert.InDelta(t, 290, metrics["ttft_p95_ms"], 0.001)
+	assert.InDelta(t, 500, metrics["prompt_tokens_per_second"], 0.001)
+	assert.InDelta(t, 20, metrics["decode_tokens_per_second"], 0.001)
+	assert.InDelta(t, 15.5, metrics["throughput_tokens_per_second"], 0.001)
+	assert.InDelta(t, 0.5, metrics["requests_per_second"], 0.001)
+}
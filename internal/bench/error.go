@@ -0,0 +1,10 @@
+package bench
+
+import "errors"
+
+// Error definitions for the bench package.
+var (
+	ErrInvalidOptions = errors.New("invalid benchmark options")
+	ErrAllFailed      = errors.New("every request failed")
+	ErrNoInput        = errors.New("workload has no input")
+)
@@ -0,0 +1,114 @@
+package bench
+
+import (
+	"context"
+	"time"
+
+	"github.com/ju4n97/relic/internal/backend"
+	"github.com/ju4n97/relic/internal/backend/llama"
+	"github.com/ju4n97/relic/internal/model"
+	"github.com/ju4n97/relic/internal/service"
+)
+
+// LLM is a workload of chat completions. Replies are streamed to measure the
+// time to the first token.
+type LLM struct {
+	Service   *service.LLM
+	Prompts   []string // Sent in turn, one per request
+	MaxTokens int      // Tokens generated per reply, unlimited when 0
+}
+
+// Task implements Workload.
+func (w *LLM) Task() model.Type {
+	return model.TypeLLM
+}
+
+// Do implements Workload.
+func (w *LLM) Do(ctx context.Context, target Target, i int) (Sample, error) {
+	if len(w.Prompts) == 0 {
+		return Sample{}, ErrNoInput
+	}
+
+	req := &backend.Request{
+		Messages:   []backend.Message{{Role: "user", Content: w.Prompts[i%len(w.Prompts)]}},
+		Parameters: map[string]any{},
+	}
+	if w.MaxTokens > 0 {
+		req.Parameters["n_predict"] = w.MaxTokens
+	}
+
+	start := time.Now()
+
+	chunks, err := w.Service.GenerateStream(ctx, llama.BackendName, target.Model, target.Profile, req)
+	if err != nil {
+		return Sample{}, err
+	}
+
+	var (
+		sample Sample
+		usage  *backend.Usage
+		deltas int
+	)
+	for chunk := range chunks {
+		if chunk.Error != nil {
+			return Sample{}, chunk.Error
+		}
+		if len(chunk.Data) > 0 {
+			if deltas == 0 {
+				sample.FirstOutput = time.Since(start)
+			}
+			deltas++
+		}
+		if chunk.Usage != nil {
+			usage = chunk.Usage
+		}
+		if chunk.Done {
+			break
+		}
+	}
+	sample.Latency = time.Since(start)
+
+	// llama-server streams a token per chunk, which stands in for the token
+	// count when the backend does not report it.
+	sample.GeneratedTokens = deltas
+	if usage != nil {
+		sample.PromptTokens = usage.PromptTokens
+		if usage.GeneratedTokens > 0 {
+			sample.GeneratedTokens = usage.GeneratedTokens
+		}
+	}
+
+	return sample, nil
+}
+
+// Metrics implements Workload. Prompt processing speed is measured over the time
+// to the first token, and decoding speed over the rest of the reply.
+func (w *LLM) Metrics(samples []Sample, wall time.Duration) []Metric {
+	var prompt, decode []float64
+	generated := 0
+	for _, s := range samples {
+		generated += s.GeneratedTokens
+		if s.PromptTokens > 0 && s.FirstOutput > 0 {
+			prompt = append(prompt, float64(s.PromptTokens)/s.FirstOutput.Seconds())
+		}
+		if s.GeneratedTokens > 1 && s.Latency > s.FirstOutput {
+			decode = append(decode, float64(s.GeneratedTokens-1)/(s.Latency-s.FirstOutput).Seconds())
+		}
+	}
+
+	metrics := latencyMetrics(samples, "ttft", "Time to first token", func(s Sample) time.Duration { return s.FirstOutput })
+	metrics = append(metrics,
+		Metric{Key: "prompt_tokens_per_second", Name: "Prompt processing", Unit: "tok/s", Value: mean(prompt), HigherIsBetter: true},
+		Metric{Key: "decode_tokens_per_second", Name: "Decoding", Unit: "tok/s", Value: mean(decode), HigherIsBetter: true},
+		Metric{Key: "throughput_tokens_per_second", Name: "Throughput", Unit: "tok/s", Value: float64(generated) / wall.Seconds(), HigherIsBetter: true},
+	)
+	metrics = append(metrics, latencyMetrics(samples, "latency", "Latency", func(s Sample) time.Duration { return s.Latency })...)
+
+	return append(metrics, Metric{
+		Key:            "requests_per_second",
+		Name:           "Requests",
+		Unit:           "req/s",
+		Value:          float64(len(samples)) / wall.Seconds(),
+		HigherIsBetter: true,
+	})
+}
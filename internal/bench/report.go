@@ -0,0 +1,200 @@
+package bench
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ju4n97/relic/internal/model"
+)
+
+// Metric is a measurement summarized over the requests of a run.
+type Metric struct {
+	Key            string  `json:"key"` // e.g. "ttft_p50_ms", used by the JSON and CSV outputs
+	Name           string  `json:"name"`
+	Unit           string  `json:"unit,omitempty"`
+	Value          float64 `json:"value"`
+	HigherIsBetter bool    `json:"higher_is_better"`
+}
+
+// Result is the outcome of a run.
+type Result struct {
+	Task        model.Type `json:"task"`
+	Target      Target     `json:"target"`
+	Concurrency int        `json:"concurrency"`
+	Requests    int        `json:"requests"`
+	Errors      int        `json:"errors"`
+	Metrics     []Metric   `json:"metrics"`
+}
+
+// Label returns the column label of the result in a table.
+func (r *Result) Label() string {
+	return fmt.Sprintf("%s c=%d", r.Target, r.Concurrency)
+}
+
+// Report holds the results of the runs of a benchmark, e.g. of two models or of
+// a model at several concurrency levels.
+type Report struct {
+	StartedAt time.Time `json:"started_at"`
+	Results   []*Result `json:"results"`
+}
+
+// WriteTable writes the report as a table with a column per result, so that the
+// results can be compared side by side. With two results, a column shows how
+// the second compares to the first.
+func (r *Report) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	compare := len(r.Results) == 2
+
+	header := []string{"METRIC"}
+	for _, result := range r.Results {
+		header = append(header, result.Label())
+	}
+	if compare {
+		header = append(header, "CHANGE")
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+	requests := []string{"Requests"}
+	failed := []string{"Errors"}
+	for _, result := range r.Results {
+		requests = append(requests, strconv.Itoa(result.Requests))
+		failed = append(failed, strconv.Itoa(result.Errors))
+	}
+	if compare {
+		requests = append(requests, "")
+		failed = append(failed, "")
+	}
+	fmt.Fprintln(tw, strings.Join(requests, "\t"))
+	fmt.Fprintln(tw, strings.Join(failed, "\t"))
+
+	for _, key := range r.metricKeys() {
+		var (
+			row     = []string{""}
+			metrics []*Metric
+		)
+		for _, result := range r.Results {
+			m := result.metric(key)
+			metrics = append(metrics, m)
+			if m == nil {
+				row = append(row, "-")
+				continue
+			}
+			row[0] = m.Name
+			row = append(row, formatValue(m))
+		}
+		if compare {
+			row = append(row, change(metrics[0], metrics[1]))
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteCSV writes the report as CSV, with a row per result and a column per
+// metric.
+func (r *Report) WriteCSV(w io.Writer) error {
+	keys := r.metricKeys()
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(append([]string{"task", "model", "profile", "concurrency", "requests", "errors"}, keys...)); err != nil {
+		return err
+	}
+
+	for _, result := range r.Results {
+		record := []string{
+			string(result.Task),
+			result.Target.Model,
+			result.Target.Profile,
+			strconv.Itoa(result.Concurrency),
+			strconv.Itoa(result.Requests),
+			strconv.Itoa(result.Errors),
+		}
+		for _, key := range keys {
+			value := ""
+			if m := result.metric(key); m != nil {
+				value = strconv.FormatFloat(m.Value, 'f', -1, 64)
+			}
+			record = append(record, value)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// metricKeys returns the keys of the metrics of all results, in the order they
+// first appear.
+func (r *Report) metricKeys() []string {
+	var keys []string
+	seen := map[string]bool{}
+	for _, result := range r.Results {
+		for _, m := range result.Metrics {
+			if !seen[m.Key] {
+				seen[m.Key] = true
+				keys = append(keys, m.Key)
+			}
+		}
+	}
+
+	return keys
+}
+
+// metric returns the metric of the result with a key, or nil.
+func (r *Result) metric(key string) *Metric {
+	for i := range r.Metrics {
+		if r.Metrics[i].Key == key {
+			return &r.Metrics[i]
+		}
+	}
+
+	return nil
+}
+
+// formatValue formats the value of a metric with its unit.
+func formatValue(m *Metric) string {
+	value := strconv.FormatFloat(m.Value, 'f', 2, 64)
+	if m.Unit == "" {
+		return value
+	}
+
+	return value + " " + m.Unit
+}
+
+// change describes how metric b compares to metric a, as a relative difference
+// followed by "better" or "worse".
+func change(a, b *Metric) string {
+	if a == nil || b == nil || a.Value == 0 {
+		return "-"
+	}
+
+	diff := (b.Value - a.Value) / math.Abs(a.Value) * 100
+	if math.Abs(diff) < 0.05 {
+		return "0.0%"
+	}
+
+	verdict := "worse"
+	if (diff > 0) == a.HigherIsBetter {
+		verdict = "better"
+	}
+
+	return fmt.Sprintf("%+.1f%% %s", diff, verdict)
+}
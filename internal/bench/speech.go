@@ -0,0 +1,147 @@
+package bench
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ju4n97/relic/internal/backend"
+	"github.com/ju4n97/relic/internal/backend/piper"
+	"github.com/ju4n97/relic/internal/backend/whisper"
+	"github.com/ju4n97/relic/internal/model"
+	"github.com/ju4n97/relic/internal/service"
+)
+
+// Audio is an input of the STT workload.
+type Audio struct {
+	Name      string
+	Data      []byte // WAV audio
+	Reference string // Expected transcript, the word error rate is only measured with one
+}
+
+// STT is a workload of transcriptions.
+type STT struct {
+	Service  *service.STT
+	Inputs   []Audio // Sent in turn, one per request
+	Language string  // Detected when empty
+}
+
+// Task implements Workload.
+func (w *STT) Task() model.Type {
+	return model.TypeSTT
+}
+
+// Do implements Workload.
+func (w *STT) Do(ctx context.Context, target Target, i int) (Sample, error) {
+	if len(w.Inputs) == 0 {
+		return Sample{}, ErrNoInput
+	}
+	input := w.Inputs[i%len(w.Inputs)]
+
+	start := time.Now()
+
+	resp, err := w.Service.Transcribe(ctx, whisper.BackendName, target.Model, target.Profile, &backend.Request{
+		Input:      bytes.NewReader(input.Data),
+		Parameters: map[string]any{"language": w.Language},
+	})
+	if err != nil {
+		return Sample{}, err
+	}
+
+	var text strings.Builder
+	if _, err := io.Copy(&text, resp.Output); err != nil {
+		return Sample{}, err
+	}
+
+	sample := Sample{Latency: time.Since(start)}
+	sample.FirstOutput = sample.Latency
+	sample.AudioSeconds = audioSeconds(resp, input.Data)
+	if input.Reference != "" {
+		sample.WordErrors, sample.ReferenceWords = WordErrors(input.Reference, text.String())
+	}
+
+	return sample, nil
+}
+
+// Metrics implements Workload. The word error rate is measured over all the
+// words of the references.
+func (w *STT) Metrics(samples []Sample, wall time.Duration) []Metric {
+	metrics := latencyMetrics(samples, "latency", "Latency", func(s Sample) time.Duration { return s.Latency })
+	metrics = append(metrics, realTimeFactor(samples), audioThroughput(samples, wall))
+
+	var wordErrors, words int
+	for _, s := range samples {
+		wordErrors += s.WordErrors
+		words += s.ReferenceWords
+	}
+	if words > 0 {
+		metrics = append(metrics, Metric{
+			Key:   "wer_percent",
+			Name:  "Word error rate",
+			Unit:  "%",
+			Value: float64(wordErrors) / float64(words) * 100,
+		})
+	}
+
+	return metrics
+}
+
+// TTS is a workload of speech syntheses.
+type TTS struct {
+	Service *service.TTS
+	Texts   []string // Sent in turn, one per request
+}
+
+// Task implements Workload.
+func (w *TTS) Task() model.Type {
+	return model.TypeTTS
+}
+
+// Do implements Workload. The backends return the audio at once, so the time to
+// the first audio is the time to the whole audio.
+func (w *TTS) Do(ctx context.Context, target Target, i int) (Sample, error) {
+	if len(w.Texts) == 0 {
+		return Sample{}, ErrNoInput
+	}
+
+	start := time.Now()
+
+	resp, err := w.Service.Synthesize(ctx, piper.BackendName, target.Model, target.Profile, &backend.Request{
+		Input:      strings.NewReader(w.Texts[i%len(w.Texts)]),
+		Parameters: map[string]any{},
+	})
+	if err != nil {
+		return Sample{}, err
+	}
+
+	audio, err := io.ReadAll(resp.Output)
+	if err != nil {
+		return Sample{}, err
+	}
+
+	sample := Sample{Latency: time.Since(start)}
+	sample.FirstOutput = sample.Latency
+	sample.AudioSeconds = audioSeconds(resp, audio)
+
+	return sample, nil
+}
+
+// Metrics implements Workload.
+func (w *TTS) Metrics(samples []Sample, wall time.Duration) []Metric {
+	metrics := latencyMetrics(samples, "ttfa", "Time to first audio", func(s Sample) time.Duration { return s.FirstOutput })
+
+	return append(metrics, realTimeFactor(samples), audioThroughput(samples, wall))
+}
+
+// audioSeconds returns the duration of the audio of a request, as reported by
+// the backend or else read from the WAV header.
+func audioSeconds(resp *backend.Response, audio []byte) float64 {
+	if resp.Metadata != nil && resp.Metadata.Usage != nil && resp.Metadata.Usage.AudioSeconds > 0 {
+		return resp.Metadata.Usage.AudioSeconds
+	}
+
+	seconds, _ := backend.WAVDuration(audio)
+	return seconds
+}
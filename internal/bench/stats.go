@@ -0,0 +1,94 @@
+package bench
+
+import (
+	"slices"
+	"time"
+)
+
+// percentile returns the p-th percentile (0 to 100) of values, interpolating
+// linearly between the closest ranks. It returns 0 without values.
+func percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := slices.Sorted(slices.Values(values))
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(rank)
+	if lower >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+
+	return sorted[lower] + (rank-float64(lower))*(sorted[lower+1]-sorted[lower])
+}
+
+// mean returns the mean of values, or 0 without values.
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+
+	return sum / float64(len(values))
+}
+
+// milliseconds returns the durations picked from samples, in milliseconds.
+func milliseconds(samples []Sample, pick func(Sample) time.Duration) []float64 {
+	values := make([]float64, len(samples))
+	for i, s := range samples {
+		values[i] = float64(pick(s)) / float64(time.Millisecond)
+	}
+
+	return values
+}
+
+// latencyMetrics returns the median and 95th percentile of durations picked
+// from samples, under the key prefix and name.
+func latencyMetrics(samples []Sample, key, name string, pick func(Sample) time.Duration) []Metric {
+	values := milliseconds(samples, pick)
+
+	return []Metric{
+		{Key: key + "_p50_ms", Name: name + " p50", Unit: "ms", Value: percentile(values, 50)},
+		{Key: key + "_p95_ms", Name: name + " p95", Unit: "ms", Value: percentile(values, 95)},
+	}
+}
+
+// realTimeFactor returns the processing time of samples per second of audio,
+// below 1 when faster than real time.
+func realTimeFactor(samples []Sample) Metric {
+	var processing time.Duration
+	var audio float64
+	for _, s := range samples {
+		processing += s.Latency
+		audio += s.AudioSeconds
+	}
+
+	m := Metric{Key: "rtf", Name: "Real-time factor"}
+	if audio > 0 {
+		m.Value = processing.Seconds() / audio
+	}
+
+	return m
+}
+
+// audioThroughput returns the seconds of audio samples processed per second of
+// the run.
+func audioThroughput(samples []Sample, wall time.Duration) Metric {
+	var audio float64
+	for _, s := range samples {
+		audio += s.AudioSeconds
+	}
+
+	return Metric{
+		Key:            "audio_seconds_per_second",
+		Name:           "Audio throughput",
+		Unit:           "s/s",
+		Value:          audio / wall.Seconds(),
+		HigherIsBetter: true,
+	}
+}
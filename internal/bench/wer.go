@@ -0,0 +1,57 @@
+package bench
+
+import (
+	"strings"
+	"unicode"
+)
+
+// WordErrors returns the number of words substituted, deleted and inserted to
+// turn a reference transcript into a hypothesis, and the number of words of the
+// reference; their ratio is the word error rate. Words are compared ignoring
+// case and punctuation.
+func WordErrors(reference, hypothesis string) (errors, words int) {
+	ref := normalizeWords(reference)
+	hyp := normalizeWords(hypothesis)
+
+	// Levenshtein distance over words, keeping the previous row only.
+	prev := make([]int, len(hyp)+1)
+	curr := make([]int, len(hyp)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ref); i++ {
+		curr[0] = i
+		for j := 1; j <= len(hyp); j++ {
+			cost := 1
+			if ref[i-1] == hyp[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(hyp)], len(ref)
+}
+
+// normalizeWords splits text into lowercase words without punctuation. Apostrophes
+// within words are kept, e.g. "don't".
+func normalizeWords(text string) []string {
+	text = strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '\'':
+			return unicode.ToLower(r)
+		default:
+			return ' '
+		}
+	}, text)
+
+	var words []string
+	for _, word := range strings.Fields(text) {
+		if word = strings.Trim(word, "'"); word != "" {
+			words = append(words, word)
+		}
+	}
+
+	return words
+}
@@ -0,0 +1,235 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// indexFileName is the name of the cache index file stored in the models directory.
+	indexFileName = ".relic-cache.json"
+
+	// flushInterval bounds how often last-used updates are written to disk.
+	flushInterval = time.Minute
+)
+
+// Entry tracks the files and usage of a single cached model.
+type Entry struct {
+	AddedAt  time.Time `json:"added_at"`
+	LastUsed time.Time `json:"last_used"`
+	Files    []string  `json:"files"` // Relative to the models directory
+}
+
+// Cache is an index of the models directory tracking size and last use per model.
+type Cache struct {
+	lastFlush time.Time
+	entries   map[string]*Entry
+	dir       string
+	mu        sync.Mutex
+	dirty     bool
+}
+
+// Open loads the cache index of the given models directory.
+// A missing index is not an error, an empty cache is returned instead.
+func Open(dir string) (*Cache, error) {
+	c := &Cache{
+		dir:       filepath.Clean(dir),
+		entries:   map[string]*Entry{},
+		lastFlush: time.Now(),
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, indexFileName))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return c, nil
+		}
+		return nil, fmt.Errorf("cache: failed to read index: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &c.entries); err != nil {
+		return nil, fmt.Errorf("cache: failed to decode index: %w", err)
+	}
+
+	return c, nil
+}
+
+// Dir returns the models directory the cache indexes.
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+// Record stores the files that belong to a model. Paths must be inside the models directory.
+func (c *Cache) Record(modelID string, files []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.recordLocked(modelID, files)
+}
+
+// Touch records that a model has just been used.
+func (c *Cache) Touch(modelID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[modelID]
+	if !ok {
+		return
+	}
+
+	entry.LastUsed = time.Now()
+	c.dirty = true
+
+	if time.Since(c.lastFlush) >= flushInterval {
+		// Usage timestamps are best effort, a failed write is retried on the next flush.
+		_ = c.flushLocked()
+	}
+}
+
+// Flush writes pending changes to the index file.
+func (c *Cache) Flush() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.flushLocked()
+}
+
+// recordLocked stores the files of a model. Callers must hold the lock.
+func (c *Cache) recordLocked(modelID string, files []string) {
+	if len(files) == 0 {
+		return
+	}
+
+	rel := c.relativize(files)
+
+	entry, ok := c.entries[modelID]
+	if !ok {
+		now := time.Now()
+		entry = &Entry{AddedAt: now, LastUsed: now}
+		c.entries[modelID] = entry
+		c.dirty = true
+	}
+
+	if !slices.Equal(entry.Files, rel) {
+		entry.Files = rel
+		c.dirty = true
+	}
+}
+
+// flushLocked writes the index file. Callers must hold the lock.
+func (c *Cache) flushLocked() error {
+	c.lastFlush = time.Now()
+	if !c.dirty {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cache: failed to encode index: %w", err)
+	}
+
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return fmt.Errorf("cache: failed to create %s: %w", c.dir, err)
+	}
+
+	tmp := filepath.Join(c.dir, indexFileName+".tmp")
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("cache: failed to write index: %w", err)
+	}
+
+	if err := os.Rename(tmp, filepath.Join(c.dir, indexFileName)); err != nil {
+		return fmt.Errorf("cache: failed to replace index: %w", err)
+	}
+
+	c.dirty = false
+	return nil
+}
+
+// relativize converts absolute paths to sorted paths relative to the models directory.
+func (c *Cache) relativize(files []string) []string {
+	rel := make([]string, 0, len(files))
+	for _, file := range files {
+		r, err := filepath.Rel(c.dir, file)
+		if err != nil || r == ".." || strings.HasPrefix(r, ".."+string(filepath.Separator)) {
+			continue
+		}
+		rel = append(rel, filepath.ToSlash(r))
+	}
+
+	slices.Sort(rel)
+	return slices.Compact(rel)
+}
+
+// scan returns the size of every regular file in the models directory, keyed by relative path.
+// Relic metadata, Relic directories and in-flight downloads are skipped.
+func (c *Cache) scan() (map[string]int64, error) {
+	sizes := map[string]int64{}
+
+	err := filepath.WalkDir(c.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+
+		if d.IsDir() {
+			// Relic directories, e.g. the persisted prompt caches, are not model files.
+			if path != c.dir && isProtected(d.Name()) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if isProtected(d.Name()) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+
+		rel, err := filepath.Rel(c.dir, path)
+		if err != nil {
+			return nil
+		}
+
+		sizes[filepath.ToSlash(rel)] = info.Size()
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cache: failed to scan %s: %w", c.dir, err)
+	}
+
+	return sizes, nil
+}
+
+// isProtected reports whether a file must never be garbage collected.
+func isProtected(name string) bool {
+	return strings.HasPrefix(name, ".relic-") ||
+		strings.HasSuffix(name, ".incomplete") ||
+		strings.HasSuffix(name, ".lock")
+}
+
+// huggingFaceMetadata returns the relative path of the Hugging Face local-dir
+// metadata file that accompanies a downloaded file, if there is one.
+func huggingFaceMetadata(rel string, sizes map[string]int64) (string, bool) {
+	for dir := path.Dir(rel); dir != "."; dir = path.Dir(dir) {
+		inner := strings.TrimPrefix(rel, dir+"/")
+		candidate := dir + "/.cache/huggingface/download/" + inner + ".metadata"
+		if _, ok := sizes[candidate]; ok {
+			return candidate, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,149 @@
+package cache_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ju4n97/relic/internal/cache"
+)
+
+func writeFile(t *testing.T, path string, size int) string {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, make([]byte, size), 0o644))
+	return path
+}
+
+func TestCache_Report(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	shared := writeFile(t, filepath.Join(dir, "org/repo/tokenizer.json"), 10)
+	q4 := writeFile(t, filepath.Join(dir, "org/repo/model-q4.gguf"), 100)
+	q5 := writeFile(t, filepath.Join(dir, "org/repo/model-q5.gguf"), 200)
+	writeFile(t, filepath.Join(dir, "old/repo/model.bin"), 50)
+	writeFile(t, filepath.Join(dir, "org/repo/.cache/huggingface/download/model-q4.gguf.metadata"), 1)
+	writeFile(t, filepath.Join(dir, "org/repo/.cache/huggingface/download/model-q4.gguf.abc.incomplete"), 7)
+
+	c, err := cache.Open(dir)
+	require.NoError(t, err)
+
+	report, err := c.Report(map[string][]string{
+		"q4": {q4, shared},
+		"q5": {q5, shared},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(10+100+200+50+1), report.TotalBytes, "shared files are counted once, downloads in flight are skipped")
+	assert.Equal(t, []string{"old/repo/model.bin"}, report.UnreferencedFiles)
+	assert.Equal(t, int64(50), report.UnreferencedBytes)
+
+	require.Len(t, report.Models, 2)
+	for _, usage := range report.Models {
+		assert.True(t, usage.Configured)
+		assert.True(t, usage.Shared)
+		assert.Equal(t, 2, usage.Files)
+	}
+
+	// The index is persisted.
+	reopened, err := cache.Open(dir)
+	require.NoError(t, err)
+	report, err = reopened.Report(map[string][]string{})
+	require.NoError(t, err)
+	assert.Len(t, report.Models, 2)
+}
+
+func TestCache_GC(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	kept := writeFile(t, filepath.Join(dir, "org/kept/model.gguf"), 100)
+	removed := writeFile(t, filepath.Join(dir, "org/removed/model.gguf"), 50)
+	slot := writeFile(t, filepath.Join(dir, ".relic-slots/assistant.0123.bin"), 20)
+
+	c, err := cache.Open(dir)
+	require.NoError(t, err)
+	c.Record("removed", []string{removed})
+
+	referenced := map[string][]string{"kept": {kept}}
+
+	result, err := c.GC(referenced, true)
+	require.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, []string{"org/removed/model.gguf"}, result.Removed)
+	assert.Equal(t, []string{"removed"}, result.EvictedModels)
+	assert.Equal(t, int64(50), result.FreedBytes)
+	assert.FileExists(t, removed, "dry run must not delete anything")
+
+	result, err = c.GC(referenced, false)
+	require.NoError(t, err)
+	assert.Equal(t, int64(50), result.FreedBytes)
+	assert.NoFileExists(t, removed)
+	assert.NoDirExists(t, filepath.Join(dir, "org/removed"), "empty directories are pruned")
+	assert.FileExists(t, kept)
+	assert.FileExists(t, slot, "persisted prompt caches are not model files")
+}
+
+func TestCache_Enforce(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	configured := writeFile(t, filepath.Join(dir, "org/configured/model.gguf"), 100)
+	oldest := writeFile(t, filepath.Join(dir, "org/oldest/model.gguf"), 100)
+	recent := writeFile(t, filepath.Join(dir, "org/recent/model.gguf"), 100)
+
+	c, err := cache.Open(dir)
+	require.NoError(t, err)
+	c.Record("oldest", []string{oldest})
+	time.Sleep(10 * time.Millisecond)
+	c.Record("recent", []string{recent})
+	c.Touch("recent")
+
+	referenced := map[string][]string{"configured": {configured}}
+
+	result, err := c.Enforce(250, referenced)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"oldest"}, result.EvictedModels)
+	assert.NoFileExists(t, oldest)
+	assert.FileExists(t, recent)
+
+	// Configured models are never evicted, even when over quota.
+	result, err = c.Enforce(50, referenced)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"recent"}, result.EvictedModels)
+	assert.FileExists(t, configured)
+}
+
+func TestCache_Evict(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	shared := writeFile(t, filepath.Join(dir, "org/repo/tokenizer.json"), 10)
+	q4 := writeFile(t, filepath.Join(dir, "org/repo/model-q4.gguf"), 100)
+	q5 := writeFile(t, filepath.Join(dir, "org/repo/model-q5.gguf"), 200)
+
+	c, err := cache.Open(dir)
+	require.NoError(t, err)
+
+	referenced := map[string][]string{
+		"q4": {q4, shared},
+		"q5": {q5, shared},
+	}
+
+	result, err := c.Evict("q4", referenced)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"q4"}, result.EvictedModels)
+	assert.Equal(t, []string{"org/repo/model-q4.gguf"}, result.Removed)
+	assert.Equal(t, int64(100), result.FreedBytes)
+	assert.NoFileExists(t, q4)
+	assert.FileExists(t, shared, "files used by other models are kept")
+
+	result, err = c.Evict("missing", referenced)
+	require.NoError(t, err)
+	assert.Empty(t, result.EvictedModels)
+}
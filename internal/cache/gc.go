@@ -0,0 +1,304 @@
+package cache
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// Report builds a usage report. referenced maps each configured model to its files.
+func (c *Cache) Report(referenced map[string][]string) (*Report, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.syncReferenced(referenced)
+
+	sizes, err := c.scan()
+	if err != nil {
+		return nil, err
+	}
+
+	owners := c.owners()
+	report := &Report{
+		ModelsDir:         c.dir,
+		Models:            make([]ModelUsage, 0, len(c.entries)),
+		UnreferencedFiles: []string{},
+	}
+
+	for _, size := range sizes {
+		report.TotalBytes += size
+	}
+
+	for modelID, entry := range c.entries {
+		_, configured := referenced[modelID]
+		usage := ModelUsage{
+			ModelID:    modelID,
+			LastUsed:   entry.LastUsed,
+			Configured: configured,
+		}
+
+		for _, file := range entry.Files {
+			size, ok := sizes[file]
+			if !ok {
+				continue
+			}
+			usage.Files++
+			usage.SizeBytes += size
+			if len(owners[file]) > 1 {
+				usage.Shared = true
+			}
+		}
+
+		report.Models = append(report.Models, usage)
+	}
+
+	slices.SortFunc(report.Models, func(a, b ModelUsage) int {
+		return b.LastUsed.Compare(a.LastUsed)
+	})
+
+	for _, file := range c.unreferenced(sizes, referenced) {
+		report.UnreferencedFiles = append(report.UnreferencedFiles, file)
+		report.UnreferencedBytes += sizes[file]
+	}
+
+	return report, c.flushLocked()
+}
+
+// GC removes every file not referenced by a configured model and forgets models
+// that are no longer configured. With dryRun, it only lists what would be removed.
+func (c *Cache) GC(referenced map[string][]string, dryRun bool) (*GCResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.syncReferenced(referenced)
+
+	sizes, err := c.scan()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &GCResult{Removed: []string{}, EvictedModels: []string{}, DryRun: dryRun}
+	for _, file := range c.unreferenced(sizes, referenced) {
+		result.Removed = append(result.Removed, file)
+		result.FreedBytes += sizes[file]
+	}
+
+	for modelID := range c.entries {
+		if _, ok := referenced[modelID]; !ok {
+			result.EvictedModels = append(result.EvictedModels, modelID)
+		}
+	}
+	slices.Sort(result.EvictedModels)
+
+	if dryRun {
+		return result, nil
+	}
+
+	c.remove(result.Removed)
+	for _, modelID := range result.EvictedModels {
+		delete(c.entries, modelID)
+		c.dirty = true
+	}
+
+	return result, c.flushLocked()
+}
+
+// Enforce evicts the least recently used models that are not configured until the
+// cache fits in maxBytes. Files shared with other models are kept.
+func (c *Cache) Enforce(maxBytes int64, referenced map[string][]string) (*GCResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result := &GCResult{Removed: []string{}, EvictedModels: []string{}}
+	if maxBytes <= 0 {
+		return result, nil
+	}
+
+	c.syncReferenced(referenced)
+
+	sizes, err := c.scan()
+	if err != nil {
+		return nil, err
+	}
+
+	var total int64
+	for _, size := range sizes {
+		total += size
+	}
+
+	candidates := make([]string, 0, len(c.entries))
+	for modelID := range c.entries {
+		if _, ok := referenced[modelID]; !ok {
+			candidates = append(candidates, modelID)
+		}
+	}
+	slices.SortFunc(candidates, func(a, b string) int {
+		return c.entries[a].LastUsed.Compare(c.entries[b].LastUsed)
+	})
+
+	for _, modelID := range candidates {
+		if total <= maxBytes {
+			break
+		}
+
+		files := c.ownedFiles(modelID, sizes)
+		for _, file := range files {
+			total -= sizes[file]
+			result.FreedBytes += sizes[file]
+		}
+
+		c.remove(files)
+		delete(c.entries, modelID)
+		c.dirty = true
+
+		result.Removed = append(result.Removed, files...)
+		result.EvictedModels = append(result.EvictedModels, modelID)
+
+		slog.Info("Evicted model from cache", "model_id", modelID, "files", len(files))
+	}
+
+	if total > maxBytes {
+		slog.Warn("Models cache exceeds its quota, only configured models remain",
+			"total", FormatBytes(total),
+			"max", FormatBytes(maxBytes),
+		)
+	}
+
+	return result, c.flushLocked()
+}
+
+// Evict removes the files of a model that no other model uses and forgets the
+// model. Evicting a model that is not in the index removes nothing.
+func (c *Cache) Evict(modelID string, referenced map[string][]string) (*GCResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	result := &GCResult{Removed: []string{}, EvictedModels: []string{}}
+
+	c.syncReferenced(referenced)
+	if _, ok := c.entries[modelID]; !ok {
+		return result, nil
+	}
+
+	sizes, err := c.scan()
+	if err != nil {
+		return nil, err
+	}
+
+	files := c.ownedFiles(modelID, sizes)
+	for _, file := range files {
+		result.FreedBytes += sizes[file]
+	}
+
+	c.remove(files)
+	delete(c.entries, modelID)
+	c.dirty = true
+
+	result.Removed = append(result.Removed, files...)
+	result.EvictedModels = append(result.EvictedModels, modelID)
+
+	return result, c.flushLocked()
+}
+
+// ownedFiles returns the existing files of a model that no other model uses,
+// including their Hugging Face metadata. Callers must hold the lock.
+func (c *Cache) ownedFiles(modelID string, sizes map[string]int64) []string {
+	owners := c.owners()
+
+	var files []string
+	for _, file := range c.entries[modelID].Files {
+		if _, ok := sizes[file]; ok && len(owners[file]) == 1 {
+			files = append(files, file)
+			if meta, ok := huggingFaceMetadata(file, sizes); ok {
+				files = append(files, meta)
+			}
+		}
+	}
+
+	return files
+}
+
+// syncReferenced records the files of configured models in the index.
+// Callers must hold the lock.
+func (c *Cache) syncReferenced(referenced map[string][]string) {
+	for modelID, files := range referenced {
+		c.recordLocked(modelID, files)
+	}
+}
+
+// owners maps each indexed file to the models that use it. Callers must hold the lock.
+func (c *Cache) owners() map[string][]string {
+	owners := map[string][]string{}
+	for modelID, entry := range c.entries {
+		for _, file := range entry.Files {
+			owners[file] = append(owners[file], modelID)
+		}
+	}
+
+	return owners
+}
+
+// unreferenced returns the sorted files not used by any configured model, including
+// the Hugging Face metadata of such files. Callers must hold the lock.
+func (c *Cache) unreferenced(sizes map[string]int64, referenced map[string][]string) []string {
+	keep := map[string]bool{}
+	for _, files := range referenced {
+		for _, file := range c.relativize(files) {
+			keep[file] = true
+			if meta, ok := huggingFaceMetadata(file, sizes); ok {
+				keep[meta] = true
+			}
+		}
+	}
+
+	files := make([]string, 0)
+	for file := range sizes {
+		if !keep[file] {
+			files = append(files, file)
+		}
+	}
+	slices.Sort(files)
+
+	return files
+}
+
+// remove deletes the given relative files and prunes the directories they leave empty.
+// Callers must hold the lock.
+func (c *Cache) remove(files []string) {
+	dirs := map[string]bool{}
+	for _, file := range files {
+		path := filepath.Join(c.dir, filepath.FromSlash(file))
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			slog.Error("Failed to remove cached file", "path", path, "error", err)
+			continue
+		}
+		dirs[filepath.Dir(path)] = true
+	}
+
+	for dir := range dirs {
+		for len(dir) > len(c.dir) {
+			if err := os.Remove(dir); err != nil {
+				break // Not empty
+			}
+			dir = filepath.Dir(dir)
+		}
+	}
+}
+
+// FormatBytes formats a byte count using binary units.
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,32 @@
+package cache
+
+import "time"
+
+// ModelUsage describes the disk usage of a single model.
+type ModelUsage struct {
+	LastUsed   time.Time `json:"last_used"`
+	ModelID    string    `json:"model_id"`
+	Files      int       `json:"files"`
+	SizeBytes  int64     `json:"size_bytes"`
+	Configured bool      `json:"configured"`
+	Shared     bool      `json:"shared"` // Some files are also used by other models
+}
+
+// Report describes the contents of the models directory.
+// Files shared by several models are counted once in the totals.
+type Report struct {
+	ModelsDir         string       `json:"models_dir"`
+	Models            []ModelUsage `json:"models"`
+	UnreferencedFiles []string     `json:"unreferenced_files"`
+	TotalBytes        int64        `json:"total_bytes"`
+	UnreferencedBytes int64        `json:"unreferenced_bytes"`
+	MaxBytes          int64        `json:"max_bytes,omitempty"`
+}
+
+// GCResult describes the outcome of a garbage collection or quota enforcement run.
+type GCResult struct {
+	Removed       []string `json:"removed"`
+	EvictedModels []string `json:"evicted_models"`
+	FreedBytes    int64    `json:"freed_bytes"`
+	DryRun        bool     `json:"dry_run"`
+}
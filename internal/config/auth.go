@@ -0,0 +1,108 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// API key scopes.
+const (
+	// ScopeInference allows running inference and listing models.
+	ScopeInference = "inference"
+
+	// ScopeMetrics allows scraping the Prometheus metrics.
+	ScopeMetrics = "metrics"
+
+	// ScopeAdmin allows every operation, including pulling models and managing the cache.
+	ScopeAdmin = "admin"
+)
+
+// keyHashPrefix prefixes the hashes of API keys, naming the hash function.
+const keyHashPrefix = "sha256:"
+
+// HashKey returns the hash of an API key in the format stored in the config.
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return keyHashPrefix + hex.EncodeToString(sum[:])
+}
+
+// ParseKeyHash returns the digest of an API key hash stored in the config.
+func ParseKeyHash(hash string) ([sha256.Size]byte, error) {
+	var digest [sha256.Size]byte
+
+	encoded, ok := strings.CutPrefix(hash, keyHashPrefix)
+	if !ok {
+		return digest, fmt.Errorf("hash must start with %q", keyHashPrefix)
+	}
+
+	decoded, err := hex.DecodeString(encoded)
+	if err != nil || len(decoded) != sha256.Size {
+		return digest, fmt.Errorf("hash must be %q followed by %d hex digits", keyHashPrefix, 2*sha256.Size)
+	}
+	copy(digest[:], decoded)
+
+	return digest, nil
+}
+
+// keyIssues reports the problems of the API keys: names must be set and unique,
+// hashes well formed, and scopes, services and model patterns valid. Keys of the
+// key file are reported at the key_file setting.
+func (c *Config) keyIssues(report func(warning bool, pointer []string, format string, args ...any)) {
+	services := slices.Sorted(maps.Keys(c.ServiceAssignments()))
+	names := map[string]bool{}
+	hashes := map[string]string{}
+
+	check := func(pointer []string, key APIKeyConfig) {
+		name := key.Name
+		switch {
+		case name == "":
+			report(false, pointer, "auth: API key without a name")
+			name = "<unnamed>"
+		case names[name]:
+			report(false, pointer, "auth: API key %q is defined more than once", name)
+		}
+		names[key.Name] = true
+
+		if _, err := ParseKeyHash(key.Hash); err != nil {
+			report(false, pointer, "auth: API key %q: %v", name, err)
+		} else if other, ok := hashes[key.Hash]; ok {
+			report(false, pointer, "auth: API key %q has the same hash as %q", name, other)
+		} else {
+			hashes[key.Hash] = name
+		}
+
+		if len(key.Scopes) == 0 {
+			report(false, pointer, "auth: API key %q has no scopes", name)
+		}
+		for _, scope := range key.Scopes {
+			if scope != ScopeInference && scope != ScopeMetrics && scope != ScopeAdmin {
+				report(false, pointer, "auth: API key %q: unknown scope %q, expected %s, %s or %s", name, scope, ScopeInference, ScopeMetrics, ScopeAdmin)
+			}
+		}
+
+		for _, service := range key.Services {
+			if !slices.Contains(services, service) {
+				report(false, pointer, "auth: API key %q: unknown service %q, expected one of %s", name, service, strings.Join(services, ", "))
+			}
+		}
+
+		for _, pattern := range key.Models {
+			if _, err := path.Match(pattern, ""); err != nil {
+				report(false, pointer, "auth: API key %q: invalid model pattern %q", name, pattern)
+			}
+		}
+	}
+
+	for i, key := range c.Auth.Keys {
+		check([]string{"auth", "keys", strconv.Itoa(i)}, key)
+	}
+	for _, key := range c.Auth.FileKeys {
+		check([]string{"auth", "key_file"}, key)
+	}
+}
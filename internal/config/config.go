@@ -2,6 +2,7 @@ package config
 
 import (
 	"errors"
+	"slices"
 )
 
 // SourceType represents the type of model source.
@@ -14,24 +15,117 @@ const (
 
 // Config holds the main configuration for the application.
 type Config struct {
-	Version  string                 `json:"version"           yaml:"version"`
-	Storage  StorageConfig          `json:"storage,omitempty" yaml:"storage,omitempty"`
-	Models   map[string]ModelConfig `json:"models"            yaml:"models"`
-	Services ServicesConfig         `json:"services"          yaml:"services"`
+	Version      string                       `json:"version"                 yaml:"version"`
+	Storage      StorageConfig                `json:"storage,omitempty"       yaml:"storage,omitempty"`
+	Models       map[string]ModelConfig       `json:"models"                  yaml:"models"`
+	Profiles     map[string]ProfileConfig     `json:"profiles,omitempty"      yaml:"profiles,omitempty"`
+	Services     ServicesConfig               `json:"services"                yaml:"services"`
+	Auth         AuthConfig                   `json:"auth,omitempty"          yaml:"auth,omitempty"`
+	Limits       LimitsConfig                 `json:"limits,omitempty"        yaml:"limits,omitempty"`
+	Sessions     SessionsConfig               `json:"sessions,omitempty"      yaml:"sessions,omitempty"`
+	PromptCaches map[string]PromptCacheConfig `json:"prompt_caches,omitempty" yaml:"prompt_caches,omitempty"`
+}
+
+// AuthConfig configures API key authentication. Requests must present one of the
+// keys once at least one is defined, in the config or in the key file.
+type AuthConfig struct {
+	KeyFile  string         `json:"key_file,omitempty" yaml:"key_file,omitempty"` // YAML file with more keys, relative to the config file declaring it
+	Keys     []APIKeyConfig `json:"keys,omitempty"     yaml:"keys,omitempty"`
+	FileKeys []APIKeyConfig `json:"-"                  yaml:"-"` // Keys read from KeyFile
+}
+
+// APIKeyConfig defines an API key and what it may be used for. Only the hash of
+// the key is stored.
+type APIKeyConfig struct {
+	Name     string           `json:"name"               yaml:"name"`               // Identifies the key in logs
+	Hash     string           `json:"hash"               yaml:"hash"`               // "sha256:" followed by the hex SHA-256 digest of the key
+	Scopes   []string         `json:"scopes"             yaml:"scopes"`             // "inference" and/or "admin"
+	Services []string         `json:"services,omitempty" yaml:"services,omitempty"` // Services the key may use, all when empty
+	Models   []string         `json:"models,omitempty"   yaml:"models,omitempty"`   // Glob patterns of the model IDs the key may use, all when empty
+	Limits   *RateLimitConfig `json:"limits,omitempty"   yaml:"limits,omitempty"`   // Replaces limits.clients for requests with this key
+}
+
+// KeyFileConfig is the content of an API key file.
+type KeyFileConfig struct {
+	Keys []APIKeyConfig `json:"keys" yaml:"keys"`
+}
+
+// AllKeys returns the keys defined in the config followed by those of the key file.
+func (a *AuthConfig) AllKeys() []APIKeyConfig {
+	return slices.Concat(a.Keys, a.FileKeys)
+}
+
+// LimitsConfig configures the rate limits and daily quotas of inference requests,
+// and the ledger usage is recorded in.
+type LimitsConfig struct {
+	UsageFile string                     `json:"usage_file,omitempty" yaml:"usage_file,omitempty"` // Usage ledger, DefaultUsagePath when empty
+	Clients   RateLimitConfig            `json:"clients,omitempty"    yaml:"clients,omitempty"`    // Limits of each client: API key, client certificate or IP address
+	Models    map[string]RateLimitConfig `json:"models,omitempty"     yaml:"models,omitempty"`     // Limits of each model, shared by all clients
+}
+
+// RateLimitConfig holds per-minute rates and daily quotas. Zero means unlimited.
+// Rates are enforced with token buckets holding a minute worth of tokens, so a
+// client may burst up to the rate at once.
+type RateLimitConfig struct {
+	RequestsPerMinute     int     `json:"requests_per_minute,omitempty"      yaml:"requests_per_minute,omitempty"`
+	TokensPerMinute       int     `json:"tokens_per_minute,omitempty"        yaml:"tokens_per_minute,omitempty"`        // Prompt and generated LLM tokens
+	AudioSecondsPerMinute float64 `json:"audio_seconds_per_minute,omitempty" yaml:"audio_seconds_per_minute,omitempty"` // Audio transcribed or synthesized
+	RequestsPerDay        int     `json:"requests_per_day,omitempty"         yaml:"requests_per_day,omitempty"`
+	TokensPerDay          int     `json:"tokens_per_day,omitempty"           yaml:"tokens_per_day,omitempty"`
+	AudioSecondsPerDay    float64 `json:"audio_seconds_per_day,omitempty"    yaml:"audio_seconds_per_day,omitempty"`
+}
+
+// IsZero reports whether no limit is set.
+func (r RateLimitConfig) IsZero() bool {
+	return r == RateLimitConfig{}
+}
+
+// SessionsConfig configures the chat sessions kept on the server, whose history
+// is stored in an embedded database.
+type SessionsConfig struct {
+	File        string `json:"file,omitempty"         yaml:"file,omitempty"`         // Session database, DefaultSessionsPath when empty
+	TTL         string `json:"ttl,omitempty"          yaml:"ttl,omitempty"`          // Idle time after which sessions expire, e.g. "24h"
+	MaxMessages int    `json:"max_messages,omitempty" yaml:"max_messages,omitempty"` // Messages kept besides the system prompt, oldest dropped first; zero keeps all
+}
+
+// PromptCacheConfig declares a named prompt cache: a long-lived prompt prefix
+// whose KV cache is kept in a slot of the backend and persisted in the models
+// directory, so that requests starting with it skip processing it.
+type PromptCacheConfig struct {
+	Model        string `json:"model"             yaml:"model"`             // Model ID or alias of an llm model
+	Profile      string `json:"profile,omitempty" yaml:"profile,omitempty"` // Profile the cache is built with, the model default when empty
+	SystemPrompt string `json:"system_prompt"     yaml:"system_prompt"`
 }
 
 // StorageConfig holds configuration for caching and auto-download.
 type StorageConfig struct {
-	ModelsDir string `json:"models_dir,omitempty" yaml:"models_dir,omitempty"`
+	ModelsDir string   `json:"models_dir,omitempty" yaml:"models_dir,omitempty"`
+	MaxSize   ByteSize `json:"max_size,omitempty"   yaml:"max_size,omitempty"` // Zero means unlimited
 }
 
 // ModelConfig holds configuration for a specific model.
 type ModelConfig struct {
-	Source  SourceConfig `json:"source"  yaml:"source"`
-	Type    string       `json:"type"    yaml:"type"`
-	Backend string       `json:"backend" yaml:"backend"`
-	Tags    []string     `json:"tags"    yaml:"tags"`
-	Order   int          `json:"order"   yaml:"order"`
+	Source   SourceConfig `json:"source"             yaml:"source"`
+	Type     string       `json:"type"               yaml:"type"`
+	Backend  string       `json:"backend"            yaml:"backend"`
+	Tags     []string     `json:"tags"               yaml:"tags"`
+	Aliases  []string     `json:"aliases,omitempty"  yaml:"aliases,omitempty"`  // Other names the model can be requested by
+	Profiles []string     `json:"profiles,omitempty" yaml:"profiles,omitempty"` // Profiles the model can be served with, the first is the default
+	Order    int          `json:"order"              yaml:"order"`
+}
+
+// ProfileConfig holds a named set of backend launch options, such as the model
+// file variant or the number of threads, that models can be served with.
+type ProfileConfig struct {
+	DisplayName string `json:"display_name"           yaml:"display_name"`
+	Description string `json:"description,omitempty"  yaml:"description,omitempty"`
+	ComputeType string `json:"compute_type,omitempty" yaml:"compute_type,omitempty"`
+	Variant     string `json:"variant,omitempty"      yaml:"variant,omitempty"`    // Glob selecting the model file, relative to the model directory
+	GPULayers   *int   `json:"gpu_layers,omitempty"   yaml:"gpu_layers,omitempty"` // Nil keeps the backend default, zero runs on CPU only
+	Threads     int    `json:"threads,omitempty"      yaml:"threads,omitempty"`
+	ContextSize int    `json:"ctx_size,omitempty"     yaml:"ctx_size,omitempty"`
+	BatchSize   int    `json:"batch_size,omitempty"   yaml:"batch_size,omitempty"`
+	Parallel    int    `json:"parallel,omitempty"     yaml:"parallel,omitempty"` // Requests served at once, each in its own slot of the context
 }
 
 // SourceConfig wraps optional sources (only one should be set).
@@ -52,7 +146,44 @@ type ServicesConfig struct {
 
 // ServicesConfigAssignment holds model assignments for a service.
 type ServicesConfigAssignment struct {
-	Models []string `json:"models" yaml:"models"` // List of model IDs
+	Default string   `json:"default,omitempty" yaml:"default,omitempty"` // Model used when a request names none
+	Models  []string `json:"models"            yaml:"models"`            // List of model IDs
+}
+
+// ServiceAssignments returns the service assignments keyed by service name,
+// which is also the type of the models each service serves.
+func (c *Config) ServiceAssignments() map[string]ServicesConfigAssignment {
+	return map[string]ServicesConfigAssignment{
+		"llm": c.Services.LLM,
+		"nlu": c.Services.NLU,
+		"stt": c.Services.STT,
+		"tts": c.Services.TTS,
+	}
+}
+
+// AssignedModels returns the IDs of the models assigned to at least one service.
+func (c *Config) AssignedModels() map[string]bool {
+	assigned := map[string]bool{}
+	for _, service := range c.ServiceAssignments() {
+		for _, modelID := range service.Models {
+			assigned[modelID] = true
+		}
+	}
+
+	return assigned
+}
+
+// ModelProfiles returns the definitions of the profiles a model can be served
+// with, keyed by profile name. Undefined profiles are skipped.
+func (c *Config) ModelProfiles(modelID string) map[string]ProfileConfig {
+	profiles := map[string]ProfileConfig{}
+	for _, name := range c.Models[modelID].Profiles {
+		if profile, ok := c.Profiles[name]; ok {
+			profiles[name] = profile
+		}
+	}
+
+	return profiles
 }
 
 // -------------------------
@@ -90,6 +221,15 @@ func (m *ModelConfig) GetSource() (ModelSource, error) {
 	return nil, errors.New("no source configured for model")
 }
 
+// ForceDownload reports whether the active source requests a fresh download.
+func (m *ModelConfig) ForceDownload() bool {
+	if m.Source.HuggingFace != nil {
+		return m.Source.HuggingFace.ForceDownload
+	}
+
+	return false
+}
+
 // SetHuggingFaceSource sets the Hugging Face source.
 func (m *ModelConfig) SetHuggingFaceSource(source HuggingFaceSource) {
 	m.Source.HuggingFace = &source
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strconv"
+	"strings"
 
 	"github.com/ju4n97/relic/internal/envvar"
 )
@@ -39,6 +40,88 @@ func DefaultGRPCPort() int {
 	return 50051
 }
 
+// DefaultOffline reports whether offline mode is enabled by default.
+// Precedence:
+// 1. RELIC_OFFLINE environment variable.
+// 2. false.
+func DefaultOffline() bool {
+	if v := os.Getenv(envvar.RelicOffline); v != "" {
+		value, err := strconv.ParseBool(v)
+		if err == nil {
+			return value
+		}
+	}
+
+	return false
+}
+
+// DefaultTLSCertFile returns the default TLS certificate file of the servers, if any.
+// Precedence:
+// 1. RELIC_TLS_CERT_FILE environment variable.
+// 2. "", serving plaintext.
+func DefaultTLSCertFile() string {
+	return os.Getenv(envvar.RelicTLSCertFile)
+}
+
+// DefaultTLSKeyFile returns the default TLS private key file of the servers, if any.
+// Precedence:
+// 1. RELIC_TLS_KEY_FILE environment variable.
+// 2. "", serving plaintext.
+func DefaultTLSKeyFile() string {
+	return os.Getenv(envvar.RelicTLSKeyFile)
+}
+
+// DefaultTLSClientCAFile returns the default CA file client certificates are
+// verified with, if any.
+// Precedence:
+// 1. RELIC_TLS_CLIENT_CA_FILE environment variable.
+// 2. "", not requiring client certificates.
+func DefaultTLSClientCAFile() string {
+	return os.Getenv(envvar.RelicTLSClientCAFile)
+}
+
+// DefaultServer returns the default gRPC address of the server the CLI commands
+// use, if any.
+// Precedence:
+// 1. RELIC_SERVER environment variable.
+// 2. "", using the local models.
+func DefaultServer() string {
+	return os.Getenv(envvar.RelicServer)
+}
+
+// DefaultAPIKey returns the default API key the CLI commands send to the server, if any.
+// Precedence:
+// 1. RELIC_API_KEY environment variable.
+// 2. "", sending no key.
+func DefaultAPIKey() string {
+	return os.Getenv(envvar.RelicAPIKey)
+}
+
+// DefaultOverlayPath returns the default config overlay for a config file, or ""
+// if there is none.
+// Precedence:
+// 1. RELIC_CONFIG_OVERLAY environment variable.
+// 2. <name>.<env>.<ext> next to the config file, if it exists, where env is
+// the RELIC_ENV environment variable (e.g. relic.prod.yaml).
+func DefaultOverlayPath(configPath string) string {
+	if p := os.Getenv(envvar.RelicConfigOverlay); p != "" {
+		return p
+	}
+
+	environment := strings.ToLower(strings.TrimSpace(os.Getenv(envvar.RelicEnv)))
+	if environment == "" {
+		return ""
+	}
+
+	ext := filepath.Ext(configPath)
+	overlay := strings.TrimSuffix(configPath, ext) + "." + environment + ext
+	if _, err := os.Stat(overlay); err != nil {
+		return ""
+	}
+
+	return overlay
+}
+
 // DefaultConfigPath returns the default path for RELIC config directory.
 func DefaultConfigPath() string {
 	home, err := os.UserHomeDir()
@@ -59,6 +142,18 @@ func DefaultConfigPath() string {
 	}
 }
 
+// DefaultUsagePath returns the default path of the usage ledger, in the RELIC
+// config directory.
+func DefaultUsagePath() string {
+	return filepath.Join(DefaultConfigPath(), "usage.json")
+}
+
+// DefaultSessionsPath returns the default path of the chat sessions database, in
+// the RELIC config directory.
+func DefaultSessionsPath() string {
+	return filepath.Join(DefaultConfigPath(), "sessions.db")
+}
+
 // DefaultModelsPath returns the default path for RELIC models directory.
 func DefaultModelsPath() string {
 	home, err := os.UserHomeDir()
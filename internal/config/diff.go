@@ -0,0 +1,63 @@
+package config
+
+import (
+	"reflect"
+	"slices"
+)
+
+// Diff describes what changed between two configs.
+type Diff struct {
+	AddedModels     []string `json:"added_models"`   // Assigned in the new config only
+	RemovedModels   []string `json:"removed_models"` // Assigned in the old config only
+	ChangedModels   []string `json:"changed_models"` // Assigned in both, with a different definition or profiles
+	ServicesChanged bool     `json:"services_changed"`
+	StorageChanged  bool     `json:"storage_changed"`
+}
+
+// Empty reports whether the configs are equivalent.
+func (d Diff) Empty() bool {
+	return len(d.AddedModels) == 0 &&
+		len(d.RemovedModels) == 0 &&
+		len(d.ChangedModels) == 0 &&
+		!d.ServicesChanged &&
+		!d.StorageChanged
+}
+
+// DiffConfigs compares the models assigned to services in two configs.
+// A nil old config is treated as empty.
+func DiffConfigs(old, updated *Config) Diff {
+	if old == nil {
+		old = &Config{}
+	}
+
+	oldAssigned := old.AssignedModels()
+	newAssigned := updated.AssignedModels()
+
+	var diff Diff
+	for modelID := range newAssigned {
+		if !oldAssigned[modelID] {
+			diff.AddedModels = append(diff.AddedModels, modelID)
+			continue
+		}
+
+		if !reflect.DeepEqual(old.Models[modelID], updated.Models[modelID]) ||
+			!reflect.DeepEqual(old.ModelProfiles(modelID), updated.ModelProfiles(modelID)) {
+			diff.ChangedModels = append(diff.ChangedModels, modelID)
+		}
+	}
+
+	for modelID := range oldAssigned {
+		if !newAssigned[modelID] {
+			diff.RemovedModels = append(diff.RemovedModels, modelID)
+		}
+	}
+
+	slices.Sort(diff.AddedModels)
+	slices.Sort(diff.RemovedModels)
+	slices.Sort(diff.ChangedModels)
+
+	diff.ServicesChanged = !reflect.DeepEqual(old.Services, updated.Services)
+	diff.StorageChanged = !reflect.DeepEqual(old.Storage, updated.Storage)
+
+	return diff
+}
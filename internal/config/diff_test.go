@@ -0,0 +1,65 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ju4n97/relic/internal/config"
+)
+
+func TestDiffConfigs(t *testing.T) {
+	t.Parallel()
+
+	model := func(repo string) config.ModelConfig {
+		m := config.ModelConfig{Type: "llm", Backend: "llama.cpp"}
+		m.SetHuggingFaceSource(config.HuggingFaceSource{Repo: repo})
+		return m
+	}
+
+	old := &config.Config{
+		Models: map[string]config.ModelConfig{
+			"kept":    model("org/kept"),
+			"changed": model("org/changed"),
+			"removed": model("org/removed"),
+		},
+		Services: config.ServicesConfig{
+			LLM: config.ServicesConfigAssignment{Models: []string{"kept", "changed", "removed"}},
+		},
+	}
+
+	updated := &config.Config{
+		Models: map[string]config.ModelConfig{
+			"kept":    model("org/kept"),
+			"changed": model("org/changed-v2"),
+			"removed": model("org/removed"),
+			"added":   model("org/added"),
+		},
+		Services: config.ServicesConfig{
+			LLM: config.ServicesConfigAssignment{Models: []string{"kept", "changed", "added"}},
+		},
+	}
+
+	diff := config.DiffConfigs(old, updated)
+	assert.Equal(t, []string{"added"}, diff.AddedModels)
+	assert.Equal(t, []string{"changed"}, diff.ChangedModels)
+	assert.Equal(t, []string{"removed"}, diff.RemovedModels, "unassigned models are removed even if still defined")
+	assert.True(t, diff.ServicesChanged)
+	assert.False(t, diff.StorageChanged)
+
+	assert.True(t, config.DiffConfigs(updated, updated).Empty())
+	assert.Len(t, config.DiffConfigs(nil, updated).AddedModels, 3)
+
+	profiled := model("org/profiled")
+	profiled.Profiles = []string{"fast"}
+	withProfile := func(profile config.ProfileConfig) *config.Config {
+		return &config.Config{
+			Models:   map[string]config.ModelConfig{"profiled": profiled},
+			Profiles: map[string]config.ProfileConfig{"fast": profile},
+			Services: config.ServicesConfig{LLM: config.ServicesConfigAssignment{Models: []string{"profiled"}}},
+		}
+	}
+
+	diff = config.DiffConfigs(withProfile(config.ProfileConfig{Threads: 4}), withProfile(config.ProfileConfig{Threads: 8}))
+	assert.Equal(t, []string{"profiled"}, diff.ChangedModels, "models follow the profiles they use")
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"maps"
+	"os"
+
+	"github.com/ju4n97/relic/internal/envvar"
+	"github.com/ju4n97/relic/internal/xfs"
+)
+
+// redacted replaces secret values in configs meant to be displayed.
+const redacted = "<redacted>"
+
+// ModelsDir returns the directory models are stored in.
+// Precedence:
+// 1. RELIC_MODELS_PATH environment variable.
+// 2. storage.models_dir in the config.
+// 3. Default models path.
+func (c *Config) ModelsDir() string {
+	if p := os.Getenv(envvar.RelicModelsPath); p != "" {
+		return xfs.ExpandTilde(p)
+	}
+	if c.Storage.ModelsDir != "" {
+		return xfs.ExpandTilde(c.Storage.ModelsDir)
+	}
+	return xfs.ExpandTilde(DefaultModelsPath())
+}
+
+// UsagePath returns the file the usage ledger is persisted in.
+// Precedence:
+// 1. limits.usage_file in the config.
+// 2. Default usage path.
+func (c *Config) UsagePath() string {
+	if c.Limits.UsageFile != "" {
+		return xfs.ExpandTilde(c.Limits.UsageFile)
+	}
+	return DefaultUsagePath()
+}
+
+// Effective returns a copy of the config as RELIC runs it: environment
+// overrides are applied and unset values are replaced by their defaults.
+func (c *Config) Effective() *Config {
+	effective := c.clone()
+	effective.Storage.ModelsDir = c.ModelsDir()
+	effective.Limits.UsageFile = c.UsagePath()
+	effective.Sessions.File = c.SessionsPath()
+	effective.Sessions.TTL = c.SessionTTL().String()
+
+	for _, modelConfig := range effective.Models {
+		if hf := modelConfig.Source.HuggingFace; hf != nil {
+			if hf.RepoType == "" {
+				hf.RepoType = "model"
+			}
+			if hf.MaxWorkers == 0 {
+				hf.MaxWorkers = 8
+			}
+		}
+	}
+
+	return effective
+}
+
+// Redacted returns a copy of the config with its secrets masked.
+func (c *Config) Redacted() *Config {
+	redactedConfig := c.clone()
+
+	for _, modelConfig := range redactedConfig.Models {
+		if hf := modelConfig.Source.HuggingFace; hf != nil && hf.Token != "" {
+			hf.Token = redacted
+		}
+	}
+
+	return redactedConfig
+}
+
+// clone returns a copy of the config that can be modified without affecting it.
+// Slices are shared, as configs never modify them in place.
+func (c *Config) clone() *Config {
+	cloned := *c
+	cloned.Models = maps.Clone(c.Models)
+	cloned.Profiles = maps.Clone(c.Profiles)
+
+	for modelID, modelConfig := range cloned.Models {
+		if hf := modelConfig.Source.HuggingFace; hf != nil {
+			source := *hf
+			modelConfig.Source.HuggingFace = &source
+			cloned.Models[modelID] = modelConfig
+		}
+	}
+
+	return &cloned
+}
@@ -0,0 +1,8 @@
+package config
+
+import "errors"
+
+// Error definitions for the config package.
+var (
+	ErrEnvNotSet = errors.New("environment variable is not set")
+)
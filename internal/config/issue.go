@@ -0,0 +1,133 @@
+package config
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// Position is a location in a config file.
+type Position struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+// String returns the position as file:line:column.
+func (p Position) String() string {
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+}
+
+// Issue is a problem found in a config.
+type Issue struct {
+	Position *Position `json:"position,omitempty"` // Where the offending value was written, if known
+	Pointer  string    `json:"pointer"`            // JSON pointer to the offending value, e.g. /models/qwen/backend
+	Message  string    `json:"message"`
+	Warning  bool      `json:"warning,omitempty"` // Does not prevent the config from loading
+}
+
+// String returns the issue prefixed by its position, if known.
+func (i Issue) String() string {
+	severity := "error"
+	if i.Warning {
+		severity = "warning"
+	}
+
+	if i.Position == nil {
+		return fmt.Sprintf("%s: %s: %s", i.Pointer, severity, i.Message)
+	}
+
+	return fmt.Sprintf("%s: %s: %s", i.Position, severity, i.Message)
+}
+
+// Check loads a config like LoadAndValidate, but instead of stopping at the
+// first problem it returns every schema violation and consistency issue, along
+// with warnings, located in the files they were written in. The error is only
+// set when the config files cannot be read.
+func Check(path, schemaPath string, overlays ...string) ([]Issue, error) {
+	schema, err := compileSchema(schemaPath)
+	if err != nil {
+		return nil, fmt.Errorf("manager: failed to compile schema: %w", err)
+	}
+
+	l := newLoader()
+	raw, err := l.compose(path, overlays)
+	if err != nil {
+		return nil, fmt.Errorf("manager: failed to load config: %w", err)
+	}
+
+	var issues []Issue
+	if err := schema.Validate(raw); err != nil {
+		var validationErr *jsonschema.ValidationError
+		if !errors.As(err, &validationErr) {
+			return nil, fmt.Errorf("manager: failed to validate config: %w", err)
+		}
+
+		for _, leaf := range schemaLeaves(validationErr) {
+			issues = append(issues, Issue{Pointer: leaf.InstanceLocation, Message: leaf.Message})
+		}
+	} else {
+		config, err := decode(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := l.readKeyFile(config); err != nil {
+			return nil, fmt.Errorf("manager: failed to load config: %w", err)
+		}
+
+		issues = config.Issues()
+	}
+
+	for i := range issues {
+		issues[i].Position = l.position(issues[i].Pointer)
+	}
+
+	slices.SortStableFunc(issues, func(a, b Issue) int {
+		switch {
+		case a.Position == nil && b.Position == nil:
+			return strings.Compare(a.Pointer, b.Pointer)
+		case a.Position == nil:
+			return 1
+		case b.Position == nil:
+			return -1
+		}
+
+		return cmp.Or(
+			strings.Compare(a.Position.File, b.Position.File),
+			cmp.Compare(a.Position.Line, b.Position.Line),
+			cmp.Compare(a.Position.Column, b.Position.Column),
+		)
+	})
+
+	return issues, nil
+}
+
+// schemaLeaves returns the most specific causes of a schema validation error.
+func schemaLeaves(err *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(err.Causes) == 0 {
+		return []*jsonschema.ValidationError{err}
+	}
+
+	var leaves []*jsonschema.ValidationError
+	for _, cause := range err.Causes {
+		leaves = append(leaves, schemaLeaves(cause)...)
+	}
+
+	return leaves
+}
+
+// pointerOf returns the JSON pointer made of the given reference tokens.
+func pointerOf(tokens ...string) string {
+	var sb strings.Builder
+	for _, token := range tokens {
+		sb.WriteByte('/')
+		sb.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(token))
+	}
+
+	return sb.String()
+}
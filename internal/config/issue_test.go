@@ -0,0 +1,64 @@
+package config_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ju4n97/relic/internal/config"
+)
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	dir := writeFiles(t, map[string]string{
+		"models.yaml": `models:
+  qwen:
+    type: llm
+    backend: llama.cpp
+    source: {huggingface: {repo: org/qwen}}
+    order: 10
+  phi:
+    type: llm
+    backend: llama.cpp
+    source: {huggingface: {repo: org/phi}}
+    order: 10
+`,
+		"relic.yaml": `version: "1"
+include: models.yaml
+services:
+  llm:
+    models: [qwen, missing]
+`,
+		"invalid.yaml": `version: "1"
+models:
+  qwen:
+    type: llm
+    backend: llama.cpp
+    order: ten
+services: {}
+`,
+	})
+
+	// The embedded schema is used when no schema path is given.
+	issues, err := config.Check(filepath.Join(dir, "relic.yaml"), "")
+	require.NoError(t, err)
+	require.Len(t, issues, 2)
+
+	assert.True(t, issues[0].Warning, "duplicate orders are only reported")
+	assert.Equal(t, "/models/qwen/order", issues[0].Pointer)
+	assert.Equal(t, config.Position{File: filepath.Join(dir, "models.yaml"), Line: 6, Column: 5}, *issues[0].Position,
+		"issues are located in the included file that defines the value")
+
+	assert.False(t, issues[1].Warning)
+	assert.Equal(t, `services.llm: model "missing" is not defined`, issues[1].Message)
+	assert.Equal(t, config.Position{File: filepath.Join(dir, "relic.yaml"), Line: 5, Column: 20}, *issues[1].Position)
+
+	issues, err = config.Check(filepath.Join(dir, "invalid.yaml"), "")
+	require.NoError(t, err)
+	require.Len(t, issues, 2, "every schema violation is reported")
+	assert.Equal(t, 3, issues[0].Position.Line, "missing properties are located at their parent")
+	assert.Equal(t, 6, issues[1].Position.Line)
+}
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+)
+
+// limitIssues reports the problems of the rate limits: values must not be
+// negative, and limited models must be defined.
+func (c *Config) limitIssues(report func(warning bool, pointer []string, format string, args ...any)) {
+	check := func(pointer []string, name string, limits RateLimitConfig) {
+		if limits.RequestsPerMinute < 0 || limits.TokensPerMinute < 0 || limits.AudioSecondsPerMinute < 0 ||
+			limits.RequestsPerDay < 0 || limits.TokensPerDay < 0 || limits.AudioSecondsPerDay < 0 {
+			report(false, pointer, "limits: %s: limits must not be negative", name)
+		}
+	}
+
+	check([]string{"limits", "clients"}, "clients", c.Limits.Clients)
+
+	for _, modelID := range slices.Sorted(maps.Keys(c.Limits.Models)) {
+		pointer := []string{"limits", "models", modelID}
+		if _, ok := c.Models[modelID]; !ok {
+			report(false, pointer, "limits: model %q is not defined", modelID)
+		}
+		check(pointer, "model "+strconv.Quote(modelID), c.Limits.Models[modelID])
+	}
+
+	for i, key := range c.Auth.Keys {
+		if key.Limits != nil {
+			check([]string{"auth", "keys", strconv.Itoa(i), "limits"}, "API key "+strconv.Quote(key.Name), *key.Limits)
+		}
+	}
+	for _, key := range c.Auth.FileKeys {
+		if key.Limits != nil {
+			check([]string{"auth", "key_file"}, "API key "+strconv.Quote(key.Name), *key.Limits)
+		}
+	}
+}
@@ -1,32 +1,93 @@
 package config
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/santhosh-tekuri/jsonschema/v5"
 	"go.yaml.in/yaml/v3"
+
+	relicschema "github.com/ju4n97/relic/jsonschema"
 )
 
-// LoadAndValidate loads and validates the configuration.
-func LoadAndValidate(path, schemaPath string) (*Config, error) {
-	data, err := os.ReadFile(path)
+// includeKey is the top-level key listing the files a config file is based on.
+const includeKey = "include"
+
+// LoadAndValidate loads the config file at path together with the files it
+// includes, merges the overlays on top of it, in order, and validates the result.
+// An empty schemaPath validates against the embedded schema.
+//
+// String values may reference environment variables as ${VAR} or ${VAR:-default},
+// and secrets as file:<path>, which is replaced by the content of the file. Only
+// values written as file:<path> read a file, not values of variables; file\:
+// escapes a literal "file:".
+func LoadAndValidate(path, schemaPath string, overlays ...string) (*Config, error) {
+	config, _, err := load(path, schemaPath, overlays)
+	return config, err
+}
+
+// load is LoadAndValidate, also returning every file the config was read from.
+// The files are returned even on error so that they can be watched for a fix.
+func load(path, schemaPath string, overlays []string) (*Config, []string, error) {
+	l := newLoader()
+
+	raw, err := l.compose(path, overlays)
+	if err != nil {
+		return nil, l.files, fmt.Errorf("manager: failed to load config: %w", err)
+	}
+
+	schema, err := compileSchema(schemaPath)
 	if err != nil {
-		return nil, fmt.Errorf("manager: failed to read config: %w", err)
+		return nil, l.files, fmt.Errorf("manager: failed to compile schema: %w", err)
 	}
 
-	var raw any
-	if err := yaml.Unmarshal(data, &raw); err != nil {
-		return nil, fmt.Errorf("invalid YAML: %w", err)
+	if err := schema.Validate(raw); err != nil {
+		return nil, l.files, fmt.Errorf("manager: config validation failed: %w", err)
 	}
 
-	schema, err := jsonschema.Compile(schemaPath)
+	config, err := decode(raw)
 	if err != nil {
-		return nil, fmt.Errorf("manager: failed to compile schema: %w", err)
+		return nil, l.files, err
 	}
 
-	if err := schema.Validate(raw); err != nil {
-		return nil, fmt.Errorf("manager: config validation failed: %w", err)
+	if err := l.readKeyFile(config); err != nil {
+		return nil, l.files, fmt.Errorf("manager: failed to load config: %w", err)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, l.files, fmt.Errorf("manager: config validation failed: %w", err)
+	}
+
+	return config, l.files, nil
+}
+
+// compileSchema compiles the schema at schemaPath, or the embedded one if empty.
+func compileSchema(schemaPath string) (*jsonschema.Schema, error) {
+	if schemaPath != "" {
+		return jsonschema.Compile(schemaPath)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(relicschema.RelicV1ID, bytes.NewReader(relicschema.RelicV1)); err != nil {
+		return nil, err
+	}
+
+	return compiler.Compile(relicschema.RelicV1ID)
+}
+
+// decode converts a validated raw config into a Config.
+func decode(raw map[string]any) (*Config, error) {
+	data, err := yaml.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("manager: failed to marshal merged config: %w", err)
 	}
 
 	var config Config
@@ -36,3 +97,372 @@ func LoadAndValidate(path, schemaPath string) (*Config, error) {
 
 	return &config, nil
 }
+
+// loader reads config files and the files they include.
+type loader struct {
+	positions map[string]Position // JSON pointers to where their value was last written
+	files     []string            // Every file read, in order
+	stack     []string            // Files being read, to detect include cycles
+}
+
+// newLoader creates a new loader.
+func newLoader() *loader {
+	return &loader{positions: map[string]Position{}}
+}
+
+// compose reads the config file at path and merges the overlays on top of it.
+func (l *loader) compose(path string, overlays []string) (map[string]any, error) {
+	raw, err := l.document(path)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, overlay := range overlays {
+		doc, err := l.document(overlay)
+		if err != nil {
+			return nil, fmt.Errorf("overlay: %w", err)
+		}
+		raw = merge(raw, doc)
+	}
+
+	return raw, nil
+}
+
+// position returns where the value at a JSON pointer was written, falling back
+// to its closest written parent, e.g. for missing properties.
+func (l *loader) position(pointer string) *Position {
+	for {
+		if position, ok := l.positions[pointer]; ok {
+			return &position
+		}
+
+		i := strings.LastIndexByte(pointer, '/')
+		if i < 0 {
+			return nil
+		}
+		pointer = pointer[:i]
+	}
+}
+
+// record records the positions of the values of a YAML node.
+func (l *loader) record(node *yaml.Node, file, pointer string) {
+	l.positions[pointer] = Position{File: file, Line: node.Line, Column: node.Column}
+
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			l.record(child, file, pointer)
+		}
+
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			l.record(child, file, pointer+pointerOf(strconv.Itoa(i)))
+		}
+
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			key := node.Content[i-1]
+			l.record(node.Content[i], file, pointer+pointerOf(key.Value))
+			// Point at the key, which is where editors expect the problem.
+			l.positions[pointer+pointerOf(key.Value)] = Position{File: file, Line: key.Line, Column: key.Column}
+		}
+	}
+}
+
+// document reads a config file, interpolates its values, and merges it on top
+// of the files it includes. Include paths are relative to the including file.
+func (l *loader) document(path string) (map[string]any, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if slices.Contains(l.stack, path) {
+		return nil, fmt.Errorf("include cycle: %s", strings.Join(append(l.stack, path), " -> "))
+	}
+	if !slices.Contains(l.files, path) {
+		l.files = append(l.files, path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("invalid YAML in %s: %w", path, err)
+	}
+
+	dir := filepath.Dir(path)
+	if err := l.interpolate(&node, dir); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	doc := map[string]any{}
+	if err := node.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid config in %s: %w", path, err)
+	}
+
+	includes, err := includesOf(doc)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	delete(doc, includeKey)
+	resolveKeyFile(doc, dir)
+
+	l.stack = append(l.stack, path)
+	defer func() { l.stack = l.stack[:len(l.stack)-1] }()
+
+	merged := map[string]any{}
+	for _, include := range includes {
+		if !filepath.IsAbs(include) {
+			include = filepath.Join(dir, include)
+		}
+
+		included, err := l.document(include)
+		if err != nil {
+			return nil, err
+		}
+		merged = merge(merged, included)
+	}
+
+	// Recorded after the includes, since the file overrides them.
+	l.record(&node, path, "")
+
+	return merge(merged, doc), nil
+}
+
+// resolveKeyFile makes the API key file path of a config document, relative to
+// the document, relative to the working directory instead.
+func resolveKeyFile(doc map[string]any, dir string) {
+	auth, ok := doc["auth"].(map[string]any)
+	if !ok {
+		return
+	}
+
+	if keyFile, ok := auth["key_file"].(string); ok && keyFile != "" && !filepath.IsAbs(keyFile) {
+		auth["key_file"] = filepath.Join(dir, keyFile)
+	}
+}
+
+// readKeyFile reads the API keys of the key file the config refers to, if any.
+// The key file is recorded with the config files so that changes to it are seen.
+func (l *loader) readKeyFile(config *Config) error {
+	path := config.Auth.KeyFile
+	if path == "" {
+		return nil
+	}
+
+	if !slices.Contains(l.files, path) {
+		l.files = append(l.files, path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	var keyFile KeyFileConfig
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&keyFile); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid key file %s: %w", path, err)
+	}
+	config.Auth.FileKeys = keyFile.Keys
+
+	return nil
+}
+
+// includesOf returns the files a config document includes, given as a path or
+// a list of paths.
+func includesOf(doc map[string]any) ([]string, error) {
+	switch value := doc[includeKey].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{value}, nil
+	case []any:
+		includes := make([]string, 0, len(value))
+		for _, item := range value {
+			include, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s: expected file paths, got %v", includeKey, item)
+			}
+			includes = append(includes, include)
+		}
+		return includes, nil
+	default:
+		return nil, fmt.Errorf("%s: expected a file path or a list of file paths", includeKey)
+	}
+}
+
+// merge returns base with overlay merged on top of it. Mappings are merged
+// recursively, other values are replaced, and null values remove the key.
+func merge(base, overlay map[string]any) map[string]any {
+	merged := maps.Clone(base)
+	if merged == nil {
+		merged = map[string]any{}
+	}
+
+	for key, value := range overlay {
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+
+		if overlayMap, ok := value.(map[string]any); ok {
+			if baseMap, ok := merged[key].(map[string]any); ok {
+				merged[key] = merge(baseMap, overlayMap)
+				continue
+			}
+		}
+
+		merged[key] = value
+	}
+
+	return merged
+}
+
+// interpolate expands the environment variable and secret file references of
+// the string values of a YAML node, recursively. Plain scalars are re-typed after
+// expansion, so that "threads: ${THREADS}" yields an integer. The secret files
+// are recorded with the config files so that changes to them are seen.
+func (l *loader) interpolate(node *yaml.Node, dir string) error {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			if err := l.interpolate(child, dir); err != nil {
+				return err
+			}
+		}
+
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			if err := l.interpolate(node.Content[i], dir); err != nil {
+				return fmt.Errorf("%s: %w", node.Content[i-1].Value, err)
+			}
+		}
+
+	case yaml.ScalarNode:
+		if node.Tag != "!!str" {
+			return nil
+		}
+
+		// Decided on the value as written, so that variables cannot read files.
+		if ref, ok := strings.CutPrefix(node.Value, "file:"); ok {
+			return l.readSecret(node, ref, dir)
+		}
+
+		raw := node.Value
+		if rest, ok := strings.CutPrefix(raw, `file\:`); ok {
+			raw = "file:" + rest
+		}
+
+		value, err := expandEnv(raw)
+		if err != nil {
+			return err
+		}
+
+		if value != node.Value {
+			node.Value = value
+			if node.Style == 0 {
+				node.Tag = "" // Resolved again from the expanded value
+			}
+		}
+	}
+
+	return nil
+}
+
+// readSecret replaces the value of a node with the content of the secret file
+// at ref, relative to dir, without its trailing newlines. The path may
+// reference environment variables.
+func (l *loader) readSecret(node *yaml.Node, ref, dir string) error {
+	path, err := expandEnv(ref)
+	if err != nil {
+		return err
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	if !slices.Contains(l.files, path) {
+		l.files = append(l.files, path)
+	}
+
+	secret, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read secret file: %w", err)
+	}
+
+	node.Value = strings.TrimRight(string(secret), "\r\n")
+	return nil
+}
+
+// expandEnv replaces the ${VAR} and ${VAR:-default} references of a string with
+// the value of the environment variables. The default is used when the variable
+// is unset or empty; "$${" escapes a literal "${".
+func expandEnv(s string) (string, error) {
+	if !strings.Contains(s, "${") {
+		return s, nil
+	}
+
+	var sb strings.Builder
+	for {
+		start := strings.Index(s, "${")
+		if start < 0 {
+			sb.WriteString(s)
+			return sb.String(), nil
+		}
+
+		if start > 0 && s[start-1] == '$' {
+			sb.WriteString(s[:start-1])
+			sb.WriteString("${")
+			s = s[start+2:]
+			continue
+		}
+
+		end := strings.IndexByte(s[start:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated variable reference in %q", s)
+		}
+		end += start
+
+		sb.WriteString(s[:start])
+
+		name, fallback, hasFallback := strings.Cut(s[start+2:end], ":-")
+		if !validEnvName(name) {
+			return "", fmt.Errorf("invalid variable name %q", name)
+		}
+
+		value, ok := os.LookupEnv(name)
+		switch {
+		case hasFallback && value == "":
+			value = fallback
+		case !ok:
+			return "", fmt.Errorf("%w: %s", ErrEnvNotSet, name)
+		}
+
+		sb.WriteString(value)
+		s = s[end+1:]
+	}
+}
+
+// validEnvName reports whether name is a valid environment variable name.
+func validEnvName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for i, r := range name {
+		switch {
+		case r == '_', r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z':
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
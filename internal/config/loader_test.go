@@ -0,0 +1,149 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ju4n97/relic/internal/config"
+)
+
+const schemaPath = "../../jsonschema/relic.v1.schema.json"
+
+// writeFiles writes the given files into a temporary directory and returns it.
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	return dir
+}
+
+func TestLoadAndValidate_IncludesAndOverlays(t *testing.T) {
+	t.Setenv("RELIC_TEST_HF_TOKEN", "hf_secret")
+	t.Setenv("RELIC_TEST_THREADS", "")
+
+	dir := writeFiles(t, map[string]string{
+		"models.yaml": `
+models:
+  qwen:
+    type: llm
+    backend: llama.cpp
+    source:
+      huggingface:
+        repo: Qwen/Qwen2.5-1.5B-Instruct-GGUF
+        token: ${RELIC_TEST_HF_TOKEN}
+    order: 10
+  whisper:
+    type: stt
+    backend: whisper.cpp
+    source:
+      huggingface:
+        repo: ggerganov/whisper.cpp
+        token: file:hf_token
+    order: 10
+`,
+		"hf_token": "hf_from_file\n",
+		"relic.yaml": `
+version: "1"
+include: [models.yaml]
+profiles:
+  cpu:
+    display_name: CPU
+    threads: ${RELIC_TEST_THREADS:-4}
+services:
+  llm:
+    models: [qwen]
+  stt:
+    models: [whisper]
+`,
+		"relic.prod.yaml": `
+storage:
+  models_dir: /var/lib/relic/models
+models:
+  whisper: null
+services:
+  stt: null
+`,
+	})
+
+	cfg, err := config.LoadAndValidate(filepath.Join(dir, "relic.yaml"), schemaPath)
+	require.NoError(t, err)
+	assert.Equal(t, "hf_secret", cfg.Models["qwen"].Source.HuggingFace.Token, "environment variables are expanded")
+	assert.Equal(t, "hf_from_file", cfg.Models["whisper"].Source.HuggingFace.Token, "secret files are read relative to the config")
+	assert.Equal(t, 4, cfg.Profiles["cpu"].Threads, "defaults apply to empty variables and keep their type")
+
+	cfg, err = config.LoadAndValidate(filepath.Join(dir, "relic.yaml"), schemaPath, filepath.Join(dir, "relic.prod.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "/var/lib/relic/models", cfg.Storage.ModelsDir)
+	assert.Contains(t, cfg.Models, "qwen", "overlays are merged on top of the config")
+	assert.NotContains(t, cfg.Models, "whisper", "null values remove keys")
+	assert.Empty(t, cfg.Services.STT.Models)
+}
+
+func TestLoadAndValidate_Errors(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.yaml":     "include: b.yaml\n",
+		"b.yaml":     "include: [a.yaml]\n",
+		"unset.yaml": "version: \"1\"\nmodels: {}\nservices:\n  llm:\n    models: [\"${RELIC_TEST_UNSET}\"]\n",
+		"bad.yaml":   "version: \"1\"\nmodels: {}\nservices: {}\nunknown: true\n",
+	})
+
+	_, err := config.LoadAndValidate(filepath.Join(dir, "a.yaml"), schemaPath)
+	require.ErrorContains(t, err, "include cycle")
+
+	_, err = config.LoadAndValidate(filepath.Join(dir, "unset.yaml"), schemaPath)
+	require.ErrorIs(t, err, config.ErrEnvNotSet)
+
+	_, err = config.LoadAndValidate(filepath.Join(dir, "bad.yaml"), schemaPath)
+	require.ErrorContains(t, err, "validation failed", "the merged result is validated against the schema")
+}
+
+func TestLoadAndValidate_SecretFiles(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"hf_token": "hf_from_file\n",
+		"relic.yaml": `
+version: "1"
+models:
+  variable:
+    type: llm
+    backend: llama.cpp
+    source:
+      huggingface:
+        repo: Qwen/Qwen2.5-1.5B-Instruct-GGUF
+        token: ${RELIC_TEST_TOKEN}
+    order: 10
+  escaped:
+    type: llm
+    backend: llama.cpp
+    source:
+      huggingface:
+        repo: Qwen/Qwen2.5-1.5B-Instruct-GGUF
+        token: file\:hf_token
+    order: 10
+  path:
+    type: llm
+    backend: llama.cpp
+    source:
+      huggingface:
+        repo: Qwen/Qwen2.5-1.5B-Instruct-GGUF
+        token: file:${RELIC_TEST_TOKEN_FILE}
+    order: 10
+services: {}
+`,
+	})
+	t.Setenv("RELIC_TEST_TOKEN", "file:hf_token")
+	t.Setenv("RELIC_TEST_TOKEN_FILE", "hf_token")
+
+	cfg, err := config.LoadAndValidate(filepath.Join(dir, "relic.yaml"), schemaPath)
+	require.NoError(t, err)
+	assert.Equal(t, "file:hf_token", cfg.Models["variable"].Source.HuggingFace.Token, "values of variables do not read files")
+	assert.Equal(t, "file:hf_token", cfg.Models["escaped"].Source.HuggingFace.Token)
+	assert.Equal(t, "hf_from_file", cfg.Models["path"].Source.HuggingFace.Token, "paths may reference variables")
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"maps"
+	"path/filepath"
+	"regexp"
+	"slices"
+)
+
+// promptCacheName matches the names of prompt caches, which name their files.
+var promptCacheName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ValidPromptCacheName reports whether name can name a prompt cache: lowercase
+// letters, digits, dashes and underscores.
+func ValidPromptCacheName(name string) bool {
+	return promptCacheName.MatchString(name)
+}
+
+// PromptCacheDir returns the directory the KV caches of the prompt caches are
+// persisted in, inside the models directory. Its name keeps it out of the reach
+// of the cache garbage collector.
+func (c *Config) PromptCacheDir() string {
+	return filepath.Join(c.ModelsDir(), ".relic-slots")
+}
+
+// promptCacheIssues reports the problems of the prompt caches: names must be
+// valid, and the model must be an llm model served by llama.cpp with the profile
+// given.
+func (c *Config) promptCacheIssues(report func(warning bool, pointer []string, format string, args ...any)) {
+	for _, name := range slices.Sorted(maps.Keys(c.PromptCaches)) {
+		cache := c.PromptCaches[name]
+		pointer := []string{"prompt_caches", name}
+
+		if !ValidPromptCacheName(name) {
+			report(false, pointer, "prompt cache %q: name must only have lowercase letters, digits, dashes and underscores", name)
+		}
+
+		modelID := c.ResolveAlias(cache.Model)
+		modelConfig, ok := c.Models[modelID]
+		if !ok {
+			report(false, append(pointer, "model"), "prompt cache %q: model %q is not defined", name, cache.Model)
+			continue
+		}
+
+		if modelConfig.Backend != "llama.cpp" {
+			report(false, append(pointer, "model"), "prompt cache %q: model %q is not served by llama.cpp", name, cache.Model)
+		}
+
+		if cache.Profile != "" && !slices.Contains(modelConfig.Profiles, cache.Profile) {
+			report(false, append(pointer, "profile"), "prompt cache %q: model %q has no profile %q", name, cache.Model, cache.Profile)
+		}
+	}
+}
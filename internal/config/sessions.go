@@ -0,0 +1,45 @@
+package config
+
+import (
+	"time"
+
+	"github.com/ju4n97/relic/internal/xfs"
+)
+
+// DefaultSessionTTL is how long sessions are kept after their last turn when
+// sessions.ttl is not set.
+const DefaultSessionTTL = 24 * time.Hour
+
+// SessionsPath returns the database chat sessions are stored in.
+// Precedence:
+// 1. sessions.file in the config.
+// 2. Default sessions path.
+func (c *Config) SessionsPath() string {
+	if c.Sessions.File != "" {
+		return xfs.ExpandTilde(c.Sessions.File)
+	}
+	return DefaultSessionsPath()
+}
+
+// SessionTTL returns how long sessions are kept after their last turn. Invalid
+// durations, reported by Issues, fall back to DefaultSessionTTL.
+func (c *Config) SessionTTL() time.Duration {
+	ttl, err := time.ParseDuration(c.Sessions.TTL)
+	if err != nil || ttl <= 0 {
+		return DefaultSessionTTL
+	}
+	return ttl
+}
+
+// sessionIssues reports the problems of the sessions settings.
+func (c *Config) sessionIssues(report func(warning bool, pointer []string, format string, args ...any)) {
+	if c.Sessions.TTL != "" {
+		if ttl, err := time.ParseDuration(c.Sessions.TTL); err != nil || ttl <= 0 {
+			report(false, []string{"sessions", "ttl"}, "sessions: ttl %q is not a positive duration, e.g. \"24h\"", c.Sessions.TTL)
+		}
+	}
+
+	if c.Sessions.MaxMessages < 0 {
+		report(false, []string{"sessions", "max_messages"}, "sessions: max_messages must not be negative")
+	}
+}
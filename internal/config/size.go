@@ -0,0 +1,64 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// sizePattern matches human readable sizes such as "512MB", "1.5 GiB" or "1024".
+var sizePattern = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([KMGTP]?I?B?)$`)
+
+// sizeUnits maps unit suffixes to their multiplier in bytes.
+var sizeUnits = map[string]float64{
+	"": 1, "B": 1,
+	"K": 1e3, "KB": 1e3, "KIB": 1 << 10, "KI": 1 << 10,
+	"M": 1e6, "MB": 1e6, "MIB": 1 << 20, "MI": 1 << 20,
+	"G": 1e9, "GB": 1e9, "GIB": 1 << 30, "GI": 1 << 30,
+	"T": 1e12, "TB": 1e12, "TIB": 1 << 40, "TI": 1 << 40,
+	"P": 1e15, "PB": 1e15, "PIB": 1 << 50, "PI": 1 << 50,
+}
+
+// ByteSize is a size in bytes that can be written in YAML as a plain integer
+// or as a human readable string like "50GB" or "512MiB".
+type ByteSize int64
+
+// ParseByteSize parses a human readable size. Decimal (KB, MB, GB) and binary
+// (KiB, MiB, GiB) units are supported; a bare number is a count of bytes.
+func ParseByteSize(s string) (ByteSize, error) {
+	match := sizePattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(s)))
+	if match == nil {
+		return 0, fmt.Errorf("invalid size: %q", s)
+	}
+
+	value, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size: %q: %w", s, err)
+	}
+
+	multiplier, ok := sizeUnits[match[2]]
+	if !ok {
+		return 0, fmt.Errorf("invalid size unit: %q", s)
+	}
+
+	return ByteSize(value * multiplier), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (b *ByteSize) UnmarshalYAML(node *yaml.Node) error {
+	size, err := ParseByteSize(node.Value)
+	if err != nil {
+		return err
+	}
+
+	*b = size
+	return nil
+}
+
+// Bytes returns the size in bytes.
+func (b ByteSize) Bytes() int64 {
+	return int64(b)
+}
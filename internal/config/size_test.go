@@ -0,0 +1,49 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/ju4n97/relic/internal/config"
+)
+
+func TestParseByteSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		want  config.ByteSize
+	}{
+		{"1024", 1024},
+		{"512B", 512},
+		{"50GB", 50_000_000_000},
+		{"1.5 GiB", 1_610_612_736},
+		{"512mib", 536_870_912},
+		{"2T", 2_000_000_000_000},
+	}
+
+	for _, tt := range tests {
+		got, err := config.ParseByteSize(tt.input)
+		require.NoError(t, err, tt.input)
+		assert.Equal(t, tt.want, got, tt.input)
+	}
+
+	for _, input := range []string{"", "GB", "-1GB", "10 XB"} {
+		_, err := config.ParseByteSize(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestStorageConfig_MaxSize(t *testing.T) {
+	t.Parallel()
+
+	var storage config.StorageConfig
+	require.NoError(t, yaml.Unmarshal([]byte("max_size: 10GiB"), &storage))
+	assert.Equal(t, int64(10<<30), storage.MaxSize.Bytes())
+
+	require.NoError(t, yaml.Unmarshal([]byte("max_size: 2048"), &storage))
+	assert.Equal(t, int64(2048), storage.MaxSize.Bytes())
+}
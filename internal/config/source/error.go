@@ -0,0 +1,9 @@
+package source
+
+import "errors"
+
+// Error definitions for the source package.
+var (
+	ErrNotCached = errors.New("model files are not cached locally")
+	ErrCorrupt   = errors.New("model files are incomplete")
+)
@@ -24,23 +24,24 @@ const (
 type HuggingFaceDownloader struct{}
 
 // Download downloads Hugging Face model to local cache and returns the actual model file path.
-func (d *HuggingFaceDownloader) Download(ctx context.Context, modelConfig *config.ModelConfig, targetDir string) (string, error) {
-	source, err := modelConfig.GetSource()
+// If the local manifest shows the files are already present, the download is skipped.
+// onProgress, if not nil, is called periodically while files are being downloaded.
+func (d *HuggingFaceDownloader) Download(ctx context.Context, modelConfig *config.ModelConfig, targetDir string, onProgress ProgressFunc) (string, error) {
+	hfSource, err := huggingFaceSource(modelConfig)
 	if err != nil {
-		return "", fmt.Errorf("manager: huggingface: failed to get model source: %w", err)
-	}
-
-	hfSource, ok := source.(config.HuggingFaceSource)
-	if !ok {
-		return "", fmt.Errorf("huggingface: invalid source type: %T", source)
+		return "", err
 	}
 
 	repo := strings.TrimSpace(hfSource.Repo)
-	if repo == "" {
-		return "", fmt.Errorf("huggingface: invalid repo name: %s", repo)
+	fullPath := filepath.Join(targetDir, repo)
+
+	if !hfSource.ForceDownload {
+		if modelPath, err := d.Resolve(ctx, modelConfig, targetDir); err == nil {
+			slog.Info("Model already cached, skipping download", "repo", repo, "path", modelPath)
+			return modelPath, nil
+		}
 	}
 
-	fullPath := filepath.Join(targetDir, repo)
 	if err := os.MkdirAll(fullPath, 0o755); err != nil {
 		return "", fmt.Errorf("manager: huggingface: failed to create directory: %w", err)
 	}
@@ -79,11 +80,32 @@ func (d *HuggingFaceDownloader) Download(ctx context.Context, modelConfig *confi
 		args = append(args, "--max-workers", strconv.Itoa(hfSource.MaxWorkers))
 	}
 
+	if onProgress != nil {
+		files, err := listRemoteFiles(ctx, &hfSource)
+		if err != nil {
+			slog.Warn("Failed to list repository files, progress will not be reported", "repo", repo, "error", err)
+		} else {
+			onProgress(sampleProgress(fullPath, files))
+
+			trackCtx, stopTracking := context.WithCancel(ctx)
+			defer stopTracking()
+			go trackProgress(trackCtx, fullPath, files, onProgress)
+			defer func() {
+				stopTracking()
+				onProgress(sampleProgress(fullPath, files))
+			}()
+		}
+	}
+
 	var lastErr error
 	for attempt := range defaultMaxRetries {
 		if attempt > 0 {
 			slog.Info("Retrying download", "repo", repo, "attempt", attempt+1, "last_error", lastErr)
-			time.Sleep(defaultRetryDelay)
+			select {
+			case <-ctx.Done():
+				return "", fmt.Errorf("huggingface: download canceled: %w", ctx.Err())
+			case <-time.After(defaultRetryDelay):
+			}
 		} else {
 			slog.Info("Downloading model", "repo", repo, "path", fullPath)
 		}
@@ -96,6 +118,9 @@ func (d *HuggingFaceDownloader) Download(ctx context.Context, modelConfig *confi
 		if err == nil {
 			slog.Info("Model downloaded successfully", "repo", repo, "path", fullPath, "attempt", attempt+1)
 			modelPath := resolveModelPath(fullPath, hfSource.Include)
+			if err := recordDownload(fullPath, modelPath, &hfSource); err != nil {
+				slog.Warn("Failed to record download in manifest", "repo", repo, "error", err)
+			}
 			return modelPath, nil
 		}
 
@@ -112,6 +137,198 @@ func (d *HuggingFaceDownloader) Download(ctx context.Context, modelConfig *confi
 	return "", lastErr
 }
 
+// Resolve returns the path of a model that is already present in targetDir without
+// contacting Hugging Face. The local manifest is checked first; directories populated
+// before the manifest existed are accepted when every include pattern matches a file.
+// Returns ErrNotCached when the files are missing.
+func (d *HuggingFaceDownloader) Resolve(_ context.Context, modelConfig *config.ModelConfig, targetDir string) (string, error) {
+	hfSource, err := huggingFaceSource(modelConfig)
+	if err != nil {
+		return "", err
+	}
+
+	fullPath := filepath.Join(targetDir, strings.TrimSpace(hfSource.Repo))
+
+	manifest, err := readManifest(fullPath)
+	if err != nil {
+		return "", err
+	}
+
+	if entry, ok := manifest.Entries[sourceFingerprint(&hfSource)]; ok && entry.verify(fullPath) {
+		return filepath.Join(fullPath, filepath.FromSlash(entry.ModelPath)), nil
+	}
+
+	// A pinned revision can only be trusted through the manifest.
+	if hfSource.Revision != "" || len(hfSource.Include) == 0 {
+		return "", fmt.Errorf("huggingface: %s: %w", hfSource.Repo, ErrNotCached)
+	}
+
+	for _, pattern := range hfSource.Include {
+		if len(matchFiles(fullPath, pattern)) == 0 {
+			return "", fmt.Errorf("huggingface: %s: no local file matches %q: %w", hfSource.Repo, pattern, ErrNotCached)
+		}
+	}
+
+	return resolveModelPath(fullPath, hfSource.Include), nil
+}
+
+// Files returns the local files of a model that is already present in targetDir.
+// The manifest is authoritative; without it, the include patterns are matched,
+// and a repo without include patterns owns its whole directory.
+func (d *HuggingFaceDownloader) Files(ctx context.Context, modelConfig *config.ModelConfig, targetDir string) ([]string, error) {
+	hfSource, err := huggingFaceSource(modelConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	fullPath := filepath.Join(targetDir, strings.TrimSpace(hfSource.Repo))
+
+	manifest, err := readManifest(fullPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry, ok := manifest.Entries[sourceFingerprint(&hfSource)]; ok && entry.verify(fullPath) {
+		files := make([]string, 0, len(entry.Files))
+		for _, file := range entry.Files {
+			files = append(files, filepath.Join(fullPath, filepath.FromSlash(file.Path)))
+		}
+		return files, nil
+	}
+
+	if _, err := d.Resolve(ctx, modelConfig, targetDir); err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, pattern := range hfSource.Include {
+		files = append(files, matchFiles(fullPath, pattern)...)
+	}
+
+	return files, nil
+}
+
+// Verify checks the files of a model present in targetDir against the sizes the
+// manifest recorded when they were downloaded. Models downloaded before the
+// manifest existed can only be checked for presence.
+func (d *HuggingFaceDownloader) Verify(ctx context.Context, modelConfig *config.ModelConfig, targetDir string) error {
+	hfSource, err := huggingFaceSource(modelConfig)
+	if err != nil {
+		return err
+	}
+
+	fullPath := filepath.Join(targetDir, strings.TrimSpace(hfSource.Repo))
+
+	manifest, err := readManifest(fullPath)
+	if err != nil {
+		return err
+	}
+
+	entry, ok := manifest.Entries[sourceFingerprint(&hfSource)]
+	if !ok {
+		_, err := d.Resolve(ctx, modelConfig, targetDir)
+		return err
+	}
+
+	var problems []string
+	missing := 0
+	for _, f := range entry.Files {
+		info, err := os.Stat(filepath.Join(fullPath, filepath.FromSlash(f.Path)))
+		switch {
+		case err != nil || info.IsDir():
+			problems = append(problems, f.Path+" is missing")
+			missing++
+		case info.Size() != f.Size:
+			problems = append(problems, fmt.Sprintf("%s has %d bytes instead of %d", f.Path, info.Size(), f.Size))
+		}
+	}
+
+	if missing == len(entry.Files) {
+		return fmt.Errorf("huggingface: %s: %w", hfSource.Repo, ErrNotCached)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("huggingface: %s: %s: %w", hfSource.Repo, strings.Join(problems, ", "), ErrCorrupt)
+	}
+
+	return nil
+}
+
+// huggingFaceSource extracts and validates the Hugging Face source of a model.
+func huggingFaceSource(modelConfig *config.ModelConfig) (config.HuggingFaceSource, error) {
+	source, err := modelConfig.GetSource()
+	if err != nil {
+		return config.HuggingFaceSource{}, fmt.Errorf("manager: huggingface: failed to get model source: %w", err)
+	}
+
+	hfSource, ok := source.(config.HuggingFaceSource)
+	if !ok {
+		return config.HuggingFaceSource{}, fmt.Errorf("huggingface: invalid source type: %T", source)
+	}
+
+	if strings.TrimSpace(hfSource.Repo) == "" {
+		return config.HuggingFaceSource{}, fmt.Errorf("huggingface: invalid repo name: %s", hfSource.Repo)
+	}
+
+	return hfSource, nil
+}
+
+// recordDownload stores the files of a finished download in the repo manifest.
+func recordDownload(repoDir, modelPath string, hfSource *config.HuggingFaceSource) error {
+	var files []string
+	if len(hfSource.Include) == 0 {
+		all, err := listFiles(repoDir)
+		if err != nil {
+			return fmt.Errorf("source: failed to list files: %w", err)
+		}
+		files = all
+	} else {
+		for _, pattern := range hfSource.Include {
+			files = append(files, matchFiles(repoDir, pattern)...)
+		}
+	}
+
+	entry, err := newManifestEntry(repoDir, modelPath, files)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := readManifest(repoDir)
+	if err != nil {
+		return err
+	}
+	manifest.Entries[sourceFingerprint(hfSource)] = entry
+
+	return writeManifest(repoDir, manifest)
+}
+
+// sourceFingerprint identifies a Hugging Face source inside a repo manifest.
+func sourceFingerprint(hfSource *config.HuggingFaceSource) string {
+	return fingerprint(
+		hfSource.Repo,
+		hfSource.RepoType,
+		hfSource.Revision,
+		strings.Join(hfSource.Include, ","),
+		strings.Join(hfSource.Exclude, ","),
+	)
+}
+
+// matchFiles returns the regular files in baseDir matching the given glob pattern.
+func matchFiles(baseDir, pattern string) []string {
+	matches, err := filepath.Glob(filepath.Join(baseDir, pattern))
+	if err != nil {
+		return nil
+	}
+
+	files := make([]string, 0, len(matches))
+	for _, match := range matches {
+		if info, err := os.Stat(match); err == nil && !info.IsDir() {
+			files = append(files, match)
+		}
+	}
+
+	return files
+}
+
 // resolveModelPath finds the actual model file based on include patterns.
 // If no include patterns or multiple files match, returns the base directory.
 // If a single specific file is matched, returns that file path.
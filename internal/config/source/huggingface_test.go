@@ -0,0 +1,120 @@
+package source_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ju4n97/relic/internal/config"
+	"github.com/ju4n97/relic/internal/config/source"
+)
+
+func newModelConfig(hf config.HuggingFaceSource) *config.ModelConfig {
+	cfg := &config.ModelConfig{Type: "llm", Backend: "llama.cpp"}
+	cfg.SetHuggingFaceSource(hf)
+	return cfg
+}
+
+func writeFile(t *testing.T, path string, size int) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, make([]byte, size), 0o644))
+}
+
+func TestHuggingFaceDownloader_Resolve(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns ErrNotCached when nothing is downloaded", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		cfg := newModelConfig(config.HuggingFaceSource{
+			Repo:    "org/repo",
+			Include: []string{"model-q4.gguf"},
+		})
+
+		_, err := (&source.HuggingFaceDownloader{}).Resolve(context.Background(), cfg, dir)
+		require.ErrorIs(t, err, source.ErrNotCached)
+	})
+
+	t.Run("resolves files matching include patterns", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, "org", "repo", "model-q4.gguf"), 16)
+
+		cfg := newModelConfig(config.HuggingFaceSource{
+			Repo:    "org/repo",
+			Include: []string{"model-q4.gguf"},
+		})
+
+		path, err := (&source.HuggingFaceDownloader{}).Resolve(context.Background(), cfg, dir)
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(dir, "org", "repo", "model-q4.gguf"), path)
+	})
+
+	t.Run("requires every include pattern to match", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, "org", "voices", "en", "voice.onnx"), 16)
+
+		cfg := newModelConfig(config.HuggingFaceSource{
+			Repo:    "org/voices",
+			Include: []string{"en/*.onnx", "en/*.onnx.json"},
+		})
+
+		_, err := (&source.HuggingFaceDownloader{}).Resolve(context.Background(), cfg, dir)
+		require.ErrorIs(t, err, source.ErrNotCached)
+	})
+
+	t.Run("pinned revisions require a manifest", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, "org", "repo", "model.gguf"), 16)
+
+		cfg := newModelConfig(config.HuggingFaceSource{
+			Repo:     "org/repo",
+			Revision: "v2",
+			Include:  []string{"model.gguf"},
+		})
+
+		_, err := (&source.HuggingFaceDownloader{}).Resolve(context.Background(), cfg, dir)
+		require.ErrorIs(t, err, source.ErrNotCached)
+	})
+}
+
+func TestHuggingFaceDownloader_Verify(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns ErrNotCached when nothing is downloaded", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := newModelConfig(config.HuggingFaceSource{
+			Repo:    "org/repo",
+			Include: []string{"model-q4.gguf"},
+		})
+
+		err := (&source.HuggingFaceDownloader{}).Verify(context.Background(), cfg, t.TempDir())
+		require.ErrorIs(t, err, source.ErrNotCached)
+	})
+
+	t.Run("checks presence without a manifest", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		writeFile(t, filepath.Join(dir, "org", "repo", "model-q4.gguf"), 16)
+
+		cfg := newModelConfig(config.HuggingFaceSource{
+			Repo:    "org/repo",
+			Include: []string{"model-q4.gguf"},
+		})
+
+		require.NoError(t, (&source.HuggingFaceDownloader{}).Verify(context.Background(), cfg, dir))
+	})
+}
@@ -0,0 +1,156 @@
+package source
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// manifestFileName is the name of the manifest file written into every downloaded repo directory.
+const manifestFileName = ".relic-manifest.json"
+
+// Manifest records which files were downloaded into a repo directory.
+// Several models may share the same repo directory (e.g. different quantizations
+// of the same GGUF repo), so entries are keyed by a fingerprint of the source.
+type Manifest struct {
+	Entries map[string]ManifestEntry `json:"entries"`
+}
+
+// ManifestEntry describes the files downloaded for a single source configuration.
+type ManifestEntry struct {
+	DownloadedAt time.Time      `json:"downloaded_at"`
+	ModelPath    string         `json:"model_path"`
+	Files        []ManifestFile `json:"files"`
+}
+
+// ManifestFile describes a single downloaded file, relative to the repo directory.
+type ManifestFile struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+// readManifest reads the manifest from the given repo directory.
+// A missing manifest is not an error, an empty manifest is returned instead.
+func readManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, manifestFileName))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &Manifest{Entries: map[string]ManifestEntry{}}, nil
+		}
+		return nil, fmt.Errorf("source: failed to read manifest: %w", err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("source: failed to decode manifest: %w", err)
+	}
+	if manifest.Entries == nil {
+		manifest.Entries = map[string]ManifestEntry{}
+	}
+
+	return &manifest, nil
+}
+
+// writeManifest atomically writes the manifest into the given repo directory.
+func writeManifest(dir string, manifest *Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("source: failed to encode manifest: %w", err)
+	}
+
+	tmp := filepath.Join(dir, manifestFileName+".tmp")
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("source: failed to write manifest: %w", err)
+	}
+
+	if err := os.Rename(tmp, filepath.Join(dir, manifestFileName)); err != nil {
+		return fmt.Errorf("source: failed to replace manifest: %w", err)
+	}
+
+	return nil
+}
+
+// verify reports whether every file recorded in the entry is still present with the same size.
+func (e ManifestEntry) verify(dir string) bool {
+	if len(e.Files) == 0 {
+		return false
+	}
+
+	for _, f := range e.Files {
+		info, err := os.Stat(filepath.Join(dir, f.Path))
+		if err != nil || info.IsDir() || info.Size() != f.Size {
+			return false
+		}
+	}
+
+	return true
+}
+
+// newManifestEntry builds a manifest entry from the files currently present in dir.
+func newManifestEntry(dir, modelPath string, files []string) (ManifestEntry, error) {
+	entry := ManifestEntry{
+		DownloadedAt: time.Now(),
+		Files:        make([]ManifestFile, 0, len(files)),
+	}
+
+	if rel, err := filepath.Rel(dir, modelPath); err == nil {
+		entry.ModelPath = filepath.ToSlash(rel)
+	}
+
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return ManifestEntry{}, fmt.Errorf("source: failed to stat %s: %w", file, err)
+		}
+
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return ManifestEntry{}, fmt.Errorf("source: failed to resolve %s: %w", file, err)
+		}
+
+		entry.Files = append(entry.Files, ManifestFile{Path: filepath.ToSlash(rel), Size: info.Size()})
+	}
+
+	return entry, nil
+}
+
+// fingerprint returns a stable key identifying a source configuration inside a manifest.
+func fingerprint(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// listFiles returns all regular files under dir, skipping hidden metadata.
+func listFiles(dir string) ([]string, error) {
+	var files []string
+
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if strings.HasPrefix(d.Name(), ".") && path != dir {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !d.IsDir() {
+			files = append(files, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
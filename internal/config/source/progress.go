@@ -0,0 +1,222 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ju4n97/relic/internal/config"
+)
+
+// FileState is the download state of a single file.
+type FileState string
+
+const (
+	// FileStatePending indicates that the file has not started downloading.
+	FileStatePending FileState = "pending"
+
+	// FileStateDownloading indicates that the file is being downloaded.
+	FileStateDownloading FileState = "downloading"
+
+	// FileStateCompleted indicates that the file is fully downloaded.
+	FileStateCompleted FileState = "completed"
+)
+
+// Progress is a snapshot of a download in progress.
+type Progress struct {
+	Files      []FileProgress `json:"files"`
+	BytesDone  int64          `json:"bytes_done"`
+	BytesTotal int64          `json:"bytes_total"`
+}
+
+// FileProgress is the progress of a single file.
+type FileProgress struct {
+	Path       string    `json:"path"`
+	State      FileState `json:"state"`
+	BytesDone  int64     `json:"bytes_done"`
+	BytesTotal int64     `json:"bytes_total"`
+}
+
+// ProgressFunc receives download progress updates.
+type ProgressFunc func(Progress)
+
+// progressInterval is how often download progress is sampled.
+const progressInterval = 500 * time.Millisecond
+
+// huggingFaceAPI is the base URL of the Hugging Face Hub API.
+var huggingFaceAPI = "https://huggingface.co/api"
+
+// remoteFile is a file entry from the Hugging Face Hub tree API.
+type remoteFile struct {
+	LFS  *struct{ Size int64 } `json:"lfs,omitempty"`
+	Type string                `json:"type"`
+	Path string                `json:"path"`
+	Size int64                 `json:"size"`
+}
+
+// listRemoteFiles lists the files of a repository that match the include/exclude patterns.
+func listRemoteFiles(ctx context.Context, hfSource *config.HuggingFaceSource) ([]FileProgress, error) {
+	repoType := hfSource.RepoType
+	if repoType == "" {
+		repoType = "model"
+	}
+
+	revision := hfSource.Revision
+	if revision == "" {
+		revision = "main"
+	}
+
+	endpoint := fmt.Sprintf("%s/%ss/%s/tree/%s?recursive=true",
+		huggingFaceAPI, repoType, hfSource.Repo, url.PathEscape(revision))
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("source: failed to create request: %w", err)
+	}
+	if hfSource.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+hfSource.Token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("source: failed to list repository files: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("source: failed to list repository files: status %d", resp.StatusCode)
+	}
+
+	var entries []remoteFile
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("source: failed to decode repository files: %w", err)
+	}
+
+	files := make([]FileProgress, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type != "file" || !selected(entry.Path, hfSource.Include, hfSource.Exclude) {
+			continue
+		}
+
+		size := entry.Size
+		if entry.LFS != nil {
+			size = entry.LFS.Size
+		}
+
+		files = append(files, FileProgress{
+			Path:       entry.Path,
+			State:      FileStatePending,
+			BytesTotal: size,
+		})
+	}
+
+	return files, nil
+}
+
+// sampleProgress measures how much of each file is present in repoDir.
+// Partially downloaded files live in the Hugging Face local-dir cache as
+// ".cache/huggingface/download/<path>.<etag>.incomplete".
+func sampleProgress(repoDir string, files []FileProgress) Progress {
+	progress := Progress{Files: make([]FileProgress, len(files))}
+
+	for i, file := range files {
+		current := file
+		current.State = FileStatePending
+		current.BytesDone = 0
+
+		if info, err := os.Stat(filepath.Join(repoDir, filepath.FromSlash(file.Path))); err == nil && !info.IsDir() &&
+			(file.BytesTotal == 0 || info.Size() >= file.BytesTotal) {
+			current.State = FileStateCompleted
+			current.BytesDone = info.Size()
+		} else {
+			pattern := filepath.Join(repoDir, ".cache", "huggingface", "download", filepath.FromSlash(file.Path)) + ".*.incomplete"
+			matches, _ := filepath.Glob(pattern)
+			for _, match := range matches {
+				if info, err := os.Stat(match); err == nil {
+					current.State = FileStateDownloading
+					current.BytesDone = max(current.BytesDone, info.Size())
+				}
+			}
+		}
+
+		progress.Files[i] = current
+		progress.BytesDone += current.BytesDone
+		progress.BytesTotal += current.BytesTotal
+	}
+
+	return progress
+}
+
+// trackProgress samples progress until ctx is done.
+func trackProgress(ctx context.Context, repoDir string, files []FileProgress, onProgress ProgressFunc) {
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			onProgress(sampleProgress(repoDir, files))
+		}
+	}
+}
+
+// selected reports whether a repository path passes the include/exclude patterns.
+func selected(path string, include, exclude []string) bool {
+	if len(include) > 0 {
+		matched := false
+		for _, pattern := range include {
+			if fnmatch(pattern, path) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	for _, pattern := range exclude {
+		if fnmatch(pattern, path) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// fnmatch matches a path against a shell pattern the way the Hugging Face CLI does,
+// where "*" also matches path separators.
+func fnmatch(pattern, path string) bool {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+
+	re, err := regexp.Compile(sb.String())
+	if err != nil {
+		return false
+	}
+
+	return re.MatchString(path)
+}
@@ -0,0 +1,58 @@
+package source
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelected(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		path    string
+		include []string
+		exclude []string
+		want    bool
+	}{
+		{name: "no patterns", path: "model.gguf", want: true},
+		{name: "exact include", path: "model-q4.gguf", include: []string{"model-q4.gguf"}, want: true},
+		{name: "include miss", path: "model-q5.gguf", include: []string{"model-q4.gguf"}, want: false},
+		{name: "star crosses directories", path: "es/es_AR/daniela/high/voice.onnx", include: []string{"es/es_AR/*"}, want: true},
+		{name: "exclude wins", path: "model.bin", include: []string{"*"}, exclude: []string{"*.bin"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, selected(tt.path, tt.include, tt.exclude))
+		})
+	}
+}
+
+func TestSampleProgress(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "done.gguf"), make([]byte, 10), 0o644))
+
+	incomplete := filepath.Join(dir, ".cache", "huggingface", "download", "partial.gguf.abc123.incomplete")
+	require.NoError(t, os.MkdirAll(filepath.Dir(incomplete), 0o755))
+	require.NoError(t, os.WriteFile(incomplete, make([]byte, 4), 0o644))
+
+	progress := sampleProgress(dir, []FileProgress{
+		{Path: "done.gguf", BytesTotal: 10},
+		{Path: "partial.gguf", BytesTotal: 20},
+		{Path: "pending.gguf", BytesTotal: 30},
+	})
+
+	assert.Equal(t, int64(14), progress.BytesDone)
+	assert.Equal(t, int64(60), progress.BytesTotal)
+	assert.Equal(t, FileStateCompleted, progress.Files[0].State)
+	assert.Equal(t, FileStateDownloading, progress.Files[1].State)
+	assert.Equal(t, FileStatePending, progress.Files[2].State)
+}
@@ -10,7 +10,22 @@ import (
 
 // Downloader downloads a model to local cache.
 type Downloader interface {
-	Download(ctx context.Context, modelConfig *config.ModelConfig, targetDir string) (string, error)
+	// Download fetches the model into targetDir and returns the model path.
+	// onProgress may be nil.
+	Download(ctx context.Context, modelConfig *config.ModelConfig, targetDir string, onProgress ProgressFunc) (string, error)
+
+	// Resolve returns the model path if it is already cached in targetDir,
+	// without any remote calls. Returns ErrNotCached otherwise.
+	Resolve(ctx context.Context, modelConfig *config.ModelConfig, targetDir string) (string, error)
+
+	// Files returns the local files that belong to the model in targetDir,
+	// without any remote calls. Returns ErrNotCached if the model is missing.
+	Files(ctx context.Context, modelConfig *config.ModelConfig, targetDir string) ([]string, error)
+
+	// Verify checks that the local files of the model in targetDir are complete,
+	// without any remote calls. Returns ErrNotCached if the model is missing and
+	// ErrCorrupt if files were deleted or truncated since the download.
+	Verify(ctx context.Context, modelConfig *config.ModelConfig, targetDir string) error
 }
 
 // registry maps source types to their downloader.
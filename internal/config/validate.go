@@ -0,0 +1,138 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// backendTypes maps each known backend to the model type it serves.
+var backendTypes = map[string]string{
+	"llama.cpp":   "llm",
+	"whisper.cpp": "stt",
+	"piper":       "tts",
+}
+
+// BackendType returns the model type served by a backend, or "" if the backend is unknown.
+func BackendType(backend string) string {
+	return backendTypes[backend]
+}
+
+// Validate checks the consistency rules the schema cannot express: models must be
+// compatible with their backend and with the services they are assigned to,
+// defaults must be assigned to their service, aliases must be unique, and the
+// profiles models refer to must be defined. Warnings are not reported.
+func (c *Config) Validate() error {
+	var errs []error
+	for _, issue := range c.Issues() {
+		if !issue.Warning {
+			errs = append(errs, errors.New(issue.Message))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Issues returns every consistency problem of the config, see Validate, along
+// with warnings about suspicious but valid settings such as models of the same
+// type sharing a display order.
+func (c *Config) Issues() []Issue {
+	var issues []Issue
+	report := func(warning bool, pointer []string, format string, args ...any) {
+		issues = append(issues, Issue{
+			Pointer: pointerOf(pointer...),
+			Message: fmt.Sprintf(format, args...),
+			Warning: warning,
+		})
+	}
+
+	names := map[string]string{} // Model IDs and aliases, to the model they name
+	for _, modelID := range slices.Sorted(maps.Keys(c.Models)) {
+		names[modelID] = modelID
+	}
+
+	orders := map[string]string{} // Types and orders, to the first model using them
+	for _, modelID := range slices.Sorted(maps.Keys(c.Models)) {
+		modelConfig := c.Models[modelID]
+
+		served, ok := backendTypes[modelConfig.Backend]
+		switch {
+		case !ok:
+			report(false, []string{"models", modelID, "backend"}, "model %q: unknown backend %q, expected one of %s",
+				modelID, modelConfig.Backend, strings.Join(slices.Sorted(maps.Keys(backendTypes)), ", "))
+		case served != modelConfig.Type:
+			report(false, []string{"models", modelID, "backend"}, "model %q: backend %q serves %s models, not %s",
+				modelID, modelConfig.Backend, served, modelConfig.Type)
+		}
+
+		for i, alias := range modelConfig.Aliases {
+			if other, ok := names[alias]; ok {
+				report(false, []string{"models", modelID, "aliases", strconv.Itoa(i)}, "model %q: alias %q is already used by model %q", modelID, alias, other)
+				continue
+			}
+			names[alias] = modelID
+		}
+
+		for i, profile := range modelConfig.Profiles {
+			if _, ok := c.Profiles[profile]; !ok {
+				report(false, []string{"models", modelID, "profiles", strconv.Itoa(i)}, "model %q: profile %q is not defined", modelID, profile)
+			}
+		}
+
+		order := fmt.Sprintf("%s/%d", modelConfig.Type, modelConfig.Order)
+		if other, ok := orders[order]; ok {
+			report(true, []string{"models", modelID, "order"}, "model %q: order %d is already used by %s model %q",
+				modelID, modelConfig.Order, modelConfig.Type, other)
+		} else {
+			orders[order] = modelID
+		}
+	}
+
+	assignments := c.ServiceAssignments()
+	for _, service := range slices.Sorted(maps.Keys(assignments)) {
+		assignment := assignments[service]
+		for i, modelID := range assignment.Models {
+			pointer := []string{"services", service, "models", strconv.Itoa(i)}
+
+			modelConfig, ok := c.Models[modelID]
+			if !ok {
+				report(false, pointer, "services.%s: model %q is not defined", service, modelID)
+				continue
+			}
+
+			if modelConfig.Type != service {
+				report(false, pointer, "services.%s: model %q is a %s model", service, modelID, modelConfig.Type)
+			}
+		}
+
+		if assignment.Default != "" && !slices.Contains(assignment.Models, names[assignment.Default]) {
+			report(false, []string{"services", service, "default"}, "services.%s: default model %q is not one of the service models", service, assignment.Default)
+		}
+	}
+
+	c.keyIssues(report)
+	c.limitIssues(report)
+	c.sessionIssues(report)
+	c.promptCacheIssues(report)
+
+	return issues
+}
+
+// ResolveAlias returns the ID of the model named by an ID or an alias.
+// Unknown names are returned unchanged.
+func (c *Config) ResolveAlias(name string) string {
+	if _, ok := c.Models[name]; ok {
+		return name
+	}
+
+	for modelID, modelConfig := range c.Models {
+		if slices.Contains(modelConfig.Aliases, name) {
+			return modelID
+		}
+	}
+
+	return name
+}
@@ -0,0 +1,126 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ju4n97/relic/internal/config"
+)
+
+func newValidConfig() *config.Config {
+	return &config.Config{
+		Models: map[string]config.ModelConfig{
+			"qwen":    {Type: "llm", Backend: "llama.cpp", Aliases: []string{"chat"}},
+			"whisper": {Type: "stt", Backend: "whisper.cpp"},
+		},
+		Services: config.ServicesConfig{
+			LLM: config.ServicesConfigAssignment{Models: []string{"qwen"}, Default: "chat"},
+			STT: config.ServicesConfigAssignment{Models: []string{"whisper"}},
+		},
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, newValidConfig().Validate())
+
+	tests := []struct {
+		mutate func(*config.Config)
+		name   string
+		want   string
+	}{
+		{
+			name: "incompatible backend",
+			mutate: func(cfg *config.Config) {
+				cfg.Models["voice"] = config.ModelConfig{Type: "tts", Backend: "llama.cpp"}
+			},
+			want: `model "voice": backend "llama.cpp" serves llm models, not tts`,
+		},
+		{
+			name: "model assigned to the wrong service",
+			mutate: func(cfg *config.Config) {
+				cfg.Services.TTS.Models = []string{"whisper"}
+			},
+			want: `services.tts: model "whisper" is a stt model`,
+		},
+		{
+			name: "undefined model",
+			mutate: func(cfg *config.Config) {
+				cfg.Services.LLM.Models = append(cfg.Services.LLM.Models, "missing")
+			},
+			want: `services.llm: model "missing" is not defined`,
+		},
+		{
+			name: "alias clashes with a model ID",
+			mutate: func(cfg *config.Config) {
+				cfg.Models["whisper"] = config.ModelConfig{Type: "stt", Backend: "whisper.cpp", Aliases: []string{"qwen"}}
+			},
+			want: `alias "qwen" is already used by model "qwen"`,
+		},
+		{
+			name: "default not assigned to the service",
+			mutate: func(cfg *config.Config) {
+				cfg.Services.STT.Default = "chat"
+			},
+			want: `services.stt: default model "chat" is not one of the service models`,
+		},
+		{
+			name: "unknown backend",
+			mutate: func(cfg *config.Config) {
+				cfg.Models["voice"] = config.ModelConfig{Type: "tts", Backend: "coqui"}
+			},
+			want: `model "voice": unknown backend "coqui"`,
+		},
+		{
+			name: "undefined profile",
+			mutate: func(cfg *config.Config) {
+				cfg.Models["whisper"] = config.ModelConfig{Type: "stt", Backend: "whisper.cpp", Profiles: []string{"cpu-fast"}}
+			},
+			want: `model "whisper": profile "cpu-fast" is not defined`,
+		},
+		{
+			name: "malformed API key hash",
+			mutate: func(cfg *config.Config) {
+				cfg.Auth.Keys = []config.APIKeyConfig{{Name: "ci", Hash: "md5:abc", Scopes: []string{config.ScopeInference}}}
+			},
+			want: `auth: API key "ci": hash must start with "sha256:"`,
+		},
+		{
+			name: "API key with an unknown scope",
+			mutate: func(cfg *config.Config) {
+				cfg.Auth.Keys = []config.APIKeyConfig{{Name: "ci", Hash: config.HashKey("secret"), Scopes: []string{"root"}}}
+			},
+			want: `auth: API key "ci": unknown scope "root"`,
+		},
+		{
+			name: "malformed session TTL",
+			mutate: func(cfg *config.Config) {
+				cfg.Sessions.TTL = "1 day"
+			},
+			want: `sessions: ttl "1 day" is not a positive duration`,
+		},
+		{
+			name: "prompt cache of a speech model",
+			mutate: func(cfg *config.Config) {
+				cfg.PromptCaches = map[string]config.PromptCacheConfig{"assistant": {Model: "whisper", SystemPrompt: "Be brief."}}
+			},
+			want: `prompt cache "assistant": model "whisper" is not served by llama.cpp`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := newValidConfig()
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"log/slog"
+	"path/filepath"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -14,25 +16,46 @@ import (
 type Watcher struct {
 	onReload   func(*Config, error)
 	current    *Config
+	fsWatcher  *fsnotify.Watcher
 	path       string
 	schemaPath string
+	overlays   []string
+	files      []string        // Files the config was read from, reloaded when any changes
+	dirs       map[string]bool // Directories watched for changes of the files
 	mu         sync.RWMutex
 	reloads    atomic.Uint32
 }
 
-// NewWatcher creates a new config watcher.
-func NewWatcher(path, schemaPath string, onReload func(*Config, error)) (*Watcher, error) {
+// WatcherOption configures a Watcher.
+type WatcherOption func(*Watcher)
+
+// WithOverlays merges the given config files on top of the watched config, in order.
+func WithOverlays(paths ...string) WatcherOption {
+	return func(w *Watcher) {
+		w.overlays = append(w.overlays, paths...)
+	}
+}
+
+// NewWatcher creates a new config watcher. The config file, the files it
+// includes and the overlays are all watched.
+func NewWatcher(path, schemaPath string, onReload func(*Config, error), opts ...WatcherOption) (*Watcher, error) {
 	watcher := &Watcher{
 		path:       path,
 		schemaPath: schemaPath,
 		onReload:   onReload,
+		dirs:       map[string]bool{},
+	}
+
+	for _, opt := range opts {
+		opt(watcher)
 	}
 
-	cfg, err := LoadAndValidate(path, schemaPath)
+	cfg, files, err := load(path, schemaPath, watcher.overlays)
 	if err != nil {
 		return nil, fmt.Errorf("manager: watcher: failed to load initial config: %w", err)
 	}
 	watcher.current = cfg
+	watcher.files = files
 
 	go watcher.watch()
 
@@ -52,10 +75,10 @@ func (cw *Watcher) watch() {
 		}
 	}()
 
-	if err := watcher.Add(cw.path); err != nil {
-		slog.Error("Failed to watch config file", "path", cw.path, "error", err)
-		return
-	}
+	cw.mu.Lock()
+	cw.fsWatcher = watcher
+	cw.trackLocked()
+	cw.mu.Unlock()
 
 	var timer *time.Timer
 	const debounce = 500 * time.Millisecond
@@ -67,16 +90,25 @@ func (cw *Watcher) watch() {
 				return
 			}
 
-			if event.Op&fsnotify.Write == fsnotify.Write {
-				if timer != nil {
-					timer.Stop()
-				}
+			// Editors often replace files instead of writing them, so every
+			// change of a config file triggers a reload.
+			if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) &&
+				!event.Has(fsnotify.Rename) && !event.Has(fsnotify.Remove) {
+				continue
+			}
 
-				timer = time.AfterFunc(debounce, func() {
-					cw.reload()
-				})
+			if !cw.tracks(event.Name) {
+				continue
 			}
 
+			if timer != nil {
+				timer.Stop()
+			}
+
+			timer = time.AfterFunc(debounce, func() {
+				cw.reload()
+			})
+
 		case err, ok := <-watcher.Errors:
 			if !ok {
 				return
@@ -92,21 +124,71 @@ func (cw *Watcher) reload() {
 	count := cw.reloads.Add(1)
 	slog.Info("Reloading config file", "path", cw.path, "count", count)
 
-	cfg, err := LoadAndValidate(cw.path, cw.schemaPath)
+	cfg, files, err := load(cw.path, cw.schemaPath, cw.overlays)
+
+	cw.mu.Lock()
+	if len(files) > 0 {
+		cw.files = files
+		cw.trackLocked()
+	}
+	if err == nil {
+		cw.current = cfg
+	}
+	cw.mu.Unlock()
+
 	if err != nil {
 		slog.Error("Failed to reload config", "error", err)
 		cw.onReload(nil, err)
 		return
 	}
 
-	cw.mu.Lock()
-	cw.current = cfg
-	cw.mu.Unlock()
-
 	slog.Info("Config reloaded successfully", "count", count)
 	cw.onReload(cfg, nil)
 }
 
+// trackLocked watches the directories of the config files that are not watched yet.
+// Directories are watched rather than files so that replaced files keep being watched.
+// Callers must hold the lock.
+func (cw *Watcher) trackLocked() {
+	if cw.fsWatcher == nil {
+		return
+	}
+
+	for _, file := range cw.files {
+		dir := filepath.Dir(file)
+		if cw.dirs[dir] {
+			continue
+		}
+
+		if err := cw.fsWatcher.Add(dir); err != nil {
+			slog.Error("Failed to watch config directory", "path", dir, "error", err)
+			continue
+		}
+		cw.dirs[dir] = true
+	}
+}
+
+// tracks reports whether a file is one the config was read from.
+func (cw *Watcher) tracks(name string) bool {
+	name, err := filepath.Abs(name)
+	if err != nil {
+		return false
+	}
+
+	cw.mu.RLock()
+	defer cw.mu.RUnlock()
+
+	return slices.Contains(cw.files, name)
+}
+
+// Files returns the files the current config was read from.
+func (cw *Watcher) Files() []string {
+	cw.mu.RLock()
+	defer cw.mu.RUnlock()
+
+	return slices.Clone(cw.files)
+}
+
 // Snapshot returns the current config snapshot (thread-safe).
 func (cw *Watcher) Snapshot() *Config {
 	cw.mu.RLock()
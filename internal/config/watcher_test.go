@@ -0,0 +1,35 @@
+package config_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ju4n97/relic/internal/config"
+)
+
+func TestWatcher_TracksSecretFiles(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"hf_token": "hf_secret\n",
+		"relic.yaml": `
+version: "1"
+models:
+  qwen:
+    type: llm
+    backend: llama.cpp
+    source:
+      huggingface:
+        repo: Qwen/Qwen2.5-1.5B-Instruct-GGUF
+        token: file:hf_token
+    order: 10
+services: {}
+`,
+	})
+
+	watcher, err := config.NewWatcher(filepath.Join(dir, "relic.yaml"), schemaPath, func(*config.Config, error) {})
+	require.NoError(t, err)
+
+	assert.Contains(t, watcher.Files(), filepath.Join(dir, "hf_token"), "changes to secret files reload the config")
+}
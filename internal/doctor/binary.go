@@ -0,0 +1,178 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// groupBackends is the group of the backend checks.
+const groupBackends = "Backends"
+
+// checkBinary checks that the binary of a backend exists, is executable, finds
+// its shared libraries and runs.
+func (d *Doctor) checkBinary(ctx context.Context, report *Report, b binary) {
+	check := Check{Group: groupBackends, Name: b.backend + " binary"}
+
+	path, err := d.lookPath(b.path)
+	switch {
+	case b.path == "":
+		check.Status = StatusFailed
+		check.Detail = "no binary configured"
+		check.Fix = fmt.Sprintf("Pass the path of the %s binary with --%s.", b.backend, b.flag)
+	case errors.Is(err, fs.ErrPermission):
+		check.Status = StatusFailed
+		check.Detail = b.path + " is not executable"
+		check.Fix = "Make it executable: chmod +x " + b.path
+	case err != nil:
+		check.Status = StatusFailed
+		check.Detail = b.path + " not found"
+		check.Fix = fmt.Sprintf("Install %s and pass its path with --%s.", b.backend, b.flag)
+		if strings.Contains(b.path, "cuda") {
+			check.Fix += " The default is a CUDA build, pass a CPU build on machines without an NVIDIA GPU."
+		}
+	default:
+		check.Status = StatusOK
+		check.Detail = path
+	}
+	report.Checks = append(report.Checks, check)
+	if check.Status != StatusOK {
+		return
+	}
+
+	if strings.Contains(path, "cuda") {
+		d.checkCUDA(report, b)
+	}
+
+	if !d.checkLibraries(ctx, report, b, path) {
+		return
+	}
+	d.checkVersion(ctx, report, b, path)
+}
+
+// checkCUDA checks that the NVIDIA driver a CUDA build needs is installed.
+func (d *Doctor) checkCUDA(report *Report, b binary) {
+	check := Check{Group: groupBackends, Name: b.backend + " GPU", Status: StatusOK, Detail: "NVIDIA driver found"}
+	if _, err := d.lookPath("nvidia-smi"); err != nil {
+		check.Status = StatusWarning
+		check.Detail = "CUDA build, but no NVIDIA driver found (nvidia-smi is missing)"
+		check.Fix = fmt.Sprintf("Install the NVIDIA driver, or pass a CPU build with --%s.", b.flag)
+	}
+
+	report.Checks = append(report.Checks, check)
+}
+
+// checkLibraries checks that the shared libraries of a binary resolve, which
+// only ldd on Linux tells. It reports whether the binary can be loaded.
+func (d *Doctor) checkLibraries(ctx context.Context, report *Report, b binary, path string) bool {
+	check := Check{Group: groupBackends, Name: b.backend + " libraries"}
+	if d.goos != "linux" {
+		check.Status = StatusSkipped
+		check.Detail = "only checked on Linux"
+		report.Checks = append(report.Checks, check)
+		return true
+	}
+	if _, err := d.lookPath("ldd"); err != nil {
+		check.Status = StatusSkipped
+		check.Detail = "ldd not found"
+		report.Checks = append(report.Checks, check)
+		return true
+	}
+
+	output, err := d.run(ctx, "ldd", path)
+	missing := missingLibraries(string(output))
+	switch {
+	case len(missing) > 0:
+		dir := filepath.Dir(path)
+		check.Status = StatusFailed
+		check.Detail = "not found: " + strings.Join(missing, ", ")
+		check.Fix = fmt.Sprintf("Install the missing libraries. If they ship with %s, add their directory to the library path: export LD_LIBRARY_PATH=%s:$LD_LIBRARY_PATH", b.backend, dir)
+	case err != nil && strings.Contains(string(output), "not a dynamic executable"):
+		check.Status = StatusOK
+		check.Detail = "statically linked"
+	case err != nil:
+		check.Status = StatusSkipped
+		check.Detail = "ldd failed: " + firstLine(string(output), err)
+	default:
+		check.Status = StatusOK
+		check.Detail = "all resolved"
+	}
+	report.Checks = append(report.Checks, check)
+
+	return check.Status != StatusFailed
+}
+
+// checkVersion runs a binary with --version, which shows that it can run on
+// this machine.
+func (d *Doctor) checkVersion(ctx context.Context, report *Report, b binary, path string) {
+	check := Check{Group: groupBackends, Name: b.backend + " version", Status: StatusOK}
+
+	output, err := d.run(ctx, path, "--version")
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		check.Detail = firstLine(string(output), nil)
+	case errors.As(err, &exitErr) && strings.Contains(string(output), "error while loading shared libraries"):
+		check.Status = StatusFailed
+		check.Detail = firstLine(string(output), err)
+		check.Fix = fmt.Sprintf("Add the directory of the missing library to the library path: export LD_LIBRARY_PATH=%s:$LD_LIBRARY_PATH", filepath.Dir(path))
+	case errors.As(err, &exitErr):
+		// It ran, it just does not know the flag.
+		check.Detail = "runs, but does not report a version"
+	default:
+		check.Status = StatusFailed
+		check.Detail = "cannot run: " + err.Error()
+		check.Fix = fmt.Sprintf("Install a %s build for %s and pass it with --%s.", b.backend, d.goos, b.flag)
+	}
+
+	report.Checks = append(report.Checks, check)
+}
+
+// checkDownloader checks that the Hugging Face CLI models are pulled with is
+// installed.
+func (d *Doctor) checkDownloader(report *Report) {
+	check := Check{Group: groupBackends, Name: "Hugging Face CLI", Status: StatusOK}
+
+	path, err := d.lookPath("hf")
+	if err != nil {
+		check.Status = StatusFailed
+		check.Detail = "hf not found, models cannot be pulled"
+		check.Fix = `Install it: pip install -U "huggingface_hub[cli]"`
+	} else {
+		check.Detail = path
+	}
+
+	report.Checks = append(report.Checks, check)
+}
+
+// missingLibraries returns the libraries ldd output reports as not found, e.g.
+// "libpiper_phonemize.so.1 => not found".
+func missingLibraries(output string) []string {
+	var missing []string
+	for line := range strings.Lines(output) {
+		if name, _, ok := strings.Cut(strings.TrimSpace(line), " => not found"); ok {
+			missing = append(missing, name)
+		}
+	}
+
+	return missing
+}
+
+// firstLine returns the first non-empty line of the output of a command, or
+// the error it failed with.
+func firstLine(output string, err error) string {
+	for line := range strings.Lines(output) {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	if err != nil {
+		return err.Error()
+	}
+
+	return ""
+}
@@ -0,0 +1,10 @@
+//go:build !linux && !darwin
+
+package doctor
+
+import "errors"
+
+// freeBytes is not supported on this platform.
+func freeBytes(string) (int64, error) {
+	return 0, errors.New("free space is not checked on this platform")
+}
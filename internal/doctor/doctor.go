@@ -0,0 +1,163 @@
+// Package doctor diagnoses the environment relic runs in: the backend binaries,
+// the ports it listens on, the models directory, the config and the cached
+// models. Every problem found comes with a suggested fix.
+package doctor
+
+import (
+	"context"
+	"os/exec"
+	"runtime"
+	"time"
+)
+
+// commandTimeout bounds the commands run to inspect binaries, e.g. "--version".
+const commandTimeout = 10 * time.Second
+
+// Status is the outcome of a check.
+type Status string
+
+const (
+	// StatusOK means nothing needs to be done.
+	StatusOK Status = "ok"
+
+	// StatusWarning means relic works, but something may go wrong later, e.g.
+	// a model that is not cached yet.
+	StatusWarning Status = "warning"
+
+	// StatusFailed means requests, or the server itself, will fail.
+	StatusFailed Status = "failed"
+
+	// StatusSkipped means the check does not apply, e.g. on this platform.
+	StatusSkipped Status = "skipped"
+)
+
+// Check is the outcome of a single diagnostic.
+type Check struct {
+	Group  string `json:"group"` // e.g. "Backends" or "Models"
+	Name   string `json:"name"`
+	Status Status `json:"status"`
+	Detail string `json:"detail,omitempty"`
+	Fix    string `json:"fix,omitempty"` // What to do about a warning or failure
+}
+
+// Report holds the checks of a diagnosis, grouped in the order they ran.
+type Report struct {
+	Checks []Check `json:"checks"`
+}
+
+// Failed reports whether any check failed.
+func (r *Report) Failed() bool {
+	for _, c := range r.Checks {
+		if c.Status == StatusFailed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Count returns the number of checks with a status.
+func (r *Report) Count(status Status) int {
+	n := 0
+	for _, c := range r.Checks {
+		if c.Status == status {
+			n++
+		}
+	}
+
+	return n
+}
+
+// binary is a backend binary to check.
+type binary struct {
+	backend string
+	path    string
+	flag    string // Command-line flag selecting the binary, for the fixes
+}
+
+// port is a port relic listens on.
+type port struct {
+	name   string
+	number int
+	flag   string // Command-line flag selecting the port, empty when it is fixed
+}
+
+// Option configures a Doctor.
+type Option func(*Doctor)
+
+// WithBinary adds the binary of a backend to check. flag is the command-line
+// flag selecting it, suggested in the fixes.
+func WithBinary(backend, path, flag string) Option {
+	return func(d *Doctor) {
+		d.binaries = append(d.binaries, binary{backend: backend, path: path, flag: flag})
+	}
+}
+
+// WithPort adds a port relic listens on, which must be free. flag is the
+// command-line flag selecting it, or empty when it cannot be changed.
+func WithPort(name string, number int, flag string) Option {
+	return func(d *Doctor) {
+		d.ports = append(d.ports, port{name: name, number: number, flag: flag})
+	}
+}
+
+// WithConfig sets the config to check, and its schema and overlays.
+func WithConfig(path, schemaPath string, overlays ...string) Option {
+	return func(d *Doctor) {
+		d.configPath = path
+		d.schemaPath = schemaPath
+		d.overlays = overlays
+	}
+}
+
+// Doctor runs the diagnostics.
+type Doctor struct {
+	lookPath   func(file string) (string, error)
+	run        func(ctx context.Context, name string, args ...string) ([]byte, error)
+	configPath string
+	schemaPath string
+	goos       string
+	overlays   []string
+	binaries   []binary
+	ports      []port
+}
+
+// New creates a new Doctor.
+func New(opts ...Option) *Doctor {
+	d := &Doctor{
+		lookPath: exec.LookPath,
+		run:      runCommand,
+		goos:     runtime.GOOS,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+// Run runs every check and returns the report.
+func (d *Doctor) Run(ctx context.Context) *Report {
+	report := &Report{}
+
+	cfg := d.checkConfig(report)
+	for _, b := range d.binaries {
+		d.checkBinary(ctx, report, b)
+	}
+	d.checkDownloader(report)
+	for _, p := range d.ports {
+		d.checkPort(report, p)
+	}
+	d.checkModelsDir(report, cfg)
+	d.checkModels(ctx, report, cfg)
+
+	return report
+}
+
+// runCommand runs a command and returns its combined output.
+func runCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+	defer cancel()
+
+	return exec.CommandContext(ctx, name, args...).CombinedOutput()
+}
@@ -0,0 +1,154 @@
+package doctor_test
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ju4n97/relic/internal/doctor"
+)
+
+// writeConfig writes a config with a cached and an uncached model and returns
+// its path.
+func writeConfig(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	modelsDir := filepath.Join(dir, "models")
+	writeFile(t, filepath.Join(modelsDir, "org", "cached", "model.gguf"), "GGUF")
+
+	path := filepath.Join(dir, "relic.yaml")
+	writeFile(t, path, `
+version: "1"
+storage:
+  models_dir: `+modelsDir+`
+models:
+  cached:
+    type: llm
+    backend: llama.cpp
+    source:
+      huggingface:
+        repo: org/cached
+        include: [model.gguf]
+    order: 1
+  missing:
+    type: llm
+    backend: llama.cpp
+    source:
+      huggingface:
+        repo: org/missing
+        include: [model.gguf]
+    order: 2
+services:
+  llm:
+    models: [cached, missing]
+`)
+
+	return path
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o755))
+}
+
+// find returns the check with a name.
+func find(t *testing.T, report *doctor.Report, name string) doctor.Check {
+	t.Helper()
+
+	for _, check := range report.Checks {
+		if check.Name == name {
+			return check
+		}
+	}
+	require.Failf(t, "check not found", "%s in %+v", name, report.Checks)
+
+	return doctor.Check{}
+}
+
+func TestDoctor_Binaries(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "llama-server"), "#!/bin/sh\necho 'version: 4242 (abc123)'\n")
+	writeFile(t, filepath.Join(dir, "piper"), "#!/bin/sh\n")
+	require.NoError(t, os.Chmod(filepath.Join(dir, "piper"), 0o644))
+
+	report := doctor.New(
+		doctor.WithConfig(writeConfig(t), ""),
+		doctor.WithBinary("llama.cpp", filepath.Join(dir, "llama-server"), "llama-bin"),
+		doctor.WithBinary("whisper.cpp", filepath.Join(dir, "whisper-server-cuda"), "whisper-bin"),
+		doctor.WithBinary("piper", filepath.Join(dir, "piper"), "piper-bin"),
+	).Run(t.Context())
+
+	assert.Equal(t, doctor.StatusOK, find(t, report, "llama.cpp binary").Status)
+	version := find(t, report, "llama.cpp version")
+	assert.Equal(t, doctor.StatusOK, version.Status)
+	assert.Equal(t, "version: 4242 (abc123)", version.Detail)
+
+	whisper := find(t, report, "whisper.cpp binary")
+	assert.Equal(t, doctor.StatusFailed, whisper.Status)
+	assert.Contains(t, whisper.Fix, "--whisper-bin")
+	assert.Contains(t, whisper.Fix, "CUDA build")
+
+	if os.Geteuid() != 0 { // Root may execute anything
+		piper := find(t, report, "piper binary")
+		assert.Equal(t, doctor.StatusFailed, piper.Status)
+		assert.Contains(t, piper.Fix, "chmod +x")
+	}
+
+	assert.True(t, report.Failed())
+}
+
+func TestDoctor_Ports(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	defer listener.Close()
+	taken := listener.Addr().(*net.TCPAddr).Port
+
+	report := doctor.New(
+		doctor.WithConfig(writeConfig(t), ""),
+		doctor.WithPort("HTTP", taken, "http-port"),
+	).Run(t.Context())
+
+	check := find(t, report, fmt.Sprintf("HTTP port %d", taken))
+	assert.Equal(t, "Ports", check.Group)
+	assert.Equal(t, doctor.StatusFailed, check.Status)
+	assert.Contains(t, check.Fix, "--http-port")
+}
+
+func TestDoctor_ConfigAndModels(t *testing.T) {
+	t.Parallel()
+
+	report := doctor.New(doctor.WithConfig(writeConfig(t), "")).Run(t.Context())
+
+	assert.Equal(t, doctor.StatusOK, find(t, report, "Config").Status)
+
+	modelsDir := find(t, report, "Models directory")
+	assert.Equal(t, doctor.StatusOK, modelsDir.Status)
+
+	assert.Equal(t, doctor.StatusOK, find(t, report, "cached").Status)
+	missing := find(t, report, "missing")
+	assert.Equal(t, doctor.StatusWarning, missing.Status)
+	assert.Equal(t, "Download it: relic pull missing", missing.Fix)
+}
+
+func TestDoctor_InvalidConfig(t *testing.T) {
+	t.Parallel()
+
+	report := doctor.New(doctor.WithConfig(filepath.Join(t.TempDir(), "missing.yaml"), "")).Run(t.Context())
+
+	config := find(t, report, "Config")
+	assert.Equal(t, doctor.StatusFailed, config.Status)
+	assert.Contains(t, config.Fix, "--config")
+	assert.Equal(t, doctor.StatusSkipped, find(t, report, "Models").Status)
+	assert.True(t, report.Failed())
+}
@@ -0,0 +1,109 @@
+package doctor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ju4n97/relic/internal/config"
+	"github.com/ju4n97/relic/internal/config/source"
+	"github.com/ju4n97/relic/internal/model"
+)
+
+// Groups of the config and model checks.
+const (
+	groupConfig = "Config"
+	groupModels = "Models"
+)
+
+// checkConfig checks that the config is valid and returns it. Without a valid
+// config, an empty one is returned, so that the other checks use the defaults.
+func (d *Doctor) checkConfig(report *Report) *config.Config {
+	check := Check{Group: groupConfig, Name: "Config", Status: StatusOK, Detail: d.configPath}
+	fix := "Run relic config validate for the position of each problem."
+
+	issues, err := config.Check(d.configPath, d.schemaPath, d.overlays...)
+	if err != nil {
+		check.Status = StatusFailed
+		check.Detail = err.Error()
+		check.Fix = "Create the config, or pass its path with --config."
+		report.Checks = append(report.Checks, check)
+		return &config.Config{}
+	}
+
+	var errs, warnings []string
+	for _, issue := range issues {
+		if issue.Warning {
+			warnings = append(warnings, issue.Message)
+		} else {
+			errs = append(errs, issue.Message)
+		}
+	}
+
+	switch {
+	case len(errs) > 0:
+		check.Status = StatusFailed
+		check.Detail = fmt.Sprintf("%d errors, first: %s", len(errs), errs[0])
+		check.Fix = fix
+	case len(warnings) > 0:
+		check.Status = StatusWarning
+		check.Detail = fmt.Sprintf("%d warnings, first: %s", len(warnings), warnings[0])
+		check.Fix = fix
+	}
+	report.Checks = append(report.Checks, check)
+	if check.Status == StatusFailed {
+		return &config.Config{}
+	}
+
+	cfg, err := config.LoadAndValidate(d.configPath, d.schemaPath, d.overlays...)
+	if err != nil {
+		// Check and LoadAndValidate agree, unless the file changed in between.
+		return &config.Config{}
+	}
+
+	return cfg
+}
+
+// checkModels checks that the files of each cached model of the config are
+// complete.
+func (d *Doctor) checkModels(ctx context.Context, report *Report, cfg *config.Config) {
+	if len(cfg.Models) == 0 {
+		report.Checks = append(report.Checks, Check{
+			Group:  groupModels,
+			Name:   "Models",
+			Status: StatusSkipped,
+			Detail: "no models configured",
+		})
+		return
+	}
+
+	modelIDs := make([]string, 0, len(cfg.Models))
+	for modelID := range cfg.Models {
+		modelIDs = append(modelIDs, modelID)
+	}
+	slices.Sort(modelIDs)
+
+	for _, modelID := range modelIDs {
+		check := Check{Group: groupModels, Name: modelID, Status: StatusOK, Detail: "cached"}
+
+		err := model.Verify(ctx, cfg, modelID)
+		switch {
+		case err == nil:
+		case errors.Is(err, source.ErrNotCached):
+			check.Status = StatusWarning
+			check.Detail = "not cached"
+			check.Fix = "Download it: relic pull " + modelID
+		case errors.Is(err, source.ErrCorrupt):
+			check.Status = StatusFailed
+			check.Detail = strings.TrimSuffix(err.Error(), ": "+source.ErrCorrupt.Error())
+			check.Fix = fmt.Sprintf("Download it again: relic rm %s && relic pull %s", modelID, modelID)
+		default:
+			check.Status = StatusFailed
+			check.Detail = err.Error()
+		}
+
+		report.Checks = append(report.Checks, check)
+	}
+}
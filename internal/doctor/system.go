@@ -0,0 +1,148 @@
+package doctor
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/ju4n97/relic/internal/cache"
+	"github.com/ju4n97/relic/internal/config"
+)
+
+// Groups of the system checks.
+const (
+	groupPorts   = "Ports"
+	groupStorage = "Storage"
+)
+
+// Free space thresholds of the models directory.
+const (
+	minFreeBytes  = 1 << 30  // Below, downloads will fail
+	lowFreeBytes  = 10 << 30 // Below, larger models will not fit
+	probeFileName = ".relic-doctor-*"
+)
+
+// checkPort checks that nothing listens on a port relic needs.
+func (d *Doctor) checkPort(report *Report, p port) {
+	check := Check{
+		Group:  groupPorts,
+		Name:   fmt.Sprintf("%s port %d", p.name, p.number),
+		Status: StatusOK,
+		Detail: "free",
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", p.number))
+	if err != nil {
+		check.Status = StatusFailed
+		check.Detail = err.Error()
+		check.Fix = fmt.Sprintf("Stop the process listening on it (lsof -i :%d), or stop relic if it is already running.", p.number)
+		if p.flag != "" {
+			check.Fix += fmt.Sprintf(" Or pick another port with --%s.", p.flag)
+		}
+	} else {
+		_ = listener.Close()
+	}
+
+	report.Checks = append(report.Checks, check)
+}
+
+// checkModelsDir checks that the models directory is writable and has room for
+// downloads. A missing directory is created by the first download, so its
+// closest existing parent is checked instead.
+func (d *Doctor) checkModelsDir(report *Report, cfg *config.Config) {
+	dir := cfg.ModelsDir()
+
+	check := Check{Group: groupStorage, Name: "Models directory", Status: StatusOK, Detail: dir}
+	fix := "Make it writable by this user, or pick another directory with storage.models_dir or RELIC_MODELS_PATH."
+
+	existing, err := existingParent(dir)
+	switch {
+	case err != nil:
+		check.Status = StatusFailed
+		check.Detail = err.Error()
+		check.Fix = fix
+	case existing != dir:
+		check.Detail = dir + " will be created"
+	}
+
+	if check.Status == StatusOK {
+		if err := probeWritable(existing); err != nil {
+			check.Status = StatusFailed
+			check.Detail = fmt.Sprintf("%s is not writable: %v", existing, err)
+			check.Fix = fix
+		}
+	}
+	report.Checks = append(report.Checks, check)
+	if check.Status != StatusOK {
+		return
+	}
+
+	d.checkFreeSpace(report, cfg, existing)
+}
+
+// checkFreeSpace checks the free space of the file system of the models directory.
+func (d *Doctor) checkFreeSpace(report *Report, cfg *config.Config, dir string) {
+	check := Check{Group: groupStorage, Name: "Free space", Status: StatusOK}
+
+	free, err := freeBytes(dir)
+	if err != nil {
+		check.Status = StatusSkipped
+		check.Detail = err.Error()
+		report.Checks = append(report.Checks, check)
+		return
+	}
+	check.Detail = cache.FormatBytes(free) + " free"
+
+	fix := "Free up disk space, remove unused models with relic rm or relic cache gc, or move the models directory with storage.models_dir."
+	switch maxBytes := cfg.Storage.MaxSize.Bytes(); {
+	case free < minFreeBytes:
+		check.Status = StatusFailed
+		check.Fix = fix
+	case free < lowFreeBytes:
+		check.Status = StatusWarning
+		check.Detail += ", larger models may not fit"
+		check.Fix = fix
+	case maxBytes > 0 && free < maxBytes:
+		check.Status = StatusWarning
+		check.Detail += ", less than storage.max_size " + cache.FormatBytes(maxBytes)
+		check.Fix = "Lower storage.max_size so that the quota evicts models before the disk fills up."
+	}
+
+	report.Checks = append(report.Checks, check)
+}
+
+// existingParent returns dir, or its closest parent that exists. It fails if
+// that is not a directory.
+func existingParent(dir string) (string, error) {
+	for {
+		info, err := os.Stat(dir)
+		if err == nil {
+			if !info.IsDir() {
+				return "", fmt.Errorf("%s is not a directory", dir)
+			}
+			return dir, nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", err
+		}
+		dir = parent
+	}
+}
+
+// probeWritable creates and removes a file in dir.
+func probeWritable(dir string) error {
+	f, err := os.CreateTemp(dir, probeFileName)
+	if err != nil {
+		return err
+	}
+	_ = f.Close()
+
+	return os.Remove(f.Name())
+}
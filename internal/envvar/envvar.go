@@ -15,4 +15,45 @@ const (
 
 	// RelicConfigPath is the environment variable used to determine the path to the config.
 	RelicConfigPath = "RELIC_CONFIG_PATH"
+
+	// RelicConfigOverlay is the environment variable used to determine the path to the config overlay.
+	RelicConfigOverlay = "RELIC_CONFIG_OVERLAY"
+
+	// RelicOffline is the environment variable used to enable offline mode.
+	RelicOffline = "RELIC_OFFLINE"
+
+	// RelicTLSCertFile is the environment variable used to determine the TLS certificate of the servers.
+	RelicTLSCertFile = "RELIC_TLS_CERT_FILE"
+
+	// RelicTLSKeyFile is the environment variable used to determine the TLS private key of the servers.
+	RelicTLSKeyFile = "RELIC_TLS_KEY_FILE"
+
+	// RelicTLSClientCAFile is the environment variable used to determine the CA
+	// bundle client certificates are verified with, enabling mutual TLS.
+	RelicTLSClientCAFile = "RELIC_TLS_CLIENT_CA_FILE"
+
+	// RelicServer is the environment variable used to determine the address of the
+	// server the CLI commands use instead of the local models.
+	RelicServer = "RELIC_SERVER"
+
+	// RelicAPIKey is the environment variable used to determine the API key the CLI
+	// commands send to the server.
+	RelicAPIKey = "RELIC_API_KEY"
+
+	// OTelExporterOTLPEndpoint is the standard OpenTelemetry environment variable
+	// used to determine where traces are exported. Tracing is disabled when neither
+	// it nor OTelExporterOTLPTracesEndpoint is set.
+	OTelExporterOTLPEndpoint = "OTEL_EXPORTER_OTLP_ENDPOINT"
+
+	// OTelExporterOTLPTracesEndpoint is the standard OpenTelemetry environment
+	// variable used to determine where traces are exported, overriding OTelExporterOTLPEndpoint.
+	OTelExporterOTLPTracesEndpoint = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+
+	// OTelExporterOTLPProtocol is the standard OpenTelemetry environment variable
+	// used to determine the OTLP protocol, "grpc" or "http/protobuf".
+	OTelExporterOTLPProtocol = "OTEL_EXPORTER_OTLP_PROTOCOL"
+
+	// OTelExporterOTLPTracesProtocol is the standard OpenTelemetry environment
+	// variable used to determine the OTLP protocol of traces, overriding OTelExporterOTLPProtocol.
+	OTelExporterOTLPTracesProtocol = "OTEL_EXPORTER_OTLP_TRACES_PROTOCOL"
 )
@@ -0,0 +1,10 @@
+package health
+
+import "errors"
+
+// Error definitions for the health package.
+var (
+	ErrNoBinary       = errors.New("backend binary not configured")
+	ErrNoModels       = errors.New("no models assigned")
+	ErrBackendMissing = errors.New("backend not ready")
+)
@@ -0,0 +1,76 @@
+package health
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/ju4n97/relic/internal/model"
+	inferencev1 "github.com/ju4n97/relic/sdk-go/pb/inference/v1"
+	inferencev2 "github.com/ju4n97/relic/sdk-go/pb/inference/v2"
+)
+
+// grpcServices maps the gRPC services to the service whose readiness they
+// report. The empty name, the server as a whole, and the services spanning
+// every service report the overall readiness.
+var grpcServices = map[string]model.Type{
+	inferencev2.ChatService_ServiceDesc.ServiceName:         model.TypeLLM,
+	inferencev2.EmbeddingService_ServiceDesc.ServiceName:    model.TypeLLM,
+	inferencev2.TokenizerService_ServiceDesc.ServiceName:    model.TypeLLM,
+	inferencev2.SpeechToTextService_ServiceDesc.ServiceName: model.TypeSTT,
+	inferencev2.TextToSpeechService_ServiceDesc.ServiceName: model.TypeTTS,
+	inferencev1.InferenceService_ServiceDesc.ServiceName:    "",
+	"": "",
+}
+
+// alwaysServing are the gRPC services that do not depend on backends or models.
+var alwaysServing = []string{
+	inferencev1.ModelService_ServiceDesc.ServiceName,
+	inferencev2.ModelService_ServiceDesc.ServiceName,
+}
+
+// GRPCServer is the standard grpc.health.v1 service, reporting the readiness of
+// each gRPC service from the reports of a Checker.
+type GRPCServer struct {
+	*health.Server
+}
+
+// NewGRPCServer creates the health service and updates it with every report of
+// the checker. Services are not serving until the first check.
+func (c *Checker) NewGRPCServer() *GRPCServer {
+	s := &GRPCServer{Server: health.NewServer()}
+	for name := range grpcServices {
+		s.SetServingStatus(name, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	for _, name := range alwaysServing {
+		s.SetServingStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+
+	c.mu.Lock()
+	c.onCheck = append(c.onCheck, s.update)
+	c.mu.Unlock()
+
+	return s
+}
+
+// Register registers the health service on a gRPC server.
+func (s *GRPCServer) Register(server *grpc.Server) {
+	healthpb.RegisterHealthServer(server, s.Server)
+}
+
+// update sets the status of every gRPC service from a report.
+func (s *GRPCServer) update(r *Report) {
+	for name, service := range grpcServices {
+		ready := r.Ready()
+		if service != "" {
+			sr := r.Service(string(service))
+			ready = sr != nil && sr.Status == StatusReady
+		}
+
+		status := healthpb.HealthCheckResponse_NOT_SERVING
+		if ready {
+			status = healthpb.HealthCheckResponse_SERVING
+		}
+		s.SetServingStatus(name, status)
+	}
+}
@@ -0,0 +1,295 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ju4n97/relic/internal/model"
+)
+
+// Status is the readiness of the server, a service or a backend.
+type Status string
+
+const (
+	// StatusReady means requests can be served.
+	StatusReady Status = "ready"
+
+	// StatusNotReady means requests would fail, see the error of the report.
+	StatusNotReady Status = "not_ready"
+)
+
+// services are the services whose readiness is reported, when models are assigned to them.
+var services = []model.Type{model.TypeLLM, model.TypeNLU, model.TypeSTT, model.TypeTTS}
+
+// Report describes the readiness of the server. The server is ready when models
+// are assigned to at least one service and every such service is ready.
+type Report struct {
+	CheckedAt time.Time       `json:"checked_at"`
+	Status    Status          `json:"status"`
+	Backends  []BackendReport `json:"backends"`
+	Services  []ServiceReport `json:"services"`
+}
+
+// BackendReport describes the readiness of a backend, which requires its binary
+// to exist and be executable.
+type BackendReport struct {
+	Name   string `json:"name"`
+	Binary string `json:"binary"`
+	Status Status `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// ServiceReport describes the readiness of a service, which requires the backend
+// of its default model to be ready and the model to be cached.
+type ServiceReport struct {
+	Name         string        `json:"name"`
+	Status       Status        `json:"status"`
+	Error        string        `json:"error,omitempty"`
+	DefaultModel string        `json:"default_model,omitempty"`
+	Models       []ModelReport `json:"models"`
+}
+
+// ModelReport describes the readiness of a model assigned to a service.
+type ModelReport struct {
+	ID      string `json:"id"`
+	Backend string `json:"backend"`
+	Status  Status `json:"status"`
+	Model   string `json:"model_status"` // Status of the model instance, e.g. "not_cached"
+	Error   string `json:"error,omitempty"`
+}
+
+// Ready reports whether the server is ready.
+func (r *Report) Ready() bool {
+	return r.Status == StatusReady
+}
+
+// Service returns the report of a service, or nil if no models are assigned to it.
+func (r *Report) Service(name string) *ServiceReport {
+	for i := range r.Services {
+		if r.Services[i].Name == name {
+			return &r.Services[i]
+		}
+	}
+
+	return nil
+}
+
+// Option configures a Checker.
+type Option func(*Checker)
+
+// WithBinary sets the binary a backend runs, which must exist and be executable
+// for the backend to be ready.
+func WithBinary(backend, path string) Option {
+	return func(c *Checker) {
+		c.binaries[backend] = path
+	}
+}
+
+// Checker checks the readiness of the backends and of the models assigned to
+// each service.
+type Checker struct {
+	models   *model.Registry
+	binaries map[string]string
+	onCheck  []func(*Report) // Called with every new report
+	lookPath func(file string) (string, error)
+	last     *Report
+	mu       sync.Mutex // Serializes checks, so reports are published in order
+}
+
+// NewChecker creates a new Checker for the models of a registry.
+func NewChecker(models *model.Registry, opts ...Option) *Checker {
+	c := &Checker{
+		models:   models,
+		binaries: map[string]string{},
+		lookPath: exec.LookPath,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Check checks the readiness of the server and returns the report. Changes of
+// readiness are logged.
+func (c *Checker) Check() *Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	report := &Report{
+		CheckedAt: time.Now(),
+		Status:    StatusReady,
+		Backends:  c.checkBackends(),
+	}
+
+	backends := map[string]BackendReport{}
+	for _, b := range report.Backends {
+		backends[b.Name] = b
+	}
+
+	for _, service := range services {
+		sr, ok := c.checkService(service, backends)
+		if !ok {
+			continue
+		}
+		if sr.Status != StatusReady {
+			report.Status = StatusNotReady
+		}
+		report.Services = append(report.Services, sr)
+	}
+	if len(report.Services) == 0 {
+		report.Status = StatusNotReady
+	}
+
+	previous := c.last
+	c.last = report
+
+	if previous == nil || previous.Status != report.Status {
+		if report.Ready() {
+			slog.Info("Server ready")
+		} else {
+			slog.Warn("Server not ready", "reasons", report.reasons())
+		}
+	}
+
+	for _, fn := range c.onCheck {
+		fn(report)
+	}
+
+	return report
+}
+
+// Run checks the readiness every interval until ctx is done, so that models
+// finishing their download or binaries being installed are noticed.
+func (c *Checker) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.Check()
+		}
+	}
+}
+
+// checkBackends checks the binary of each backend.
+func (c *Checker) checkBackends() []BackendReport {
+	names := make([]string, 0, len(c.binaries))
+	for name := range c.binaries {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	reports := make([]BackendReport, 0, len(names))
+	for _, name := range names {
+		br := BackendReport{Name: name, Binary: c.binaries[name], Status: StatusReady}
+
+		err := ErrNoBinary
+		if br.Binary != "" {
+			// A path with a separator is checked as is, without searching PATH.
+			_, err = c.lookPath(br.Binary)
+		}
+		if err != nil {
+			br.Status = StatusNotReady
+			br.Error = err.Error()
+		}
+
+		reports = append(reports, br)
+	}
+
+	return reports
+}
+
+// checkService checks the models assigned to a service. It returns false if none are.
+func (c *Checker) checkService(service model.Type, backends map[string]BackendReport) (ServiceReport, bool) {
+	routes := c.models.Routes()
+
+	defaultModel := routes.Default(service)
+	if defaultModel == "" {
+		return ServiceReport{}, false
+	}
+
+	sr := ServiceReport{
+		Name:         string(service),
+		Status:       StatusReady,
+		DefaultModel: defaultModel,
+		Models:       []ModelReport{},
+	}
+
+	instances := c.models.List()
+	slices.SortFunc(instances, func(a, b *model.Instance) int {
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	found := false
+	for _, instance := range instances {
+		if _, err := routes.Resolve(service, instance.ID); err != nil {
+			continue
+		}
+
+		mr := checkModel(instance, backends)
+		sr.Models = append(sr.Models, mr)
+
+		if instance.ID == defaultModel {
+			found = true
+			if mr.Status != StatusReady {
+				sr.Status = StatusNotReady
+				sr.Error = fmt.Sprintf("default model %s: %s", instance.ID, mr.Error)
+			}
+		}
+	}
+
+	if !found {
+		sr.Status = StatusNotReady
+		sr.Error = fmt.Sprintf("default model %s: %v", defaultModel, model.ErrNotFound)
+	}
+
+	return sr, true
+}
+
+// checkModel checks a model can serve requests and its backend is ready.
+func checkModel(instance *model.Instance, backends map[string]BackendReport) ModelReport {
+	mr := ModelReport{
+		ID:      instance.ID,
+		Backend: instance.Config.Backend,
+		Status:  StatusReady,
+		Model:   string(instance.Status),
+	}
+
+	if err := instance.Available(); err != nil {
+		mr.Status = StatusNotReady
+		mr.Error = err.Error()
+		return mr
+	}
+
+	if b, ok := backends[mr.Backend]; ok && b.Status != StatusReady {
+		mr.Status = StatusNotReady
+		mr.Error = fmt.Sprintf("%v: %s: %s", ErrBackendMissing, b.Name, b.Error)
+	}
+
+	return mr
+}
+
+// reasons returns why the services of a report are not ready.
+func (r *Report) reasons() []string {
+	var reasons []string
+	for _, s := range r.Services {
+		if s.Status != StatusReady {
+			reasons = append(reasons, s.Name+": "+s.Error)
+		}
+	}
+	if len(r.Services) == 0 {
+		reasons = append(reasons, ErrNoModels.Error())
+	}
+
+	return reasons
+}
@@ -0,0 +1,144 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/ju4n97/relic/internal/config"
+	"github.com/ju4n97/relic/internal/health"
+	"github.com/ju4n97/relic/internal/model"
+	inferencev2 "github.com/ju4n97/relic/sdk-go/pb/inference/v2"
+)
+
+// newManager returns a model manager with a cached LLM model and, when whisper
+// is set, an STT model that is not cached.
+func newManager(t *testing.T, whisper bool) *model.Manager {
+	t.Helper()
+	t.Setenv("RELIC_MODELS_PATH", "")
+
+	modelsDir := t.TempDir()
+	path := filepath.Join(modelsDir, "org", "qwen", "qwen.gguf")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte("qwen"), 0o644))
+
+	llm := config.ModelConfig{Type: "llm", Backend: "llama.cpp"}
+	llm.SetHuggingFaceSource(config.HuggingFaceSource{Repo: "org/qwen", Include: []string{"qwen.gguf"}})
+
+	cfg := &config.Config{
+		Storage:  config.StorageConfig{ModelsDir: modelsDir},
+		Models:   map[string]config.ModelConfig{"qwen": llm},
+		Services: config.ServicesConfig{LLM: config.ServicesConfigAssignment{Models: []string{"qwen"}}},
+	}
+	if whisper {
+		stt := config.ModelConfig{Type: "stt", Backend: "whisper.cpp"}
+		stt.SetHuggingFaceSource(config.HuggingFaceSource{Repo: "org/whisper"})
+		cfg.Models["whisper"] = stt
+		cfg.Services.STT.Models = []string{"whisper"}
+	}
+
+	manager := model.NewManager(model.WithOffline(true))
+	t.Cleanup(manager.Close)
+	require.NoError(t, manager.LoadModelsFromConfig(context.Background(), cfg))
+
+	return manager
+}
+
+// newBinary returns the path of an executable file.
+func newBinary(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "llama-server")
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"), 0o755))
+
+	return path
+}
+
+func TestChecker_Ready(t *testing.T) {
+	manager := newManager(t, false)
+	checker := health.NewChecker(manager.Registry(), health.WithBinary("llama.cpp", newBinary(t)))
+
+	report := checker.Check()
+	require.True(t, report.Ready(), "%+v", report)
+	require.Len(t, report.Services, 1)
+	assert.Equal(t, "qwen", report.Services[0].DefaultModel)
+	assert.Equal(t, health.StatusReady, report.Services[0].Models[0].Status)
+}
+
+func TestChecker_NotReady(t *testing.T) {
+	manager := newManager(t, true)
+	checker := health.NewChecker(manager.Registry(),
+		health.WithBinary("llama.cpp", filepath.Join(t.TempDir(), "missing")),
+		health.WithBinary("whisper.cpp", newBinary(t)),
+	)
+
+	report := checker.Check()
+	assert.False(t, report.Ready())
+
+	llm := report.Service("llm")
+	require.NotNil(t, llm)
+	assert.Equal(t, health.StatusNotReady, llm.Status, "the llama.cpp binary is missing")
+	assert.Contains(t, llm.Error, "llama.cpp")
+
+	stt := report.Service("stt")
+	require.NotNil(t, stt)
+	assert.Equal(t, health.StatusNotReady, stt.Status, "the whisper model is not cached")
+	assert.Equal(t, string(model.StatusNotCached), stt.Models[0].Model)
+
+	assert.Nil(t, report.Service("tts"), "no models are assigned to tts")
+}
+
+func TestChecker_NoModels(t *testing.T) {
+	checker := health.NewChecker(model.NewRegistry())
+
+	assert.False(t, checker.Check().Ready())
+}
+
+func TestChecker_GRPCServer(t *testing.T) {
+	manager := newManager(t, true)
+	checker := health.NewChecker(manager.Registry(),
+		health.WithBinary("llama.cpp", newBinary(t)),
+		health.WithBinary("whisper.cpp", newBinary(t)),
+	)
+	server := checker.NewGRPCServer()
+
+	check := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		return resp.Status
+	}
+
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check(""), "not serving before the first check")
+
+	checker.Check()
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check(""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check(inferencev2.ChatService_ServiceDesc.ServiceName))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, check(inferencev2.SpeechToTextService_ServiceDesc.ServiceName))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, check(inferencev2.ModelService_ServiceDesc.ServiceName))
+}
+
+func TestChecker_ReadyHandler(t *testing.T) {
+	manager := newManager(t, false)
+	checker := health.NewChecker(manager.Registry(), health.WithBinary("llama.cpp", newBinary(t)))
+
+	rec := httptest.NewRecorder()
+	checker.ReadyHandler(rec, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var report health.Report
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&report))
+	assert.Equal(t, health.StatusReady, report.Status)
+
+	checker = health.NewChecker(manager.Registry(), health.WithBinary("llama.cpp", ""))
+	rec = httptest.NewRecorder()
+	checker.ReadyHandler(rec, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
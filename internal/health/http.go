@@ -0,0 +1,27 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// LiveHandler reports that the process is up and able to serve HTTP requests.
+func LiveHandler(w http.ResponseWriter, _ *http.Request) {
+	_, _ = w.Write([]byte("ok"))
+}
+
+// ReadyHandler checks the readiness of the server and responds with the JSON
+// report, with status 200 when ready and 503 otherwise.
+func (c *Checker) ReadyHandler(w http.ResponseWriter, _ *http.Request) {
+	report := c.Check()
+
+	status := http.StatusOK
+	if !report.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(report)
+}
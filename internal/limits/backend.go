@@ -0,0 +1,98 @@
+package limits
+
+import (
+	"context"
+
+	"github.com/ju4n97/relic/internal/backend"
+)
+
+// limitedBackend enforces the limits on the requests served by a backend.
+type limitedBackend struct {
+	backend.Backend
+	limiter *Limiter
+}
+
+// limitedStreamingBackend enforces the limits on the requests served by a streaming backend.
+type limitedStreamingBackend struct {
+	*limitedBackend
+	streaming backend.StreamingBackend
+}
+
+// Backend wraps a backend to admit the requests it serves against the limits of
+// their client and model, and to charge their usage. Only inference tasks are
+// limited, see backend.Task.Inference: counting tokens before a request must not
+// spend its allowance. The wrapper implements
+// backend.StreamingBackend when the backend does, so it can be registered in
+// place of the backend.
+func (l *Limiter) Backend(b backend.Backend) backend.Backend {
+	lb := &limitedBackend{Backend: b, limiter: l}
+	if sb, ok := b.(backend.StreamingBackend); ok {
+		return &limitedStreamingBackend{limitedBackend: lb, streaming: sb}
+	}
+
+	return lb
+}
+
+// Infer implements backend.Backend.
+func (b *limitedBackend) Infer(ctx context.Context, req *backend.Request) (*backend.Response, error) {
+	if !req.Task.Inference() {
+		return b.Backend.Infer(ctx, req)
+	}
+
+	client, err := b.admit(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := b.Backend.Infer(ctx, req)
+	if err == nil && resp.Metadata != nil {
+		b.limiter.Charge(client, req.ModelID, resp.Metadata.Usage)
+	}
+
+	return resp, err
+}
+
+// InferStream implements backend.StreamingBackend. The usage is charged once the
+// stream ends.
+func (b *limitedStreamingBackend) InferStream(ctx context.Context, req *backend.Request) (<-chan backend.StreamChunk, error) {
+	if !req.Task.Inference() {
+		return b.streaming.InferStream(ctx, req)
+	}
+
+	client, err := b.admit(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	chunks, err := b.streaming.InferStream(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan backend.StreamChunk)
+	go func() {
+		defer close(out)
+
+		var usage *backend.Usage
+		for chunk := range chunks {
+			if chunk.Usage != nil {
+				usage = chunk.Usage
+			}
+			out <- chunk
+		}
+
+		b.limiter.Charge(client, req.ModelID, usage)
+	}()
+
+	return out, nil
+}
+
+// admit admits a request against the limits and reports the status of its client.
+func (b *limitedBackend) admit(ctx context.Context, req *backend.Request) (string, error) {
+	client := ClientFromContext(ctx)
+
+	status, err := b.limiter.Admit(client, req.ModelID)
+	reportStatus(ctx, status)
+
+	return client, err
+}
@@ -0,0 +1,52 @@
+package limits
+
+import (
+	"time"
+)
+
+// bucket is a token bucket refilled at a rate per minute, holding at most a
+// minute worth of tokens. Usage only known once a request is served is taken
+// afterwards, so the bucket may go into debt, delaying the next requests.
+type bucket struct {
+	updated time.Time
+	rate    float64 // Tokens per minute, also the capacity
+	tokens  float64
+}
+
+// newBucket returns a full bucket.
+func newBucket(now time.Time, rate float64) *bucket {
+	return &bucket{updated: now, rate: rate, tokens: rate}
+}
+
+// refill adds the tokens accrued since the last update. A changed rate, e.g. on
+// config reload, applies from now on.
+func (b *bucket) refill(now time.Time, rate float64) {
+	if rate != b.rate {
+		b.rate = rate
+		b.tokens = min(b.tokens, rate)
+	}
+
+	if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.tokens = min(b.rate, b.tokens+elapsed.Minutes()*b.rate)
+		b.updated = now
+	}
+}
+
+// wait returns how long until the bucket holds need tokens, 0 if it already does.
+func (b *bucket) wait(need float64) time.Duration {
+	if b.tokens >= need {
+		return 0
+	}
+
+	return time.Duration((need - b.tokens) / b.rate * float64(time.Minute))
+}
+
+// take removes tokens from the bucket.
+func (b *bucket) take(tokens float64) {
+	b.tokens -= tokens
+}
+
+// full reports whether the bucket holds its capacity, so dropping it changes nothing.
+func (b *bucket) full() bool {
+	return b.tokens >= b.rate
+}
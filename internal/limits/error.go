@@ -0,0 +1,32 @@
+package limits
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Error definitions for the limits package.
+var (
+	ErrRateLimited   = errors.New("rate limit exceeded")
+	ErrQuotaExceeded = errors.New("daily quota exceeded")
+)
+
+// Error is returned for requests rejected by a limit. It wraps ErrRateLimited or
+// ErrQuotaExceeded.
+type Error struct {
+	Err        error
+	Subject    string        // Client or model the limit applies to, e.g. "key:ops" or "model:qwen"
+	Limit      string        // Setting of the limit, e.g. "requests_per_minute"
+	RetryAfter time.Duration // Until the request would be admitted
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	return fmt.Sprintf("%v: %s %s, retry in %s", e.Err, e.Subject, e.Limit, e.RetryAfter.Round(time.Second))
+}
+
+// Unwrap returns ErrRateLimited or ErrQuotaExceeded.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
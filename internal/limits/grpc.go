@@ -0,0 +1,79 @@
+package limits
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// UnaryServerInterceptor identifies the client of unary calls, see Middleware.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(withGRPCClient(ctx), req)
+	}
+}
+
+// StreamServerInterceptor identifies the client of streaming calls, see Middleware.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &clientServerStream{ServerStream: ss, ctx: withGRPCClient(ss.Context())})
+	}
+}
+
+// withGRPCClient returns a copy of the context of a call carrying the identity of
+// its client, whose allowance is reported in the ratelimit-limit,
+// ratelimit-remaining and ratelimit-reset response headers.
+func withGRPCClient(ctx context.Context) context.Context {
+	var remoteAddr string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		remoteAddr = p.Addr.String()
+	}
+
+	return WithClient(ctx, clientOf(ctx, remoteAddr), func(status Status) {
+		if status.Limit > 0 {
+			_ = grpc.SetHeader(ctx, metadata.Pairs(
+				"ratelimit-limit", strconv.Itoa(status.Limit),
+				"ratelimit-remaining", strconv.Itoa(status.Remaining),
+				"ratelimit-reset", seconds(status.Reset),
+			))
+		}
+	})
+}
+
+// GRPCStatus returns the RESOURCE_EXHAUSTED status of the error, carrying the
+// RetryInfo and QuotaFailure details, so gRPC clients know when to retry.
+func (e *Error) GRPCStatus() *status.Status {
+	st := status.New(codes.ResourceExhausted, e.Error())
+
+	detailed, err := st.WithDetails(
+		&errdetails.RetryInfo{RetryDelay: durationpb.New(e.RetryAfter)},
+		&errdetails.QuotaFailure{Violations: []*errdetails.QuotaFailure_Violation{{
+			Subject:     e.Subject,
+			Description: e.Limit,
+		}}},
+	)
+	if err != nil {
+		return st
+	}
+
+	return detailed
+}
+
+// clientServerStream overrides the context of a server stream with the one
+// carrying the client identity.
+type clientServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context implements grpc.ServerStream.
+func (s *clientServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,55 @@
+package limits
+
+import (
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// peerKey is the context key of the address of the peer of a connection.
+type peerKey struct{}
+
+// ConnContext records the address of the peer of a connection, to be set as the
+// ConnContext of the http.Server. Clients without credentials are then limited by
+// that address rather than by the RemoteAddr of their requests, which middlewares
+// such as RealIP rewrite from headers the client controls.
+func ConnContext(ctx context.Context, conn net.Conn) context.Context {
+	return context.WithValue(ctx, peerKey{}, conn.RemoteAddr().String())
+}
+
+// Middleware identifies the client of a request for the limits, by its API key,
+// client certificate or IP address, and reports its allowance in the
+// RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset response headers, and
+// Retry-After when it is rejected. It must run after the auth middleware. The IP
+// address is the one of the peer recorded by ConnContext, or RemoteAddr when the
+// server does not record it.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		remoteAddr := r.RemoteAddr
+		if peer, ok := r.Context().Value(peerKey{}).(string); ok {
+			remoteAddr = peer
+		}
+
+		header := w.Header()
+		ctx := WithClient(r.Context(), clientOf(r.Context(), remoteAddr), func(status Status) {
+			if status.Limit > 0 {
+				header.Set("RateLimit-Limit", strconv.Itoa(status.Limit))
+				header.Set("RateLimit-Remaining", strconv.Itoa(status.Remaining))
+				header.Set("RateLimit-Reset", seconds(status.Reset))
+			}
+			if status.RetryAfter > 0 {
+				header.Set("Retry-After", seconds(status.RetryAfter))
+			}
+		})
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// seconds formats a duration as whole seconds, rounded up.
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
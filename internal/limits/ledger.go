@@ -0,0 +1,230 @@
+package limits
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// dayLayout formats the UTC days usage is recorded by.
+	dayLayout = time.DateOnly
+
+	// retention is how long usage is kept in the ledger.
+	retention = 90 * 24 * time.Hour
+
+	// flushInterval bounds how often usage is written to disk.
+	flushInterval = time.Minute
+)
+
+// Tally counts the inference requests of a client.
+type Tally struct {
+	Requests        int64   `json:"requests"`
+	Rejected        int64   `json:"rejected,omitempty"` // Requests rejected by a limit, not counted in Requests
+	PromptTokens    int64   `json:"prompt_tokens,omitempty"`
+	GeneratedTokens int64   `json:"generated_tokens,omitempty"`
+	AudioSeconds    float64 `json:"audio_seconds,omitempty"` // Audio transcribed or synthesized
+	Characters      int64   `json:"characters,omitempty"`    // Text synthesized
+}
+
+// Tokens returns the prompt and generated tokens.
+func (t *Tally) Tokens() int64 {
+	return t.PromptTokens + t.GeneratedTokens
+}
+
+// Add adds other to the tally.
+func (t *Tally) Add(other *Tally) {
+	t.Requests += other.Requests
+	t.Rejected += other.Rejected
+	t.PromptTokens += other.PromptTokens
+	t.GeneratedTokens += other.GeneratedTokens
+	t.AudioSeconds += other.AudioSeconds
+	t.Characters += other.Characters
+}
+
+// Entry is the usage of a client on a model during a day.
+type Entry struct {
+	Day    string `json:"day"` // UTC, e.g. "2025-10-18"
+	Client string `json:"client"`
+	Model  string `json:"model"`
+	Tally
+}
+
+// Filter selects ledger entries. Empty fields match everything.
+type Filter struct {
+	Client string
+	Model  string
+	From   string // First day, inclusive
+	To     string // Last day, inclusive
+}
+
+// Ledger records the usage of each client and model per UTC day, persisted in a
+// JSON file. Usage older than 90 days is dropped.
+type Ledger struct {
+	lastFlush time.Time
+	days      map[string]map[string]map[string]*Tally // Day, client and model to usage
+	path      string
+	mu        sync.Mutex
+	dirty     bool
+}
+
+// OpenLedger loads the ledger persisted in a file. A missing file is not an
+// error, an empty ledger is returned instead.
+func OpenLedger(path string) (*Ledger, error) {
+	l := &Ledger{
+		days:      map[string]map[string]map[string]*Tally{},
+		path:      path,
+		lastFlush: time.Now(),
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return l, nil
+		}
+		return nil, fmt.Errorf("limits: failed to read usage ledger: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &l.days); err != nil {
+		return nil, fmt.Errorf("limits: failed to decode usage ledger: %w", err)
+	}
+
+	return l, nil
+}
+
+// Path returns the file the ledger is persisted in.
+func (l *Ledger) Path() string {
+	return l.path
+}
+
+// Record adds usage of a client on a model at a time.
+func (l *Ledger) Record(at time.Time, client, modelID string, usage Tally) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	day := at.UTC().Format(dayLayout)
+	clients, ok := l.days[day]
+	if !ok {
+		clients = map[string]map[string]*Tally{}
+		l.days[day] = clients
+	}
+	models, ok := clients[client]
+	if !ok {
+		models = map[string]*Tally{}
+		clients[client] = models
+	}
+	total, ok := models[modelID]
+	if !ok {
+		total = &Tally{}
+		models[modelID] = total
+	}
+	total.Add(&usage)
+	l.dirty = true
+
+	if time.Since(l.lastFlush) >= flushInterval {
+		// Flushes are retried on the next record, and on close.
+		_ = l.flushLocked()
+	}
+}
+
+// Total returns the usage of the day of at matching a client and a model, where
+// empty strings match every client or model.
+func (l *Ledger) Total(at time.Time, client, modelID string) Tally {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var total Tally
+	for c, models := range l.days[at.UTC().Format(dayLayout)] {
+		if client != "" && c != client {
+			continue
+		}
+		for m, usage := range models {
+			if modelID == "" || m == modelID {
+				total.Add(usage)
+			}
+		}
+	}
+
+	return total
+}
+
+// Entries returns the entries matching a filter, sorted by day, client and model.
+func (l *Ledger) Entries(filter Filter) []Entry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entries := []Entry{}
+	for _, day := range slices.Sorted(maps.Keys(l.days)) {
+		if (filter.From != "" && day < filter.From) || (filter.To != "" && day > filter.To) {
+			continue
+		}
+
+		clients := l.days[day]
+		for _, client := range slices.Sorted(maps.Keys(clients)) {
+			if filter.Client != "" && client != filter.Client {
+				continue
+			}
+
+			models := clients[client]
+			for _, modelID := range slices.Sorted(maps.Keys(models)) {
+				if filter.Model != "" && modelID != filter.Model {
+					continue
+				}
+				entries = append(entries, Entry{Day: day, Client: client, Model: modelID, Tally: *models[modelID]})
+			}
+		}
+	}
+
+	return entries
+}
+
+// Flush writes pending usage to the ledger file.
+func (l *Ledger) Flush() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.flushLocked()
+}
+
+// flushLocked drops expired usage and writes the ledger file, if any. Callers
+// must hold the lock.
+func (l *Ledger) flushLocked() error {
+	l.lastFlush = time.Now()
+	if !l.dirty || l.path == "" {
+		return nil
+	}
+
+	oldest := time.Now().Add(-retention).UTC().Format(dayLayout)
+	for day := range l.days {
+		if day < oldest {
+			delete(l.days, day)
+		}
+	}
+
+	data, err := json.MarshalIndent(l.days, "", "  ")
+	if err != nil {
+		return fmt.Errorf("limits: failed to encode usage ledger: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o755); err != nil {
+		return fmt.Errorf("limits: failed to create %s: %w", filepath.Dir(l.path), err)
+	}
+
+	tmp := l.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("limits: failed to write usage ledger: %w", err)
+	}
+
+	if err := os.Rename(tmp, l.path); err != nil {
+		return fmt.Errorf("limits: failed to replace usage ledger: %w", err)
+	}
+
+	l.dirty = false
+	return nil
+}
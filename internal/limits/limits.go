@@ -0,0 +1,372 @@
+package limits
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ju4n97/relic/internal/auth"
+	"github.com/ju4n97/relic/internal/backend"
+	"github.com/ju4n97/relic/internal/config"
+	"github.com/ju4n97/relic/internal/tlsconfig"
+)
+
+// sweepInterval bounds how often buckets that refilled completely are dropped.
+const sweepInterval = time.Minute
+
+// Bucket kinds.
+const (
+	kindRequests = "requests"
+	kindTokens   = "tokens"
+	kindAudio    = "audio"
+)
+
+// Status describes the request rate allowed to a client, reported to it in
+// response headers.
+type Status struct {
+	Limit      int           // Requests per minute allowed to the client, 0 when unlimited
+	Remaining  int           // Requests the client may still make right away
+	Reset      time.Duration // Until the allowance is fully restored
+	RetryAfter time.Duration // Until the request would be admitted, when it is rejected
+}
+
+// Option is a function that configures a Limiter.
+type Option func(*Limiter)
+
+// WithClock sets the function returning the current time, e.g. in tests.
+func WithClock(now func() time.Time) Option {
+	return func(l *Limiter) {
+		l.now = now
+	}
+}
+
+// Limiter enforces the rate limits and daily quotas of the config on inference
+// requests, per client and per model, and records their usage in a ledger.
+type Limiter struct {
+	ledger    *Ledger
+	now       func() time.Time
+	clients   config.RateLimitConfig
+	keys      map[string]config.RateLimitConfig // Limits of the API keys that override clients
+	models    map[string]config.RateLimitConfig
+	buckets   map[bucketKey]*bucket
+	lastSweep time.Time
+	mu        sync.Mutex
+}
+
+// bucketKey identifies the bucket of a subject, a client or a model.
+type bucketKey struct {
+	subject string
+	kind    string
+}
+
+// New creates a Limiter. It enforces nothing and keeps usage in memory until
+// Update is called with a config.
+func New(opts ...Option) *Limiter {
+	l := &Limiter{
+		ledger:  &Ledger{days: map[string]map[string]map[string]*Tally{}},
+		now:     time.Now,
+		keys:    map[string]config.RateLimitConfig{},
+		models:  map[string]config.RateLimitConfig{},
+		buckets: map[bucketKey]*bucket{},
+	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+	l.lastSweep = l.now()
+
+	return l
+}
+
+// Update applies the limits of the config, e.g. on reload, and opens its usage
+// ledger if it changed. Tokens accrued by clients are kept. On error nothing is
+// applied.
+func (l *Limiter) Update(cfg *config.Config) error {
+	keys := map[string]config.RateLimitConfig{}
+	for _, key := range cfg.Auth.AllKeys() {
+		if key.Limits != nil {
+			keys[key.Name] = *key.Limits
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if path := cfg.UsagePath(); path != l.ledger.Path() {
+		ledger, err := OpenLedger(path)
+		if err != nil {
+			return err
+		}
+		if err := l.ledger.Flush(); err != nil {
+			slog.Error("Failed to flush usage ledger", "path", l.ledger.Path(), "error", err)
+		}
+		l.ledger = ledger
+	}
+
+	l.clients = cfg.Limits.Clients
+	l.keys = keys
+	l.models = cfg.Limits.Models
+
+	return nil
+}
+
+// Ledger returns the ledger usage is recorded in.
+func (l *Limiter) Ledger() *Ledger {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.ledger
+}
+
+// Close writes the pending usage to the ledger file.
+func (l *Limiter) Close() error {
+	return l.Ledger().Flush()
+}
+
+// Admit checks a request of a client for a model against the limits and counts
+// it. It returns an *Error if a limit rejects it. The returned status describes
+// the allowance of the client either way.
+func (l *Limiter) Admit(client, modelID string) (Status, error) {
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweepLocked(now)
+
+	clientLimits := l.clientLimitsLocked(client)
+	subjects := []struct {
+		name   string
+		limits config.RateLimitConfig
+		usage  Tally
+	}{
+		{client, clientLimits, l.ledger.Total(now, client, "")},
+		{"model:" + modelID, l.models[modelID], l.ledger.Total(now, "", modelID)},
+	}
+
+	for _, s := range subjects {
+		if err := l.checkLocked(now, s.name, s.limits, &s.usage); err != nil {
+			l.ledger.Record(now, client, modelID, Tally{Rejected: 1})
+
+			status := l.statusLocked(now, client, clientLimits)
+			status.RetryAfter = err.RetryAfter
+			return status, err
+		}
+	}
+
+	for _, s := range subjects {
+		if s.limits.RequestsPerMinute > 0 {
+			l.bucketLocked(now, s.name, kindRequests, float64(s.limits.RequestsPerMinute)).take(1)
+		}
+	}
+	l.ledger.Record(now, client, modelID, Tally{Requests: 1})
+
+	return l.statusLocked(now, client, clientLimits), nil
+}
+
+// Charge records the usage of a request admitted for a client, taking its tokens
+// and audio seconds from the rate limits.
+func (l *Limiter) Charge(client, modelID string, usage *backend.Usage) {
+	if usage == nil {
+		return
+	}
+	now := l.now()
+
+	tokens := float64(usage.PromptTokens + usage.GeneratedTokens)
+
+	l.mu.Lock()
+	ledger := l.ledger
+	for _, s := range []struct {
+		name   string
+		limits config.RateLimitConfig
+	}{
+		{client, l.clientLimitsLocked(client)},
+		{"model:" + modelID, l.models[modelID]},
+	} {
+		if s.limits.TokensPerMinute > 0 && tokens > 0 {
+			l.bucketLocked(now, s.name, kindTokens, float64(s.limits.TokensPerMinute)).take(tokens)
+		}
+		if s.limits.AudioSecondsPerMinute > 0 && usage.AudioSeconds > 0 {
+			l.bucketLocked(now, s.name, kindAudio, s.limits.AudioSecondsPerMinute).take(usage.AudioSeconds)
+		}
+	}
+	l.mu.Unlock()
+
+	ledger.Record(now, client, modelID, Tally{
+		PromptTokens:    int64(usage.PromptTokens),
+		GeneratedTokens: int64(usage.GeneratedTokens),
+		AudioSeconds:    usage.AudioSeconds,
+		Characters:      int64(usage.Characters),
+	})
+}
+
+// checkLocked returns an *Error if the rates or quotas of a subject, given its
+// usage of the day, reject a request. Tokens and audio seconds are only known
+// once a request is served, so requests are rejected while their bucket is in
+// debt. Callers must hold the lock.
+func (l *Limiter) checkLocked(now time.Time, subject string, cfg config.RateLimitConfig, today *Tally) *Error {
+	rates := []struct {
+		limit string
+		kind  string
+		rate  float64
+		need  float64
+	}{
+		{"requests_per_minute", kindRequests, float64(cfg.RequestsPerMinute), 1},
+		{"tokens_per_minute", kindTokens, float64(cfg.TokensPerMinute), 0},
+		{"audio_seconds_per_minute", kindAudio, cfg.AudioSecondsPerMinute, 0},
+	}
+	for _, r := range rates {
+		if r.rate <= 0 {
+			continue
+		}
+		if wait := l.bucketLocked(now, subject, r.kind, r.rate).wait(r.need); wait > 0 {
+			return &Error{Err: ErrRateLimited, Subject: subject, Limit: r.limit, RetryAfter: wait}
+		}
+	}
+
+	quotas := []struct {
+		limit string
+		quota float64
+		used  float64
+	}{
+		{"requests_per_day", float64(cfg.RequestsPerDay), float64(today.Requests)},
+		{"tokens_per_day", float64(cfg.TokensPerDay), float64(today.Tokens())},
+		{"audio_seconds_per_day", cfg.AudioSecondsPerDay, today.AudioSeconds},
+	}
+	for _, q := range quotas {
+		if q.quota > 0 && q.used >= q.quota {
+			return &Error{Err: ErrQuotaExceeded, Subject: subject, Limit: q.limit, RetryAfter: untilTomorrow(now)}
+		}
+	}
+
+	return nil
+}
+
+// statusLocked returns the request allowance of a client. Callers must hold the lock.
+func (l *Limiter) statusLocked(now time.Time, client string, cfg config.RateLimitConfig) Status {
+	if cfg.RequestsPerMinute <= 0 {
+		return Status{}
+	}
+
+	b := l.bucketLocked(now, client, kindRequests, float64(cfg.RequestsPerMinute))
+
+	return Status{
+		Limit:     cfg.RequestsPerMinute,
+		Remaining: max(0, int(b.tokens)),
+		Reset:     b.wait(b.rate),
+	}
+}
+
+// clientLimitsLocked returns the limits of a client: those of its API key if
+// set, else the limits of every client. Callers must hold the lock.
+func (l *Limiter) clientLimitsLocked(client string) config.RateLimitConfig {
+	if name, ok := strings.CutPrefix(client, clientKeyPrefix); ok {
+		if limits, ok := l.keys[name]; ok {
+			return limits
+		}
+	}
+
+	return l.clients
+}
+
+// bucketLocked returns the refilled bucket of a subject. Callers must hold the lock.
+func (l *Limiter) bucketLocked(now time.Time, subject, kind string, rate float64) *bucket {
+	key := bucketKey{subject: subject, kind: kind}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = newBucket(now, rate)
+		l.buckets[key] = b
+	}
+	b.refill(now, rate)
+
+	return b
+}
+
+// sweepLocked drops the buckets that refilled completely, so clients seen once
+// do not accumulate. Callers must hold the lock.
+func (l *Limiter) sweepLocked(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		b.refill(now, b.rate)
+		if b.full() {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// untilTomorrow returns the time until the next UTC day, when daily quotas reset.
+func untilTomorrow(now time.Time) time.Duration {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC).Sub(now)
+}
+
+// Client identity prefixes.
+const (
+	clientKeyPrefix  = "key:"
+	clientCertPrefix = "cert:"
+	clientIPPrefix   = "ip:"
+	clientAnonymous  = "anonymous"
+)
+
+// clientOf returns the identity limits apply to for a request: its API key, else
+// its client certificate, else the IP address of remoteAddr.
+func clientOf(ctx context.Context, remoteAddr string) string {
+	if key, ok := auth.KeyFromContext(ctx); ok {
+		return clientKeyPrefix + key.Name
+	}
+
+	if identity := tlsconfig.IdentityFromContext(ctx); identity != "" {
+		return clientCertPrefix + identity
+	}
+
+	if remoteAddr == "" {
+		return clientAnonymous
+	}
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+
+	return clientIPPrefix + host
+}
+
+// clientContextKey is the context key of the client identity.
+type clientContextKey struct{}
+
+// reporterContextKey is the context key of the function reporting the status.
+type reporterContextKey struct{}
+
+// WithClient returns a copy of ctx carrying the identity of the client, and the
+// function its status is reported to, if any.
+func WithClient(ctx context.Context, client string, report func(Status)) context.Context {
+	ctx = context.WithValue(ctx, clientContextKey{}, client)
+	if report != nil {
+		ctx = context.WithValue(ctx, reporterContextKey{}, report)
+	}
+
+	return ctx
+}
+
+// ClientFromContext returns the identity of the client of a request.
+func ClientFromContext(ctx context.Context) string {
+	if client, ok := ctx.Value(clientContextKey{}).(string); ok {
+		return client
+	}
+
+	return clientAnonymous
+}
+
+// reportStatus reports the status of the client of a request, if it is reported.
+func reportStatus(ctx context.Context, status Status) {
+	if report, ok := ctx.Value(reporterContextKey{}).(func(Status)); ok {
+		report(status)
+	}
+}
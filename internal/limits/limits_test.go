@@ -0,0 +1,275 @@
+package limits_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ju4n97/relic/internal/backend"
+	"github.com/ju4n97/relic/internal/config"
+	"github.com/ju4n97/relic/internal/limits"
+)
+
+// fakeBackend serves every request with the configured usage.
+type fakeBackend struct {
+	usage *backend.Usage
+}
+
+func (b *fakeBackend) Provider() string { return "llama.cpp" }
+
+func (b *fakeBackend) Infer(_ context.Context, _ *backend.Request) (*backend.Response, error) {
+	return &backend.Response{
+		Output:   bytes.NewReader(nil),
+		Metadata: &backend.ResponseMetadata{Usage: b.usage},
+	}, nil
+}
+
+func (b *fakeBackend) Close() error { return nil }
+
+// clock is a settable time source.
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time { return c.now }
+
+func newLimiter(t *testing.T, limitsConfig config.LimitsConfig) (*limits.Limiter, *clock) {
+	t.Helper()
+
+	c := &clock{now: time.Date(2025, 10, 18, 12, 0, 0, 0, time.UTC)}
+	limitsConfig.UsageFile = filepath.Join(t.TempDir(), "usage.json")
+
+	limiter := limits.New(limits.WithClock(c.Now))
+	require.NoError(t, limiter.Update(&config.Config{Limits: limitsConfig}))
+
+	return limiter, c
+}
+
+func TestLimiter_RequestsPerMinute(t *testing.T) {
+	t.Parallel()
+
+	limiter, c := newLimiter(t, config.LimitsConfig{
+		Clients: config.RateLimitConfig{RequestsPerMinute: 2},
+	})
+
+	status, err := limiter.Admit("ip:10.0.0.1", "qwen")
+	require.NoError(t, err)
+	assert.Equal(t, limits.Status{Limit: 2, Remaining: 1, Reset: 30 * time.Second}, status)
+
+	_, err = limiter.Admit("ip:10.0.0.1", "qwen")
+	require.NoError(t, err)
+
+	status, err = limiter.Admit("ip:10.0.0.1", "qwen")
+	require.ErrorIs(t, err, limits.ErrRateLimited)
+	assert.Equal(t, 30*time.Second, status.RetryAfter)
+
+	_, err = limiter.Admit("ip:10.0.0.2", "qwen")
+	require.NoError(t, err, "clients are limited independently")
+
+	c.now = c.now.Add(30 * time.Second)
+	_, err = limiter.Admit("ip:10.0.0.1", "qwen")
+	require.NoError(t, err, "the bucket refills over time")
+
+	usage := limiter.Ledger().Total(c.now, "ip:10.0.0.1", "")
+	assert.Equal(t, limits.Tally{Requests: 3, Rejected: 1}, usage)
+}
+
+func TestLimiter_TokensPerMinuteAndModelQuota(t *testing.T) {
+	t.Parallel()
+
+	limiter, c := newLimiter(t, config.LimitsConfig{
+		Clients: config.RateLimitConfig{TokensPerMinute: 100},
+		Models:  map[string]config.RateLimitConfig{"qwen": {TokensPerDay: 250}},
+	})
+	b := limiter.Backend(&fakeBackend{usage: &backend.Usage{PromptTokens: 30, GeneratedTokens: 120}})
+	ctx := limits.WithClient(t.Context(), "key:app", nil)
+
+	_, err := b.Infer(ctx, &backend.Request{ModelID: "qwen"})
+	require.NoError(t, err)
+
+	// The first request put the client 50 tokens in debt, paid back in 30 seconds.
+	_, err = b.Infer(ctx, &backend.Request{ModelID: "qwen"})
+	var limitErr *limits.Error
+	require.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, "tokens_per_minute", limitErr.Limit)
+	assert.Equal(t, 30*time.Second, limitErr.RetryAfter)
+
+	c.now = c.now.Add(time.Minute)
+	_, err = b.Infer(limits.WithClient(t.Context(), "key:other", nil), &backend.Request{ModelID: "qwen"})
+	require.NoError(t, err)
+
+	// 300 tokens of qwen were used today, over its daily quota.
+	_, err = b.Infer(ctx, &backend.Request{ModelID: "qwen"})
+	require.ErrorIs(t, err, limits.ErrQuotaExceeded)
+	require.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, "model:qwen", limitErr.Subject)
+	assert.Equal(t, 11*time.Hour+59*time.Minute, limitErr.RetryAfter)
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Len(t, st.Details(), 2)
+	retry, ok := st.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	assert.Equal(t, limitErr.RetryAfter, retry.RetryDelay.AsDuration())
+}
+
+func TestLimiter_KeyLimitsReplaceClientLimits(t *testing.T) {
+	t.Parallel()
+
+	limiter, _ := newLimiter(t, config.LimitsConfig{
+		Clients: config.RateLimitConfig{RequestsPerDay: 1},
+	})
+	require.NoError(t, limiter.Update(&config.Config{
+		Limits: config.LimitsConfig{
+			UsageFile: limiter.Ledger().Path(),
+			Clients:   config.RateLimitConfig{RequestsPerDay: 1},
+		},
+		Auth: config.AuthConfig{Keys: []config.APIKeyConfig{{
+			Name:   "batch",
+			Limits: &config.RateLimitConfig{RequestsPerDay: 3},
+		}}},
+	}))
+
+	for range 3 {
+		_, err := limiter.Admit("key:batch", "qwen")
+		require.NoError(t, err)
+	}
+	_, err := limiter.Admit("key:batch", "qwen")
+	require.ErrorIs(t, err, limits.ErrQuotaExceeded)
+
+	_, err = limiter.Admit("ip:10.0.0.1", "qwen")
+	require.NoError(t, err)
+	_, err = limiter.Admit("ip:10.0.0.1", "qwen")
+	require.ErrorIs(t, err, limits.ErrQuotaExceeded)
+}
+
+func TestLedger_Persists(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "state", "usage.json")
+	ledger, err := limits.OpenLedger(path)
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 23, 0, 0, 0, time.UTC)
+	today := day.Format(time.DateOnly)
+	ledger.Record(day, "key:app", "qwen", limits.Tally{Requests: 1, PromptTokens: 10})
+	ledger.Record(day, "key:app", "whisper", limits.Tally{Requests: 1, AudioSeconds: 2.5})
+	ledger.Record(day.Add(2*time.Hour), "key:app", "qwen", limits.Tally{Requests: 1})
+	require.NoError(t, ledger.Flush())
+
+	reopened, err := limits.OpenLedger(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, []limits.Entry{
+		{Day: today, Client: "key:app", Model: "qwen", Tally: limits.Tally{Requests: 1, PromptTokens: 10}},
+		{Day: today, Client: "key:app", Model: "whisper", Tally: limits.Tally{Requests: 1, AudioSeconds: 2.5}},
+	}, reopened.Entries(limits.Filter{To: today}))
+	assert.Len(t, reopened.Entries(limits.Filter{Model: "qwen"}), 2)
+}
+
+func TestLimiter_Backend_UtilityTasks(t *testing.T) {
+	t.Parallel()
+
+	limiter, _ := newLimiter(t, config.LimitsConfig{
+		Clients: config.RateLimitConfig{RequestsPerMinute: 1, RequestsPerDay: 1},
+	})
+	b := limiter.Backend(&fakeBackend{})
+	ctx := limits.WithClient(context.Background(), "key:app", nil)
+
+	for _, task := range []backend.Task{backend.TaskTokenize, backend.TaskDetokenize, backend.TaskApplyTemplate, backend.TaskWarmPromptCache} {
+		_, err := b.Infer(ctx, &backend.Request{ModelID: "qwen", Task: task})
+		require.NoError(t, err, task)
+	}
+
+	_, err := b.Infer(ctx, &backend.Request{ModelID: "qwen"})
+	require.NoError(t, err, "utility tasks must not spend the allowance")
+	assert.Equal(t, []limits.Entry{{
+		Day: "2025-10-18", Client: "key:app", Model: "qwen", Tally: limits.Tally{Requests: 1},
+	}}, limiter.Ledger().Entries(limits.Filter{}))
+
+	_, err = b.Infer(ctx, &backend.Request{ModelID: "qwen", Task: backend.TaskEmbedding})
+	require.ErrorIs(t, err, limits.ErrRateLimited)
+}
+
+func TestMiddleware_IgnoresForwardedAddress(t *testing.T) {
+	t.Parallel()
+
+	limiter, _ := newLimiter(t, config.LimitsConfig{
+		Clients: config.RateLimitConfig{RequestsPerMinute: 1},
+	})
+	b := limiter.Backend(&fakeBackend{})
+
+	// RealIP rewrites RemoteAddr from the headers, as in the server.
+	server := httptest.NewUnstartedServer(middleware.RealIP(limits.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := b.Infer(r.Context(), &backend.Request{ModelID: "qwen"}); err != nil {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))))
+	server.Config.ConnContext = limits.ConnContext
+	server.Start()
+	t.Cleanup(server.Close)
+
+	serve := func(forwardedFor string) int {
+		req, err := http.NewRequest(http.MethodPost, server.URL+"/v1/llm", nil)
+		require.NoError(t, err)
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusOK, serve("203.0.113.1"))
+	assert.Equal(t, http.StatusTooManyRequests, serve("203.0.113.2"), "a forged address must not get a fresh allowance")
+	assert.Equal(t, "ip:127.0.0.1", limiter.Ledger().Entries(limits.Filter{})[0].Client)
+}
+
+func TestMiddleware_ReportsStatus(t *testing.T) {
+	t.Parallel()
+
+	limiter, _ := newLimiter(t, config.LimitsConfig{
+		Clients: config.RateLimitConfig{RequestsPerMinute: 1},
+	})
+	b := limiter.Backend(&fakeBackend{})
+
+	handler := limits.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := b.Infer(r.Context(), &backend.Request{ModelID: "qwen"}); err != nil {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+
+	serve := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/llm", nil)
+		req.RemoteAddr = "10.0.0.1:52100"
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve()
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "0", rec.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "60", rec.Header().Get("RateLimit-Reset"))
+
+	rec = serve()
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "60", rec.Header().Get("Retry-After"))
+	assert.Equal(t, []limits.Entry{{
+		Day: "2025-10-18", Client: "ip:10.0.0.1", Model: "qwen", Tally: limits.Tally{Requests: 1, Rejected: 1},
+	}}, limiter.Ledger().Entries(limits.Filter{}))
+}
@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Transports used as the transport label of API metrics.
+const (
+	TransportHTTP = "http"
+	TransportGRPC = "grpc"
+)
+
+// HTTPMiddleware records the requests handled by a chi router. Requests are
+// labeled with their route pattern so that path parameters do not create new series.
+func (m *Metrics) HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		start := time.Now()
+
+		next.ServeHTTP(ww, r)
+
+		operation := "unmatched"
+		if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+			operation = r.Method + " " + rctx.RoutePattern()
+		}
+
+		code := ww.Status()
+		if code == 0 {
+			code = http.StatusOK
+		}
+
+		m.observeAPI(TransportHTTP, operation, strconv.Itoa(code), time.Since(start))
+	})
+}
+
+// UnaryServerInterceptor records unary RPC calls.
+func (m *Metrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		m.observeAPI(TransportGRPC, info.FullMethod, status.Code(err).String(), time.Since(start))
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor records streaming RPC calls, once the stream ends.
+func (m *Metrics) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		start := time.Now()
+
+		err := handler(srv, ss)
+
+		m.observeAPI(TransportGRPC, info.FullMethod, status.Code(err).String(), time.Since(start))
+		return err
+	}
+}
+
+// observeAPI records an API call.
+func (m *Metrics) observeAPI(transport, operation, code string, elapsed time.Duration) {
+	m.apiRequests.WithLabelValues(transport, operation, code).Inc()
+	m.apiDuration.WithLabelValues(transport, operation).Observe(elapsed.Seconds())
+}
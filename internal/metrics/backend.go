@@ -0,0 +1,145 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ju4n97/relic/internal/backend"
+	"github.com/ju4n97/relic/internal/config"
+)
+
+// instrumentedBackend records the requests served by a backend.
+type instrumentedBackend struct {
+	backend.Backend
+	metrics *Metrics
+}
+
+// instrumentedStreamingBackend records the requests served by a streaming backend.
+type instrumentedStreamingBackend struct {
+	*instrumentedBackend
+	streaming backend.StreamingBackend
+}
+
+// Backend wraps a backend to record the requests it serves. The wrapper
+// implements backend.StreamingBackend when the backend does, so it can be
+// registered in place of the backend.
+func (m *Metrics) Backend(b backend.Backend) backend.Backend {
+	ib := &instrumentedBackend{Backend: b, metrics: m}
+	if sb, ok := b.(backend.StreamingBackend); ok {
+		return &instrumentedStreamingBackend{instrumentedBackend: ib, streaming: sb}
+	}
+
+	return ib
+}
+
+// Infer implements backend.Backend.
+func (b *instrumentedBackend) Infer(ctx context.Context, req *backend.Request) (*backend.Response, error) {
+	done := b.begin(req)
+
+	resp, err := b.Backend.Infer(ctx, req)
+
+	var usage *backend.Usage
+	if resp != nil && resp.Metadata != nil {
+		usage = resp.Metadata.Usage
+	}
+	done(usage, err)
+
+	return resp, err
+}
+
+// InferStream implements backend.StreamingBackend. The request is recorded once
+// the stream ends.
+func (b *instrumentedStreamingBackend) InferStream(ctx context.Context, req *backend.Request) (<-chan backend.StreamChunk, error) {
+	done := b.begin(req)
+
+	chunks, err := b.streaming.InferStream(ctx, req)
+	if err != nil {
+		done(nil, err)
+		return nil, err
+	}
+
+	out := make(chan backend.StreamChunk)
+	go func() {
+		defer close(out)
+
+		var (
+			usage  *backend.Usage
+			errEnd error
+		)
+		for chunk := range chunks {
+			if chunk.Usage != nil {
+				usage = chunk.Usage
+			}
+			if chunk.Error != nil {
+				errEnd = chunk.Error
+			}
+			out <- chunk
+		}
+
+		done(usage, errEnd)
+	}()
+
+	return out, nil
+}
+
+// begin records the start of a request and returns the function recording its end.
+func (b *instrumentedBackend) begin(req *backend.Request) func(*backend.Usage, error) {
+	provider := b.Provider()
+	service := config.BackendType(provider)
+	m := b.metrics
+
+	m.inflight.WithLabelValues(service, req.ModelID, provider).Inc()
+	start := time.Now()
+
+	return func(usage *backend.Usage, err error) {
+		elapsed := time.Since(start).Seconds()
+
+		m.inflight.WithLabelValues(service, req.ModelID, provider).Dec()
+		m.duration.WithLabelValues(service, req.ModelID, provider).Observe(elapsed)
+		m.requests.WithLabelValues(service, req.ModelID, provider, statusOf(err)).Inc()
+
+		if err == nil && usage != nil {
+			m.observeUsage(req.ModelID, usage, elapsed)
+		}
+	}
+}
+
+// observeUsage records the work reported by a backend for a successful request.
+func (m *Metrics) observeUsage(modelID string, usage *backend.Usage, elapsed float64) {
+	if usage.PromptTokens > 0 {
+		m.promptTokens.WithLabelValues(modelID).Add(float64(usage.PromptTokens))
+	}
+	if usage.GeneratedTokens > 0 {
+		m.generatedTokens.WithLabelValues(modelID).Add(float64(usage.GeneratedTokens))
+	}
+	if usage.TokensPerSecond > 0 {
+		m.tokensPerSecond.WithLabelValues(modelID).Observe(usage.TokensPerSecond)
+	}
+
+	// Characters are only reported for synthesized text, so the audio is produced.
+	if usage.Characters > 0 {
+		m.characters.WithLabelValues(modelID).Add(float64(usage.Characters))
+		if usage.AudioSeconds > 0 {
+			m.audioProduced.WithLabelValues(modelID).Add(usage.AudioSeconds)
+		}
+		return
+	}
+
+	if usage.AudioSeconds > 0 {
+		m.audioTranscribed.WithLabelValues(modelID).Add(usage.AudioSeconds)
+		m.realTimeFactor.WithLabelValues(modelID).Observe(elapsed / usage.AudioSeconds)
+	}
+}
+
+// statusOf returns the status label of a request that ended with err.
+func statusOf(err error) string {
+	switch {
+	case err == nil:
+		return StatusOK
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return StatusCanceled
+	default:
+		return StatusError
+	}
+}
@@ -0,0 +1,203 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/ju4n97/relic/internal/backend"
+	"github.com/ju4n97/relic/internal/model"
+)
+
+// namespace prefixes every metric name.
+const namespace = "relic"
+
+// Request outcomes used as the status label of inference metrics.
+const (
+	StatusOK       = "ok"
+	StatusError    = "error"
+	StatusCanceled = "canceled"
+)
+
+var (
+	// latencyBuckets covers fast API calls up to long generations, in seconds.
+	latencyBuckets = prometheus.ExponentialBuckets(0.01, 2.5, 10)
+
+	// loadBuckets covers small models starting in a fraction of a second up to
+	// large ones taking minutes, in seconds.
+	loadBuckets = prometheus.ExponentialBuckets(0.25, 2, 11)
+)
+
+// Metrics holds the Prometheus collectors exported by RELIC.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	apiRequests *prometheus.CounterVec
+	apiDuration *prometheus.HistogramVec
+
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	inflight *prometheus.GaugeVec
+
+	promptTokens     *prometheus.CounterVec
+	generatedTokens  *prometheus.CounterVec
+	tokensPerSecond  *prometheus.HistogramVec
+	audioTranscribed *prometheus.CounterVec
+	realTimeFactor   *prometheus.HistogramVec
+	characters       *prometheus.CounterVec
+	audioProduced    *prometheus.CounterVec
+
+	modelLoad     *prometheus.HistogramVec
+	downloads     *prometheus.CounterVec
+	downloadBytes *prometheus.CounterVec
+}
+
+// New creates the RELIC metrics in their own registry, along with the Go
+// runtime and process metrics.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+
+		apiRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "api_requests_total",
+			Help:      "API requests handled, by transport, operation and response code.",
+		}, []string{"transport", "operation", "code"}),
+		apiDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "api_request_duration_seconds",
+			Help:      "Time spent handling API requests, by transport and operation.",
+			Buckets:   latencyBuckets,
+		}, []string{"transport", "operation"}),
+
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "inference_requests_total",
+			Help:      "Inference requests served, by service, model, backend and status.",
+		}, []string{"service", "model", "backend", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "inference_duration_seconds",
+			Help:      "Time spent serving inference requests, including waiting for the backend to start.",
+			Buckets:   latencyBuckets,
+		}, []string{"service", "model", "backend"}),
+		inflight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "inference_requests_in_flight",
+			Help:      "Inference requests being served or waiting for their backend.",
+		}, []string{"service", "model", "backend"}),
+
+		promptTokens: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "llm_prompt_tokens_total",
+			Help:      "Prompt tokens processed by language models.",
+		}, []string{"model"}),
+		generatedTokens: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "llm_generated_tokens_total",
+			Help:      "Tokens generated by language models.",
+		}, []string{"model"}),
+		tokensPerSecond: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "llm_tokens_per_second",
+			Help:      "Generation speed reported by the backend, in tokens per second.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 10),
+		}, []string{"model"}),
+		audioTranscribed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "stt_audio_seconds_total",
+			Help:      "Seconds of audio transcribed.",
+		}, []string{"model"}),
+		realTimeFactor: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "stt_real_time_factor",
+			Help:      "Time spent transcribing divided by the duration of the audio.",
+			Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 0.75, 1, 1.5, 2, 4},
+		}, []string{"model"}),
+		characters: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tts_characters_total",
+			Help:      "Characters of text synthesized.",
+		}, []string{"model"}),
+		audioProduced: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tts_audio_seconds_total",
+			Help:      "Seconds of audio synthesized.",
+		}, []string{"model"}),
+
+		modelLoad: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "model_load_duration_seconds",
+			Help:      "Time taken by a backend server to load a model and become ready.",
+			Buckets:   loadBuckets,
+		}, []string{"backend", "model"}),
+		downloads: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "model_downloads_total",
+			Help:      "Model downloads finished, by final state.",
+		}, []string{"model", "state"}),
+		downloadBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "model_download_bytes_total",
+			Help:      "Bytes downloaded for models.",
+		}, []string{"model"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.apiRequests, m.apiDuration,
+		m.requests, m.duration, m.inflight,
+		m.promptTokens, m.generatedTokens, m.tokensPerSecond,
+		m.audioTranscribed, m.realTimeFactor,
+		m.characters, m.audioProduced,
+		m.modelLoad, m.downloads, m.downloadBytes,
+	)
+
+	return m
+}
+
+// Handler returns the HTTP handler serving the metrics in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// Registry returns the registry holding the metrics.
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// CountConfigReloads exports the number of config reloads reported by count,
+// e.g. config.Watcher.ReloadCount.
+func (m *Metrics) CountConfigReloads(count func() uint32) {
+	m.registry.MustRegister(prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "config_reloads_total",
+		Help:      "Times the config has been reloaded.",
+	}, func() float64 {
+		return float64(count())
+	}))
+}
+
+// WatchServers exports the state of the backend servers run by the manager.
+func (m *Metrics) WatchServers(sm *backend.ServerManager) {
+	m.registry.MustRegister(newServerCollector(sm))
+}
+
+// ServerStarted records the time a backend server took to load its model. It
+// matches the function expected by backend.WithOnStart.
+func (m *Metrics) ServerStarted(cfg backend.ServerConfig, elapsed time.Duration) {
+	m.modelLoad.WithLabelValues(cfg.Name, cfg.Model).Observe(elapsed.Seconds())
+}
+
+// PullFinished records a finished model download. It matches the function
+// expected by model.WithOnPullFinished.
+func (m *Metrics) PullFinished(status model.PullStatus) {
+	m.downloads.WithLabelValues(status.ModelID, string(status.State)).Inc()
+	if status.BytesDone > 0 {
+		m.downloadBytes.WithLabelValues(status.ModelID).Add(float64(status.BytesDone))
+	}
+}
@@ -0,0 +1,178 @@
+package metrics_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ju4n97/relic/internal/backend"
+	"github.com/ju4n97/relic/internal/metrics"
+	"github.com/ju4n97/relic/internal/model"
+)
+
+// fakeBackend serves every request with the configured usage and error.
+type fakeBackend struct {
+	err      error
+	usage    *backend.Usage
+	provider string
+}
+
+func (b *fakeBackend) Provider() string { return b.provider }
+
+func (b *fakeBackend) Infer(_ context.Context, _ *backend.Request) (*backend.Response, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
+	return &backend.Response{
+		Output:   bytes.NewReader(nil),
+		Metadata: &backend.ResponseMetadata{Usage: b.usage},
+	}, nil
+}
+
+func (b *fakeBackend) InferStream(_ context.Context, _ *backend.Request) (<-chan backend.StreamChunk, error) {
+	chunks := make(chan backend.StreamChunk, 2)
+	chunks <- backend.StreamChunk{Data: []byte("hi")}
+	chunks <- backend.StreamChunk{Usage: b.usage, Error: b.err, Done: true}
+	close(chunks)
+
+	return chunks, nil
+}
+
+func (b *fakeBackend) Close() error { return nil }
+
+func TestBackend_Infer(t *testing.T) {
+	m := metrics.New()
+	b := m.Backend(&fakeBackend{
+		provider: "llama.cpp",
+		usage:    &backend.Usage{PromptTokens: 5, GeneratedTokens: 20, TokensPerSecond: 40},
+	})
+
+	_, err := b.Infer(t.Context(), &backend.Request{ModelID: "qwen"})
+	require.NoError(t, err)
+
+	expected := `
+# HELP relic_inference_requests_total Inference requests served, by service, model, backend and status.
+# TYPE relic_inference_requests_total counter
+relic_inference_requests_total{backend="llama.cpp",model="qwen",service="llm",status="ok"} 1
+# HELP relic_llm_generated_tokens_total Tokens generated by language models.
+# TYPE relic_llm_generated_tokens_total counter
+relic_llm_generated_tokens_total{model="qwen"} 20
+# HELP relic_llm_prompt_tokens_total Prompt tokens processed by language models.
+# TYPE relic_llm_prompt_tokens_total counter
+relic_llm_prompt_tokens_total{model="qwen"} 5
+`
+	assert.NoError(t, testutil.GatherAndCompare(m.Registry(), strings.NewReader(expected),
+		"relic_inference_requests_total", "relic_llm_generated_tokens_total", "relic_llm_prompt_tokens_total"))
+}
+
+func TestBackend_InferStream(t *testing.T) {
+	m := metrics.New()
+	b := m.Backend(&fakeBackend{
+		provider: "llama.cpp",
+		err:      context.Canceled,
+	})
+
+	sb, ok := b.(backend.StreamingBackend)
+	require.True(t, ok, "streaming backends must stay streaming")
+
+	chunks, err := sb.InferStream(t.Context(), &backend.Request{ModelID: "qwen"})
+	require.NoError(t, err)
+	for range chunks {
+	}
+
+	expected := `
+# HELP relic_inference_requests_total Inference requests served, by service, model, backend and status.
+# TYPE relic_inference_requests_total counter
+relic_inference_requests_total{backend="llama.cpp",model="qwen",service="llm",status="canceled"} 1
+# HELP relic_inference_requests_in_flight Inference requests being served or waiting for their backend.
+# TYPE relic_inference_requests_in_flight gauge
+relic_inference_requests_in_flight{backend="llama.cpp",model="qwen",service="llm"} 0
+`
+	assert.NoError(t, testutil.GatherAndCompare(m.Registry(), strings.NewReader(expected),
+		"relic_inference_requests_total", "relic_inference_requests_in_flight"))
+}
+
+func TestBackend_Audio(t *testing.T) {
+	m := metrics.New()
+
+	stt := m.Backend(&fakeBackend{provider: "whisper.cpp", usage: &backend.Usage{AudioSeconds: 30}})
+	_, err := stt.Infer(t.Context(), &backend.Request{ModelID: "whisper"})
+	require.NoError(t, err)
+
+	tts := m.Backend(&fakeBackend{provider: "piper", usage: &backend.Usage{Characters: 12, AudioSeconds: 1.5}})
+	_, err = tts.Infer(t.Context(), &backend.Request{ModelID: "voice"})
+	require.NoError(t, err)
+
+	expected := `
+# HELP relic_stt_audio_seconds_total Seconds of audio transcribed.
+# TYPE relic_stt_audio_seconds_total counter
+relic_stt_audio_seconds_total{model="whisper"} 30
+# HELP relic_tts_audio_seconds_total Seconds of audio synthesized.
+# TYPE relic_tts_audio_seconds_total counter
+relic_tts_audio_seconds_total{model="voice"} 1.5
+# HELP relic_tts_characters_total Characters of text synthesized.
+# TYPE relic_tts_characters_total counter
+relic_tts_characters_total{model="voice"} 12
+`
+	assert.NoError(t, testutil.GatherAndCompare(m.Registry(), strings.NewReader(expected),
+		"relic_stt_audio_seconds_total", "relic_tts_audio_seconds_total", "relic_tts_characters_total"))
+	assert.Equal(t, 1, testutil.CollectAndCount(m.Registry(), "relic_stt_real_time_factor"))
+}
+
+func TestPullFinished(t *testing.T) {
+	m := metrics.New()
+	m.PullFinished(model.PullStatus{ModelID: "qwen", State: model.PullStateCompleted, BytesDone: 1024})
+
+	expected := `
+# HELP relic_model_download_bytes_total Bytes downloaded for models.
+# TYPE relic_model_download_bytes_total counter
+relic_model_download_bytes_total{model="qwen"} 1024
+# HELP relic_model_downloads_total Model downloads finished, by final state.
+# TYPE relic_model_downloads_total counter
+relic_model_downloads_total{model="qwen",state="completed"} 1
+`
+	assert.NoError(t, testutil.GatherAndCompare(m.Registry(), strings.NewReader(expected),
+		"relic_model_download_bytes_total", "relic_model_downloads_total"))
+}
+
+func TestHTTPMiddleware(t *testing.T) {
+	m := metrics.New()
+
+	router := chi.NewRouter()
+	router.Use(m.HTTPMiddleware)
+	router.Get("/models/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	for _, id := range []string{"a", "b"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/models/"+id, http.NoBody))
+	}
+
+	expected := `
+# HELP relic_api_requests_total API requests handled, by transport, operation and response code.
+# TYPE relic_api_requests_total counter
+relic_api_requests_total{code="404",operation="GET /models/{id}",transport="http"} 2
+`
+	assert.NoError(t, testutil.GatherAndCompare(m.Registry(), strings.NewReader(expected), "relic_api_requests_total"))
+}
+
+func TestCountConfigReloads(t *testing.T) {
+	m := metrics.New()
+	m.CountConfigReloads(func() uint32 { return 3 })
+
+	expected := `
+# HELP relic_config_reloads_total Times the config has been reloaded.
+# TYPE relic_config_reloads_total counter
+relic_config_reloads_total 3
+`
+	assert.NoError(t, testutil.GatherAndCompare(m.Registry(), strings.NewReader(expected), "relic_config_reloads_total"))
+}
@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ju4n97/relic/internal/backend"
+)
+
+// serverCollector exports the state of the backend servers when scraped.
+type serverCollector struct {
+	servers  *backend.ServerManager
+	up       *prometheus.Desc
+	restarts *prometheus.Desc
+	rss      *prometheus.Desc
+	inflight *prometheus.Desc
+}
+
+// newServerCollector creates a collector for the servers run by the manager.
+func newServerCollector(sm *backend.ServerManager) *serverCollector {
+	labels := []string{"backend", "port"}
+
+	return &serverCollector{
+		servers: sm,
+		up: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "backend", "up"),
+			"Whether the backend server process is running.",
+			labels, nil,
+		),
+		restarts: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "backend", "restarts_total"),
+			"Times the backend server was started again, after a crash or to change its model or launch options.",
+			labels, nil,
+		),
+		rss: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "backend", "resident_memory_bytes"),
+			"Resident memory of the backend server process.",
+			labels, nil,
+		),
+		inflight: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "backend", "requests_in_flight"),
+			"Requests being served by the backend server.",
+			labels, nil,
+		),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *serverCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.up
+	ch <- c.restarts
+	ch <- c.rss
+	ch <- c.inflight
+}
+
+// Collect implements prometheus.Collector.
+func (c *serverCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, process := range c.servers.Processes() {
+		labels := []string{process.Name, strconv.Itoa(process.Port)}
+
+		up := 0.0
+		if process.Running {
+			up = 1
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.up, prometheus.GaugeValue, up, labels...)
+		ch <- prometheus.MustNewConstMetric(c.restarts, prometheus.CounterValue, float64(process.Restarts()), labels...)
+		ch <- prometheus.MustNewConstMetric(c.inflight, prometheus.GaugeValue, float64(process.InFlight), labels...)
+
+		if !process.Running {
+			continue
+		}
+		if rss, err := residentMemory(process.PID); err == nil {
+			ch <- prometheus.MustNewConstMetric(c.rss, prometheus.GaugeValue, float64(rss), labels...)
+		}
+	}
+}
+
+// residentMemory returns the resident memory of a process in bytes. It is only
+// available where procfs is, i.e. on Linux.
+func residentMemory(pid int) (int64, error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/statm", pid))
+	if err != nil {
+		return 0, err
+	}
+
+	fields := bytes.Fields(data)
+	if len(fields) < 2 {
+		return 0, fmt.Errorf("metrics: unexpected statm format for pid %d", pid)
+	}
+
+	pages, err := strconv.ParseInt(string(fields[1]), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("metrics: failed to parse resident pages for pid %d: %w", pid, err)
+	}
+
+	return pages * int64(os.Getpagesize()), nil
+}
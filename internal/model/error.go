@@ -1,8 +1,27 @@
 package model
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // Error definitions for the model package.
 var (
-	ErrNotFound = errors.New("model not found in registry")
+	ErrNotFound        = errors.New("model not found in registry")
+	ErrUnavailable     = errors.New("model is not available")
+	ErrNotCached       = fmt.Errorf("%w: not cached locally", ErrUnavailable)
+	ErrDownloading     = fmt.Errorf("%w: still downloading", ErrUnavailable)
+	ErrFailed          = fmt.Errorf("%w: failed to load", ErrUnavailable)
+	ErrUnloading       = fmt.Errorf("%w: being unloaded", ErrUnavailable)
+	ErrOffline         = errors.New("model manager is in offline mode")
+	ErrPullNotFound    = errors.New("pull job not found")
+	ErrNotAssigned     = errors.New("model is not enabled for this service")
+	ErrNoDefault       = errors.New("no model given and the service has no default model")
+	ErrBackendMismatch = errors.New("model is served by a different backend")
+
+	ErrProfileNotFound  = errors.New("profile not available for this model")
+	ErrVariantNotCached = fmt.Errorf("%w: profile variant", ErrNotCached)
+
+	ErrNoConfig       = errors.New("no config loaded")
+	ErrPullInProgress = errors.New("model downloads are in progress")
 )
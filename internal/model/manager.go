@@ -2,116 +2,809 @@ package model
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
-	"os"
+	"maps"
+	"path/filepath"
+	"slices"
 	"sync"
+	"time"
 
+	"github.com/ju4n97/relic/internal/cache"
 	"github.com/ju4n97/relic/internal/config"
 	"github.com/ju4n97/relic/internal/config/source"
-	"github.com/ju4n97/relic/internal/envvar"
-	"github.com/ju4n97/relic/internal/xfs"
 )
 
+// finishedJobRetention is how long finished pull jobs remain queryable.
+const finishedJobRetention = time.Hour
+
 // Manager orchestrates model lifecycle for any model type.
 type Manager struct {
-	registry *Registry
-	mu       sync.RWMutex // Use RWMutex for better read concurrency
+	registry   *Registry
+	config     *config.Config
+	cache      *cache.Cache
+	onUnload   func(*Instance)
+	onPulled   func(PullStatus)
+	jobs       map[string]*PullJob // All known pull jobs, keyed by job ID
+	pulls      map[string]*PullJob // Active pull jobs, keyed by model ID
+	modelsPath string
+	mu         sync.RWMutex // Use RWMutex for better read concurrency
+	offline    bool
+}
+
+// Option is a function that configures the Manager.
+type Option func(*Manager)
+
+// WithOffline sets whether the manager runs in offline mode.
+// In offline mode no remote calls are made: models are only resolved from
+// the local cache and missing ones are reported with StatusNotCached.
+func WithOffline(offline bool) Option {
+	return func(m *Manager) {
+		m.offline = offline
+	}
+}
+
+// WithOnUnload sets a function called once a model removed from the registry
+// has finished serving its in-flight requests, e.g. to stop its backend process.
+// It is not called while another loaded model still uses the same files.
+func WithOnUnload(fn func(*Instance)) Option {
+	return func(m *Manager) {
+		m.onUnload = fn
+	}
+}
+
+// WithOnPullFinished sets a function called with the final status of every pull
+// job, e.g. to record download sizes. It must not call back into the manager.
+func WithOnPullFinished(fn func(PullStatus)) Option {
+	return func(m *Manager) {
+		m.onPulled = fn
+	}
 }
 
 // NewManager creates a new Manager instance for a given model type.
-func NewManager() *Manager {
-	return &Manager{}
+func NewManager(opts ...Option) *Manager {
+	m := &Manager{
+		registry: NewRegistry(),
+		jobs:     map[string]*PullJob{},
+		pulls:    map[string]*PullJob{},
+	}
+	m.registry.onUse = m.touch
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
 }
 
 // Registry returns the model registry.
+// The registry lives as long as the manager; reloads update it in place.
 func (m *Manager) Registry() *Registry {
+	return m.registry
+}
+
+// Config returns the config currently applied, or nil if none has been loaded.
+func (m *Manager) Config() *config.Config {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	return m.registry
+	return m.config
 }
 
-// LoadModelsFromConfig loads models from the config and updates the registry.
+// LoadModelsFromConfig applies the config to the live registry. Only the models
+// whose assignment or definition changed are touched: new ones are resolved from
+// the local cache or pulled in the background, changed ones are replaced, and
+// removed ones stop accepting requests and are unloaded once drained.
+// On error nothing is applied and the previous config keeps being served.
 func (m *Manager) LoadModelsFromConfig(ctx context.Context, cfg *config.Config) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	m.registry = NewRegistry()
-
-	assignedModels := map[string]bool{}
-	for _, model := range cfg.Services.LLM.Models {
-		assignedModels[model] = true
-	}
-	for _, model := range cfg.Services.STT.Models {
-		assignedModels[model] = true
-	}
-	for _, model := range cfg.Services.TTS.Models {
-		assignedModels[model] = true
-	}
-	for _, model := range cfg.Services.NLU.Models {
-		assignedModels[model] = true
-	}
-
-	modelsPath := resolveModelsPath(cfg)
+	modelsPath := cfg.ModelsDir()
 	if err := source.EnsureModelsDirectory(modelsPath); err != nil {
 		return fmt.Errorf("manager: failed to prepare models directory %s: %w", modelsPath, err)
 	}
 
-	loadedKeys := map[string]bool{}
-	for modelID := range assignedModels {
+	diff := config.DiffConfigs(m.config, cfg)
+
+	pending := slices.Concat(diff.AddedModels, diff.ChangedModels)
+	if m.modelsPath != "" && m.modelsPath != modelsPath {
+		// Every model lives somewhere else now.
+		pending = slices.Sorted(maps.Keys(cfg.AssignedModels()))
+	}
+
+	var (
+		updates  []*Instance
+		launches []func()
+		removed  = slices.Clone(diff.RemovedModels)
+	)
+	for _, modelID := range pending {
 		modelConfig, ok := cfg.Models[modelID]
 		if !ok {
 			slog.Warn("Model not found in config", "model_id", modelID)
+			removed = append(removed, modelID)
 			continue
 		}
 
-		modelSource, err := modelConfig.GetSource()
+		instance, launch, err := m.prepare(ctx, modelID, modelConfig, modelsPath)
 		if err != nil {
-			return fmt.Errorf("manager: failed to get model source for %s: %w", modelID, err)
+			return err
 		}
+		instance.Profiles = profilesFor(cfg, &modelConfig)
 
-		downloader, err := source.GetDownloader(ctx, modelSource.Type())
-		if err != nil {
-			return fmt.Errorf("manager: failed to get downloader for %s: %w", modelID, err)
+		updates = append(updates, instance)
+		if launch != nil {
+			launches = append(launches, launch)
 		}
+	}
 
-		downloadPath, err := downloader.Download(ctx, &modelConfig, modelsPath)
+	modelsCache := m.cache
+	if modelsCache == nil || modelsCache.Dir() != filepath.Clean(modelsPath) {
+		var err error
+		modelsCache, err = cache.Open(modelsPath)
 		if err != nil {
-			return fmt.Errorf("manager: failed to download model %s into %s: %w", modelID, modelsPath, err)
+			return fmt.Errorf("manager: failed to open models cache: %w", err)
+		}
+	}
+
+	// Everything is prepared, apply the new config.
+	if modelsCache != m.cache {
+		if err := m.closeCache(); err != nil {
+			slog.Error("Failed to flush models cache index", "error", err)
+		}
+		m.cache = modelsCache
+	}
+	m.config = cfg
+	m.modelsPath = modelsPath
+
+	for _, modelID := range slices.Concat(pending, removed) {
+		if job, ok := m.pulls[modelID]; ok {
+			job.Cancel()
+			slog.Info("Model download canceled, model configuration changed", "model_id", modelID, "job_id", job.ID())
+		}
+	}
+
+	for _, instance := range m.registry.apply(updates, removed, NewRoutes(cfg)) {
+		m.retire(instance)
+	}
+
+	for _, launch := range launches {
+		launch()
+	}
+
+	for _, instance := range updates {
+		if instance.Path != "" {
+			m.recordFiles(ctx, instance.ID, instance.Config)
+		}
+	}
+
+	m.enforceQuota(ctx)
+
+	if !diff.Empty() {
+		slog.Info("Config applied",
+			"added", diff.AddedModels,
+			"changed", diff.ChangedModels,
+			"removed", diff.RemovedModels,
+			"services_changed", diff.ServicesChanged,
+		)
+	}
+
+	return nil
+}
+
+// Pull starts downloading a configured model in the background and returns the job.
+// If a download for the model is already running, its job is returned instead.
+// A model that is already cached is left serving, and gets a completed job.
+func (m *Manager) Pull(ctx context.Context, modelID string) (*PullJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if job, ok := m.pulls[modelID]; ok {
+		return job, nil
+	}
+
+	if m.config == nil {
+		return nil, ErrNotFound
+	}
+
+	current, ok := m.registry.Get(modelID)
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	modelConfig, ok := m.config.Models[modelID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	downloader, err := downloaderFor(ctx, modelID, &modelConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if current.Path != "" {
+		if _, err := downloader.Resolve(ctx, &modelConfig, m.modelsPath); err == nil {
+			job := newPullJob(modelID, func() {})
+			job.finish(PullStateCompleted, nil)
+
+			m.pruneJobs()
+			m.jobs[job.ID()] = job
+
+			slog.Info("Model already cached, nothing to pull", "model_id", modelID, "job_id", job.ID())
+			return job, nil
+		}
+	}
+
+	if m.offline {
+		return nil, ErrOffline
+	}
+
+	instance, launch := m.newPull(modelID, modelConfig, downloader, m.modelsPath)
+	instance.Profiles = profilesFor(m.config, &modelConfig)
+	for _, previous := range m.registry.apply([]*Instance{instance}, nil, nil) {
+		m.retire(previous)
+	}
+	launch()
+
+	return instance.Job, nil
+}
+
+// PullJob returns the pull job with the given ID.
+func (m *Manager) PullJob(id string) (*PullJob, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	job, ok := m.jobs[id]
+	return job, ok
+}
+
+// PullJobs returns all known pull jobs, oldest first.
+func (m *Manager) PullJobs() []*PullJob {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	jobs := make([]*PullJob, 0, len(m.jobs))
+	for _, job := range m.jobs {
+		jobs = append(jobs, job)
+	}
+
+	slices.SortFunc(jobs, func(a, b *PullJob) int {
+		return a.status.CreatedAt.Compare(b.status.CreatedAt)
+	})
+
+	return jobs
+}
+
+// CancelPull cancels a running pull job.
+func (m *Manager) CancelPull(id string) error {
+	job, ok := m.PullJob(id)
+	if !ok {
+		return ErrPullNotFound
+	}
+
+	job.Cancel()
+	return nil
+}
+
+// CacheReport returns the disk usage of the models directory.
+func (m *Manager) CacheReport(ctx context.Context) (*cache.Report, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.cache == nil {
+		return nil, ErrNoConfig
+	}
+
+	report, err := m.cache.Report(referencedFiles(ctx, m.config, m.modelsPath))
+	if err != nil {
+		return nil, fmt.Errorf("manager: failed to build cache report: %w", err)
+	}
+	report.MaxBytes = m.config.Storage.MaxSize.Bytes()
+
+	return report, nil
+}
+
+// CollectGarbage removes every file in the models directory that no configured
+// model references. With dryRun, it only reports what would be removed.
+// It refuses to run while models are being downloaded.
+func (m *Manager) CollectGarbage(ctx context.Context, dryRun bool) (*cache.GCResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.cache == nil {
+		return nil, ErrNoConfig
+	}
+
+	if len(m.pulls) > 0 {
+		return nil, ErrPullInProgress
+	}
+
+	result, err := m.cache.GC(referencedFiles(ctx, m.config, m.modelsPath), dryRun)
+	if err != nil {
+		return nil, fmt.Errorf("manager: failed to collect garbage: %w", err)
+	}
+
+	return result, nil
+}
+
+// RemoveModel removes the cached files of a model, keeping those other models
+// use. A configured model stays in the registry as not cached, and is unloaded
+// once its in-flight requests are done. It refuses to run while the model is
+// being downloaded.
+func (m *Manager) RemoveModel(ctx context.Context, modelID string) (*cache.GCResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.cache == nil {
+		return nil, ErrNoConfig
+	}
+
+	if _, ok := m.pulls[modelID]; ok {
+		return nil, ErrPullInProgress
+	}
+
+	result, err := m.cache.Evict(modelID, referencedFiles(ctx, m.config, m.modelsPath))
+	if err != nil {
+		return nil, fmt.Errorf("manager: failed to remove model %s: %w", modelID, err)
+	}
+
+	current, configured := m.registry.Get(modelID)
+	if len(result.EvictedModels) == 0 {
+		if !configured {
+			return nil, ErrNotFound
+		}
+		return nil, ErrNotCached
+	}
+
+	if configured {
+		instance := NewModelInstance(current.Config, modelID, "")
+		instance.Profiles = current.Profiles
+		instance.SetStatus(StatusNotCached)
+		instance.SetError(errors.New("removed from cache"))
+		for _, previous := range m.registry.apply([]*Instance{instance}, nil, nil) {
+			m.retire(previous)
+		}
+	}
+
+	slog.Info("Model removed from cache",
+		"model_id", modelID,
+		"files", len(result.Removed),
+		"freed", cache.FormatBytes(result.FreedBytes),
+	)
+
+	return result, nil
+}
+
+// Close cancels all running pull jobs and flushes the cache index.
+func (m *Manager) Close() {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, job := range m.pulls {
+		job.Cancel()
+	}
+
+	if err := m.closeCache(); err != nil {
+		slog.Error("Failed to flush models cache index", "error", err)
+	}
+}
+
+// prepare builds the registry entry of a model without touching the registry.
+// Cached models are resolved right away; the others get a pull job, started by
+// the returned launch function once the entry is in the registry.
+// Callers must hold the lock.
+func (m *Manager) prepare(ctx context.Context, modelID string, modelConfig config.ModelConfig, modelsPath string) (*Instance, func(), error) {
+	downloader, err := downloaderFor(ctx, modelID, &modelConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if m.offline || !modelConfig.ForceDownload() {
+		downloadPath, err := downloader.Resolve(ctx, &modelConfig, modelsPath)
+		if err == nil {
+			slog.Info("Model resolved from local cache", "model_id", modelID, "path", downloadPath)
+			return NewModelInstance(&modelConfig, modelID, downloadPath), nil, nil
+		}
+		if !errors.Is(err, source.ErrNotCached) {
+			return nil, nil, fmt.Errorf("manager: failed to resolve model %s in %s: %w", modelID, modelsPath, err)
 		}
 
-		instance := NewModelInstance(&modelConfig, modelID, downloadPath)
-		loadedKeys[modelID] = true
+		if m.offline {
+			instance := NewModelInstance(&modelConfig, modelID, "")
+			instance.SetStatus(StatusNotCached)
+			instance.SetError(err)
+
+			slog.Warn("Model not cached, skipping in offline mode", "model_id", modelID, "error", err)
+			return instance, nil, nil
+		}
+	}
+
+	instance, launch := m.newPull(modelID, modelConfig, downloader, modelsPath)
+	return instance, launch, nil
+}
+
+// newPull creates a downloading registry entry for the model together with a
+// function that registers its pull job and runs it in the background.
+// Callers must hold the lock, and must call launch after the entry is in the registry.
+func (m *Manager) newPull(modelID string, modelConfig config.ModelConfig, downloader source.Downloader, modelsPath string) (*Instance, func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	job := newPullJob(modelID, cancel)
+
+	instance := NewModelInstance(&modelConfig, modelID, "")
+	instance.SetStatus(StatusDownloading)
+	instance.Job = job
+
+	launch := func() {
+		m.pruneJobs()
+		m.jobs[job.ID()] = job
+		m.pulls[modelID] = job
+
+		slog.Info("Model download started", "model_id", modelID, "job_id", job.ID())
+
+		go m.runPull(ctx, job, modelConfig, downloader, modelsPath)
+	}
+
+	return instance, launch
+}
+
+// retire stops an instance from accepting requests and unloads it once its
+// in-flight requests are done.
+func (m *Manager) retire(instance *Instance) {
+	drained := instance.retire()
+
+	go func() {
+		<-drained
+
+		if m.onUnload == nil || instance.Path == "" {
+			return
+		}
+
+		for _, current := range m.registry.List() {
+			if current.Path == instance.Path {
+				return // Still in use by the replacement
+			}
+		}
+
+		slog.Info("Model unloaded", "model_id", instance.ID)
+		m.onUnload(instance)
+	}()
+}
+
+// runPull downloads the model and swaps the registry entry once done.
+func (m *Manager) runPull(ctx context.Context, job *PullJob, modelConfig config.ModelConfig, downloader source.Downloader, modelsPath string) {
+	defer job.cancel()
+	defer m.enforceQuotaLocked(context.Background())
+
+	job.start()
+	downloadPath, err := downloader.Download(ctx, &modelConfig, modelsPath, job.update)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	modelID := job.ModelID()
+	if m.pulls[modelID] == job {
+		delete(m.pulls, modelID)
+	}
+
+	// The registry entry is only updated if it still belongs to this job;
+	// the model may have been removed or reloaded in the meantime.
+	current, ok := m.registry.Get(modelID)
+	owned := ok && current.Job == job
+	replace := func(instance *Instance) {
+		instance.Profiles = current.Profiles
 		m.registry.Set(instance)
-		instance.SetStatus(StatusUnloaded)
+	}
 
+	switch {
+	case err == nil:
+		if owned {
+			replace(NewModelInstance(&modelConfig, modelID, downloadPath))
+		}
+		if modelsPath == m.modelsPath {
+			m.recordFiles(ctx, modelID, &modelConfig)
+		}
+		job.finish(PullStateCompleted, nil)
 		slog.Info("Model loaded into registry", "model_id", modelID, "download_path", downloadPath)
+
+	case ctx.Err() != nil:
+		if owned {
+			instance := NewModelInstance(&modelConfig, modelID, "")
+			instance.SetStatus(StatusNotCached)
+			instance.SetError(errors.New("download canceled"))
+			replace(instance)
+		}
+		job.finish(PullStateCanceled, nil)
+		slog.Info("Model download canceled", "model_id", modelID, "job_id", job.ID())
+
+	default:
+		if owned {
+			instance := NewModelInstance(&modelConfig, modelID, "")
+			instance.SetStatus(StatusFailed)
+			instance.SetError(err)
+			replace(instance)
+		}
+		job.finish(PullStateFailed, err)
+		slog.Error("Failed to download model", "model_id", modelID, "job_id", job.ID(), "error", err)
+	}
+
+	if m.onPulled != nil {
+		m.onPulled(job.Status())
+	}
+}
+
+// touch records that a model has been used.
+func (m *Manager) touch(modelID string) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.cache != nil {
+		m.cache.Touch(modelID)
+	}
+}
+
+// recordFiles stores the local files of a model in the cache index.
+// Callers must hold the lock.
+func (m *Manager) recordFiles(ctx context.Context, modelID string, modelConfig *config.ModelConfig) {
+	downloader, err := downloaderFor(ctx, modelID, modelConfig)
+	if err != nil {
+		return
+	}
+
+	files, err := downloader.Files(ctx, modelConfig, m.modelsPath)
+	if err != nil {
+		slog.Warn("Failed to list model files", "model_id", modelID, "error", err)
+		return
+	}
+
+	m.cache.Record(modelID, files)
+}
+
+// enforceQuotaLocked acquires the lock and enforces the storage quota.
+func (m *Manager) enforceQuotaLocked(ctx context.Context) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.enforceQuota(ctx)
+}
+
+// enforceQuota evicts models that are no longer configured while the models
+// directory exceeds the configured maximum size. Callers must hold the lock.
+func (m *Manager) enforceQuota(ctx context.Context) {
+	if m.cache == nil || m.config == nil || m.config.Storage.MaxSize <= 0 {
+		return
+	}
+
+	result, err := m.cache.Enforce(m.config.Storage.MaxSize.Bytes(), referencedFiles(ctx, m.config, m.modelsPath))
+	if err != nil {
+		slog.Error("Failed to enforce storage quota", "error", err)
+		return
+	}
+
+	if len(result.EvictedModels) > 0 {
+		slog.Info("Storage quota enforced",
+			"evicted_models", result.EvictedModels,
+			"freed", cache.FormatBytes(result.FreedBytes),
+		)
+	}
+}
+
+// closeCache flushes the cache index. Callers must hold the lock.
+func (m *Manager) closeCache() error {
+	if m.cache == nil {
+		return nil
+	}
+
+	return m.cache.Flush()
+}
+
+// pruneJobs forgets finished jobs older than finishedJobRetention.
+// Callers must hold the lock.
+func (m *Manager) pruneJobs() {
+	for id, job := range m.jobs {
+		status := job.Status()
+		if status.FinishedAt != nil && time.Since(*status.FinishedAt) > finishedJobRetention {
+			delete(m.jobs, id)
+		}
+	}
+}
+
+// downloaderFor returns the downloader of the model's source.
+func downloaderFor(ctx context.Context, modelID string, modelConfig *config.ModelConfig) (source.Downloader, error) {
+	modelSource, err := modelConfig.GetSource()
+	if err != nil {
+		return nil, fmt.Errorf("manager: failed to get model source for %s: %w", modelID, err)
+	}
+
+	downloader, err := source.GetDownloader(ctx, modelSource.Type())
+	if err != nil {
+		return nil, fmt.Errorf("manager: failed to get downloader for %s: %w", modelID, err)
+	}
+
+	return downloader, nil
+}
+
+// CacheReport returns the disk usage of the models directory of the given config,
+// without loading any model. It is meant for tools running next to the server.
+func CacheReport(ctx context.Context, cfg *config.Config) (*cache.Report, error) {
+	modelsCache, modelsPath, err := openCache(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := modelsCache.Report(referencedFiles(ctx, cfg, modelsPath))
+	if err != nil {
+		return nil, fmt.Errorf("manager: failed to build cache report: %w", err)
+	}
+	report.MaxBytes = cfg.Storage.MaxSize.Bytes()
+
+	return report, nil
+}
+
+// CollectGarbage removes every file in the models directory of the given config
+// that no configured model references. With dryRun, it only reports what would be removed.
+func CollectGarbage(ctx context.Context, cfg *config.Config, dryRun bool) (*cache.GCResult, error) {
+	modelsCache, modelsPath, err := openCache(cfg)
+	if err != nil {
+		return nil, err
 	}
 
-	// Delete unloaded models from the registry (if any)
-	current := m.registry.List()
-	for _, instance := range current {
-		if !loadedKeys[instance.ID] {
-			m.registry.Delete(instance.ID)
-			slog.Info("Model unloaded successfully", "model_entry", instance.ID)
+	result, err := modelsCache.GC(referencedFiles(ctx, cfg, modelsPath), dryRun)
+	if err != nil {
+		return nil, fmt.Errorf("manager: failed to collect garbage: %w", err)
+	}
+
+	return result, nil
+}
+
+// RemoveModel removes the cached files of a model of the given config, keeping
+// those other models use. It is meant for tools running next to the server.
+func RemoveModel(ctx context.Context, cfg *config.Config, modelID string) (*cache.GCResult, error) {
+	modelsCache, modelsPath, err := openCache(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := modelsCache.Evict(modelID, referencedFiles(ctx, cfg, modelsPath))
+	if err != nil {
+		return nil, fmt.Errorf("manager: failed to remove model %s: %w", modelID, err)
+	}
+
+	if len(result.EvictedModels) == 0 {
+		if _, ok := cfg.Models[modelID]; !ok {
+			return nil, ErrNotFound
 		}
+		return nil, ErrNotCached
 	}
 
-	return nil
+	return result, nil
+}
+
+// Pull downloads a model of the given config in the foreground, calling
+// onProgress with its status as the download goes, and records its files in the
+// cache index. It returns the final status. It is meant for tools running next
+// to the server; onProgress may be nil.
+func Pull(ctx context.Context, cfg *config.Config, modelID string, onProgress func(PullStatus)) (PullStatus, error) {
+	modelConfig, ok := cfg.Models[modelID]
+	if !ok {
+		return PullStatus{}, ErrNotFound
+	}
+
+	downloader, err := downloaderFor(ctx, modelID, &modelConfig)
+	if err != nil {
+		return PullStatus{}, err
+	}
+
+	modelsPath := cfg.ModelsDir()
+	if err := source.EnsureModelsDirectory(modelsPath); err != nil {
+		return PullStatus{}, fmt.Errorf("manager: failed to prepare models directory %s: %w", modelsPath, err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	job := newPullJob(modelID, cancel)
+	defer job.cancel()
+
+	updates, unsubscribe := job.Subscribe()
+	defer unsubscribe()
+
+	forwarded := make(chan struct{})
+	go func() {
+		defer close(forwarded)
+		for status := range updates {
+			if onProgress != nil {
+				onProgress(status)
+			}
+		}
+	}()
+
+	job.start()
+	_, err = downloader.Download(ctx, &modelConfig, modelsPath, job.update)
+	switch {
+	case err == nil:
+		job.finish(PullStateCompleted, nil)
+	case ctx.Err() != nil:
+		job.finish(PullStateCanceled, nil)
+		err = ctx.Err()
+	default:
+		job.finish(PullStateFailed, err)
+		err = fmt.Errorf("manager: failed to download model %s: %w", modelID, err)
+	}
+	<-forwarded
+
+	if err != nil {
+		return job.Status(), err
+	}
+
+	modelsCache, _, err := openCache(cfg)
+	if err != nil {
+		return job.Status(), err
+	}
+
+	files, err := downloader.Files(ctx, &modelConfig, modelsPath)
+	if err != nil {
+		return job.Status(), fmt.Errorf("manager: failed to list model files: %w", err)
+	}
+	modelsCache.Record(modelID, files)
+
+	if err := modelsCache.Flush(); err != nil {
+		return job.Status(), fmt.Errorf("manager: failed to flush models cache index: %w", err)
+	}
+
+	return job.Status(), nil
 }
 
-// resolveModelsPath returns the path to the models directory.
-// Precedence:
-// 1. RELIC_MODELS_PATH environment variable.
-// 2. ModelsDir field in the config.
-// 3. Default models path.
-func resolveModelsPath(cfg *config.Config) string {
-	if p := os.Getenv(envvar.RelicModelsPath); p != "" {
-		return xfs.ExpandTilde(p)
+// Verify checks that the cached files of a model of the given config are
+// complete. It returns an error wrapping source.ErrNotCached when the model is
+// not cached and source.ErrCorrupt when its files were deleted or truncated. It
+// is meant for tools running next to the server.
+func Verify(ctx context.Context, cfg *config.Config, modelID string) error {
+	modelConfig, ok := cfg.Models[modelID]
+	if !ok {
+		return ErrNotFound
 	}
-	if cfg.Storage.ModelsDir != "" {
-		return xfs.ExpandTilde(cfg.Storage.ModelsDir)
+
+	downloader, err := downloaderFor(ctx, modelID, &modelConfig)
+	if err != nil {
+		return err
 	}
-	return xfs.ExpandTilde(config.DefaultModelsPath())
+
+	return downloader.Verify(ctx, &modelConfig, cfg.ModelsDir())
+}
+
+// openCache opens the models cache of the given config.
+func openCache(cfg *config.Config) (*cache.Cache, string, error) {
+	modelsPath := cfg.ModelsDir()
+
+	modelsCache, err := cache.Open(modelsPath)
+	if err != nil {
+		return nil, "", fmt.Errorf("manager: failed to open models cache: %w", err)
+	}
+
+	return modelsCache, modelsPath, nil
+}
+
+// referencedFiles returns the local files of every model defined in the config.
+// Models that are not cached are still listed, with no files.
+func referencedFiles(ctx context.Context, cfg *config.Config, modelsPath string) map[string][]string {
+	referenced := make(map[string][]string, len(cfg.Models))
+	for modelID, modelConfig := range cfg.Models {
+		referenced[modelID] = nil
+
+		downloader, err := downloaderFor(ctx, modelID, &modelConfig)
+		if err != nil {
+			continue
+		}
+
+		files, err := downloader.Files(ctx, &modelConfig, modelsPath)
+		if err != nil {
+			if !errors.Is(err, source.ErrNotCached) {
+				slog.Warn("Failed to list model files", "model_id", modelID, "error", err)
+			}
+			continue
+		}
+
+		referenced[modelID] = files
+	}
+
+	return referenced
 }
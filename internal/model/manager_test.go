@@ -0,0 +1,186 @@
+package model_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ju4n97/relic/internal/config"
+	"github.com/ju4n97/relic/internal/model"
+)
+
+// newCachedConfig returns a config whose models are all present in modelsDir.
+func newCachedConfig(t *testing.T, modelsDir string, models ...string) *config.Config {
+	t.Helper()
+
+	cfg := &config.Config{
+		Storage: config.StorageConfig{ModelsDir: modelsDir},
+		Models:  map[string]config.ModelConfig{},
+	}
+
+	for _, modelID := range models {
+		path := filepath.Join(modelsDir, "org", modelID, modelID+".gguf")
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(modelID), 0o644))
+
+		modelConfig := config.ModelConfig{Type: "llm", Backend: "llama.cpp"}
+		modelConfig.SetHuggingFaceSource(config.HuggingFaceSource{
+			Repo:    "org/" + modelID,
+			Include: []string{modelID + ".gguf"},
+		})
+		cfg.Models[modelID] = modelConfig
+		cfg.Services.LLM.Models = append(cfg.Services.LLM.Models, modelID)
+	}
+
+	return cfg
+}
+
+func TestManager_LoadModelsFromConfig_Incremental(t *testing.T) {
+	modelsDir := t.TempDir()
+	t.Setenv("RELIC_MODELS_PATH", "")
+
+	unloaded := make(chan string, 4)
+	manager := model.NewManager(
+		model.WithOffline(true),
+		model.WithOnUnload(func(instance *model.Instance) {
+			unloaded <- instance.ID
+		}),
+	)
+	defer manager.Close()
+
+	ctx := context.Background()
+	registry := manager.Registry()
+
+	require.NoError(t, manager.LoadModelsFromConfig(ctx, newCachedConfig(t, modelsDir, "a", "b")))
+
+	a, err := registry.Acquire(model.TypeLLM, "a")
+	require.NoError(t, err)
+	b, ok := registry.Get("b")
+	require.True(t, ok)
+
+	// Remove a, keep b, add c.
+	next := newCachedConfig(t, modelsDir, "b", "c")
+	require.NoError(t, manager.LoadModelsFromConfig(ctx, next))
+
+	assert.Same(t, registry, manager.Registry(), "the live registry is updated in place")
+
+	_, ok = registry.Get("a")
+	assert.False(t, ok)
+	_, err = registry.Acquire(model.TypeLLM, "a")
+	require.ErrorIs(t, err, model.ErrNotAssigned)
+
+	current, ok := registry.Get("b")
+	require.True(t, ok)
+	assert.Same(t, b, current, "unchanged models are not reloaded")
+
+	_, ok = registry.Get("c")
+	assert.True(t, ok)
+
+	select {
+	case id := <-unloaded:
+		t.Fatalf("model %s unloaded while serving a request", id)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	a.Release()
+	select {
+	case id := <-unloaded:
+		assert.Equal(t, "a", id)
+	case <-time.After(time.Second):
+		t.Fatal("model a was not unloaded after draining")
+	}
+
+	// A config that cannot be applied leaves the previous one in place.
+	broken := newCachedConfig(t, modelsDir, "b", "c")
+	broken.Models["d"] = config.ModelConfig{Type: "llm", Backend: "llama.cpp"}
+	broken.Services.LLM.Models = append(broken.Services.LLM.Models, "d")
+
+	require.Error(t, manager.LoadModelsFromConfig(ctx, broken))
+	assert.Same(t, next, manager.Config())
+	_, ok = registry.Get("d")
+	assert.False(t, ok)
+}
+
+func TestManager_RemoveModel(t *testing.T) {
+	modelsDir := t.TempDir()
+	t.Setenv("RELIC_MODELS_PATH", "")
+
+	unloaded := make(chan string, 1)
+	manager := model.NewManager(
+		model.WithOffline(true),
+		model.WithOnUnload(func(instance *model.Instance) {
+			unloaded <- instance.ID
+		}),
+	)
+	defer manager.Close()
+
+	ctx := context.Background()
+	require.NoError(t, manager.LoadModelsFromConfig(ctx, newCachedConfig(t, modelsDir, "a", "b")))
+
+	result, err := manager.RemoveModel(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"org/a/a.gguf"}, result.Removed)
+	assert.NoFileExists(t, filepath.Join(modelsDir, "org", "a", "a.gguf"))
+
+	instance, ok := manager.Registry().Get("a")
+	require.True(t, ok, "configured models stay in the registry")
+	assert.Equal(t, model.StatusNotCached, instance.Status)
+
+	select {
+	case id := <-unloaded:
+		assert.Equal(t, "a", id)
+	case <-time.After(time.Second):
+		t.Fatal("removed model was not unloaded")
+	}
+
+	_, err = manager.RemoveModel(ctx, "a")
+	require.ErrorIs(t, err, model.ErrNotCached)
+
+	_, err = manager.RemoveModel(ctx, "missing")
+	require.ErrorIs(t, err, model.ErrNotFound)
+}
+
+func TestManager_Pull_Cached(t *testing.T) {
+	modelsDir := t.TempDir()
+	t.Setenv("RELIC_MODELS_PATH", "")
+
+	unloaded := make(chan string, 1)
+	manager := model.NewManager(
+		model.WithOffline(true),
+		model.WithOnUnload(func(instance *model.Instance) {
+			unloaded <- instance.ID
+		}),
+	)
+	defer manager.Close()
+
+	ctx := context.Background()
+	require.NoError(t, manager.LoadModelsFromConfig(ctx, newCachedConfig(t, modelsDir, "a")))
+
+	serving, err := manager.Registry().Acquire(model.TypeLLM, "a")
+	require.NoError(t, err)
+	defer serving.Release()
+
+	job, err := manager.Pull(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, model.PullStateCompleted, job.Status().State)
+
+	found, ok := manager.PullJob(job.ID())
+	require.True(t, ok)
+	assert.Same(t, job, found)
+
+	current, ok := manager.Registry().Get("a")
+	require.True(t, ok)
+	assert.Same(t, serving, current, "a cached model keeps serving")
+	require.NoError(t, current.Available())
+
+	select {
+	case id := <-unloaded:
+		t.Fatalf("model %s unloaded by a pull of a cached model", id)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
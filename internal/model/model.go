@@ -1,6 +1,7 @@
 package model
 
 import (
+	"sync"
 	"time"
 
 	"github.com/ju4n97/relic/internal/config"
@@ -47,16 +48,30 @@ const (
 
 	// StatusUnloading indicates that the model is being unloaded.
 	StatusUnloading Status = "unloading"
+
+	// StatusDownloading indicates that the model files are being downloaded.
+	StatusDownloading Status = "downloading"
+
+	// StatusNotCached indicates that the model files are not available locally
+	// and could not be downloaded (e.g. in offline mode).
+	StatusNotCached Status = "not_cached"
 )
 
 // Instance represents a loaded model instance.
 type Instance struct {
 	Config   *config.ModelConfig `json:"config"`
 	LoadedAt *time.Time          `json:"loaded_at,omitempty"`
+	Job      *PullJob            `json:"-"`
+	Profiles []Profile           `json:"profiles,omitempty"` // The first one is the default
 	ID       string              `json:"id"`
 	Path     string              `json:"-"`
 	Status   Status              `json:"status"`
 	Error    string              `json:"error,omitempty"`
+
+	drained  chan struct{} // Closed once retired and no request is in flight
+	mu       sync.Mutex
+	inflight int
+	retired  bool
 }
 
 // NewModelInstance creates a new model instance.
@@ -82,3 +97,73 @@ func (mi *Instance) SetStatus(status Status) {
 func (mi *Instance) SetError(err error) {
 	mi.Error = err.Error()
 }
+
+// Available returns an error if the model cannot serve requests in its current status.
+func (mi *Instance) Available() error {
+	switch mi.Status {
+	case StatusNotCached:
+		return ErrNotCached
+	case StatusDownloading:
+		return ErrDownloading
+	case StatusFailed:
+		return ErrFailed
+	default:
+		return nil
+	}
+}
+
+// acquire registers a request served by the instance.
+// It returns false if the instance has been retired.
+func (mi *Instance) acquire() bool {
+	mi.mu.Lock()
+	defer mi.mu.Unlock()
+
+	if mi.retired {
+		return false
+	}
+
+	mi.inflight++
+	return true
+}
+
+// Release marks the end of a request started with Registry.Acquire.
+func (mi *Instance) Release() {
+	mi.mu.Lock()
+	defer mi.mu.Unlock()
+
+	if mi.inflight == 0 {
+		return
+	}
+
+	mi.inflight--
+	if mi.retired && mi.inflight == 0 {
+		close(mi.drained)
+	}
+}
+
+// InFlight returns the number of requests currently served by the instance.
+func (mi *Instance) InFlight() int {
+	mi.mu.Lock()
+	defer mi.mu.Unlock()
+
+	return mi.inflight
+}
+
+// retire stops the instance from accepting new requests. The returned channel
+// is closed once every in-flight request has been released.
+func (mi *Instance) retire() <-chan struct{} {
+	mi.mu.Lock()
+	defer mi.mu.Unlock()
+
+	if mi.retired {
+		return mi.drained
+	}
+
+	mi.retired = true
+	mi.drained = make(chan struct{})
+	if mi.inflight == 0 {
+		close(mi.drained)
+	}
+
+	return mi.drained
+}
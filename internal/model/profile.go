@@ -0,0 +1,76 @@
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/ju4n97/relic/internal/config"
+)
+
+// Profile is a named set of backend launch options a model can be served with.
+type Profile struct {
+	Name string `json:"name"`
+	config.ProfileConfig
+}
+
+// profilesFor returns the profiles a model can be served with, in the order the
+// model lists them.
+func profilesFor(cfg *config.Config, modelConfig *config.ModelConfig) []Profile {
+	profiles := make([]Profile, 0, len(modelConfig.Profiles))
+	for _, name := range modelConfig.Profiles {
+		if profileConfig, ok := cfg.Profiles[name]; ok {
+			profiles = append(profiles, Profile{Name: name, ProfileConfig: profileConfig})
+		}
+	}
+
+	return profiles
+}
+
+// Profile returns the profile a request asked for. An empty name selects the
+// default profile, the first one listed for the model, or nil if the model has none.
+func (mi *Instance) Profile(name string) (*Profile, error) {
+	if name == "" {
+		if len(mi.Profiles) == 0 {
+			return nil, nil
+		}
+		return &mi.Profiles[0], nil
+	}
+
+	for i := range mi.Profiles {
+		if mi.Profiles[i].Name == name {
+			return &mi.Profiles[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s has no profile %q", ErrProfileNotFound, mi.ID, name)
+}
+
+// VariantPath returns the model file to serve for a profile. The variant pattern
+// is matched against the files of the model directory; without a variant the
+// model path is used. When several files match, the first one by name is used.
+func (mi *Instance) VariantPath(profile *Profile) (string, error) {
+	if profile == nil || profile.Variant == "" {
+		return mi.Path, nil
+	}
+
+	dir := mi.Path
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+
+	matches, err := filepath.Glob(filepath.Join(dir, profile.Variant))
+	if err != nil {
+		return "", fmt.Errorf("model: invalid variant pattern %q of profile %s: %w", profile.Variant, profile.Name, err)
+	}
+	slices.Sort(matches)
+
+	for _, match := range matches {
+		if info, err := os.Stat(match); err == nil && !info.IsDir() {
+			return match, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: no file of %s matches %q (profile %s)", ErrVariantNotCached, mi.ID, profile.Variant, profile.Name)
+}
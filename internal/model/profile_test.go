@@ -0,0 +1,56 @@
+package model_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ju4n97/relic/internal/config"
+	"github.com/ju4n97/relic/internal/model"
+)
+
+func TestInstance_Profile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for _, name := range []string{"qwen-q4_k_m.gguf", "qwen-q8_0.gguf"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("gguf"), 0o644))
+	}
+
+	instance := model.NewModelInstance(&config.ModelConfig{Type: "llm"}, "qwen", filepath.Join(dir, "qwen-q4_k_m.gguf"))
+	instance.Profiles = []model.Profile{
+		{Name: "cpu-fast", ProfileConfig: config.ProfileConfig{Variant: "*q4_k_m.gguf", Threads: 8}},
+		{Name: "cpu-accurate", ProfileConfig: config.ProfileConfig{Variant: "*q8_0.gguf"}},
+		{Name: "missing", ProfileConfig: config.ProfileConfig{Variant: "*f16.gguf"}},
+	}
+
+	profile, err := instance.Profile("")
+	require.NoError(t, err)
+	assert.Equal(t, "cpu-fast", profile.Name, "the first profile is the default")
+
+	profile, err = instance.Profile("cpu-accurate")
+	require.NoError(t, err)
+
+	path, err := instance.VariantPath(profile)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "qwen-q8_0.gguf"), path, "variants are looked up next to the model file")
+
+	path, err = instance.VariantPath(nil)
+	require.NoError(t, err)
+	assert.Equal(t, instance.Path, path)
+
+	profile, err = instance.Profile("missing")
+	require.NoError(t, err)
+	_, err = instance.VariantPath(profile)
+	require.ErrorIs(t, err, model.ErrNotCached)
+
+	_, err = instance.Profile("gpu")
+	require.ErrorIs(t, err, model.ErrProfileNotFound)
+
+	profile, err = model.NewModelInstance(&config.ModelConfig{}, "plain", dir).Profile("")
+	require.NoError(t, err)
+	assert.Nil(t, profile, "models without profiles are served with the backend defaults")
+}
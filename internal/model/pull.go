@@ -0,0 +1,229 @@
+package model
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/ju4n97/relic/internal/config/source"
+)
+
+// PullState is the state of a pull job.
+type PullState string
+
+const (
+	// PullStateQueued indicates that the job has been created but not started.
+	PullStateQueued PullState = "queued"
+
+	// PullStateRunning indicates that the model is being downloaded.
+	PullStateRunning PullState = "running"
+
+	// PullStateCompleted indicates that the model was downloaded successfully.
+	PullStateCompleted PullState = "completed"
+
+	// PullStateFailed indicates that the download failed.
+	PullStateFailed PullState = "failed"
+
+	// PullStateCanceled indicates that the download was canceled.
+	PullStateCanceled PullState = "canceled"
+)
+
+// Terminal reports whether the state is final.
+func (s PullState) Terminal() bool {
+	return s == PullStateCompleted || s == PullStateFailed || s == PullStateCanceled
+}
+
+// PullStatus is a point-in-time snapshot of a pull job.
+type PullStatus struct {
+	CreatedAt       time.Time             `json:"created_at"`
+	StartedAt       *time.Time            `json:"started_at,omitempty"`
+	FinishedAt      *time.Time            `json:"finished_at,omitempty"`
+	ID              string                `json:"id"`
+	ModelID         string                `json:"model_id"`
+	State           PullState             `json:"state"`
+	Error           string                `json:"error,omitempty"`
+	Files           []source.FileProgress `json:"files"`
+	BytesDone       int64                 `json:"bytes_done"`
+	BytesTotal      int64                 `json:"bytes_total"`
+	RateBytesPerSec float64               `json:"rate_bytes_per_second"`
+	ETASeconds      float64               `json:"eta_seconds"`
+}
+
+// PullJob tracks the download of a single model.
+type PullJob struct {
+	cancel      context.CancelFunc
+	done        chan struct{}
+	subscribers map[chan PullStatus]struct{}
+	lastSample  time.Time
+	status      PullStatus
+	mu          sync.RWMutex
+}
+
+// newPullJob creates a queued pull job for the given model.
+func newPullJob(modelID string, cancel context.CancelFunc) *PullJob {
+	return &PullJob{
+		cancel:      cancel,
+		done:        make(chan struct{}),
+		subscribers: map[chan PullStatus]struct{}{},
+		status: PullStatus{
+			ID:        newJobID(),
+			ModelID:   modelID,
+			State:     PullStateQueued,
+			Files:     []source.FileProgress{},
+			CreatedAt: time.Now(),
+		},
+	}
+}
+
+// ID returns the job ID.
+func (j *PullJob) ID() string {
+	return j.status.ID
+}
+
+// ModelID returns the ID of the model being pulled.
+func (j *PullJob) ModelID() string {
+	return j.status.ModelID
+}
+
+// Status returns a snapshot of the job status.
+func (j *PullJob) Status() PullStatus {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	return j.snapshot()
+}
+
+// Done returns a channel that is closed once the job reaches a terminal state.
+func (j *PullJob) Done() <-chan struct{} {
+	return j.done
+}
+
+// Cancel cancels the job. It is a no-op if the job already finished.
+func (j *PullJob) Cancel() {
+	j.cancel()
+}
+
+// Subscribe returns a channel receiving status updates and a function to unsubscribe.
+// The current status is delivered immediately; the channel is closed once the job
+// reaches a terminal state. Slow subscribers may miss intermediate updates.
+func (j *PullJob) Subscribe() (<-chan PullStatus, func()) {
+	ch := make(chan PullStatus, 16)
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	ch <- j.snapshot()
+	if j.status.State.Terminal() {
+		close(ch)
+		return ch, func() {}
+	}
+
+	j.subscribers[ch] = struct{}{}
+
+	return ch, func() {
+		j.mu.Lock()
+		defer j.mu.Unlock()
+
+		if _, ok := j.subscribers[ch]; ok {
+			delete(j.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// start marks the job as running.
+func (j *PullJob) start() {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := time.Now()
+	j.status.State = PullStateRunning
+	j.status.StartedAt = &now
+	j.lastSample = now
+	j.broadcast()
+}
+
+// update records a progress sample and derives the transfer rate and ETA.
+func (j *PullJob) update(progress source.Progress) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := time.Now()
+	if elapsed := now.Sub(j.lastSample).Seconds(); elapsed > 0 {
+		delta := float64(progress.BytesDone - j.status.BytesDone)
+		if delta < 0 {
+			delta = 0
+		}
+
+		// Exponential moving average smooths out bursty hf CLI writes.
+		const alpha = 0.3
+		rate := delta / elapsed
+		if j.status.RateBytesPerSec == 0 {
+			j.status.RateBytesPerSec = rate
+		} else {
+			j.status.RateBytesPerSec = alpha*rate + (1-alpha)*j.status.RateBytesPerSec
+		}
+	}
+	j.lastSample = now
+
+	j.status.Files = progress.Files
+	j.status.BytesDone = progress.BytesDone
+	j.status.BytesTotal = progress.BytesTotal
+
+	j.status.ETASeconds = 0
+	if remaining := progress.BytesTotal - progress.BytesDone; remaining > 0 && j.status.RateBytesPerSec > 0 {
+		j.status.ETASeconds = float64(remaining) / j.status.RateBytesPerSec
+	}
+
+	j.broadcast()
+}
+
+// finish moves the job into a terminal state and closes all subscriptions.
+func (j *PullJob) finish(state PullState, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := time.Now()
+	j.status.State = state
+	j.status.FinishedAt = &now
+	j.status.RateBytesPerSec = 0
+	j.status.ETASeconds = 0
+	if err != nil {
+		j.status.Error = err.Error()
+	}
+
+	j.broadcast()
+	for ch := range j.subscribers {
+		close(ch)
+		delete(j.subscribers, ch)
+	}
+	close(j.done)
+}
+
+// broadcast sends the current status to all subscribers without blocking.
+// Callers must hold the lock.
+func (j *PullJob) broadcast() {
+	status := j.snapshot()
+	for ch := range j.subscribers {
+		select {
+		case ch <- status:
+		default:
+		}
+	}
+}
+
+// snapshot copies the status. Callers must hold the lock.
+func (j *PullJob) snapshot() PullStatus {
+	status := j.status
+	status.Files = append([]source.FileProgress(nil), j.status.Files...)
+	return status
+}
+
+// newJobID returns a random job identifier.
+func newJobID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,109 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ju4n97/relic/internal/config/source"
+)
+
+func TestPullJob_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	job := newPullJob("model-a", func() {})
+	assert.Equal(t, PullStateQueued, job.Status().State)
+
+	updates, unsubscribe := job.Subscribe()
+	defer unsubscribe()
+
+	initial := <-updates
+	assert.Equal(t, "model-a", initial.ModelID)
+
+	job.start()
+	assert.Equal(t, PullStateRunning, (<-updates).State)
+
+	job.lastSample = time.Now().Add(-time.Second)
+	job.update(source.Progress{
+		BytesDone:  50,
+		BytesTotal: 150,
+		Files: []source.FileProgress{
+			{Path: "model.gguf", State: source.FileStateDownloading, BytesDone: 50, BytesTotal: 150},
+		},
+	})
+
+	progress := <-updates
+	assert.Equal(t, int64(50), progress.BytesDone)
+	assert.Equal(t, int64(150), progress.BytesTotal)
+	assert.Greater(t, progress.RateBytesPerSec, 0.0)
+	assert.Greater(t, progress.ETASeconds, 0.0)
+	require.Len(t, progress.Files, 1)
+
+	job.finish(PullStateCompleted, nil)
+
+	final := <-updates
+	assert.Equal(t, PullStateCompleted, final.State)
+	assert.NotNil(t, final.FinishedAt)
+
+	_, ok := <-updates
+	assert.False(t, ok, "subscription should be closed once the job finishes")
+
+	select {
+	case <-job.Done():
+	default:
+		t.Fatal("done channel should be closed")
+	}
+}
+
+func TestPullJob_SubscribeAfterFinish(t *testing.T) {
+	t.Parallel()
+
+	job := newPullJob("model-a", func() {})
+	job.start()
+	job.finish(PullStateFailed, errors.New("boom"))
+
+	updates, unsubscribe := job.Subscribe()
+	defer unsubscribe()
+
+	status, ok := <-updates
+	require.True(t, ok)
+	assert.Equal(t, PullStateFailed, status.State)
+	assert.Equal(t, "boom", status.Error)
+
+	_, ok = <-updates
+	assert.False(t, ok)
+}
+
+func TestInstance_Available(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		want   error
+		status Status
+	}{
+		{status: StatusUnloaded, want: nil},
+		{status: StatusLoaded, want: nil},
+		{status: StatusNotCached, want: ErrNotCached},
+		{status: StatusDownloading, want: ErrDownloading},
+		{status: StatusFailed, want: ErrFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			t.Parallel()
+
+			instance := &Instance{Status: tt.status}
+			err := instance.Available()
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, tt.want)
+			assert.ErrorIs(t, err, ErrUnavailable)
+		})
+	}
+}
@@ -1,19 +1,113 @@
 package model
 
 import (
+	"fmt"
+	"slices"
 	"sync"
+
+	"github.com/ju4n97/relic/internal/config"
 )
 
 // Registry stores loaded model instances.
 type Registry struct {
 	models map[string]*Instance
+	routes *Routes
+	onUse  func(id string) // Called when a model is used to serve a request, may be nil
 	mu     sync.RWMutex
 }
 
+// Routes maps the model names used in requests to the models each service may serve.
+type Routes struct {
+	services map[Type]route
+	aliases  map[string]string // Alias to model ID
+}
+
+// route lists the models enabled for a service.
+type route struct {
+	models       map[string]bool
+	defaultModel string
+}
+
+// NewRoutes builds the request routes of a config.
+// The default model of a service is its configured default, or its first model.
+func NewRoutes(cfg *config.Config) *Routes {
+	routes := &Routes{
+		services: map[Type]route{},
+		aliases:  map[string]string{},
+	}
+
+	for modelID, modelConfig := range cfg.Models {
+		for _, alias := range modelConfig.Aliases {
+			routes.aliases[alias] = modelID
+		}
+	}
+
+	for service, assignment := range cfg.ServiceAssignments() {
+		r := route{models: map[string]bool{}}
+		for _, modelID := range assignment.Models {
+			r.models[modelID] = true
+		}
+
+		switch {
+		case assignment.Default != "":
+			r.defaultModel = cfg.ResolveAlias(assignment.Default)
+		case len(assignment.Models) > 0:
+			r.defaultModel = assignment.Models[0]
+		}
+
+		routes.services[Type(service)] = r
+	}
+
+	return routes
+}
+
+// Resolve returns the ID of the model a request for the given service should use.
+// name may be a model ID, an alias, or empty to use the service default.
+func (rt *Routes) Resolve(service Type, name string) (string, error) {
+	r := rt.services[service]
+
+	if name == "" {
+		if r.defaultModel == "" {
+			return "", fmt.Errorf("%w: %s", ErrNoDefault, service)
+		}
+		return r.defaultModel, nil
+	}
+
+	modelID := name
+	if target, ok := rt.aliases[name]; ok {
+		modelID = target
+	}
+
+	if !r.models[modelID] {
+		return "", fmt.Errorf("%w: %s is not enabled for %s", ErrNotAssigned, name, service)
+	}
+
+	return modelID, nil
+}
+
+// Default returns the default model of a service, or "" if it has none.
+func (rt *Routes) Default(service Type) string {
+	return rt.services[service].defaultModel
+}
+
+// Aliases returns the aliases of a model.
+func (rt *Routes) Aliases(modelID string) []string {
+	var aliases []string
+	for alias, target := range rt.aliases {
+		if target == modelID {
+			aliases = append(aliases, alias)
+		}
+	}
+
+	slices.Sort(aliases)
+	return aliases
+}
+
 // NewRegistry creates a new model registry.
 func NewRegistry() *Registry {
 	return &Registry{
 		models: map[string]*Instance{},
+		routes: NewRoutes(&config.Config{}),
 	}
 }
 
@@ -34,6 +128,72 @@ func (r *Registry) Get(id string) (*Instance, bool) {
 	return instance, ok
 }
 
+// Acquire returns the model a service should use for a request if it can serve
+// requests, and records that it has been used. name may be a model ID, an alias,
+// or empty to use the service default. Callers must call Release on the instance
+// once the request is done, so the model can be drained when it is unloaded.
+func (r *Registry) Acquire(service Type, name string) (*Instance, error) {
+	modelID, err := r.Routes().Resolve(service, name)
+	if err != nil {
+		return nil, err
+	}
+
+	instance, ok := r.Get(modelID)
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	if err := instance.Available(); err != nil {
+		return nil, err
+	}
+
+	if !instance.acquire() {
+		return nil, ErrUnloading
+	}
+
+	if r.onUse != nil {
+		r.onUse(modelID)
+	}
+
+	return instance, nil
+}
+
+// Routes returns the current request routes.
+func (r *Registry) Routes() *Routes {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.routes
+}
+
+// apply atomically sets and deletes model instances and, if routes is not nil,
+// replaces the request routes. It returns the instances that were replaced or deleted.
+func (r *Registry) apply(set []*Instance, remove []string, routes *Routes) []*Instance {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if routes != nil {
+		r.routes = routes
+	}
+
+	var previous []*Instance
+	for _, instance := range set {
+		if old, ok := r.models[instance.ID]; ok && old != instance {
+			previous = append(previous, old)
+		}
+		r.models[instance.ID] = instance
+	}
+
+	for _, id := range remove {
+		if old, ok := r.models[id]; ok {
+			previous = append(previous, old)
+			delete(r.models, id)
+		}
+	}
+
+	return previous
+}
+
 // List returns all model instances.
 func (r *Registry) List() []*Instance {
 	r.mu.RLock()
@@ -0,0 +1,47 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ju4n97/relic/internal/config"
+	"github.com/ju4n97/relic/internal/model"
+)
+
+func TestRoutes_Resolve(t *testing.T) {
+	t.Parallel()
+
+	routes := model.NewRoutes(&config.Config{
+		Models: map[string]config.ModelConfig{
+			"qwen":    {Type: "llm", Aliases: []string{"chat"}},
+			"phi":     {Type: "llm"},
+			"whisper": {Type: "stt"},
+		},
+		Services: config.ServicesConfig{
+			LLM: config.ServicesConfigAssignment{Models: []string{"phi", "qwen"}, Default: "chat"},
+			STT: config.ServicesConfigAssignment{Models: []string{"whisper"}},
+		},
+	})
+
+	modelID, err := routes.Resolve(model.TypeLLM, "chat")
+	require.NoError(t, err)
+	assert.Equal(t, "qwen", modelID, "aliases resolve to their model")
+
+	modelID, err = routes.Resolve(model.TypeLLM, "")
+	require.NoError(t, err)
+	assert.Equal(t, "qwen", modelID, "the configured default is used when no model is given")
+
+	modelID, err = routes.Resolve(model.TypeSTT, "")
+	require.NoError(t, err)
+	assert.Equal(t, "whisper", modelID, "the first model is the default otherwise")
+
+	_, err = routes.Resolve(model.TypeSTT, "qwen")
+	require.ErrorIs(t, err, model.ErrNotAssigned)
+
+	_, err = routes.Resolve(model.TypeTTS, "")
+	require.ErrorIs(t, err, model.ErrNoDefault)
+
+	assert.Equal(t, []string{"chat"}, routes.Aliases("qwen"))
+}
@@ -0,0 +1,12 @@
+package promptcache
+
+import "errors"
+
+// Error definitions for the promptcache package.
+var (
+	ErrNotFound      = errors.New("prompt cache not found")
+	ErrExists        = errors.New("prompt cache already exists")
+	ErrDeclared      = errors.New("prompt cache is declared in the config")
+	ErrModelMismatch = errors.New("model is not the model of the prompt cache")
+	ErrInvalidName   = errors.New("prompt cache names must only have lowercase letters, digits, dashes and underscores")
+)
@@ -0,0 +1,220 @@
+package promptcache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ju4n97/relic/internal/backend"
+	"github.com/ju4n97/relic/internal/config"
+)
+
+// Sources of prompt caches.
+const (
+	SourceConfig = "config" // Declared in the config
+	SourceAPI    = "api"    // Created at runtime
+)
+
+// Cache is a named prompt cache: a long-lived prompt prefix whose KV cache the
+// backend keeps in a slot and persists.
+type Cache struct {
+	CreatedAt    *time.Time `json:"created_at,omitempty"` // Set for the caches created at runtime
+	Name         string     `json:"name"`
+	Model        string     `json:"model"` // Model ID or alias
+	Profile      string     `json:"profile,omitempty"`
+	SystemPrompt string     `json:"system_prompt"`
+	Source       string     `json:"source"`
+}
+
+// Prefix returns the prompt prefix backends cache for the requests using the
+// cache.
+func (c *Cache) Prefix() *backend.PromptCache {
+	return &backend.PromptCache{
+		Name:     c.Name,
+		Messages: []backend.Message{{Role: "system", Content: c.SystemPrompt}},
+	}
+}
+
+// Registry holds the prompt caches declared in the config and those created at
+// runtime, which are persisted in a JSON file. The KV caches are persisted by the
+// backend in the directory of that file.
+type Registry struct {
+	declared map[string]Cache
+	created  map[string]Cache
+	path     string
+	mu       sync.RWMutex
+}
+
+// Open loads the prompt caches created at runtime from a file. A missing file is
+// not an error, no cache was created yet.
+func Open(path string) (*Registry, error) {
+	r := &Registry{
+		declared: map[string]Cache{},
+		created:  map[string]Cache{},
+		path:     path,
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return r, nil
+		}
+		return nil, fmt.Errorf("promptcache: failed to read prompt caches: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &r.created); err != nil {
+		return nil, fmt.Errorf("promptcache: failed to decode prompt caches: %w", err)
+	}
+
+	return r, nil
+}
+
+// Dir returns the directory the KV caches are persisted in.
+func (r *Registry) Dir() string {
+	return filepath.Dir(r.path)
+}
+
+// Update replaces the caches declared in the config, e.g. on reload. A declared
+// cache hides a created one of the same name.
+func (r *Registry) Update(cfg *config.Config) {
+	declared := make(map[string]Cache, len(cfg.PromptCaches))
+	for name, cacheConfig := range cfg.PromptCaches {
+		declared[name] = Cache{
+			Name:         name,
+			Model:        cacheConfig.Model,
+			Profile:      cacheConfig.Profile,
+			SystemPrompt: cacheConfig.SystemPrompt,
+			Source:       SourceConfig,
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.declared = declared
+}
+
+// Get returns a prompt cache by name.
+func (r *Registry) Get(name string) (Cache, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if cache, ok := r.declared[name]; ok {
+		return cache, true
+	}
+	cache, ok := r.created[name]
+	return cache, ok
+}
+
+// List returns the prompt caches, sorted by name.
+func (r *Registry) List() []Cache {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	caches := make(map[string]Cache, len(r.declared)+len(r.created))
+	maps.Copy(caches, r.created)
+	maps.Copy(caches, r.declared)
+
+	list := make([]Cache, 0, len(caches))
+	for _, name := range slices.Sorted(maps.Keys(caches)) {
+		list = append(list, caches[name])
+	}
+
+	return list
+}
+
+// Create adds a prompt cache at a time and persists it.
+func (r *Registry) Create(now time.Time, cache Cache) (Cache, error) {
+	if !config.ValidPromptCacheName(cache.Name) {
+		return Cache{}, fmt.Errorf("%w: %q", ErrInvalidName, cache.Name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, declared := r.declared[cache.Name]
+	if _, created := r.created[cache.Name]; declared || created {
+		return Cache{}, fmt.Errorf("%w: %s", ErrExists, cache.Name)
+	}
+
+	cache.CreatedAt = &now
+	cache.Source = SourceAPI
+	r.created[cache.Name] = cache
+
+	if err := r.flushLocked(); err != nil {
+		delete(r.created, cache.Name)
+		return Cache{}, err
+	}
+
+	return cache, nil
+}
+
+// Delete removes a prompt cache created at runtime, along with its persisted KV
+// caches. Caches declared in the config cannot be deleted.
+func (r *Registry) Delete(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.declared[name]; ok {
+		return fmt.Errorf("%w: %s", ErrDeclared, name)
+	}
+
+	cache, ok := r.created[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+
+	delete(r.created, name)
+	if err := r.flushLocked(); err != nil {
+		r.created[name] = cache
+		return err
+	}
+
+	r.removeFilesLocked(name)
+
+	return nil
+}
+
+// removeFilesLocked removes the KV caches persisted for a prompt cache, for every
+// model and prefix it was built with, see backend.PromptCache.FileName. Names
+// have no dots, so the pattern matches the files of no other cache. Callers must
+// hold the lock.
+func (r *Registry) removeFilesLocked(name string) {
+	files, _ := filepath.Glob(filepath.Join(r.Dir(), name+".*.bin"))
+	for _, file := range files {
+		if err := os.Remove(file); err != nil {
+			slog.Warn("Failed to remove prompt cache file", "file", file, "error", err)
+		}
+	}
+}
+
+// flushLocked writes the caches created at runtime to the file. Callers must
+// hold the lock.
+func (r *Registry) flushLocked() error {
+	data, err := json.MarshalIndent(r.created, "", "  ")
+	if err != nil {
+		return fmt.Errorf("promptcache: failed to encode prompt caches: %w", err)
+	}
+
+	if err := os.MkdirAll(r.Dir(), 0o755); err != nil {
+		return fmt.Errorf("promptcache: failed to create %s: %w", r.Dir(), err)
+	}
+
+	tmp := r.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("promptcache: failed to write prompt caches: %w", err)
+	}
+
+	if err := os.Rename(tmp, r.path); err != nil {
+		return fmt.Errorf("promptcache: failed to replace prompt caches: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package promptcache_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ju4n97/relic/internal/config"
+	"github.com/ju4n97/relic/internal/promptcache"
+)
+
+func TestRegistry_CreatePersists(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), ".relic-slots", "prompt-caches.json")
+	now := time.Date(2025, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	registry, err := promptcache.Open(path)
+	require.NoError(t, err)
+
+	created, err := registry.Create(now, promptcache.Cache{Name: "kitchen", Model: "qwen", SystemPrompt: "You help in the kitchen."})
+	require.NoError(t, err)
+	assert.Equal(t, promptcache.SourceAPI, created.Source)
+
+	_, err = registry.Create(now, promptcache.Cache{Name: "kitchen", Model: "qwen", SystemPrompt: "Again."})
+	require.ErrorIs(t, err, promptcache.ErrExists)
+
+	_, err = registry.Create(now, promptcache.Cache{Name: "../kitchen", Model: "qwen", SystemPrompt: "Escape."})
+	require.ErrorIs(t, err, promptcache.ErrInvalidName)
+
+	reopened, err := promptcache.Open(path)
+	require.NoError(t, err)
+	cache, ok := reopened.Get("kitchen")
+	require.True(t, ok)
+	assert.Equal(t, "You help in the kitchen.", cache.SystemPrompt)
+	assert.Equal(t, now, *cache.CreatedAt)
+}
+
+func TestRegistry_DeclaredCaches(t *testing.T) {
+	t.Parallel()
+
+	registry, err := promptcache.Open(filepath.Join(t.TempDir(), "prompt-caches.json"))
+	require.NoError(t, err)
+
+	registry.Update(&config.Config{PromptCaches: map[string]config.PromptCacheConfig{
+		"assistant": {Model: "qwen", SystemPrompt: "You are a voice assistant."},
+	}})
+
+	_, err = registry.Create(time.Now(), promptcache.Cache{Name: "assistant", Model: "qwen", SystemPrompt: "Shadow."})
+	require.ErrorIs(t, err, promptcache.ErrExists)
+	require.ErrorIs(t, registry.Delete("assistant"), promptcache.ErrDeclared)
+
+	caches := registry.List()
+	require.Len(t, caches, 1)
+	assert.Equal(t, promptcache.SourceConfig, caches[0].Source)
+
+	prefix := caches[0].Prefix()
+	assert.Equal(t, "assistant", prefix.Name)
+	assert.Equal(t, "You are a voice assistant.", prefix.Messages[0].Content)
+}
+
+func TestRegistry_DeleteRemovesFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	registry, err := promptcache.Open(filepath.Join(dir, "prompt-caches.json"))
+	require.NoError(t, err)
+
+	for _, name := range []string{"kitchen", "kitchen-v2"} {
+		cache, err := registry.Create(time.Now(), promptcache.Cache{Name: name, Model: "qwen", SystemPrompt: "Cook."})
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, cache.Prefix().FileName("/models/qwen.gguf")), []byte("kv"), 0o644))
+	}
+
+	require.NoError(t, registry.Delete("kitchen"))
+	require.ErrorIs(t, registry.Delete("kitchen"), promptcache.ErrNotFound)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.bin"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Contains(t, filepath.Base(files[0]), "kitchen-v2.")
+}
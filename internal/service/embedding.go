@@ -0,0 +1,71 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/ju4n97/relic/internal/backend"
+	"github.com/ju4n97/relic/internal/model"
+	"github.com/ju4n97/relic/internal/tracing"
+)
+
+// Embedding is a service abstraction for text embeddings. Embeddings are
+// computed by the models of the LLM service.
+type Embedding struct {
+	backends *backend.Registry
+	models   *model.Registry
+}
+
+// NewEmbedding creates a new Embedding service.
+func NewEmbedding(backends *backend.Registry, models *model.Registry) *Embedding {
+	return &Embedding{
+		backends: backends,
+		models:   models,
+	}
+}
+
+// Embed computes the embedding of each input, in order.
+func (s *Embedding) Embed(ctx context.Context, provider, modelID, profile string, inputs []string) (_ [][]float32, _ *backend.ResponseMetadata, err error) {
+	ctx, span := startSpan(ctx, "service.Embedding.Embed", model.TypeLLM, provider, modelID, profile)
+	defer func() { tracing.End(span, err) }()
+
+	b, ok := s.backends.Get(provider)
+	if !ok {
+		return nil, nil, backend.ErrNotFound
+	}
+
+	m, err := acquire(ctx, s.models, model.TypeLLM, provider, modelID)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer m.Release()
+
+	input, err := json.Marshal(inputs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("service: failed to encode inputs: %w", err)
+	}
+
+	breq, err := BackendRequest(m, profile, &backend.Request{
+		Input: bytes.NewReader(input),
+		Task:  backend.TaskEmbedding,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := b.Infer(ctx, breq)
+	if err != nil {
+		slog.Error("Failed to compute embeddings", "error", err)
+		return nil, nil, err
+	}
+
+	var vectors [][]float32
+	if err := json.NewDecoder(resp.Output).Decode(&vectors); err != nil {
+		return nil, nil, fmt.Errorf("service: failed to decode embeddings: %w", err)
+	}
+
+	return vectors, resp.Metadata, nil
+}
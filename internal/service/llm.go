@@ -6,6 +6,7 @@ import (
 
 	"github.com/ju4n97/relic/internal/backend"
 	"github.com/ju4n97/relic/internal/model"
+	"github.com/ju4n97/relic/internal/tracing"
 )
 
 // LLM is a service abstraction for large language models.
@@ -23,21 +24,24 @@ func NewLLM(backends *backend.Registry, models *model.Registry) *LLM {
 }
 
 // Generate generates text using a large language model.
-func (s *LLM) Generate(ctx context.Context, provider, modelID string, req *backend.Request) (*backend.Response, error) {
+func (s *LLM) Generate(ctx context.Context, provider, modelID, profile string, req *backend.Request) (_ *backend.Response, err error) {
+	ctx, span := startSpan(ctx, "service.LLM.Generate", model.TypeLLM, provider, modelID, profile)
+	defer func() { tracing.End(span, err) }()
+
 	b, ok := s.backends.Get(provider)
 	if !ok {
 		return nil, backend.ErrNotFound
 	}
 
-	m, ok := s.models.Get(modelID)
-	if !ok {
-		return nil, model.ErrNotFound
+	m, err := acquire(ctx, s.models, model.TypeLLM, provider, modelID)
+	if err != nil {
+		return nil, err
 	}
+	defer m.Release()
 
-	breq := &backend.Request{
-		ModelPath:  m.Path,
-		Input:      req.Input,
-		Parameters: req.Parameters,
+	breq, err := BackendRequest(m, profile, req)
+	if err != nil {
+		return nil, err
 	}
 
 	resp, err := b.Infer(ctx, breq)
@@ -50,7 +54,15 @@ func (s *LLM) Generate(ctx context.Context, provider, modelID string, req *backe
 }
 
 // GenerateStream generates streamed text using a large language model.
-func (s *LLM) GenerateStream(ctx context.Context, provider, modelID string, req *backend.Request) (<-chan backend.StreamChunk, error) {
+// The span of the call ends with the stream.
+func (s *LLM) GenerateStream(ctx context.Context, provider, modelID, profile string, req *backend.Request) (_ <-chan backend.StreamChunk, err error) {
+	ctx, span := startSpan(ctx, "service.LLM.GenerateStream", model.TypeLLM, provider, modelID, profile)
+	defer func() {
+		if err != nil {
+			tracing.End(span, err)
+		}
+	}()
+
 	b, ok := s.backends.Get(provider)
 	if !ok {
 		return nil, backend.ErrNotFound
@@ -61,22 +73,26 @@ func (s *LLM) GenerateStream(ctx context.Context, provider, modelID string, req
 		return nil, backend.ErrNotStreamable
 	}
 
-	m, ok := s.models.Get(modelID)
-	if !ok {
-		return nil, model.ErrNotFound
+	m, err := acquire(ctx, s.models, model.TypeLLM, provider, modelID)
+	if err != nil {
+		return nil, err
 	}
 
-	breq := &backend.Request{
-		ModelPath:  m.Path,
-		Input:      req.Input,
-		Parameters: req.Parameters,
+	breq, err := BackendRequest(m, profile, req)
+	if err != nil {
+		m.Release()
+		return nil, err
 	}
 
 	resp, err := bs.InferStream(ctx, breq)
 	if err != nil {
+		m.Release()
 		slog.Error("Failed to generate streamed text", "error", err)
 		return nil, err
 	}
 
-	return resp, nil
+	return releaseOnDone(ctx, resp, func() {
+		m.Release()
+		span.End()
+	}), nil
 }
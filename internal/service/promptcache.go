@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ju4n97/relic/internal/backend"
+	"github.com/ju4n97/relic/internal/config"
+	"github.com/ju4n97/relic/internal/model"
+	"github.com/ju4n97/relic/internal/promptcache"
+	"github.com/ju4n97/relic/internal/tracing"
+)
+
+// promptCachesFile is the file of the prompt cache directory the caches created
+// at runtime are kept in.
+const promptCachesFile = "prompt-caches.json"
+
+// errPromptCachesNotConfigured is returned when prompt caches are used before a
+// config loaded them.
+var errPromptCachesNotConfigured = errors.New("service: prompt caches are not configured")
+
+// PromptCaches is a service for named prompt caches: long-lived prompt prefixes,
+// e.g. the system prompt of an assistant, whose KV cache the backend keeps in a
+// slot and persists so that requests starting with them skip processing them.
+type PromptCaches struct {
+	llm      *LLM
+	models   *model.Registry
+	registry *promptcache.Registry // Nil until Update
+	mu       sync.Mutex
+}
+
+// NewPromptCaches creates a new PromptCaches service. Prompt caches are loaded
+// once Update is called with a config.
+func NewPromptCaches(llm *LLM, models *model.Registry) *PromptCaches {
+	return &PromptCaches{
+		llm:    llm,
+		models: models,
+	}
+}
+
+// Update applies the prompt caches declared in the config, e.g. on reload. The
+// first call loads the caches created at runtime from the prompt cache directory
+// of the config, which is kept from then on: it is the directory the backend
+// persists the KV caches in.
+func (s *PromptCaches) Update(cfg *config.Config) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.registry == nil {
+		registry, err := promptcache.Open(filepath.Join(cfg.PromptCacheDir(), promptCachesFile))
+		if err != nil {
+			return err
+		}
+		s.registry = registry
+	}
+
+	s.registry.Update(cfg)
+
+	return nil
+}
+
+// List returns the prompt caches, sorted by name.
+func (s *PromptCaches) List() []promptcache.Cache {
+	registry, err := s.current()
+	if err != nil {
+		return nil
+	}
+	return registry.List()
+}
+
+// Get returns a prompt cache by name.
+func (s *PromptCaches) Get(name string) (promptcache.Cache, error) {
+	registry, err := s.current()
+	if err != nil {
+		return promptcache.Cache{}, err
+	}
+
+	cache, ok := registry.Get(name)
+	if !ok {
+		return promptcache.Cache{}, fmt.Errorf("%w: %s", promptcache.ErrNotFound, name)
+	}
+	return cache, nil
+}
+
+// Create creates a prompt cache with a model served by a backend, and warms it
+// if warm is set. The model and the profile are checked before the cache is
+// stored.
+func (s *PromptCaches) Create(ctx context.Context, provider string, cache promptcache.Cache, warm bool) (_ promptcache.Cache, err error) {
+	ctx, span := startSpan(ctx, "service.PromptCaches.Create", model.TypeLLM, provider, cache.Model, cache.Profile)
+	defer func() { tracing.End(span, err) }()
+
+	m, err := acquire(ctx, s.models, model.TypeLLM, provider, cache.Model)
+	if err != nil {
+		return promptcache.Cache{}, err
+	}
+	defer m.Release()
+
+	if _, err := m.Profile(cache.Profile); err != nil {
+		return promptcache.Cache{}, err
+	}
+
+	registry, err := s.current()
+	if err != nil {
+		return promptcache.Cache{}, err
+	}
+
+	cache, err = registry.Create(time.Now(), cache)
+	if err != nil {
+		return promptcache.Cache{}, err
+	}
+
+	if warm {
+		if _, err := s.Warm(ctx, provider, cache.Name); err != nil {
+			return promptcache.Cache{}, err
+		}
+	}
+
+	return cache, nil
+}
+
+// Delete deletes a prompt cache created at runtime and its persisted KV caches.
+func (s *PromptCaches) Delete(name string) error {
+	registry, err := s.current()
+	if err != nil {
+		return err
+	}
+	return registry.Delete(name)
+}
+
+// Warm makes the backend hold the prefix of a prompt cache, restored from disk or
+// processed, and persist it, so that the first request using it is served fast.
+func (s *PromptCaches) Warm(ctx context.Context, provider, name string) (_ *backend.PromptCacheStatus, err error) {
+	cache, err := s.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.llm.Generate(ctx, provider, cache.Model, cache.Profile, &backend.Request{
+		Task:        backend.TaskWarmPromptCache,
+		PromptCache: cache.Prefix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var status backend.PromptCacheStatus
+	if err := json.NewDecoder(resp.Output).Decode(&status); err != nil {
+		return nil, fmt.Errorf("service: failed to decode prompt cache status: %w", err)
+	}
+
+	return &status, nil
+}
+
+// Apply makes a generation request start with the prefix of a prompt cache, and
+// returns the model and the profile it must be served with: those of the cache,
+// unless the request names them. A request naming another model than the one of
+// the cache fails with promptcache.ErrModelMismatch. A prompt is turned into a
+// user message following the prefix.
+func (s *PromptCaches) Apply(name, modelID, profile string, req *backend.Request) (string, string, error) {
+	cache, err := s.Get(name)
+	if err != nil {
+		return "", "", err
+	}
+
+	if modelID != "" && modelID != cache.Model {
+		same, err := s.sameModel(modelID, cache.Model)
+		if err != nil {
+			return "", "", err
+		}
+		if !same {
+			return "", "", fmt.Errorf("%w: %s uses %s", promptcache.ErrModelMismatch, name, cache.Model)
+		}
+	}
+	if profile == "" {
+		profile = cache.Profile
+	}
+
+	messages := req.Messages
+	if req.Input != nil {
+		prompt, err := io.ReadAll(req.Input)
+		if err != nil {
+			return "", "", fmt.Errorf("service: failed to read prompt: %w", err)
+		}
+		messages = []backend.Message{{Role: "user", Content: string(prompt)}}
+		req.Input = nil
+	}
+
+	req.PromptCache = cache.Prefix()
+	req.Messages = slices.Concat(req.PromptCache.Messages, messages)
+
+	return cache.Model, profile, nil
+}
+
+// sameModel reports whether two model IDs or aliases name the same llm model.
+func (s *PromptCaches) sameModel(a, b string) (bool, error) {
+	ma, err := s.models.Acquire(model.TypeLLM, a)
+	if err != nil {
+		return false, err
+	}
+	defer ma.Release()
+
+	mb, err := s.models.Acquire(model.TypeLLM, b)
+	if err != nil {
+		return false, err
+	}
+	defer mb.Release()
+
+	return ma.ID == mb.ID, nil
+}
+
+// current returns the registry of the prompt caches.
+func (s *PromptCaches) current() (*promptcache.Registry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.registry == nil {
+		return nil, errPromptCachesNotConfigured
+	}
+	return s.registry, nil
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ju4n97/relic/internal/auth"
+	"github.com/ju4n97/relic/internal/backend"
+	"github.com/ju4n97/relic/internal/model"
+	"github.com/ju4n97/relic/internal/tracing"
+)
+
+// startSpan starts the span of a service call.
+func startSpan(ctx context.Context, name string, service model.Type, provider, modelID, profile string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, name, trace.WithAttributes(
+		tracing.Service.String(string(service)),
+		tracing.Backend.String(provider),
+		tracing.ModelID.String(modelID),
+		tracing.Profile.String(profile),
+	))
+}
+
+// acquire returns the model a request for the service should use, making sure it
+// runs on the given backend. name may be a model ID, an alias, or empty to use
+// the service default. Callers must release the instance once done.
+// The resolved model ID is recorded on the span of the call.
+func acquire(ctx context.Context, models *model.Registry, service model.Type, provider, name string) (*model.Instance, error) {
+	m, err := models.Acquire(service, name)
+	if err != nil {
+		return nil, err
+	}
+	trace.SpanFromContext(ctx).SetAttributes(tracing.ModelID.String(m.ID))
+
+	if m.Config.Backend != provider {
+		m.Release()
+		return nil, fmt.Errorf("%w: %s runs on %s, not %s", model.ErrBackendMismatch, m.ID, m.Config.Backend, provider)
+	}
+
+	if err := auth.CheckModel(ctx, string(service), m.ID); err != nil {
+		m.Release()
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// BackendRequest builds the request a backend serves with the model and the
+// profile the caller asked for. An empty profile selects the model default.
+func BackendRequest(m *model.Instance, profile string, req *backend.Request) (*backend.Request, error) {
+	p, err := m.Profile(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	modelPath, err := m.VariantPath(p)
+	if err != nil {
+		return nil, err
+	}
+
+	breq := &backend.Request{
+		ModelID:     m.ID,
+		ModelPath:   modelPath,
+		Input:       req.Input,
+		Parameters:  req.Parameters,
+		Messages:    req.Messages,
+		Task:        req.Task,
+		CacheKey:    req.CacheKey,
+		PromptCache: req.PromptCache,
+	}
+	if p != nil {
+		breq.Launch = backend.LaunchOptions{
+			GPULayers:   p.GPULayers,
+			Threads:     p.Threads,
+			ContextSize: p.ContextSize,
+			BatchSize:   p.BatchSize,
+			Parallel:    p.Parallel,
+		}
+	}
+
+	return breq, nil
+}
@@ -0,0 +1,390 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ju4n97/relic/internal/auth"
+	"github.com/ju4n97/relic/internal/backend"
+	"github.com/ju4n97/relic/internal/config"
+	"github.com/ju4n97/relic/internal/model"
+	"github.com/ju4n97/relic/internal/session"
+	"github.com/ju4n97/relic/internal/tracing"
+)
+
+// errSessionsNotConfigured is returned when sessions are used before a config
+// opened their database.
+var errSessionsNotConfigured = errors.New("service: sessions are not configured")
+
+// Sessions is a service for chat sessions whose history is kept on the server,
+// for clients that cannot hold it. Turns of a session are served one at a time,
+// pinned to the same backend cache so that the history is not processed again.
+type Sessions struct {
+	llm         *LLM
+	models      *model.Registry
+	store       *session.Store      // Nil until Update
+	busy        map[string]struct{} // Sessions with a turn in progress
+	ttl         time.Duration
+	maxMessages int
+	mu          sync.Mutex
+}
+
+// NewSessions creates a new Sessions service. Sessions are stored once Update is
+// called with a config.
+func NewSessions(llm *LLM, models *model.Registry) *Sessions {
+	return &Sessions{
+		llm:    llm,
+		models: models,
+		busy:   map[string]struct{}{},
+		ttl:    config.DefaultSessionTTL,
+	}
+}
+
+// Update applies the sessions settings of the config, e.g. on reload, and opens
+// the session database if it changed. The TTL and the messages kept apply to the
+// sessions created and the turns taken from then on. On error nothing is applied.
+func (s *Sessions) Update(cfg *config.Config) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if path := cfg.SessionsPath(); s.store == nil || path != s.store.Path() {
+		store, err := session.Open(path)
+		if err != nil {
+			return err
+		}
+		if s.store != nil {
+			if err := s.store.Close(); err != nil {
+				slog.Error("Failed to close session database", "path", s.store.Path(), "error", err)
+			}
+		}
+		s.store = store
+	}
+
+	s.ttl = cfg.SessionTTL()
+	s.maxMessages = cfg.Sessions.MaxMessages
+
+	return nil
+}
+
+// Close closes the session database.
+func (s *Sessions) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.store == nil {
+		return nil
+	}
+	return s.store.Close()
+}
+
+// Run deletes the expired sessions every interval until ctx is done.
+func (s *Sessions) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			store, err := s.current()
+			if err != nil {
+				continue
+			}
+
+			deleted, err := store.DeleteExpired()
+			if err != nil {
+				slog.Error("Failed to delete expired sessions", "error", err)
+			} else if deleted > 0 {
+				slog.Info("Deleted expired sessions", "count", deleted)
+			}
+		}
+	}
+}
+
+// CreateSessionOptions configures a new session.
+type CreateSessionOptions struct {
+	Parameters   map[string]any // Generation parameters of every turn
+	ModelID      string         // Model ID or alias, the service default when empty
+	Profile      string
+	SystemPrompt string
+	TTL          time.Duration // Idle time after which the session expires, the configured TTL when zero
+}
+
+// Create creates a session with a model. The model is resolved once, so the
+// session keeps it even if the default model or the aliases change.
+func (s *Sessions) Create(ctx context.Context, provider string, opts CreateSessionOptions) (_ *session.Session, err error) {
+	ctx, span := startSpan(ctx, "service.Sessions.Create", model.TypeLLM, provider, opts.ModelID, opts.Profile)
+	defer func() { tracing.End(span, err) }()
+
+	m, err := acquire(ctx, s.models, model.TypeLLM, provider, opts.ModelID)
+	if err != nil {
+		return nil, err
+	}
+	defer m.Release()
+
+	if _, err := m.Profile(opts.Profile); err != nil {
+		return nil, err
+	}
+
+	store, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	ttl := s.ttl
+	s.mu.Unlock()
+	if opts.TTL > 0 {
+		ttl = opts.TTL
+	}
+
+	sess := session.New(store.Now(), ttl)
+	sess.Owner = ownerOf(ctx)
+	sess.Backend = provider
+	sess.ModelID = m.ID
+	sess.Profile = opts.Profile
+	sess.SystemPrompt = opts.SystemPrompt
+	sess.Parameters = opts.Parameters
+
+	if err := store.Put(sess); err != nil {
+		return nil, fmt.Errorf("service: failed to store session: %w", err)
+	}
+
+	return sess, nil
+}
+
+// Get returns a session of the caller.
+func (s *Sessions) Get(ctx context.Context, id string) (*session.Session, error) {
+	store, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+
+	sess, err := store.Get(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sessions of other API keys are hidden rather than forbidden.
+	if sess.Owner != ownerOf(ctx) {
+		return nil, fmt.Errorf("%w: %s", session.ErrNotFound, id)
+	}
+
+	return sess, nil
+}
+
+// List returns the sessions of the caller.
+func (s *Sessions) List(ctx context.Context) ([]*session.Session, error) {
+	store, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+
+	return store.List(ownerOf(ctx))
+}
+
+// Delete deletes a session of the caller.
+func (s *Sessions) Delete(ctx context.Context, id string) error {
+	if _, err := s.Get(ctx, id); err != nil {
+		return err
+	}
+
+	store, err := s.current()
+	if err != nil {
+		return err
+	}
+
+	return store.Delete(id)
+}
+
+// SessionReply is the reply to a turn of a session.
+type SessionReply struct {
+	Session  *session.Session // Session with the turn added
+	Metadata *backend.ResponseMetadata
+	Text     string
+}
+
+// Send adds a user message to a session and returns the reply of the model. The
+// turn is only recorded once the reply is complete. Parameters override those
+// of the session for this turn.
+func (s *Sessions) Send(ctx context.Context, id, content string, parameters map[string]any) (_ *SessionReply, err error) {
+	sess, done, err := s.begin(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	ctx, span := startSpan(ctx, "service.Sessions.Send", model.TypeLLM, sess.Backend, sess.ModelID, sess.Profile)
+	defer func() { tracing.End(span, err) }()
+
+	resp, err := s.llm.Generate(ctx, sess.Backend, sess.ModelID, sess.Profile, turnRequest(sess, content, parameters))
+	if err != nil {
+		return nil, err
+	}
+
+	var sb strings.Builder
+	if _, err := io.Copy(&sb, resp.Output); err != nil {
+		return nil, fmt.Errorf("service: failed to read reply: %w", err)
+	}
+
+	sess, err = s.addTurn(id, content, sb.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return &SessionReply{Session: sess, Text: sb.String(), Metadata: resp.Metadata}, nil
+}
+
+// SendStream adds a user message to a session and streams the reply of the
+// model. The turn is recorded before the last chunk is sent, and not at all if
+// the stream fails or the consumer goes away.
+func (s *Sessions) SendStream(ctx context.Context, id, content string, parameters map[string]any) (_ <-chan backend.StreamChunk, err error) {
+	sess, done, err := s.begin(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			done()
+		}
+	}()
+
+	stream, err := s.llm.GenerateStream(ctx, sess.Backend, sess.ModelID, sess.Profile, turnRequest(sess, content, parameters))
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan backend.StreamChunk)
+
+	go func() {
+		defer close(out)
+		defer done()
+
+		var reply strings.Builder
+		for chunk := range stream {
+			reply.Write(chunk.Data)
+			if chunk.Done && chunk.Error == nil {
+				if _, err := s.addTurn(id, content, reply.String()); err != nil {
+					chunk = backend.StreamChunk{Error: err, Done: true}
+				}
+			}
+
+			select {
+			case out <- chunk:
+			case <-ctx.Done():
+				go drain(stream)
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// begin marks a session of the caller as busy with a turn, and returns it with
+// the function ending the turn. It returns session.ErrBusy if a turn is already
+// in progress.
+func (s *Sessions) begin(ctx context.Context, id string) (*session.Session, func(), error) {
+	s.mu.Lock()
+	if _, ok := s.busy[id]; ok {
+		s.mu.Unlock()
+		return nil, nil, fmt.Errorf("%w: %s", session.ErrBusy, id)
+	}
+	s.busy[id] = struct{}{}
+	s.mu.Unlock()
+
+	done := func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.busy, id)
+	}
+
+	// Read once busy, so that the history includes the previous turn.
+	sess, err := s.Get(ctx, id)
+	if err != nil {
+		done()
+		return nil, nil, err
+	}
+
+	return sess, done, nil
+}
+
+// addTurn records a turn of a session.
+func (s *Sessions) addTurn(id, content, reply string) (*session.Session, error) {
+	store, err := s.current()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	maxMessages := s.maxMessages
+	s.mu.Unlock()
+
+	sess, err := store.Update(id, func(sess *session.Session) error {
+		sess.AddTurn(store.Now(), content, reply, maxMessages)
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, session.ErrNotFound) {
+			// Deleted or expired while the reply was generated.
+			return nil, err
+		}
+		return nil, fmt.Errorf("service: failed to store turn: %w", err)
+	}
+
+	return sess, nil
+}
+
+// current returns the store sessions are kept in.
+func (s *Sessions) current() (*session.Store, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.store == nil {
+		return nil, errSessionsNotConfigured
+	}
+	return s.store, nil
+}
+
+// turnRequest builds the request of a turn: the system prompt, the history of
+// the session and the new user message. Requests of a session share its cache
+// key, so backends reuse the processed history.
+func turnRequest(sess *session.Session, content string, parameters map[string]any) *backend.Request {
+	messages := make([]backend.Message, 0, len(sess.Messages)+2)
+	if sess.SystemPrompt != "" {
+		messages = append(messages, backend.Message{Role: session.RoleSystem, Content: sess.SystemPrompt})
+	}
+	for _, msg := range sess.Messages {
+		messages = append(messages, backend.Message{Role: msg.Role, Content: msg.Content})
+	}
+	messages = append(messages, backend.Message{Role: session.RoleUser, Content: content})
+
+	params := maps.Clone(sess.Parameters)
+	if params == nil {
+		params = map[string]any{}
+	}
+	maps.Copy(params, parameters)
+
+	return &backend.Request{
+		Messages:   messages,
+		Parameters: params,
+		CacheKey:   "session:" + sess.ID,
+	}
+}
+
+// ownerOf returns the owner of the sessions a caller creates and sees: the name
+// of its API key, or an empty string when authentication is disabled.
+func ownerOf(ctx context.Context) string {
+	if key, ok := auth.KeyFromContext(ctx); ok {
+		return key.Name
+	}
+	return ""
+}
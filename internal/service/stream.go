@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+
+	"github.com/ju4n97/relic/internal/backend"
+)
+
+// releaseOnDone forwards a stream and calls release once it is exhausted or the
+// consumer goes away, so the model stays acquired for the duration of the stream.
+func releaseOnDone(ctx context.Context, chunks <-chan backend.StreamChunk, release func()) <-chan backend.StreamChunk {
+	out := make(chan backend.StreamChunk)
+
+	go func() {
+		defer close(out)
+		defer release()
+
+		for chunk := range chunks {
+			select {
+			case out <- chunk:
+			case <-ctx.Done():
+				// Nobody reads anymore, let the backend run to completion.
+				go drain(chunks)
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// drain discards the remaining chunks of a stream.
+func drain(chunks <-chan backend.StreamChunk) {
+	for range chunks {
+		continue
+	}
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/ju4n97/relic/internal/backend"
+	"github.com/ju4n97/relic/internal/model"
+	"github.com/ju4n97/relic/internal/tracing"
+)
+
+// Tokenizer is a service abstraction for the tokenizers and chat templates of
+// the models of the LLM service.
+type Tokenizer struct {
+	backends *backend.Registry
+	models   *model.Registry
+}
+
+// NewTokenizer creates a new Tokenizer service.
+func NewTokenizer(backends *backend.Registry, models *model.Registry) *Tokenizer {
+	return &Tokenizer{
+		backends: backends,
+		models:   models,
+	}
+}
+
+// TokenizeOptions configures a tokenization.
+type TokenizeOptions struct {
+	AddSpecial bool // Add the special tokens of the model, e.g. BOS, to text
+	WithPieces bool // Return the text of each token
+}
+
+// Tokenize tokenizes text, or messages formatted with the chat template of the
+// model. The tokenization reports the context length of the model.
+func (s *Tokenizer) Tokenize(ctx context.Context, provider, modelID, profile, text string, messages []backend.Message, opts TokenizeOptions) (_ *backend.Tokenization, _ *backend.ResponseMetadata, err error) {
+	ctx, span := startSpan(ctx, "service.Tokenizer.Tokenize", model.TypeLLM, provider, modelID, profile)
+	defer func() { tracing.End(span, err) }()
+
+	resp, err := s.infer(ctx, provider, modelID, profile, &backend.Request{
+		Input:    strings.NewReader(text),
+		Messages: messages,
+		Parameters: map[string]any{
+			"add_special": opts.AddSpecial,
+			"with_pieces": opts.WithPieces,
+		},
+		Task: backend.TaskTokenize,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var tokenization backend.Tokenization
+	if err := json.NewDecoder(resp.Output).Decode(&tokenization); err != nil {
+		return nil, nil, fmt.Errorf("service: failed to decode tokens: %w", err)
+	}
+
+	return &tokenization, resp.Metadata, nil
+}
+
+// Detokenize converts tokens back to text.
+func (s *Tokenizer) Detokenize(ctx context.Context, provider, modelID, profile string, tokens []int) (_ string, _ *backend.ResponseMetadata, err error) {
+	ctx, span := startSpan(ctx, "service.Tokenizer.Detokenize", model.TypeLLM, provider, modelID, profile)
+	defer func() { tracing.End(span, err) }()
+
+	input, err := json.Marshal(tokens)
+	if err != nil {
+		return "", nil, fmt.Errorf("service: failed to encode tokens: %w", err)
+	}
+
+	resp, err := s.infer(ctx, provider, modelID, profile, &backend.Request{
+		Input: bytes.NewReader(input),
+		Task:  backend.TaskDetokenize,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	text, err := io.ReadAll(resp.Output)
+	if err != nil {
+		return "", nil, fmt.Errorf("service: failed to read text: %w", err)
+	}
+
+	return string(text), resp.Metadata, nil
+}
+
+// ApplyTemplate formats messages with the chat template of the model.
+func (s *Tokenizer) ApplyTemplate(ctx context.Context, provider, modelID, profile string, messages []backend.Message) (_ string, _ *backend.ResponseMetadata, err error) {
+	ctx, span := startSpan(ctx, "service.Tokenizer.ApplyTemplate", model.TypeLLM, provider, modelID, profile)
+	defer func() { tracing.End(span, err) }()
+
+	resp, err := s.infer(ctx, provider, modelID, profile, &backend.Request{
+		Messages: messages,
+		Task:     backend.TaskApplyTemplate,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	prompt, err := io.ReadAll(resp.Output)
+	if err != nil {
+		return "", nil, fmt.Errorf("service: failed to read prompt: %w", err)
+	}
+
+	return string(prompt), resp.Metadata, nil
+}
+
+// infer serves a tokenizer request with the model.
+func (s *Tokenizer) infer(ctx context.Context, provider, modelID, profile string, req *backend.Request) (*backend.Response, error) {
+	b, ok := s.backends.Get(provider)
+	if !ok {
+		return nil, backend.ErrNotFound
+	}
+
+	m, err := acquire(ctx, s.models, model.TypeLLM, provider, modelID)
+	if err != nil {
+		return nil, err
+	}
+	defer m.Release()
+
+	breq, err := BackendRequest(m, profile, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := b.Infer(ctx, breq)
+	if err != nil {
+		slog.Error("Failed to serve tokenizer request", "task", req.Task, "error", err)
+		return nil, err
+	}
+
+	return resp, nil
+}
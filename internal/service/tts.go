@@ -6,6 +6,7 @@ import (
 
 	"github.com/ju4n97/relic/internal/backend"
 	"github.com/ju4n97/relic/internal/model"
+	"github.com/ju4n97/relic/internal/tracing"
 )
 
 // TTS is a service abstraction for text-to-speech.
@@ -23,21 +24,24 @@ func NewTTS(backends *backend.Registry, models *model.Registry) *TTS {
 }
 
 // Synthesize synthesizes speech using a text-to-speech model.
-func (s *TTS) Synthesize(ctx context.Context, provider, modelID string, req *backend.Request) (*backend.Response, error) {
+func (s *TTS) Synthesize(ctx context.Context, provider, modelID, profile string, req *backend.Request) (_ *backend.Response, err error) {
+	ctx, span := startSpan(ctx, "service.TTS.Synthesize", model.TypeTTS, provider, modelID, profile)
+	defer func() { tracing.End(span, err) }()
+
 	b, ok := s.backends.Get(provider)
 	if !ok {
 		return nil, backend.ErrNotFound
 	}
 
-	m, ok := s.models.Get(modelID)
-	if !ok {
-		return nil, model.ErrNotFound
+	m, err := acquire(ctx, s.models, model.TypeTTS, provider, modelID)
+	if err != nil {
+		return nil, err
 	}
+	defer m.Release()
 
-	breq := &backend.Request{
-		ModelPath:  m.Path,
-		Input:      req.Input,
-		Parameters: req.Parameters,
+	breq, err := BackendRequest(m, profile, req)
+	if err != nil {
+		return nil, err
 	}
 
 	resp, err := b.Infer(ctx, breq)
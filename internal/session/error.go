@@ -0,0 +1,9 @@
+package session
+
+import "errors"
+
+// Error definitions for the session package.
+var (
+	ErrNotFound = errors.New("session not found")
+	ErrBusy     = errors.New("session is busy with another turn")
+)
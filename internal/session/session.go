@@ -0,0 +1,105 @@
+package session
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Roles of the messages of a session.
+const (
+	RoleSystem    = "system"
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// Message is a message of a session.
+type Message struct {
+	CreatedAt time.Time `json:"created_at"`
+	Role      string    `json:"role"`
+	Content   string    `json:"content"`
+}
+
+// Session is a chat conversation whose history is kept on the server.
+type Session struct {
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"` // Time of the last turn
+	ExpiresAt    time.Time      `json:"expires_at"`
+	Parameters   map[string]any `json:"parameters,omitempty"` // Generation parameters of every turn
+	ID           string         `json:"id"`
+	Owner        string         `json:"owner,omitempty"` // API key that created the session, empty without authentication
+	Backend      string         `json:"backend"`
+	ModelID      string         `json:"model_id"`
+	Profile      string         `json:"profile,omitempty"`
+	SystemPrompt string         `json:"system_prompt,omitempty"`
+	Messages     []Message      `json:"messages"` // Turns of the conversation, without the system prompt
+	TTLSeconds   int64          `json:"ttl_seconds"`
+	Turns        int            `json:"turns"` // Replies generated, including those of dropped messages
+}
+
+// New creates a session at a time, expiring after ttl without turns.
+func New(now time.Time, ttl time.Duration) *Session {
+	return &Session{
+		ID:         newID(),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		ExpiresAt:  now.Add(ttl),
+		TTLSeconds: int64(ttl / time.Second),
+		Messages:   []Message{},
+	}
+}
+
+// TTL returns how long the session is kept after its last turn.
+func (s *Session) TTL() time.Duration {
+	return time.Duration(s.TTLSeconds) * time.Second
+}
+
+// Expired reports whether the session expired at a time.
+func (s *Session) Expired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
+
+// AddTurn appends a user message and the reply of the assistant, and extends the
+// session lifetime. At most maxMessages messages are kept when positive, the
+// oldest turns dropped first.
+func (s *Session) AddTurn(now time.Time, user, reply string, maxMessages int) {
+	s.Messages = append(s.Messages,
+		Message{CreatedAt: now, Role: RoleUser, Content: user},
+		Message{CreatedAt: now, Role: RoleAssistant, Content: reply},
+	)
+	s.Turns++
+	s.UpdatedAt = now
+	s.ExpiresAt = now.Add(s.TTL())
+
+	if maxMessages > 0 && len(s.Messages) > maxMessages {
+		drop := len(s.Messages) - maxMessages
+		// Keep the conversation starting with a user message.
+		for drop < len(s.Messages) && s.Messages[drop].Role != RoleUser {
+			drop++
+		}
+		s.Messages = append([]Message{}, s.Messages[drop:]...)
+	}
+}
+
+// Transcript returns the conversation as plain text, one paragraph per message
+// prefixed by its role.
+func (s *Session) Transcript() string {
+	var sb strings.Builder
+	if s.SystemPrompt != "" {
+		fmt.Fprintf(&sb, "%s: %s\n\n", RoleSystem, s.SystemPrompt)
+	}
+	for _, msg := range s.Messages {
+		fmt.Fprintf(&sb, "%s: %s\n\n", msg.Role, msg.Content)
+	}
+
+	return sb.String()
+}
+
+// newID returns a random session ID.
+func newID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,219 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// sessionsBucket is the bucket sessions are stored in, keyed by ID.
+var sessionsBucket = []byte("sessions")
+
+// Option is a function that configures a Store.
+type Option func(*Store)
+
+// WithClock sets the function returning the current time, e.g. in tests.
+func WithClock(now func() time.Time) Option {
+	return func(s *Store) {
+		s.now = now
+	}
+}
+
+// Store keeps sessions in a bbolt database, one JSON document per session.
+// Expired sessions are not returned, and are removed by DeleteExpired.
+type Store struct {
+	db  *bolt.DB
+	now func() time.Time
+}
+
+// Open opens the session database at path, creating it if needed.
+func Open(path string, opts ...Option) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("session: failed to create %s: %w", filepath.Dir(path), err)
+	}
+
+	// Another process holding the database would block forever otherwise.
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("session: failed to open %s: %w", path, err)
+	}
+
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(sessionsBucket)
+		return err
+	}); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("session: failed to initialize %s: %w", path, err)
+	}
+
+	s := &Store{db: db, now: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s, nil
+}
+
+// Path returns the file of the database.
+func (s *Store) Path() string {
+	return s.db.Path()
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Now returns the current time of the store.
+func (s *Store) Now() time.Time {
+	return s.now()
+}
+
+// Put stores a session, replacing the session with the same ID.
+func (s *Store) Put(sess *Session) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return put(tx, sess)
+	})
+}
+
+// Get returns a session. It returns ErrNotFound if the session does not exist or
+// expired.
+func (s *Store) Get(id string) (*Session, error) {
+	var sess *Session
+	err := s.db.View(func(tx *bolt.Tx) error {
+		var err error
+		sess, err = s.get(tx, id)
+		return err
+	})
+
+	return sess, err
+}
+
+// Update applies fn to a session and stores the result, atomically. Nothing is
+// stored if fn fails.
+func (s *Store) Update(id string, fn func(*Session) error) (*Session, error) {
+	var sess *Session
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		var err error
+		if sess, err = s.get(tx, id); err != nil {
+			return err
+		}
+		if err := fn(sess); err != nil {
+			return err
+		}
+		return put(tx, sess)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sess, nil
+}
+
+// List returns the sessions of an owner that did not expire, by creation time.
+func (s *Store) List(owner string) ([]*Session, error) {
+	sessions := []*Session{}
+	now := s.now()
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(sessionsBucket).ForEach(func(_, data []byte) error {
+			var sess Session
+			if err := json.Unmarshal(data, &sess); err != nil {
+				return fmt.Errorf("session: failed to decode session: %w", err)
+			}
+			if sess.Owner == owner && !sess.Expired(now) {
+				sessions = append(sessions, &sess)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(sessions, func(a, b *Session) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	return sessions, nil
+}
+
+// Delete removes a session. It returns ErrNotFound if the session does not exist.
+func (s *Store) Delete(id string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(sessionsBucket)
+		if bucket.Get([]byte(id)) == nil {
+			return fmt.Errorf("%w: %s", ErrNotFound, id)
+		}
+		return bucket.Delete([]byte(id))
+	})
+}
+
+// DeleteExpired removes the sessions that expired and returns how many.
+func (s *Store) DeleteExpired() (int, error) {
+	deleted := 0
+	now := s.now()
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(sessionsBucket)
+
+		var expired [][]byte
+		if err := bucket.ForEach(func(id, data []byte) error {
+			var sess Session
+			// Undecodable sessions can never be served, drop them too.
+			if err := json.Unmarshal(data, &sess); err != nil || sess.Expired(now) {
+				expired = append(expired, id)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for _, id := range expired {
+			if err := bucket.Delete(id); err != nil {
+				return err
+			}
+		}
+		deleted = len(expired)
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("session: failed to delete expired sessions: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// get reads a session in a transaction.
+func (s *Store) get(tx *bolt.Tx, id string) (*Session, error) {
+	data := tx.Bucket(sessionsBucket).Get([]byte(id))
+	if data == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+
+	var sess Session
+	if err := json.Unmarshal(data, &sess); err != nil {
+		return nil, fmt.Errorf("session: failed to decode session %s: %w", id, err)
+	}
+	if sess.Expired(s.now()) {
+		return nil, fmt.Errorf("%w: %s expired", ErrNotFound, id)
+	}
+
+	return &sess, nil
+}
+
+// put writes a session in a transaction.
+func put(tx *bolt.Tx, sess *Session) error {
+	data, err := json.Marshal(sess)
+	if err != nil {
+		return fmt.Errorf("session: failed to encode session %s: %w", sess.ID, err)
+	}
+
+	return tx.Bucket(sessionsBucket).Put([]byte(sess.ID), data)
+}
@@ -0,0 +1,152 @@
+package session_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ju4n97/relic/internal/session"
+)
+
+// clock is a settable time source.
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time { return c.now }
+
+func newStore(t *testing.T) (*session.Store, *clock, string) {
+	t.Helper()
+
+	c := &clock{now: time.Date(2025, 10, 18, 12, 0, 0, 0, time.UTC)}
+	path := filepath.Join(t.TempDir(), "sessions.db")
+
+	store, err := session.Open(path, session.WithClock(c.Now))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	return store, c, path
+}
+
+func TestStore_PersistsSessions(t *testing.T) {
+	t.Parallel()
+
+	c := &clock{now: time.Date(2025, 10, 18, 12, 0, 0, 0, time.UTC)}
+	path := filepath.Join(t.TempDir(), "sessions.db")
+
+	store, err := session.Open(path, session.WithClock(c.Now))
+	require.NoError(t, err)
+
+	sess := session.New(c.now, time.Hour)
+	sess.ModelID = "qwen"
+	sess.SystemPrompt = "You are a kitchen assistant."
+	sess.AddTurn(c.now, "Set a timer", "For how long?", 0)
+	require.NoError(t, store.Put(sess))
+	require.NoError(t, store.Close())
+
+	store, err = session.Open(path, session.WithClock(c.Now))
+	require.NoError(t, err)
+	defer store.Close()
+
+	got, err := store.Get(sess.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "qwen", got.ModelID)
+	assert.Equal(t, "You are a kitchen assistant.", got.SystemPrompt)
+	require.Len(t, got.Messages, 2)
+	assert.Equal(t, session.RoleAssistant, got.Messages[1].Role)
+	assert.Equal(t, "For how long?", got.Messages[1].Content)
+}
+
+func TestStore_Expiration(t *testing.T) {
+	t.Parallel()
+
+	store, c, _ := newStore(t)
+
+	sess := session.New(c.now, time.Hour)
+	require.NoError(t, store.Put(sess))
+
+	// A turn extends the lifetime of the session.
+	c.now = c.now.Add(50 * time.Minute)
+	_, err := store.Update(sess.ID, func(s *session.Session) error {
+		s.AddTurn(c.now, "hi", "hello", 0)
+		return nil
+	})
+	require.NoError(t, err)
+
+	c.now = c.now.Add(50 * time.Minute)
+	_, err = store.Get(sess.ID)
+	require.NoError(t, err)
+
+	c.now = c.now.Add(10 * time.Minute)
+	_, err = store.Get(sess.ID)
+	require.ErrorIs(t, err, session.ErrNotFound)
+
+	deleted, err := store.DeleteExpired()
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	require.ErrorIs(t, store.Delete(sess.ID), session.ErrNotFound)
+}
+
+func TestStore_ListByOwner(t *testing.T) {
+	t.Parallel()
+
+	store, c, _ := newStore(t)
+
+	for _, owner := range []string{"kitchen", "hallway", "kitchen"} {
+		sess := session.New(c.now, time.Hour)
+		sess.Owner = owner
+		require.NoError(t, store.Put(sess))
+		c.now = c.now.Add(time.Second)
+	}
+
+	sessions, err := store.List("kitchen")
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	assert.True(t, sessions[0].CreatedAt.Before(sessions[1].CreatedAt))
+
+	sessions, err = store.List("")
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+}
+
+func TestStore_UpdateFailureKeepsSession(t *testing.T) {
+	t.Parallel()
+
+	store, c, _ := newStore(t)
+
+	sess := session.New(c.now, time.Hour)
+	require.NoError(t, store.Put(sess))
+
+	failure := errors.New("generation failed")
+	_, err := store.Update(sess.ID, func(s *session.Session) error {
+		s.AddTurn(c.now, "hi", "hello", 0)
+		return failure
+	})
+	require.ErrorIs(t, err, failure)
+
+	got, err := store.Get(sess.ID)
+	require.NoError(t, err)
+	assert.Empty(t, got.Messages)
+}
+
+func TestSession_AddTurnDropsOldestTurns(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 10, 18, 12, 0, 0, 0, time.UTC)
+	sess := session.New(now, time.Hour)
+	sess.SystemPrompt = "Be brief."
+
+	sess.AddTurn(now, "one", "1", 3)
+	sess.AddTurn(now, "two", "2", 3)
+	sess.AddTurn(now, "three", "3", 3)
+
+	// Dropping one message would start the history with a reply.
+	require.Len(t, sess.Messages, 2)
+	assert.Equal(t, "three", sess.Messages[0].Content)
+	assert.Equal(t, 3, sess.Turns)
+	assert.Equal(t, "system: Be brief.\n\nuser: three\n\nassistant: 3\n\n", sess.Transcript())
+}
@@ -0,0 +1,9 @@
+package tlsconfig
+
+import "errors"
+
+// Error definitions for the tlsconfig package.
+var (
+	ErrMissingKeyPair = errors.New("both a certificate and a key are required")
+	ErrNoCertificates = errors.New("no PEM certificates found")
+)
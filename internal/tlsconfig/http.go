@@ -0,0 +1,25 @@
+package tlsconfig
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/httplog/v3"
+)
+
+// Middleware attaches the identity of the client certificate of a request to the
+// request context and logs.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		identity := Identity(r.TLS)
+		if identity == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := WithIdentity(r.Context(), identity)
+		httplog.SetAttrs(ctx, slog.String("client_identity", identity))
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,208 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// Options are the files the TLS configuration of the listeners is read from.
+type Options struct {
+	CertFile     string // PEM certificate chain of the server
+	KeyFile      string // PEM private key of the server
+	ClientCAFile string // PEM CA bundle client certificates must chain to; enables mutual TLS
+}
+
+// Enabled reports whether TLS is configured.
+func (o Options) Enabled() bool {
+	return o.CertFile != "" || o.KeyFile != ""
+}
+
+// Server serves the TLS configuration of the listeners. The certificate, key and
+// client CA files are reloaded when they change, so rotated certificates are
+// picked up by new connections without a restart.
+type Server struct {
+	opts Options
+
+	mu       sync.Mutex
+	config   *tls.Config
+	modTimes map[string]time.Time // Modification times of the files config was read from
+}
+
+// NewServer loads the files of opts and returns the Server serving them.
+func NewServer(opts Options) (*Server, error) {
+	if opts.CertFile == "" || opts.KeyFile == "" {
+		return nil, fmt.Errorf("tlsconfig: %w", ErrMissingKeyPair)
+	}
+
+	s := &Server{opts: opts}
+	if err := s.reload(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Config returns the TLS configuration to serve with. Each handshake uses the
+// latest certificate and client CAs.
+func (s *Server) Config() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return s.current(), nil
+		},
+	}
+}
+
+// MutualTLS reports whether clients must present a certificate.
+func (s *Server) MutualTLS() bool {
+	return s.opts.ClientCAFile != ""
+}
+
+// current returns the TLS configuration, reloaded first if a file changed. A
+// failed reload keeps the previous configuration, e.g. while a certificate and
+// its key are being replaced one after the other.
+func (s *Server) current() *tls.Config {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.changed() {
+		if err := s.reloadLocked(); err != nil {
+			slog.Warn("Failed to reload TLS certificates, keeping the previous ones", "error", err)
+		} else {
+			slog.Info("TLS certificates reloaded", "cert", s.opts.CertFile)
+		}
+	}
+
+	return s.config
+}
+
+// changed reports whether a file was modified since it was loaded.
+func (s *Server) changed() bool {
+	for path, modTime := range s.modTimes {
+		info, err := os.Stat(path)
+		if err != nil || !info.ModTime().Equal(modTime) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// reload loads the files.
+func (s *Server) reload() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.reloadLocked()
+}
+
+// reloadLocked loads the files. s.mu must be held.
+func (s *Server) reloadLocked() error {
+	modTimes := map[string]time.Time{}
+	for _, path := range []string{s.opts.CertFile, s.opts.KeyFile, s.opts.ClientCAFile} {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("tlsconfig: %w", err)
+		}
+		modTimes[path] = info.ModTime()
+	}
+
+	cert, err := tls.LoadX509KeyPair(s.opts.CertFile, s.opts.KeyFile)
+	if err != nil {
+		return fmt.Errorf("tlsconfig: failed to load key pair: %w", err)
+	}
+
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+		NextProtos:   []string{"h2", "http/1.1"},
+	}
+
+	if s.opts.ClientCAFile != "" {
+		pool, err := LoadCertPool(s.opts.ClientCAFile)
+		if err != nil {
+			return err
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	s.config = config
+	s.modTimes = modTimes
+
+	return nil
+}
+
+// LoadCertPool returns a pool of the PEM certificates of a file.
+func LoadCertPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("tlsconfig: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("tlsconfig: %s: %w", path, ErrNoCertificates)
+	}
+
+	return pool, nil
+}
+
+// Identity returns the identity of the verified client certificate of a
+// connection: its common name, else its first DNS name, URI or email address.
+// It returns an empty string if the client presented no certificate.
+func Identity(state *tls.ConnectionState) string {
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return ""
+	}
+
+	cert := state.VerifiedChains[0][0]
+	switch {
+	case cert.Subject.CommonName != "":
+		return cert.Subject.CommonName
+	case len(cert.DNSNames) > 0:
+		return cert.DNSNames[0]
+	case len(cert.URIs) > 0:
+		return cert.URIs[0].String()
+	case len(cert.EmailAddresses) > 0:
+		return cert.EmailAddresses[0]
+	default:
+		return cert.Subject.String()
+	}
+}
+
+// identityContextKey is the context key of the client certificate identity.
+type identityContextKey struct{}
+
+// WithIdentity returns a copy of ctx carrying the identity of the client certificate.
+func WithIdentity(ctx context.Context, identity string) context.Context {
+	return context.WithValue(ctx, identityContextKey{}, identity)
+}
+
+// IdentityFromContext returns the identity of the client certificate of a request,
+// attached by the HTTP middleware, or of the peer of a gRPC call.
+func IdentityFromContext(ctx context.Context) string {
+	if identity, ok := ctx.Value(identityContextKey{}).(string); ok {
+		return identity
+	}
+
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			return Identity(&info.State)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,196 @@
+package tlsconfig_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ju4n97/relic/internal/tlsconfig"
+)
+
+// authority is a self-signed CA issuing test certificates.
+type authority struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newAuthority(t *testing.T) *authority {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "relic test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &authority{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns a PEM certificate and key for a name, valid for localhost.
+func (a *authority) issue(t *testing.T, name string, serial int64) (certPEM, keyPEM []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, a.cert, &key.PublicKey, a.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// writeFile writes a file with a modification time distinct from the previous one.
+func writeFile(t *testing.T, path string, data []byte, modTime time.Time) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+// serve starts an HTTPS server echoing the client identity.
+func serve(t *testing.T, server *tlsconfig.Server) *httptest.Server {
+	t.Helper()
+
+	ts := httptest.NewUnstartedServer(tlsconfig.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, tlsconfig.IdentityFromContext(r.Context()))
+	})))
+	ts.TLS = server.Config()
+	ts.StartTLS()
+	t.Cleanup(ts.Close)
+
+	return ts
+}
+
+func client(ca *authority, certs ...tls.Certificate) *http.Client {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+		RootCAs:      pool,
+		Certificates: certs,
+	}}}
+}
+
+func TestServer_MutualTLS(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	ca := newAuthority(t)
+	now := time.Now()
+
+	certPEM, keyPEM := ca.issue(t, "relic", 2)
+	opts := tlsconfig.Options{
+		CertFile:     filepath.Join(dir, "server.crt"),
+		KeyFile:      filepath.Join(dir, "server.key"),
+		ClientCAFile: filepath.Join(dir, "ca.crt"),
+	}
+	writeFile(t, opts.CertFile, certPEM, now)
+	writeFile(t, opts.KeyFile, keyPEM, now)
+	writeFile(t, opts.ClientCAFile, ca.pem, now)
+
+	server, err := tlsconfig.NewServer(opts)
+	require.NoError(t, err)
+	assert.True(t, server.MutualTLS())
+	ts := serve(t, server)
+
+	clientCertPEM, clientKeyPEM := ca.issue(t, "voice-agent", 3)
+	clientCert, err := tls.X509KeyPair(clientCertPEM, clientKeyPEM)
+	require.NoError(t, err)
+
+	resp, err := client(ca, clientCert).Get(ts.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "voice-agent", string(body))
+
+	_, err = client(ca).Get(ts.URL)
+	require.Error(t, err, "clients without a certificate are rejected")
+}
+
+func TestServer_ReloadsRotatedCertificate(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	ca := newAuthority(t)
+	now := time.Now()
+
+	certPEM, keyPEM := ca.issue(t, "relic", 2)
+	opts := tlsconfig.Options{
+		CertFile: filepath.Join(dir, "server.crt"),
+		KeyFile:  filepath.Join(dir, "server.key"),
+	}
+	writeFile(t, opts.CertFile, certPEM, now)
+	writeFile(t, opts.KeyFile, keyPEM, now)
+
+	server, err := tlsconfig.NewServer(opts)
+	require.NoError(t, err)
+	ts := serve(t, server)
+
+	serial := func() int64 {
+		conn, err := tls.Dial("tcp", ts.Listener.Addr().String(), client(ca).Transport.(*http.Transport).TLSClientConfig)
+		require.NoError(t, err)
+		defer conn.Close()
+		return conn.ConnectionState().PeerCertificates[0].SerialNumber.Int64()
+	}
+	assert.Equal(t, int64(2), serial())
+
+	// A half-written rotation keeps serving the previous certificate.
+	rotatedCertPEM, rotatedKeyPEM := ca.issue(t, "relic", 4)
+	writeFile(t, opts.CertFile, rotatedCertPEM, now.Add(time.Second))
+	assert.Equal(t, int64(2), serial())
+
+	writeFile(t, opts.KeyFile, rotatedKeyPEM, now.Add(2*time.Second))
+	assert.Equal(t, int64(4), serial())
+}
+
+func TestNewServer_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := tlsconfig.NewServer(tlsconfig.Options{CertFile: "server.crt"})
+	require.ErrorIs(t, err, tlsconfig.ErrMissingKeyPair)
+
+	_, err = tlsconfig.NewServer(tlsconfig.Options{CertFile: "missing.crt", KeyFile: "missing.key"})
+	require.Error(t, err)
+}
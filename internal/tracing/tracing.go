@@ -0,0 +1,157 @@
+package tracing
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ju4n97/relic/internal/envvar"
+)
+
+// TracerName is the name of the tracer creating the RELIC spans.
+const TracerName = "github.com/ju4n97/relic"
+
+// Attribute keys set on the RELIC spans.
+const (
+	Service         = attribute.Key("relic.service")
+	ModelID         = attribute.Key("relic.model.id")
+	Backend         = attribute.Key("relic.backend")
+	Profile         = attribute.Key("relic.profile")
+	InputBytes      = attribute.Key("relic.input.bytes")
+	OutputBytes     = attribute.Key("relic.output.bytes")
+	PromptTokens    = attribute.Key("relic.llm.prompt_tokens")
+	GeneratedTokens = attribute.Key("relic.llm.generated_tokens")
+	TokensPerSecond = attribute.Key("relic.llm.tokens_per_second")
+	AudioSeconds    = attribute.Key("relic.audio.seconds")
+	Characters      = attribute.Key("relic.tts.characters")
+)
+
+// Option is a function that configures Setup.
+type Option func(*options)
+
+// options holds the configuration of Setup.
+type options struct {
+	exporter    sdktrace.SpanExporter
+	serviceName string
+}
+
+// WithExporter sets the exporter spans are sent to instead of the OTLP exporter
+// configured from the environment, e.g. an in-memory exporter in tests. Spans are
+// exported synchronously as they end.
+func WithExporter(exporter sdktrace.SpanExporter) Option {
+	return func(o *options) {
+		o.exporter = exporter
+	}
+}
+
+// WithServiceName sets the service name reported with the spans. OTEL_SERVICE_NAME
+// takes precedence.
+func WithServiceName(name string) Option {
+	return func(o *options) {
+		o.serviceName = name
+	}
+}
+
+// Setup installs the global tracer provider and the W3C trace context propagator.
+// Spans are exported over OTLP to the endpoint set in OTEL_EXPORTER_OTLP_ENDPOINT
+// (or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT), using the protocol set in
+// OTEL_EXPORTER_OTLP_PROTOCOL, http/protobuf by default. Without an endpoint no
+// span is recorded, but the trace context of incoming requests is still propagated.
+// The returned function flushes the pending spans and must be called on exit.
+func Setup(ctx context.Context, opts ...Option) (func(context.Context) error, error) {
+	o := &options{serviceName: "relic"}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var processor sdktrace.TracerProviderOption
+	if o.exporter != nil {
+		processor = sdktrace.WithSyncer(o.exporter)
+	} else {
+		endpoint := cmp.Or(os.Getenv(envvar.OTelExporterOTLPTracesEndpoint), os.Getenv(envvar.OTelExporterOTLPEndpoint))
+		if endpoint == "" {
+			slog.Info("Tracing disabled, no OTLP endpoint configured")
+			return func(context.Context) error { return nil }, nil
+		}
+
+		exporter, err := newOTLPExporter(ctx)
+		if err != nil {
+			return nil, err
+		}
+		processor = sdktrace.WithBatcher(exporter)
+		slog.Info("Tracing enabled", "endpoint", endpoint)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(o.serviceName)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("tracing: failed to create resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		processor,
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// newOTLPExporter creates the OTLP exporter for the protocol set in the environment.
+// The exporters read the endpoint, headers and TLS settings from the environment.
+func newOTLPExporter(ctx context.Context) (sdktrace.SpanExporter, error) {
+	protocol := cmp.Or(os.Getenv(envvar.OTelExporterOTLPTracesProtocol), os.Getenv(envvar.OTelExporterOTLPProtocol), "http/protobuf")
+
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
+	)
+	switch protocol {
+	case "grpc":
+		exporter, err = otlptracegrpc.New(ctx)
+	case "http/protobuf":
+		exporter, err = otlptracehttp.New(ctx)
+	default:
+		return nil, fmt.Errorf("tracing: unsupported OTLP protocol %q", protocol)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tracing: failed to create OTLP exporter: %w", err)
+	}
+
+	return exporter, nil
+}
+
+// Tracer returns the tracer creating the RELIC spans from the global provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// End records the error a span ended with, if any, and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
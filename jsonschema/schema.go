@@ -0,0 +1,12 @@
+// Package jsonschema embeds the JSON schemas of the RELIC config files.
+package jsonschema
+
+import _ "embed"
+
+// RelicV1ID is the ID of the schema of version 1 config files.
+const RelicV1ID = "https://relic.pages.dev/schemas/relic.v1.schema.json"
+
+// RelicV1 is the schema of version 1 config files.
+//
+//go:embed relic.v1.schema.json
+var RelicV1 []byte
@@ -0,0 +1,85 @@
+package relic
+
+import (
+	"time"
+
+	inferencev2 "github.com/ju4n97/relic/sdk-go/pb/inference/v2"
+)
+
+// ChatResult is the reply of the assistant to a conversation.
+type ChatResult struct {
+	Usage        *Usage   // Set when the backend reports it
+	Timings      *Timings // Set when the backend reports them
+	Text         string
+	Model        string // Model the reply was generated with, aliases resolved
+	FinishReason string // Why generation stopped, e.g. "stop" or "length"
+}
+
+// Usage is the work done to serve a request. Only the fields that apply to the
+// service are set.
+type Usage struct {
+	PromptTokens    int
+	GeneratedTokens int
+	TokensPerSecond float64
+	AudioSeconds    float64 // Audio transcribed or produced
+	Characters      int     // Text synthesized
+}
+
+// Timings is the time the backend spent on a reply.
+type Timings struct {
+	Prompt                   time.Duration // Processing the prompt
+	Generation               time.Duration // Generating the reply
+	PromptTokensPerSecond    float64
+	GeneratedTokensPerSecond float64
+}
+
+// buildChatResult converts a chat reply from protobuf.
+func buildChatResult(resp *inferencev2.ChatResponse) *ChatResult {
+	return &ChatResult{
+		Usage:        buildUsage(resp.GetMetadata().GetUsage()),
+		Timings:      buildTimings(resp.GetTimings()),
+		Text:         resp.GetMessage().GetContent(),
+		Model:        resp.GetMetadata().GetModelId(),
+		FinishReason: resp.GetFinishReason(),
+	}
+}
+
+// buildUsage converts a usage from protobuf.
+func buildUsage(u *inferencev2.Usage) *Usage {
+	if u == nil {
+		return nil
+	}
+
+	return &Usage{
+		PromptTokens:    int(u.PromptTokens),
+		GeneratedTokens: int(u.GeneratedTokens),
+		TokensPerSecond: u.TokensPerSecond,
+		AudioSeconds:    u.AudioSeconds,
+		Characters:      int(u.Characters),
+	}
+}
+
+// buildTimings converts timings from protobuf.
+func buildTimings(t *inferencev2.Timings) *Timings {
+	if t == nil {
+		return nil
+	}
+
+	return &Timings{
+		Prompt:                   seconds(t.PromptSeconds),
+		Generation:               seconds(t.GenerationSeconds),
+		PromptTokensPerSecond:    t.PromptTokensPerSecond,
+		GeneratedTokensPerSecond: t.GeneratedTokensPerSecond,
+	}
+}
+
+// buildStreamChunk converts a chunk of a streamed reply from protobuf.
+func buildStreamChunk(chunk *inferencev2.ChatChunk) StreamChunk {
+	return StreamChunk{
+		Done:         chunk.Done,
+		Content:      chunk.Delta,
+		FinishReason: chunk.FinishReason,
+		Usage:        buildUsage(chunk.GetMetadata().GetUsage()),
+		Timings:      buildTimings(chunk.Timings),
+	}
+}
@@ -0,0 +1,58 @@
+package relic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	inferencev2 "github.com/ju4n97/relic/sdk-go/pb/inference/v2"
+)
+
+func TestBuildChatResult(t *testing.T) {
+	result := buildChatResult(&inferencev2.ChatResponse{
+		Message:      &inferencev2.ChatMessage{Role: inferencev2.Role_ROLE_ASSISTANT, Content: "Hello!"},
+		FinishReason: "length",
+		Metadata: &inferencev2.ResponseMetadata{
+			ModelId: "qwen",
+			Usage:   &inferencev2.Usage{PromptTokens: 12, GeneratedTokens: 3, TokensPerSecond: 2},
+		},
+		Timings: &inferencev2.Timings{PromptSeconds: 0.25, GenerationSeconds: 1.5, PromptTokensPerSecond: 48, GeneratedTokensPerSecond: 2},
+	})
+
+	assert.Equal(t, &ChatResult{
+		Usage:        &Usage{PromptTokens: 12, GeneratedTokens: 3, TokensPerSecond: 2},
+		Timings:      &Timings{Prompt: 250 * time.Millisecond, Generation: 1500 * time.Millisecond, PromptTokensPerSecond: 48, GeneratedTokensPerSecond: 2},
+		Text:         "Hello!",
+		Model:        "qwen",
+		FinishReason: "length",
+	}, result)
+}
+
+func TestBuildChatResult_NoMetadata(t *testing.T) {
+	result := buildChatResult(&inferencev2.ChatResponse{Message: &inferencev2.ChatMessage{Content: "Hello!"}})
+
+	assert.Equal(t, "Hello!", result.Text)
+	assert.Empty(t, result.Model)
+	assert.Nil(t, result.Usage, "unreported usage stays unset")
+	assert.Nil(t, result.Timings, "unreported timings stay unset")
+}
+
+func TestBuildStreamChunk(t *testing.T) {
+	delta := buildStreamChunk(&inferencev2.ChatChunk{Delta: "Hel"})
+	assert.Equal(t, StreamChunk{Content: "Hel"}, delta)
+
+	last := buildStreamChunk(&inferencev2.ChatChunk{
+		Done:         true,
+		FinishReason: "stop",
+		Metadata:     &inferencev2.ResponseMetadata{Usage: &inferencev2.Usage{GeneratedTokens: 2}},
+		Timings:      &inferencev2.Timings{GenerationSeconds: 0.5},
+	})
+	assert.True(t, last.Done)
+	assert.Equal(t, "stop", last.FinishReason)
+	require.NotNil(t, last.Usage)
+	assert.Equal(t, 2, last.Usage.GeneratedTokens)
+	require.NotNil(t, last.Timings)
+	assert.Equal(t, 500*time.Millisecond, last.Timings.Generation)
+}
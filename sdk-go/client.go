@@ -2,45 +2,206 @@ package relic
 
 import (
 	"context"
-	"encoding/json"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"io"
-	"maps"
+	"os"
+	"strings"
+	"time"
 
-	inferencev1 "github.com/ju4n97/relic/sdk-go/pb/inference/v1"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
-	"google.golang.org/protobuf/types/known/structpb"
+	inferencev2 "github.com/ju4n97/relic/sdk-go/pb/inference/v2"
+	"google.golang.org/grpc/keepalive"
 )
 
 // Client is a client for RELIC inference services.
 type Client struct {
-	conn            *grpc.ClientConn
-	inferenceClient inferencev1.InferenceServiceClient
+	transport   transport
+	retryPolicy RetryPolicy
+	timeout     time.Duration
+}
+
+// ClientOption is a function that configures a Client.
+type ClientOption func(*clientOptions)
+
+// clientOptions holds the configuration of a Client.
+type clientOptions struct {
+	apiKey     string
+	tls        bool
+	tlsConfig  *tls.Config
+	rootCAFile string
+	certFile   string
+	keyFile    string
+	serverName string
+
+	retryPolicy RetryPolicy
+	timeout     time.Duration
+	keepalive   keepalive.ClientParameters
+}
+
+// Keepalive defaults of gRPC connections. The server accepts pings every 10
+// seconds at most.
+const (
+	defaultKeepaliveTime    = 30 * time.Second
+	defaultKeepaliveTimeout = 10 * time.Second
+)
+
+// WithDefaultTimeout bounds the calls that take no WithTimeout option, retries
+// included. PullModel is not bounded, downloads take as long as they take.
+func WithDefaultTimeout(timeout time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithKeepalive pings gRPC servers every interval while calls are running, and
+// closes the connection if a ping is not answered within timeout, so that calls
+// to a server that went away fail instead of hanging. An interval of 0 disables
+// pings. The default is a ping every 30 seconds with a timeout of 10 seconds.
+func WithKeepalive(interval, timeout time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.keepalive = keepalive.ClientParameters{Time: interval, Timeout: timeout}
+	}
+}
+
+// WithAPIKey sets the API key sent with every call, for servers that require
+// authentication.
+func WithAPIKey(key string) ClientOption {
+	return func(o *clientOptions) {
+		o.apiKey = key
+	}
+}
+
+// WithTLS connects over TLS, verifying the server certificate with the system
+// roots. The other TLS options imply it.
+func WithTLS() ClientOption {
+	return func(o *clientOptions) {
+		o.tls = true
+	}
+}
+
+// WithTLSConfig connects over TLS with a base configuration, which the other
+// TLS options are applied on top of.
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return func(o *clientOptions) {
+		o.tls = true
+		o.tlsConfig = config
+	}
+}
+
+// WithRootCA connects over TLS, verifying the server certificate with the PEM CA
+// bundle of a file instead of the system roots, e.g. for self-signed servers.
+func WithRootCA(caFile string) ClientOption {
+	return func(o *clientOptions) {
+		o.tls = true
+		o.rootCAFile = caFile
+	}
+}
+
+// WithClientCertificate connects over TLS presenting a client certificate, for
+// servers that require mutual TLS.
+func WithClientCertificate(certFile, keyFile string) ClientOption {
+	return func(o *clientOptions) {
+		o.tls = true
+		o.certFile = certFile
+		o.keyFile = keyFile
+	}
+}
+
+// WithServerName connects over TLS, verifying the server certificate against a
+// name other than the host of the address.
+func WithServerName(name string) ClientOption {
+	return func(o *clientOptions) {
+		o.tls = true
+		o.serverName = name
+	}
+}
+
+// buildTLSConfig returns the TLS configuration of the connection.
+func (o *clientOptions) buildTLSConfig() (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if o.tlsConfig != nil {
+		config = o.tlsConfig.Clone()
+	}
+
+	if o.rootCAFile != "" {
+		data, err := os.ReadFile(o.rootCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("relic: failed to read root CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("relic: no PEM certificates found in %s", o.rootCAFile)
+		}
+		config.RootCAs = pool
+	}
+
+	if o.certFile != "" || o.keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(o.certFile, o.keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("relic: failed to load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	if o.serverName != "" {
+		config.ServerName = o.serverName
+	}
+
+	return config, nil
 }
 
 // NewClient creates a new Client instance.
-func NewClient(addr string) (*Client, error) {
-	conn, err := grpc.NewClient(addr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+//
+// The address selects the transport. An http:// or https:// URL of the server
+// root, e.g. "https://relic.example.com", uses the HTTP API, for deployments
+// behind proxies that only pass HTTP. Any other address, e.g. "localhost:50051"
+// or "unix:///run/relic.sock", is dialed over gRPC. The HTTP API does not serve
+// embeddings, which fail with ErrUnsupported.
+//
+// Calls that take WithRetries are retried by the DefaultRetryPolicy while the
+// server is unavailable or too busy, see WithRetryPolicy. Errors of the server
+// match the error definitions of the package with errors.Is.
+//
+// Calls are traced with the global OpenTelemetry tracer provider and carry the
+// W3C trace context of ctx, so server spans join the trace of the caller. Nothing
+// is recorded unless the application sets up a tracer provider.
+func NewClient(addr string, opts ...ClientOption) (*Client, error) {
+	o := &clientOptions{
+		retryPolicy: DefaultRetryPolicy,
+		keepalive:   keepalive.ClientParameters{Time: defaultKeepaliveTime, Timeout: defaultKeepaliveTimeout},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	var (
+		t   transport
+		err error
 	)
+	if strings.HasPrefix(addr, "http://") || strings.HasPrefix(addr, "https://") {
+		t, err = newHTTPTransport(addr, o)
+	} else {
+		t, err = newGRPCTransport(addr, o)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("relic: failed to create gRPC client: %w", err)
+		return nil, err
 	}
 
 	return &Client{
-		conn:            conn,
-		inferenceClient: inferencev1.NewInferenceServiceClient(conn),
+		transport:   t,
+		retryPolicy: o.retryPolicy,
+		timeout:     o.timeout,
 	}, nil
 }
 
-// Close closes the client gRPC connection.
+// Close closes the client connection.
 func (c *Client) Close() error {
-	return c.conn.Close()
+	return c.transport.close()
 }
 
-// Generate calls the LLM inference service.
+// Generate calls the chat service and returns the reply of the assistant.
 //
 // Example:
 //
@@ -51,22 +212,44 @@ func (c *Client) Close() error {
 //
 //	fmt.Println(output)
 func (c *Client) Generate(ctx context.Context, messages []Message, options ...Option) (string, error) {
+	result, err := c.Chat(ctx, messages, options...)
+	if err != nil {
+		return "", err
+	}
+
+	return result.Text, nil
+}
+
+// Chat calls the chat service and returns the reply of the assistant with its
+// usage, timings and finish reason.
+//
+// Example:
+//
+//	result, err := client.Chat(ctx, messages, opts...)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	fmt.Println(result.Text, result.FinishReason, result.Usage.GeneratedTokens)
+func (c *Client) Chat(ctx context.Context, messages []Message, options ...Option) (*ChatResult, error) {
 	cfg := c.applyOptions(options...)
 
-	req, err := c.buildLLMRequest(messages, cfg)
+	req, err := buildChatRequest(messages, cfg)
 	if err != nil {
-		return "", fmt.Errorf("relic: failed to build generate request: %w", err)
+		return nil, fmt.Errorf("relic: failed to build generate request: %w", err)
 	}
 
-	resp, err := c.inferenceClient.Infer(ctx, req)
+	resp, err := call(ctx, c, cfg.Timeout, cfg.Retries, func(ctx context.Context) (*inferencev2.ChatResponse, error) {
+		return c.transport.chat(ctx, req)
+	})
 	if err != nil {
-		return "", fmt.Errorf("relic: failed to generate: %w", err)
+		return nil, fmt.Errorf("relic: failed to generate: %w", err)
 	}
 
-	return string(resp.Output), nil
+	return buildChatResult(resp), nil
 }
 
-// GenerateStream calls the LLM inference service with streaming support.
+// GenerateStream calls the chat service with streaming support.
 // The channel is closed when streaming completes or an error occurs.
 //
 // Example:
@@ -88,28 +271,23 @@ func (c *Client) GenerateStream(ctx context.Context, messages []Message, options
 
 		cfg := c.applyOptions(options...)
 
-		req, err := c.buildLLMRequest(messages, cfg)
+		req, err := buildChatRequest(messages, cfg)
 		if err != nil {
 			ch <- StreamChunk{Error: fmt.Errorf("relic: failed to build generate request: %w", err)}
 			return
 		}
 
-		stream, err := c.inferenceClient.InferStream(ctx)
+		ctx, cancel := withTimeout(ctx, cfg.Timeout)
+		defer cancel()
+
+		stream, err := openStream(ctx, c, cfg.Retries, func(ctx context.Context) (receiver[*inferencev2.ChatChunk], error) {
+			return c.transport.chatStream(ctx, req)
+		})
 		if err != nil {
 			ch <- StreamChunk{Error: fmt.Errorf("relic: failed to create stream: %w", err)}
 			return
 		}
 
-		if err := stream.Send(req); err != nil {
-			ch <- StreamChunk{Error: fmt.Errorf("relic: failed to send initial request: %w", err)}
-			return
-		}
-
-		if err := stream.CloseSend(); err != nil {
-			ch <- StreamChunk{Error: fmt.Errorf("relic: failed to close stream: %w", err)}
-			return
-		}
-
 		for {
 			chunk, err := stream.Recv()
 			if err == io.EOF {
@@ -121,7 +299,7 @@ func (c *Client) GenerateStream(ctx context.Context, messages []Message, options
 			}
 
 			select {
-			case ch <- StreamChunk{Content: string(chunk.Data)}:
+			case ch <- buildStreamChunk(chunk):
 			case <-ctx.Done():
 				ch <- StreamChunk{Error: ctx.Err()}
 				return
@@ -132,7 +310,7 @@ func (c *Client) GenerateStream(ctx context.Context, messages []Message, options
 	return ch
 }
 
-// TranscribeAudio calls the STT inference service.
+// TranscribeAudio calls the speech-to-text service with WAV audio.
 //
 // Example:
 //
@@ -143,29 +321,46 @@ func (c *Client) GenerateStream(ctx context.Context, messages []Message, options
 //
 //	fmt.Println(output)
 func (c *Client) TranscribeAudio(ctx context.Context, audio []byte, options ...Option) (string, error) {
-	cfg := c.applyOptions(options...)
-
-	parameters, err := c.buildParameters(cfg.Parameters)
+	transcript, err := c.Transcribe(ctx, audio, options...)
 	if err != nil {
-		return "", fmt.Errorf("relic: failed to build parameters: %w", err)
+		return "", err
 	}
 
-	req := &inferencev1.InferenceRequest{
-		Provider:   cfg.Provider,
-		ModelId:    cfg.ModelID,
-		Parameters: parameters,
-		Input:      audio,
+	return transcript.Text, nil
+}
+
+// Transcribe calls the speech-to-text service with WAV audio and returns the
+// transcript with its timed segments.
+//
+// Example:
+//
+//	transcript, err := client.Transcribe(ctx, audio, opts...)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	for _, segment := range transcript.Segments {
+//		fmt.Println(segment.Start, segment.Text)
+//	}
+func (c *Client) Transcribe(ctx context.Context, audio []byte, options ...Option) (*Transcript, error) {
+	cfg := c.applyOptions(options...)
+
+	req, err := buildTranscribeRequest(audio, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("relic: failed to build transcribe request: %w", err)
 	}
 
-	resp, err := c.inferenceClient.Infer(ctx, req)
+	resp, err := call(ctx, c, cfg.Timeout, cfg.Retries, func(ctx context.Context) (*inferencev2.TranscribeResponse, error) {
+		return c.transport.transcribe(ctx, req)
+	})
 	if err != nil {
-		return "", fmt.Errorf("relic: failed to transcribe audio: %w", err)
+		return nil, fmt.Errorf("relic: failed to transcribe audio: %w", err)
 	}
 
-	return string(resp.Output), nil
+	return buildTranscript(resp), nil
 }
 
-// SynthesizeSpeech converts text to speech using the TTS inference service.
+// SynthesizeSpeech converts text to WAV audio using the text-to-speech service.
 //
 // Example:
 //
@@ -176,92 +371,99 @@ func (c *Client) TranscribeAudio(ctx context.Context, audio []byte, options ...O
 //
 //	fmt.Println(audio)
 func (c *Client) SynthesizeSpeech(ctx context.Context, text string, options ...Option) ([]byte, error) {
-	cfg := c.applyOptions(options...)
-
-	parameters, err := c.buildParameters(cfg.Parameters)
+	speech, err := c.Synthesize(ctx, text, options...)
 	if err != nil {
-		return nil, fmt.Errorf("relic: failed to build parameters: %w", err)
+		return nil, err
 	}
 
-	req := &inferencev1.InferenceRequest{
-		Provider:   cfg.Provider,
-		ModelId:    cfg.ModelID,
-		Parameters: parameters,
-		Input:      []byte(text),
+	return speech.Audio, nil
+}
+
+// Synthesize converts text to speech using the text-to-speech service and
+// returns the audio with its format, sample rate and duration.
+//
+// Example:
+//
+//	speech, err := client.Synthesize(ctx, "Hello, world!", opts...)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	fmt.Println(speech.Duration, speech.SampleRate)
+func (c *Client) Synthesize(ctx context.Context, text string, options ...Option) (*Speech, error) {
+	cfg := c.applyOptions(options...)
+
+	req, err := buildSynthesizeRequest(text, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("relic: failed to build synthesize request: %w", err)
 	}
 
-	resp, err := c.inferenceClient.Infer(ctx, req)
+	resp, err := call(ctx, c, cfg.Timeout, cfg.Retries, func(ctx context.Context) (*inferencev2.SynthesizeResponse, error) {
+		return c.transport.synthesize(ctx, req)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("relic: failed to synthesize speech: %w", err)
 	}
 
-	return resp.Output, nil
+	return buildSpeech(resp), nil
 }
 
-// applyOptions applies all options and returns a configured Config.
-func (c *Client) applyOptions(options ...Option) *Config {
-	cfg := &Config{
-		Parameters: map[string]any{},
-	}
+// Embed calls the embedding service and returns the embedding of each input, in
+// order. Embeddings are computed by the LLM models.
+//
+// Example:
+//
+//	vectors, err := client.Embed(ctx, []string{"Hello, world!"}, opts...)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	fmt.Println(len(vectors[0]))
+func (c *Client) Embed(ctx context.Context, inputs []string, options ...Option) ([][]float32, error) {
+	cfg := c.applyOptions(options...)
 
-	for _, option := range options {
-		option(cfg)
+	if len(inputs) == 0 {
+		return nil, errors.New("relic: inputs cannot be empty")
 	}
-
-	return cfg
-}
-
-// buildLLMRequest constructs an InferenceRequest for LLM operations.
-func (c *Client) buildLLMRequest(messages []Message, cfg *Config) (*inferencev1.InferenceRequest, error) {
-	if len(messages) == 0 {
-		return nil, errors.New("relic: messages cannot be empty")
+	if err := checkProvider(cfg, backendLlama); err != nil {
+		return nil, err
 	}
-
-	chatMessages := make([]map[string]string, len(messages))
-	for i, msg := range messages {
-		chatMessages[i] = map[string]string{
-			"role":    string(msg.Role),
-			"content": msg.Content,
-		}
+	if len(cfg.Parameters) > 0 {
+		return nil, errors.New("relic: embeddings take no parameters")
 	}
 
-	messagesJSON, err := json.Marshal(chatMessages)
+	req := &inferencev2.EmbedRequest{
+		ModelId: cfg.ModelID,
+		Profile: cfg.Profile,
+		Inputs:  inputs,
+	}
+	resp, err := call(ctx, c, cfg.Timeout, cfg.Retries, func(ctx context.Context) (*inferencev2.EmbedResponse, error) {
+		return c.transport.embed(ctx, req)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("relic: failed to marshal messages: %w", err)
+		return nil, fmt.Errorf("relic: failed to embed: %w", err)
 	}
 
-	parametersMap := make(map[string]any, len(cfg.Parameters)+1)
-	parametersMap["messages"] = string(messagesJSON)
-	maps.Copy(parametersMap, cfg.Parameters)
-
-	parameters, err := c.buildParameters(parametersMap)
-	if err != nil {
-		return nil, fmt.Errorf("relic: failed to build parameters: %w", err)
+	vectors := make([][]float32, len(inputs))
+	for _, embedding := range resp.Embeddings {
+		if i := int(embedding.Index); i >= 0 && i < len(vectors) {
+			vectors[i] = embedding.Values
+		}
 	}
 
-	return &inferencev1.InferenceRequest{
-		Provider:   cfg.Provider,
-		ModelId:    cfg.ModelID,
-		Input:      []byte(""),
-		Parameters: parameters,
-	}, nil
+	return vectors, nil
 }
 
-// buildParameters converts Go native types to protobuf Value map.
-func (c *Client) buildParameters(params map[string]any) (*structpb.Struct, error) {
-	if params == nil {
-		return &structpb.Struct{Fields: map[string]*structpb.Value{}}, nil
+// applyOptions applies all options and returns a configured Config.
+func (c *Client) applyOptions(options ...Option) *Config {
+	cfg := &Config{
+		Parameters: map[string]any{},
+		Timeout:    c.timeout,
 	}
 
-	fields := make(map[string]*structpb.Value, len(params))
-	for k, v := range params {
-		val, err := structpb.NewValue(v)
-		if err != nil {
-			return nil, fmt.Errorf("relic: failed to set parameter %s: %w", k, err)
-		}
-
-		fields[k] = val
+	for _, option := range options {
+		option(cfg)
 	}
 
-	return &structpb.Struct{Fields: fields}, nil
+	return cfg
 }
@@ -1,18 +1,27 @@
 package relic
 
-import "maps"
+import (
+	"maps"
+	"time"
+)
 
 // Config represents the configuration for inference operations.
 type Config struct {
-	Provider   string
+	Provider   string // Deprecated: the backend is the one the model runs on
 	ModelID    string
-	Parameters map[string]any
+	Profile    string
+	Parameters map[string]any // Typed by the request, others are passed through to the backend
+	Timeout    time.Duration  // Of the whole call, retries included; 0 for none
+	Retries    bool           // Retried by the retry policy of the client, see WithRetries
 }
 
 // Option is a function that configures inference operations.
 type Option func(*Config)
 
 // WithProvider sets the provider for inference operations.
+//
+// Deprecated: requests are served by the backend the model runs on. Requests
+// naming another provider than that backend fail.
 func WithProvider(provider string) Option {
 	return func(c *Config) {
 		c.Provider = provider
@@ -26,6 +35,23 @@ func WithModelID(modelID string) Option {
 	}
 }
 
+// WithProfile sets the compute profile the model is served with.
+// The first profile of the model is used when unset.
+func WithProfile(profile string) Option {
+	return func(c *Config) {
+		c.Profile = profile
+	}
+}
+
+// WithTimeout bounds a call, retries included, overriding the default timeout
+// of the client. For streams, it bounds the whole stream. A timeout of 0 leaves
+// the call unbounded.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Config) {
+		c.Timeout = timeout
+	}
+}
+
 // WithParameters merges the provided parameters with existing ones.
 func WithParameters(parameters map[string]any) Option {
 	return func(c *Config) {
@@ -47,3 +73,46 @@ func WithParameter(key string, value any) Option {
 		c.Parameters[key] = value
 	}
 }
+
+// WithMaxTokens sets the maximum number of tokens to generate.
+func WithMaxTokens(n int) Option {
+	return WithParameter("max_tokens", n)
+}
+
+// WithTemperature sets the sampling temperature of text generation, or of
+// transcription.
+func WithTemperature(temperature float64) Option {
+	return WithParameter("temperature", temperature)
+}
+
+// WithTopK limits sampling to the k most likely tokens.
+func WithTopK(k int) Option {
+	return WithParameter("top_k", k)
+}
+
+// WithTopP limits sampling to the most likely tokens whose probabilities add up to p.
+func WithTopP(p float64) Option {
+	return WithParameter("top_p", p)
+}
+
+// WithLanguage sets the spoken language of a transcription, e.g. "en".
+// The language is detected when unset.
+func WithLanguage(language string) Option {
+	return WithParameter("language", language)
+}
+
+// WithSpeaker sets the speaker of multi-speaker voices.
+func WithSpeaker(id int) Option {
+	return WithParameter("speaker_id", id)
+}
+
+// WithSpecialTokens adds the special tokens of the model, e.g. BOS, when
+// tokenizing text. Messages are always tokenized with them.
+func WithSpecialTokens() Option {
+	return WithParameter("add_special", true)
+}
+
+// WithTokenPieces returns the text of each token of a tokenization.
+func WithTokenPieces() Option {
+	return WithParameter("with_pieces", true)
+}
@@ -0,0 +1,408 @@
+package relic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Defaults of conversations.
+const (
+	defaultReplyTokens = 512 // Tokens kept free in the context window for the reply
+	charsPerToken      = 4   // Rough average of English text
+	messageTokens      = 4   // Chat template tokens around each message
+	summaryPrefix      = "Summary of the earlier conversation: "
+)
+
+// TokenCounter counts the tokens of messages as the model sees them, i.e. with
+// the chat template applied.
+type TokenCounter interface {
+	CountTokens(ctx context.Context, messages []Message, options ...Option) (int, error)
+}
+
+// TokenCounterFunc adapts a function to a TokenCounter.
+type TokenCounterFunc func(ctx context.Context, messages []Message, options ...Option) (int, error)
+
+// CountTokens implements TokenCounter.
+func (f TokenCounterFunc) CountTokens(ctx context.Context, messages []Message, options ...Option) (int, error) {
+	return f(ctx, messages, options...)
+}
+
+// EstimateTokens estimates the tokens of messages from their length, about 4
+// characters per token plus a few tokens per message for the chat template. It
+// is only a rough guess outside of English text; prefer counting with the Client,
+// as conversations do unless given EstimatedTokens.
+func EstimateTokens(messages []Message) int {
+	tokens := 0
+	for _, msg := range messages {
+		tokens += messageTokens + (len(msg.Content)+charsPerToken-1)/charsPerToken
+	}
+
+	return tokens
+}
+
+// EstimatedTokens is the TokenCounter of EstimateTokens, which makes no calls to
+// the server.
+var EstimatedTokens TokenCounter = TokenCounterFunc(func(_ context.Context, messages []Message, _ ...Option) (int, error) {
+	return EstimateTokens(messages), nil
+})
+
+// FitFunc reports whether messages fit the context window of a conversation.
+type FitFunc func(ctx context.Context, messages []Message) (bool, error)
+
+// TruncationStrategy shortens the messages of a conversation that do not fit its
+// context window. The last message, the one being sent, must be kept.
+type TruncationStrategy func(ctx context.Context, messages []Message, fits FitFunc) ([]Message, error)
+
+// DropOldest drops the oldest messages, the system prompt included, until the
+// conversation fits.
+func DropOldest(ctx context.Context, messages []Message, fits FitFunc) ([]Message, error) {
+	return dropOldest(ctx, messages, fits, 0)
+}
+
+// KeepSystemPrompt drops the oldest messages after the leading system messages
+// until the conversation fits, so that the instructions of the assistant are
+// kept. It is the truncation strategy of conversations unless WithTruncation is
+// given.
+func KeepSystemPrompt(ctx context.Context, messages []Message, fits FitFunc) ([]Message, error) {
+	return dropOldest(ctx, messages, fits, leadingSystemMessages(messages))
+}
+
+// Summarize returns a strategy that has the model summarize the older turns of
+// the conversation into a system message, keeping the system prompt and the
+// last keepLast messages as they are. If the summary does not make the
+// conversation fit, the oldest messages are dropped as by KeepSystemPrompt.
+func Summarize(client *Client, keepLast int, options ...Option) TruncationStrategy {
+	return func(ctx context.Context, messages []Message, fits FitFunc) ([]Message, error) {
+		ok, err := fits(ctx, messages)
+		if err != nil || ok {
+			return messages, err
+		}
+
+		start := leadingSystemMessages(messages)
+		end := max(len(messages)-max(keepLast, 1), start)
+		if end-start < 2 {
+			return KeepSystemPrompt(ctx, messages, fits)
+		}
+
+		summary, err := summarize(ctx, client, messages[start:end], options)
+		if err != nil {
+			return nil, err
+		}
+
+		summarized := slices.Concat(messages[:start], []Message{NewSystemMessage(summaryPrefix + summary)}, messages[end:])
+		return KeepSystemPrompt(ctx, summarized, fits)
+	}
+}
+
+// summarize has the model summarize messages, including the summaries of
+// earlier truncations among them.
+func summarize(ctx context.Context, client *Client, messages []Message, options []Option) (string, error) {
+	var transcript strings.Builder
+	for _, msg := range messages {
+		fmt.Fprintf(&transcript, "%s: %s\n", msg.Role, strings.TrimPrefix(msg.Content, summaryPrefix))
+	}
+
+	summary, err := client.Generate(ctx, []Message{
+		NewSystemMessage("Summarize the conversation below in a few sentences. Keep the facts, names, decisions and open questions the rest of the conversation may need."),
+		NewUserMessage(transcript.String()),
+	}, options...)
+	if err != nil {
+		return "", fmt.Errorf("relic: failed to summarize conversation: %w", err)
+	}
+
+	return strings.TrimSpace(summary), nil
+}
+
+// dropOldest drops the oldest messages after keep until the conversation fits,
+// and then the replies that lost the message they answered.
+func dropOldest(ctx context.Context, messages []Message, fits FitFunc, keep int) ([]Message, error) {
+	messages = slices.Clone(messages)
+	for {
+		ok, err := fits(ctx, messages)
+		if err != nil || ok {
+			return messages, err
+		}
+		if len(messages)-keep <= 1 {
+			return nil, ErrContextOverflow
+		}
+
+		messages = slices.Delete(messages, keep, keep+1)
+		for len(messages)-keep > 1 && messages[keep].Role != MessageRoleUser {
+			messages = slices.Delete(messages, keep, keep+1)
+		}
+	}
+}
+
+// leadingSystemMessages returns the number of system messages at the start of
+// messages.
+func leadingSystemMessages(messages []Message) int {
+	n := 0
+	for n < len(messages)-1 && messages[n].Role == MessageRoleSystem {
+		n++
+	}
+
+	return n
+}
+
+// Conversation is a chat with a model that keeps its history, appending each
+// reply of the assistant, and truncates it to fit the context window of the
+// model. It is safe for concurrent use; turns are taken one at a time.
+//
+// Example:
+//
+//	conversation := relic.NewConversation(client,
+//		relic.WithSystemPrompt("You are a helpful assistant."),
+//		relic.WithCallOptions(relic.WithModelID("qwen")),
+//	)
+//
+//	reply, err := conversation.Send(ctx, "How do I read a file in Go?")
+type Conversation struct {
+	client        *Client
+	counter       TokenCounter
+	truncate      TruncationStrategy
+	options       []Option
+	messages      []Message
+	contextWindow int
+	windowKnown   bool // The context window was given or read from the server
+	replyTokens   int
+	mu            sync.Mutex
+}
+
+// ConversationOption is a function that configures a Conversation.
+type ConversationOption func(*Conversation)
+
+// WithSystemPrompt starts the conversation with a system message.
+func WithSystemPrompt(prompt string) ConversationOption {
+	return func(c *Conversation) {
+		c.messages = append(c.messages, NewSystemMessage(prompt))
+	}
+}
+
+// WithContextWindow sets the context window of the model in tokens. Messages are
+// truncated so that the conversation and the reply fit it. A window of 0 or less
+// disables truncation. When unset, the context length the model is served with
+// is read from the server on the first turn, see Client.ContextLength.
+func WithContextWindow(tokens int) ConversationOption {
+	return func(c *Conversation) {
+		c.contextWindow = tokens
+		c.windowKnown = true
+	}
+}
+
+// WithReplyTokens sets the tokens kept free in the context window for the reply
+// of the assistant, 512 by default.
+func WithReplyTokens(tokens int) ConversationOption {
+	return func(c *Conversation) {
+		c.replyTokens = tokens
+	}
+}
+
+// WithTruncation sets how the conversation is shortened when it does not fit the
+// context window, KeepSystemPrompt by default.
+func WithTruncation(strategy TruncationStrategy) ConversationOption {
+	return func(c *Conversation) {
+		c.truncate = strategy
+	}
+}
+
+// WithTokenCounter sets how the tokens of the conversation are counted, by the
+// Client of the conversation by default, exactly, with the tokenizer and chat
+// template of the model. EstimatedTokens saves the calls to the server.
+func WithTokenCounter(counter TokenCounter) ConversationOption {
+	return func(c *Conversation) {
+		c.counter = counter
+	}
+}
+
+// WithCallOptions sets the options of every call of the conversation, e.g. the
+// model and the sampling parameters. Options given to Send are applied after them.
+func WithCallOptions(options ...Option) ConversationOption {
+	return func(c *Conversation) {
+		c.options = append(c.options, options...)
+	}
+}
+
+// NewConversation creates a new Conversation instance.
+func NewConversation(client *Client, opts ...ConversationOption) *Conversation {
+	c := &Conversation{
+		client:      client,
+		counter:     client,
+		truncate:    KeepSystemPrompt,
+		replyTokens: defaultReplyTokens,
+	}
+	if client == nil {
+		c.counter = EstimatedTokens
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Messages returns a copy of the messages of the conversation.
+func (c *Conversation) Messages() []Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return slices.Clone(c.messages)
+}
+
+// Add appends a message to the conversation without sending it, e.g. to restore
+// a conversation or to add the result of a tool.
+func (c *Conversation) Add(messages ...Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.messages = append(c.messages, messages...)
+}
+
+// Reset removes the messages of the conversation, keeping its leading system
+// messages.
+func (c *Conversation) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := 0
+	for n < len(c.messages) && c.messages[n].Role == MessageRoleSystem {
+		n++
+	}
+	c.messages = c.messages[:n]
+}
+
+// Tokens returns the number of tokens of the conversation, as counted by its
+// token counter.
+func (c *Conversation) Tokens(ctx context.Context) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.counter.CountTokens(ctx, c.messages, c.options...)
+}
+
+// Send sends a user message and returns the reply of the assistant, which is
+// appended to the conversation. If the call fails, the conversation is left as
+// it was.
+func (c *Conversation) Send(ctx context.Context, content string, options ...Option) (*ChatResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	options = slices.Concat(c.options, options)
+
+	messages, err := c.prepare(ctx, NewUserMessage(content), options)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.client.Chat(ctx, messages, options...)
+	if err != nil {
+		return nil, err
+	}
+	c.messages = append(messages, NewAssistantMessage(result.Text))
+
+	return result, nil
+}
+
+// SendStream sends a user message and streams the reply of the assistant, which
+// is appended to the conversation once the last chunk is received. If the stream
+// fails, the conversation is left as it was. The next turn waits for the stream
+// to end.
+func (c *Conversation) SendStream(ctx context.Context, content string, options ...Option) <-chan StreamChunk {
+	ch := make(chan StreamChunk)
+
+	go func() {
+		defer close(ch)
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		options := slices.Concat(c.options, options)
+
+		messages, err := c.prepare(ctx, NewUserMessage(content), options)
+		if err != nil {
+			ch <- StreamChunk{Error: err}
+			return
+		}
+
+		var reply strings.Builder
+		for chunk := range c.client.GenerateStream(ctx, messages, options...) {
+			reply.WriteString(chunk.Content)
+			if chunk.Done && chunk.Error == nil {
+				c.messages = append(messages, NewAssistantMessage(reply.String()))
+			}
+
+			select {
+			case ch <- chunk:
+			case <-ctx.Done():
+				// GenerateStream ends and closes its channel on its own.
+			}
+		}
+	}()
+
+	return ch
+}
+
+// prepare returns the messages of the conversation with a new message, truncated
+// to fit the context window.
+func (c *Conversation) prepare(ctx context.Context, msg Message, options []Option) ([]Message, error) {
+	messages := append(slices.Clone(c.messages), msg)
+	if !c.windowKnown && c.client != nil {
+		tokens, err := c.client.ContextLength(ctx, options...)
+		if err != nil {
+			return nil, err
+		}
+		c.contextWindow, c.windowKnown = tokens, true
+	}
+	if c.contextWindow <= 0 {
+		return messages, nil
+	}
+
+	budget := c.contextWindow - c.replyTokens
+	fits := func(ctx context.Context, messages []Message) (bool, error) {
+		tokens, err := c.counter.CountTokens(ctx, messages, options...)
+		if err != nil {
+			return false, fmt.Errorf("relic: failed to truncate conversation: %w", err)
+		}
+		return tokens <= budget, nil
+	}
+
+	truncated, err := c.truncate(ctx, messages, fits)
+	if err != nil {
+		return nil, err
+	}
+
+	return truncated, nil
+}
+
+// conversationJSON is the JSON encoding of a conversation.
+type conversationJSON struct {
+	Messages []Message `json:"messages"`
+}
+
+// MarshalJSON implements json.Marshaler. Only the messages are encoded, the
+// options are those of the conversation that decodes them.
+func (c *Conversation) MarshalJSON() ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return json.Marshal(conversationJSON{Messages: c.messages})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The decoded messages replace those
+// of the conversation.
+func (c *Conversation) UnmarshalJSON(data []byte) error {
+	var decoded conversationJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.messages = decoded.Messages
+
+	return nil
+}
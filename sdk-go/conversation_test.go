@@ -0,0 +1,197 @@
+package relic_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	relic "github.com/ju4n97/relic/sdk-go"
+)
+
+// msg returns a message without a timestamp, so that messages compare equal.
+func msg(role relic.MessageRole, content string) relic.Message {
+	return relic.Message{Role: role, Content: content}
+}
+
+// fitsMessages reports that a conversation fits when it has at most n messages.
+func fitsMessages(n int) relic.FitFunc {
+	return func(_ context.Context, messages []relic.Message) (bool, error) {
+		return len(messages) <= n, nil
+	}
+}
+
+var history = []relic.Message{
+	msg(relic.MessageRoleSystem, "Be brief."),
+	msg(relic.MessageRoleUser, "Hi"),
+	msg(relic.MessageRoleAssistant, "Hello!"),
+	msg(relic.MessageRoleUser, "What is Go?"),
+	msg(relic.MessageRoleAssistant, "A programming language."),
+	msg(relic.MessageRoleUser, "Who made it?"),
+}
+
+func TestEstimateTokens(t *testing.T) {
+	assert.Zero(t, relic.EstimateTokens(nil))
+	// 4 tokens per message, plus a token per 4 characters rounded up.
+	assert.Equal(t, 4+3, relic.EstimateTokens([]relic.Message{msg(relic.MessageRoleUser, "Hello world")}))
+	assert.Equal(t, 2*4+1+1, relic.EstimateTokens([]relic.Message{msg(relic.MessageRoleUser, "Hi"), msg(relic.MessageRoleAssistant, "Hey!")}))
+}
+
+func TestKeepSystemPrompt(t *testing.T) {
+	truncated, err := relic.KeepSystemPrompt(context.Background(), history, fitsMessages(4))
+	require.NoError(t, err)
+
+	// The oldest turn is dropped with its reply; the system prompt is kept.
+	assert.Equal(t, []relic.Message{history[0], history[3], history[4], history[5]}, truncated)
+	assert.Len(t, history, 6, "the messages given are left as they are")
+
+	truncated, err = relic.KeepSystemPrompt(context.Background(), history, fitsMessages(2))
+	require.NoError(t, err)
+	assert.Equal(t, []relic.Message{history[0], history[5]}, truncated)
+
+	_, err = relic.KeepSystemPrompt(context.Background(), history, fitsMessages(1))
+	assert.ErrorIs(t, err, relic.ErrContextOverflow)
+}
+
+func TestDropOldest(t *testing.T) {
+	truncated, err := relic.DropOldest(context.Background(), history, fitsMessages(3))
+	require.NoError(t, err)
+	assert.Equal(t, history[3:], truncated, "the system prompt is dropped first")
+
+	truncated, err = relic.DropOldest(context.Background(), history, fitsMessages(1))
+	require.NoError(t, err)
+	assert.Equal(t, history[5:], truncated)
+
+	_, err = relic.DropOldest(context.Background(), history, fitsMessages(0))
+	assert.ErrorIs(t, err, relic.ErrContextOverflow)
+}
+
+func TestConversation_Send(t *testing.T) {
+	var sent [][]string
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/llm", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Messages []struct {
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		var contents []string
+		for _, m := range body.Messages {
+			contents = append(contents, m.Content)
+		}
+		sent = append(sent, contents)
+
+		_, _ = io.WriteString(w, `{"text": "Reply"}`)
+	})
+	client := newHTTPClient(t, mux)
+
+	// Each message counts as 10 tokens: 4 messages fit the 40 tokens not kept
+	// for the reply.
+	counter := relic.TokenCounterFunc(func(_ context.Context, messages []relic.Message, _ ...relic.Option) (int, error) {
+		return 10 * len(messages), nil
+	})
+	conversation := relic.NewConversation(client,
+		relic.WithSystemPrompt("Be brief."),
+		relic.WithContextWindow(50),
+		relic.WithReplyTokens(10),
+		relic.WithTokenCounter(counter),
+	)
+
+	for _, content := range []string{"One", "Two", "Three"} {
+		result, err := conversation.Send(context.Background(), content)
+		require.NoError(t, err)
+		assert.Equal(t, "Reply", result.Text)
+	}
+
+	assert.Equal(t, [][]string{
+		{"Be brief.", "One"},
+		{"Be brief.", "One", "Reply", "Two"},
+		{"Be brief.", "Two", "Reply", "Three"},
+	}, sent)
+
+	messages := conversation.Messages()
+	require.Len(t, messages, 5)
+	assert.Equal(t, relic.MessageRoleSystem, messages[0].Role)
+	assert.Equal(t, relic.MessageRoleAssistant, messages[4].Role)
+
+	tokens, err := conversation.Tokens(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 50, tokens)
+
+	conversation.Reset()
+	assert.Len(t, conversation.Messages(), 1, "the system prompt is kept")
+}
+
+func TestConversation_Send_Defaults(t *testing.T) {
+	var (
+		windowReads int
+		sent        []int
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/llm/count-tokens", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			ModelID  string            `json:"model_id"`
+			Messages []json.RawMessage `json:"messages"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "qwen", body.ModelID)
+		if len(body.Messages) == 0 {
+			windowReads++
+		}
+
+		// Each message counts as 200 tokens: 2 messages fit the 1024-512 tokens
+		// not kept for the reply.
+		_ = json.NewEncoder(w).Encode(map[string]int{"tokens": 200 * len(body.Messages), "context_length": 1024})
+	})
+	mux.HandleFunc("POST /v1/llm", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Messages []json.RawMessage `json:"messages"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		sent = append(sent, len(body.Messages))
+
+		_, _ = io.WriteString(w, `{"text": "Reply"}`)
+	})
+	client := newHTTPClient(t, mux)
+
+	conversation := relic.NewConversation(client, relic.WithCallOptions(relic.WithModelID("qwen")))
+	for _, content := range []string{"One", "Two"} {
+		_, err := conversation.Send(context.Background(), content)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 1, windowReads, "the context window is read once")
+	assert.Equal(t, []int{1, 1}, sent, "the tokens are counted by the server")
+}
+
+func TestConversation_Send_Overflow(t *testing.T) {
+	client := newHTTPClient(t, http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		t.Error("a message that does not fit is not sent")
+	}))
+	conversation := relic.NewConversation(client, relic.WithContextWindow(520), relic.WithTokenCounter(relic.EstimatedTokens))
+
+	_, err := conversation.Send(context.Background(), string(make([]byte, 100)))
+	assert.ErrorIs(t, err, relic.ErrContextOverflow)
+	assert.Empty(t, conversation.Messages(), "the conversation is left as it was")
+}
+
+func TestConversation_JSON(t *testing.T) {
+	conversation := relic.NewConversation(nil)
+	conversation.Add(history...)
+
+	data, err := json.Marshal(conversation)
+	require.NoError(t, err)
+
+	restored := relic.NewConversation(nil, relic.WithSystemPrompt("Replaced."))
+	require.NoError(t, json.Unmarshal(data, restored))
+	assert.Equal(t, history, restored.Messages())
+
+	assert.Error(t, json.Unmarshal([]byte(`{"messages": "nope"}`), restored))
+	assert.Equal(t, history, restored.Messages(), "invalid JSON leaves the messages as they were")
+}
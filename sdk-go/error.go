@@ -0,0 +1,158 @@
+package relic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Error definitions for the relic package.
+//
+// Errors returned by the server match one of them with errors.Is, when the
+// failure fits one. The error of the transport stays reachable, a gRPC status
+// with status.FromError or an *HTTPError with errors.As.
+var (
+	ErrUnsupported        = errors.New("not supported by the HTTP API")
+	ErrModelNotFound      = errors.New("model not found")
+	ErrInvalidArgument    = errors.New("invalid argument")
+	ErrBackendUnavailable = errors.New("backend unavailable")
+	ErrOverloaded         = errors.New("server overloaded")
+	ErrContextOverflow    = errors.New("message does not fit the context window")
+)
+
+// HTTPError is returned for calls the HTTP API rejects.
+type HTTPError struct {
+	Message    string   // Detail of the problem, e.g. "model not found"
+	Details    []string // Underlying errors, e.g. the fields that failed validation
+	StatusCode int
+	RetryAfter time.Duration // From the Retry-After header
+}
+
+// Error implements error.
+func (e *HTTPError) Error() string {
+	msg := fmt.Sprintf("http %d: %s", e.StatusCode, e.Message)
+	if len(e.Details) > 0 {
+		msg += ": " + strings.Join(e.Details, "; ")
+	}
+
+	return msg
+}
+
+// callError is an error of the transport, classified.
+type callError struct {
+	err        error
+	kind       error         // One of the error definitions, or nil
+	retryable  bool          // The call did not reach a model, it may succeed later
+	retryAfter time.Duration // Time the server asked to wait before retrying
+}
+
+// Error implements error.
+func (e *callError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the error of the transport and its kind.
+func (e *callError) Unwrap() []error {
+	if e.kind == nil {
+		return []error{e.err}
+	}
+
+	return []error{e.err, e.kind}
+}
+
+// classify classifies an error of the transport by its gRPC status code or HTTP
+// status. Other errors, e.g. of the network, are returned unchanged.
+func classify(err error) error {
+	var classified *callError
+	if err == nil || errors.As(err, &classified) {
+		return err
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return classifyHTTP(httpErr)
+	}
+	if st, ok := status.FromError(err); ok {
+		return classifyGRPC(err, st)
+	}
+
+	return err
+}
+
+// reasonModelNotFound is the reason of the ErrorInfo detail of the NOT_FOUND
+// errors the server returns for missing models.
+const reasonModelNotFound = "MODEL_NOT_FOUND"
+
+// classifyGRPC classifies an error by its gRPC status code and details.
+func classifyGRPC(err error, st *status.Status) *callError {
+	e := &callError{err: err}
+
+	var reason string
+	for _, detail := range st.Details() {
+		switch detail := detail.(type) {
+		case *errdetails.RetryInfo:
+			e.retryAfter = detail.GetRetryDelay().AsDuration()
+		case *errdetails.ErrorInfo:
+			reason = detail.GetReason()
+		}
+	}
+
+	switch st.Code() {
+	case codes.NotFound:
+		// Also returned for other missing resources, e.g. pull jobs.
+		if reason == reasonModelNotFound {
+			e.kind = ErrModelNotFound
+		}
+	case codes.InvalidArgument, codes.OutOfRange:
+		e.kind = ErrInvalidArgument
+	case codes.Unavailable:
+		e.kind = ErrBackendUnavailable
+		e.retryable = true
+	case codes.Unknown:
+		// The server reports backend failures, e.g. a crashed backend, as Unknown.
+		e.kind = ErrBackendUnavailable
+	case codes.ResourceExhausted:
+		e.kind = ErrOverloaded
+		e.retryable = true
+	case codes.DeadlineExceeded:
+		e.kind = context.DeadlineExceeded
+	case codes.Canceled:
+		e.kind = context.Canceled
+	}
+
+	return e
+}
+
+// classifyHTTP classifies an error by its HTTP status.
+func classifyHTTP(err *HTTPError) *callError {
+	e := &callError{err: err, retryAfter: err.RetryAfter}
+
+	switch err.StatusCode {
+	case http.StatusNotFound:
+		// The HTTP API only tells missing models from other missing resources,
+		// e.g. pull jobs or paths a proxy does not route, by the detail.
+		if strings.HasPrefix(err.Message, "model not ") {
+			e.kind = ErrModelNotFound
+		}
+	case http.StatusBadRequest, http.StatusUnprocessableEntity, http.StatusRequestEntityTooLarge:
+		e.kind = ErrInvalidArgument
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		// Also returned by proxies in front of the server.
+		e.kind = ErrBackendUnavailable
+		e.retryable = true
+	case http.StatusInternalServerError:
+		e.kind = ErrBackendUnavailable
+	case http.StatusTooManyRequests:
+		e.kind = ErrOverloaded
+		e.retryable = true
+	}
+
+	return e
+}
@@ -0,0 +1,126 @@
+package relic
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	inferencev2 "github.com/ju4n97/relic/sdk-go/pb/inference/v2"
+)
+
+func TestClassify_GRPC(t *testing.T) {
+	tests := []struct {
+		code codes.Code
+		want error
+	}{
+		{codes.InvalidArgument, ErrInvalidArgument},
+		{codes.OutOfRange, ErrInvalidArgument},
+		{codes.Unavailable, ErrBackendUnavailable},
+		{codes.Unknown, ErrBackendUnavailable},
+		{codes.ResourceExhausted, ErrOverloaded},
+		{codes.DeadlineExceeded, context.DeadlineExceeded},
+		{codes.Canceled, context.Canceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			err := classify(status.Error(tt.code, "failed"))
+
+			assert.ErrorIs(t, err, tt.want)
+			st, ok := status.FromError(err)
+			require.True(t, ok, "the status stays reachable")
+			assert.Equal(t, tt.code, st.Code())
+		})
+	}
+
+	err := classify(status.Error(codes.Internal, "failed"))
+	for _, kind := range []error{ErrModelNotFound, ErrInvalidArgument, ErrBackendUnavailable, ErrOverloaded} {
+		assert.NotErrorIs(t, err, kind)
+	}
+}
+
+func TestClassify_GRPC_NotFound(t *testing.T) {
+	assert.ErrorIs(t, classify(modelNotFound(t)), ErrModelNotFound)
+
+	err := classify(status.Error(codes.NotFound, "pull job not found: 42"))
+	assert.NotErrorIs(t, err, ErrModelNotFound, "only missing models are classified")
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestClassify_HTTP(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusBadRequest, ErrInvalidArgument},
+		{http.StatusUnprocessableEntity, ErrInvalidArgument},
+		{http.StatusRequestEntityTooLarge, ErrInvalidArgument},
+		{http.StatusBadGateway, ErrBackendUnavailable},
+		{http.StatusServiceUnavailable, ErrBackendUnavailable},
+		{http.StatusGatewayTimeout, ErrBackendUnavailable},
+		{http.StatusInternalServerError, ErrBackendUnavailable},
+		{http.StatusTooManyRequests, ErrOverloaded},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			err := classify(&HTTPError{StatusCode: tt.status, Message: "failed"})
+
+			assert.ErrorIs(t, err, tt.want)
+			var httpErr *HTTPError
+			require.ErrorAs(t, err, &httpErr, "the HTTP error stays reachable")
+			assert.Equal(t, tt.status, httpErr.StatusCode)
+		})
+	}
+}
+
+func TestClassify_HTTP_NotFound(t *testing.T) {
+	assert.ErrorIs(t, classify(&HTTPError{StatusCode: http.StatusNotFound, Message: "model not found"}), ErrModelNotFound)
+	assert.ErrorIs(t, classify(&HTTPError{StatusCode: http.StatusNotFound, Message: "model not enabled for this service"}), ErrModelNotFound)
+
+	for _, msg := range []string{"pull job not found", "Not Found"} {
+		assert.NotErrorIs(t, classify(&HTTPError{StatusCode: http.StatusNotFound, Message: msg}), ErrModelNotFound, msg)
+	}
+}
+
+func TestClassify_Other(t *testing.T) {
+	assert.NoError(t, classify(nil))
+
+	network := errors.New("connection refused")
+	assert.Same(t, network, classify(network))
+
+	classified := classify(status.Error(codes.NotFound, "failed"))
+	assert.Same(t, classified, classify(classified), "errors are classified once")
+}
+
+func TestHTTPError_Error(t *testing.T) {
+	err := &HTTPError{StatusCode: http.StatusUnprocessableEntity, Message: "validation failed", Details: []string{"expected messages", "expected text"}}
+	assert.Equal(t, "http 422: validation failed: expected messages; expected text", err.Error())
+}
+
+func TestClient_Errors(t *testing.T) {
+	chat := &fakeChat{chat: func(int, *inferencev2.ChatRequest) (*inferencev2.ChatResponse, error) {
+		return nil, modelNotFound(t)
+	}}
+	client := newGRPCClient(t, fastRetries, chat)
+
+	_, err := client.Generate(context.Background(), hello, WithModelID("missing"))
+	assert.ErrorIs(t, err, ErrModelNotFound)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Contains(t, err.Error(), "relic: failed to generate")
+}
+
+// modelNotFound returns the error of the server for a missing model.
+func modelNotFound(t *testing.T) error {
+	st, err := status.New(codes.NotFound, "model not found: missing").WithDetails(&errdetails.ErrorInfo{Reason: "MODEL_NOT_FOUND", Domain: "relic"})
+	require.NoError(t, err)
+
+	return st.Err()
+}
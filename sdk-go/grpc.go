@@ -0,0 +1,127 @@
+package relic
+
+import (
+	"context"
+	"fmt"
+
+	inferencev2 "github.com/ju4n97/relic/sdk-go/pb/inference/v2"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// grpcTransport calls the inference.v2 gRPC services.
+type grpcTransport struct {
+	conn            *grpc.ClientConn
+	chatClient      inferencev2.ChatServiceClient
+	sttClient       inferencev2.SpeechToTextServiceClient
+	ttsClient       inferencev2.TextToSpeechServiceClient
+	embeddingClient inferencev2.EmbeddingServiceClient
+	tokenizerClient inferencev2.TokenizerServiceClient
+	modelClient     inferencev2.ModelServiceClient
+}
+
+// newGRPCTransport returns a transport that connects to the gRPC server at addr.
+func newGRPCTransport(addr string, o *clientOptions) (*grpcTransport, error) {
+	creds := insecure.NewCredentials()
+	if o.tls {
+		config, err := o.buildTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		creds = credentials.NewTLS(config)
+	}
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	}
+	if o.keepalive.Time > 0 {
+		dialOpts = append(dialOpts, grpc.WithKeepaliveParams(o.keepalive))
+	}
+	if o.apiKey != "" {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(apiKeyCredentials(o.apiKey)))
+	}
+
+	conn, err := grpc.NewClient(addr, dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("relic: failed to create gRPC client: %w", err)
+	}
+
+	return &grpcTransport{
+		conn:            conn,
+		chatClient:      inferencev2.NewChatServiceClient(conn),
+		sttClient:       inferencev2.NewSpeechToTextServiceClient(conn),
+		ttsClient:       inferencev2.NewTextToSpeechServiceClient(conn),
+		embeddingClient: inferencev2.NewEmbeddingServiceClient(conn),
+		tokenizerClient: inferencev2.NewTokenizerServiceClient(conn),
+		modelClient:     inferencev2.NewModelServiceClient(conn),
+	}, nil
+}
+
+func (t *grpcTransport) chat(ctx context.Context, req *inferencev2.ChatRequest) (*inferencev2.ChatResponse, error) {
+	return t.chatClient.Chat(ctx, req)
+}
+
+func (t *grpcTransport) chatStream(ctx context.Context, req *inferencev2.ChatRequest) (receiver[*inferencev2.ChatChunk], error) {
+	return t.chatClient.ChatStream(ctx, req)
+}
+
+func (t *grpcTransport) transcribe(ctx context.Context, req *inferencev2.TranscribeRequest) (*inferencev2.TranscribeResponse, error) {
+	return t.sttClient.Transcribe(ctx, req)
+}
+
+func (t *grpcTransport) synthesize(ctx context.Context, req *inferencev2.SynthesizeRequest) (*inferencev2.SynthesizeResponse, error) {
+	return t.ttsClient.Synthesize(ctx, req)
+}
+
+func (t *grpcTransport) embed(ctx context.Context, req *inferencev2.EmbedRequest) (*inferencev2.EmbedResponse, error) {
+	return t.embeddingClient.Embed(ctx, req)
+}
+
+func (t *grpcTransport) tokenize(ctx context.Context, req *inferencev2.TokenizeRequest) (*inferencev2.TokenizeResponse, error) {
+	return t.tokenizerClient.Tokenize(ctx, req)
+}
+
+func (t *grpcTransport) detokenize(ctx context.Context, req *inferencev2.DetokenizeRequest) (*inferencev2.DetokenizeResponse, error) {
+	return t.tokenizerClient.Detokenize(ctx, req)
+}
+
+func (t *grpcTransport) applyTemplate(ctx context.Context, req *inferencev2.ApplyTemplateRequest) (*inferencev2.ApplyTemplateResponse, error) {
+	return t.tokenizerClient.ApplyTemplate(ctx, req)
+}
+
+func (t *grpcTransport) countTokens(ctx context.Context, req *inferencev2.CountTokensRequest) (*inferencev2.CountTokensResponse, error) {
+	return t.tokenizerClient.CountTokens(ctx, req)
+}
+
+func (t *grpcTransport) listModels(ctx context.Context) (*inferencev2.ListModelsResponse, error) {
+	return t.modelClient.ListModels(ctx, &inferencev2.ListModelsRequest{})
+}
+
+func (t *grpcTransport) pullModel(ctx context.Context, req *inferencev2.PullModelRequest) (receiver[*inferencev2.PullProgress], error) {
+	return t.modelClient.PullModel(ctx, req)
+}
+
+func (t *grpcTransport) removeModel(ctx context.Context, req *inferencev2.RemoveModelRequest) (*inferencev2.RemoveModelResponse, error) {
+	return t.modelClient.RemoveModel(ctx, req)
+}
+
+func (t *grpcTransport) close() error {
+	return t.conn.Close()
+}
+
+// apiKeyCredentials sends an API key as a bearer token with every call.
+type apiKeyCredentials string
+
+// GetRequestMetadata implements credentials.PerRPCCredentials.
+func (k apiKeyCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(k)}, nil
+}
+
+// RequireTransportSecurity implements credentials.PerRPCCredentials. The key is
+// also sent over plaintext connections, for servers behind a TLS-terminating proxy.
+func (apiKeyCredentials) RequireTransportSecurity() bool {
+	return false
+}
@@ -0,0 +1,60 @@
+package relic
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	inferencev2 "github.com/ju4n97/relic/sdk-go/pb/inference/v2"
+)
+
+// fakeChat is a chat service answering with chat and chatStream, and counting
+// the calls.
+type fakeChat struct {
+	inferencev2.UnimplementedChatServiceServer
+	chat       func(attempt int, req *inferencev2.ChatRequest) (*inferencev2.ChatResponse, error)
+	chatStream func(attempt int, stream inferencev2.ChatService_ChatStreamServer) error
+	calls      atomic.Int32
+}
+
+func (s *fakeChat) Chat(_ context.Context, req *inferencev2.ChatRequest) (*inferencev2.ChatResponse, error) {
+	return s.chat(int(s.calls.Add(1)), req)
+}
+
+func (s *fakeChat) ChatStream(_ *inferencev2.ChatRequest, stream inferencev2.ChatService_ChatStreamServer) error {
+	return s.chatStream(int(s.calls.Add(1)), stream)
+}
+
+// newGRPCClient returns a client of a chat service served over an in-memory
+// listener, retrying by policy.
+func newGRPCClient(t *testing.T, policy RetryPolicy, chat inferencev2.ChatServiceServer) *Client {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	inferencev2.RegisterChatServiceServer(server, chat)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	client := &Client{
+		transport:   &grpcTransport{conn: conn, chatClient: inferencev2.NewChatServiceClient(conn)},
+		retryPolicy: policy,
+	}
+	t.Cleanup(func() { _ = client.Close() })
+
+	return client
+}
@@ -0,0 +1,935 @@
+package relic
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	inferencev2 "github.com/ju4n97/relic/sdk-go/pb/inference/v2"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxEventSize is the size of the largest server-sent event a stream accepts.
+const maxEventSize = 1 << 20
+
+// httpTransport calls the HTTP API of the server.
+type httpTransport struct {
+	client  *http.Client
+	baseURL string // API root, e.g. "https://relic.example.com/v1"
+	apiKey  string
+}
+
+// newHTTPTransport returns a transport that calls the HTTP API of the server
+// whose root URL is addr.
+func newHTTPTransport(addr string, o *clientOptions) (*httpTransport, error) {
+	u, err := url.Parse(addr)
+	if err != nil {
+		return nil, fmt.Errorf("relic: invalid server URL: %w", err)
+	}
+
+	base := http.DefaultTransport.(*http.Transport).Clone()
+	if o.tls {
+		if u.Scheme != "https" {
+			return nil, fmt.Errorf("relic: TLS options need an https:// server URL, got %s", addr)
+		}
+		config, err := o.buildTLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		base.TLSClientConfig = config
+	}
+
+	return &httpTransport{
+		// No timeout, streams last as long as the generation. Calls are bounded
+		// by their context.
+		client:  &http.Client{Transport: otelhttp.NewTransport(base)},
+		baseURL: strings.TrimSuffix(u.String(), "/") + "/v1",
+		apiKey:  o.apiKey,
+	}, nil
+}
+
+type (
+	// httpGenerateRequest is the body of POST /v1/llm and /v1/llm/stream.
+	httpGenerateRequest struct {
+		Parameters map[string]any `json:"parameters,omitempty"`
+		ModelID    string         `json:"model_id,omitempty"`
+		Profile    string         `json:"profile,omitempty"`
+		Messages   []httpMessage  `json:"messages"`
+	}
+
+	// httpMessage is a message of a conversation.
+	httpMessage struct {
+		Role    string `json:"role"`
+		Content string `json:"content"`
+	}
+
+	// httpSynthesizeRequest is the body of POST /v1/tts.
+	httpSynthesizeRequest struct {
+		Parameters map[string]any `json:"parameters,omitempty"`
+		ModelID    string         `json:"model_id,omitempty"`
+		Profile    string         `json:"profile,omitempty"`
+		Text       string         `json:"text"`
+	}
+
+	// httpTokenizeRequest is the body of POST /v1/llm/tokenize.
+	httpTokenizeRequest struct {
+		ModelID    string        `json:"model_id,omitempty"`
+		Profile    string        `json:"profile,omitempty"`
+		Text       string        `json:"text,omitempty"`
+		Messages   []httpMessage `json:"messages,omitempty"`
+		AddSpecial bool          `json:"add_special,omitempty"`
+		WithPieces bool          `json:"with_pieces,omitempty"`
+	}
+
+	// httpTokenizeResponse is the response of POST /v1/llm/tokenize.
+	httpTokenizeResponse struct {
+		Metadata      *httpMetadata `json:"metadata"`
+		Prompt        string        `json:"prompt"`
+		Tokens        []int32       `json:"tokens"`
+		Pieces        []string      `json:"pieces"`
+		ContextLength int32         `json:"context_length"`
+	}
+
+	// httpDetokenizeRequest is the body of POST /v1/llm/detokenize.
+	httpDetokenizeRequest struct {
+		ModelID string  `json:"model_id,omitempty"`
+		Profile string  `json:"profile,omitempty"`
+		Tokens  []int32 `json:"tokens"`
+	}
+
+	// httpChatTemplateRequest is the body of POST /v1/llm/apply-template and
+	// /v1/llm/count-tokens.
+	httpChatTemplateRequest struct {
+		ModelID  string        `json:"model_id,omitempty"`
+		Profile  string        `json:"profile,omitempty"`
+		Messages []httpMessage `json:"messages,omitempty"`
+	}
+
+	// httpApplyTemplateResponse is the response of POST /v1/llm/apply-template.
+	httpApplyTemplateResponse struct {
+		Metadata *httpMetadata `json:"metadata"`
+		Prompt   string        `json:"prompt"`
+	}
+
+	// httpCountTokensResponse is the response of POST /v1/llm/count-tokens.
+	httpCountTokensResponse struct {
+		Metadata      *httpMetadata `json:"metadata"`
+		Tokens        int32         `json:"tokens"`
+		ContextLength int32         `json:"context_length"`
+	}
+
+	// httpTextResponse is the response of POST /v1/llm, /v1/stt and
+	// /v1/llm/detokenize.
+	httpTextResponse struct {
+		Metadata *httpMetadata `json:"metadata"`
+		Text     string        `json:"text"`
+	}
+
+	// httpMetadata is the metadata of a response.
+	httpMetadata struct {
+		Timestamp       time.Time                  `json:"timestamp"`
+		BackendSpecific map[string]json.RawMessage `json:"backend_specific"`
+		Usage           *httpUsage                 `json:"usage"`
+		Provider        string                     `json:"provider"`
+		Model           string                     `json:"model"`
+		DurationSeconds float64                    `json:"inference_time_seconds"`
+	}
+
+	// httpUsage is the usage of a request.
+	httpUsage struct {
+		PromptTokens    int32   `json:"prompt_tokens"`
+		GeneratedTokens int32   `json:"generated_tokens"`
+		TokensPerSecond float64 `json:"tokens_per_second"`
+		AudioSeconds    float64 `json:"audio_seconds"`
+		Characters      int32   `json:"characters"`
+	}
+
+	// httpTranscription is the whisper.cpp response in the metadata of a
+	// transcription.
+	httpTranscription struct {
+		DetectedLanguage string `json:"detected_language"`
+		Segments         []struct {
+			Text         string  `json:"text"`
+			ID           int32   `json:"id"`
+			Start        float64 `json:"start"`
+			End          float64 `json:"end"`
+			AvgLogprob   float64 `json:"avg_logprob"`
+			NoSpeechProb float64 `json:"no_speech_prob"`
+			Words        []struct {
+				Word        string  `json:"word"`
+				Start       float64 `json:"start"`
+				End         float64 `json:"end"`
+				Probability float64 `json:"probability"`
+			} `json:"words"`
+		} `json:"segments"`
+	}
+
+	// httpStreamEvent is the data of an event of POST /v1/llm/stream.
+	httpStreamEvent struct {
+		Metadata *httpMetadata `json:"metadata"`
+		Done     bool          `json:"done"`
+		Text     string        `json:"text"`
+		Error    string        `json:"error"`
+	}
+
+	// httpChatCompletion is the llama-server response in the metadata of a reply.
+	httpChatCompletion struct {
+		Timings map[string]float64 `json:"timings"`
+		Choices []struct {
+			FinishReason string `json:"finish_reason"`
+		} `json:"choices"`
+	}
+
+	// httpModel is a model of GET /v1/models.
+	httpModel struct {
+		Pull     *httpPullStatus `json:"pull"`
+		ID       string          `json:"id"`
+		Type     string          `json:"type"`
+		Backend  string          `json:"backend"`
+		Status   string          `json:"status"`
+		Error    string          `json:"error"`
+		Tags     []string        `json:"tags"`
+		Aliases  []string        `json:"aliases"`
+		Profiles []struct {
+			Name string `json:"name"`
+		} `json:"profiles"`
+		Order int32 `json:"order"`
+	}
+
+	// httpPullStatus is the response of POST /v1/models/{model_id}/pull and the
+	// data of an event of GET /v1/pulls/{job_id}/events.
+	httpPullStatus struct {
+		StartedAt  *time.Time `json:"started_at"`
+		FinishedAt *time.Time `json:"finished_at"`
+		ID         string     `json:"id"`
+		ModelID    string     `json:"model_id"`
+		State      string     `json:"state"`
+		Error      string     `json:"error"`
+		Files      []struct {
+			Path       string `json:"path"`
+			State      string `json:"state"`
+			BytesDone  int64  `json:"bytes_done"`
+			BytesTotal int64  `json:"bytes_total"`
+		} `json:"files"`
+		BytesDone       int64   `json:"bytes_done"`
+		BytesTotal      int64   `json:"bytes_total"`
+		RateBytesPerSec float64 `json:"rate_bytes_per_second"`
+		ETASeconds      float64 `json:"eta_seconds"`
+	}
+
+	// httpGCResult is the response of DELETE /v1/cache/{model_id}.
+	httpGCResult struct {
+		Removed    []string `json:"removed"`
+		FreedBytes int64    `json:"freed_bytes"`
+	}
+
+	// httpProblem is an error response, in the RFC 9457 format.
+	httpProblem struct {
+		Title  string `json:"title"`
+		Detail string `json:"detail"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+)
+
+func (t *httpTransport) chat(ctx context.Context, req *inferencev2.ChatRequest) (*inferencev2.ChatResponse, error) {
+	var resp httpTextResponse
+	if err := t.postJSON(ctx, "/llm", buildHTTPGenerateRequest(req), &resp); err != nil {
+		return nil, err
+	}
+
+	out := &inferencev2.ChatResponse{
+		Message:  &inferencev2.ChatMessage{Role: inferencev2.Role_ROLE_ASSISTANT, Content: resp.Text},
+		Metadata: resp.Metadata.proto(),
+	}
+	out.FinishReason, out.Timings = resp.Metadata.chatCompletion()
+
+	return out, nil
+}
+
+func (t *httpTransport) chatStream(ctx context.Context, req *inferencev2.ChatRequest) (receiver[*inferencev2.ChatChunk], error) {
+	body, err := json.Marshal(buildHTTPGenerateRequest(req))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.do(ctx, http.MethodPost, "/llm/stream", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	return &sseReceiver{sseReader: newSSEReader(resp.Body)}, nil
+}
+
+func (t *httpTransport) transcribe(ctx context.Context, req *inferencev2.TranscribeRequest) (*inferencev2.TranscribeResponse, error) {
+	parameters := req.Parameters.AsMap()
+	if req.Language != "" {
+		parameters["language"] = req.Language
+	}
+	if req.Prompt != "" {
+		parameters["prompt"] = req.Prompt
+	}
+	if req.Translate {
+		parameters["translate"] = true
+	}
+	if req.Temperature != nil {
+		parameters["temperature"] = *req.Temperature
+	}
+	if req.BeamSize != nil {
+		parameters["beam_size"] = *req.BeamSize
+	}
+	if req.BestOf != nil {
+		parameters["best_of"] = *req.BestOf
+	}
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="file"; filename="audio.wav"`)
+	header.Set("Content-Type", "audio/wav")
+	part, err := form.CreatePart(header)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(req.Audio); err != nil {
+		return nil, err
+	}
+	if req.ModelId != "" {
+		_ = form.WriteField("model_id", req.ModelId)
+	}
+	if req.Profile != "" {
+		_ = form.WriteField("profile", req.Profile)
+	}
+	if len(parameters) > 0 {
+		encoded, err := json.Marshal(parameters)
+		if err != nil {
+			return nil, err
+		}
+		_ = form.WriteField("parameters", string(encoded))
+	}
+	if err := form.Close(); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.do(ctx, http.MethodPost, "/stt", form.FormDataContentType(), &body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var out httpTextResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+
+	return buildHTTPTranscribeResponse(&out, req.Language), nil
+}
+
+func (t *httpTransport) synthesize(ctx context.Context, req *inferencev2.SynthesizeRequest) (*inferencev2.SynthesizeResponse, error) {
+	parameters := map[string]any{}
+	if req.SpeakerId != nil {
+		parameters["speaker_id"] = *req.SpeakerId
+	}
+	if req.LengthScale != nil {
+		parameters["length_scale"] = *req.LengthScale
+	}
+	if req.NoiseScale != nil {
+		parameters["noise_scale"] = *req.NoiseScale
+	}
+	if req.NoiseW != nil {
+		parameters["noise_w"] = *req.NoiseW
+	}
+	if req.SentenceSilence != nil {
+		parameters["sentence_silence"] = *req.SentenceSilence
+	}
+
+	body, err := json.Marshal(httpSynthesizeRequest{
+		Parameters: parameters,
+		ModelID:    req.ModelId,
+		Profile:    req.Profile,
+		Text:       req.Text,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := t.do(ctx, http.MethodPost, "/tts", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	audio, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &inferencev2.SynthesizeResponse{
+		Audio:  audio,
+		Format: inferencev2.AudioFormat_AUDIO_FORMAT_WAV,
+	}, nil
+}
+
+func (t *httpTransport) embed(context.Context, *inferencev2.EmbedRequest) (*inferencev2.EmbedResponse, error) {
+	return nil, ErrUnsupported
+}
+
+func (t *httpTransport) tokenize(ctx context.Context, req *inferencev2.TokenizeRequest) (*inferencev2.TokenizeResponse, error) {
+	var resp httpTokenizeResponse
+	if err := t.postJSON(ctx, "/llm/tokenize", &httpTokenizeRequest{
+		ModelID:    req.ModelId,
+		Profile:    req.Profile,
+		Text:       req.Text,
+		Messages:   buildHTTPMessages(req.Messages),
+		AddSpecial: req.AddSpecial,
+		WithPieces: req.WithPieces,
+	}, &resp); err != nil {
+		return nil, err
+	}
+
+	return &inferencev2.TokenizeResponse{
+		Tokens:        resp.Tokens,
+		Pieces:        resp.Pieces,
+		Prompt:        resp.Prompt,
+		ContextLength: resp.ContextLength,
+		Metadata:      resp.Metadata.proto(),
+	}, nil
+}
+
+func (t *httpTransport) detokenize(ctx context.Context, req *inferencev2.DetokenizeRequest) (*inferencev2.DetokenizeResponse, error) {
+	var resp httpTextResponse
+	if err := t.postJSON(ctx, "/llm/detokenize", &httpDetokenizeRequest{
+		ModelID: req.ModelId,
+		Profile: req.Profile,
+		Tokens:  req.Tokens,
+	}, &resp); err != nil {
+		return nil, err
+	}
+
+	return &inferencev2.DetokenizeResponse{Text: resp.Text, Metadata: resp.Metadata.proto()}, nil
+}
+
+func (t *httpTransport) applyTemplate(ctx context.Context, req *inferencev2.ApplyTemplateRequest) (*inferencev2.ApplyTemplateResponse, error) {
+	var resp httpApplyTemplateResponse
+	if err := t.postJSON(ctx, "/llm/apply-template", &httpChatTemplateRequest{
+		ModelID:  req.ModelId,
+		Profile:  req.Profile,
+		Messages: buildHTTPMessages(req.Messages),
+	}, &resp); err != nil {
+		return nil, err
+	}
+
+	return &inferencev2.ApplyTemplateResponse{Prompt: resp.Prompt, Metadata: resp.Metadata.proto()}, nil
+}
+
+func (t *httpTransport) countTokens(ctx context.Context, req *inferencev2.CountTokensRequest) (*inferencev2.CountTokensResponse, error) {
+	var resp httpCountTokensResponse
+	if err := t.postJSON(ctx, "/llm/count-tokens", &httpChatTemplateRequest{
+		ModelID:  req.ModelId,
+		Profile:  req.Profile,
+		Messages: buildHTTPMessages(req.Messages),
+	}, &resp); err != nil {
+		return nil, err
+	}
+
+	return &inferencev2.CountTokensResponse{
+		Tokens:        resp.Tokens,
+		ContextLength: resp.ContextLength,
+		Metadata:      resp.Metadata.proto(),
+	}, nil
+}
+
+func (t *httpTransport) listModels(ctx context.Context) (*inferencev2.ListModelsResponse, error) {
+	var models []httpModel
+	if err := t.getJSON(ctx, "/models", &models); err != nil {
+		return nil, err
+	}
+
+	out := &inferencev2.ListModelsResponse{Models: make([]*inferencev2.Model, len(models))}
+	for i, m := range models {
+		out.Models[i] = &inferencev2.Model{
+			Id:      m.ID,
+			Type:    inferencev2.ModelType(enumValue(inferencev2.ModelType_value, "MODEL_TYPE_", m.Type)),
+			Backend: m.Backend,
+			Status:  inferencev2.ModelStatus(enumValue(inferencev2.ModelStatus_value, "MODEL_STATUS_", m.Status)),
+			Error:   m.Error,
+			Tags:    m.Tags,
+			Order:   m.Order,
+			Aliases: m.Aliases,
+		}
+		if m.Pull != nil {
+			out.Models[i].Pull = m.Pull.proto()
+		}
+		for _, profile := range m.Profiles {
+			out.Models[i].Profiles = append(out.Models[i].Profiles, &inferencev2.Profile{Name: profile.Name})
+		}
+	}
+
+	return out, nil
+}
+
+func (t *httpTransport) pullModel(ctx context.Context, req *inferencev2.PullModelRequest) (receiver[*inferencev2.PullProgress], error) {
+	// The download runs in a job of the server, whose progress is streamed
+	// separately, from its current state.
+	var job httpPullStatus
+	if err := t.postJSON(ctx, "/models/"+url.PathEscape(req.ModelId)+"/pull", struct{}{}, &job); err != nil {
+		return nil, err
+	}
+
+	resp, err := t.do(ctx, http.MethodGet, "/pulls/"+url.PathEscape(job.ID)+"/events", "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pullReceiver{sseReader: newSSEReader(resp.Body)}, nil
+}
+
+func (t *httpTransport) removeModel(ctx context.Context, req *inferencev2.RemoveModelRequest) (*inferencev2.RemoveModelResponse, error) {
+	resp, err := t.do(ctx, http.MethodDelete, "/cache/"+url.PathEscape(req.ModelId), "", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result httpGCResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+
+	return &inferencev2.RemoveModelResponse{
+		RemovedFiles: result.Removed,
+		FreedBytes:   result.FreedBytes,
+	}, nil
+}
+
+func (t *httpTransport) close() error {
+	t.client.CloseIdleConnections()
+	return nil
+}
+
+// getJSON gets an API path and decodes the JSON response into out.
+func (t *httpTransport) getJSON(ctx context.Context, path string, out any) error {
+	resp, err := t.do(ctx, http.MethodGet, path, "", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("invalid response: %w", err)
+	}
+
+	return nil
+}
+
+// postJSON posts a JSON body to an API path and decodes the JSON response into out.
+func (t *httpTransport) postJSON(ctx context.Context, path string, in, out any) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+
+	resp, err := t.do(ctx, http.MethodPost, path, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("invalid response: %w", err)
+	}
+
+	return nil
+}
+
+// do sends a request to an API path. Responses with an error status are
+// returned as an *HTTPError.
+func (t *httpTransport) do(ctx context.Context, method, path, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, t.baseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if t.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+t.apiKey)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		defer resp.Body.Close()
+		return nil, readHTTPError(resp)
+	}
+
+	return resp, nil
+}
+
+// readHTTPError returns the error of a response with an error status.
+func readHTTPError(resp *http.Response) error {
+	httpErr := &HTTPError{StatusCode: resp.StatusCode}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		httpErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+
+	var problem httpProblem
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, maxEventSize))
+	if err := json.Unmarshal(data, &problem); err == nil && (problem.Title != "" || problem.Detail != "") {
+		httpErr.Message = problem.Detail
+		if httpErr.Message == "" {
+			httpErr.Message = problem.Title
+		}
+		for _, detail := range problem.Errors {
+			httpErr.Details = append(httpErr.Details, detail.Message)
+		}
+	} else {
+		// Not from relic, e.g. from a proxy in front of it.
+		httpErr.Message = strings.TrimSpace(string(data))
+	}
+	if httpErr.Message == "" {
+		httpErr.Message = http.StatusText(resp.StatusCode)
+	}
+
+	return httpErr
+}
+
+// sseReader reads the events of a stream of server-sent events.
+type sseReader struct {
+	body    io.ReadCloser
+	scanner *bufio.Scanner
+}
+
+// newSSEReader returns a reader of the events of a response body.
+func newSSEReader(body io.ReadCloser) sseReader {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(nil, maxEventSize)
+
+	return sseReader{body: body, scanner: scanner}
+}
+
+// sseReceiver receives the chunks of a reply streamed as server-sent events.
+type sseReceiver struct {
+	sseReader
+	done bool
+}
+
+// Recv implements receiver.
+func (r *sseReceiver) Recv() (*inferencev2.ChatChunk, error) {
+	if r.done {
+		return nil, io.EOF
+	}
+
+	data, err := r.next()
+	if err != nil {
+		_ = r.body.Close()
+		if err == io.EOF {
+			// The server always ends with a done event, the stream was cut.
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	var event httpStreamEvent
+	if err := json.Unmarshal(data, &event); err != nil {
+		_ = r.body.Close()
+		return nil, fmt.Errorf("invalid event: %w", err)
+	}
+	switch {
+	case event.Error != "":
+		_ = r.body.Close()
+		return nil, errors.New(event.Error)
+	case event.Done:
+		r.done = true
+		_ = r.body.Close()
+		chunk := &inferencev2.ChatChunk{Done: true, Metadata: event.Metadata.proto()}
+		chunk.FinishReason, chunk.Timings = event.Metadata.chatCompletion()
+		return chunk, nil
+	default:
+		return &inferencev2.ChatChunk{Delta: event.Text}, nil
+	}
+}
+
+// next returns the data of the next event. Event types, IDs and comments are
+// ignored.
+func (r *sseReader) next() ([]byte, error) {
+	var data []byte
+	for r.scanner.Scan() {
+		line := r.scanner.Text()
+		if line == "" {
+			if data != nil {
+				return data, nil
+			}
+			continue
+		}
+
+		if value, ok := strings.CutPrefix(line, "data:"); ok {
+			if data != nil {
+				data = append(data, '\n')
+			}
+			data = append(data, strings.TrimPrefix(value, " ")...)
+		}
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	if data != nil {
+		return data, nil
+	}
+
+	return nil, io.EOF
+}
+
+// pullReceiver receives the progress of a model download streamed as
+// server-sent events, until the download finishes.
+type pullReceiver struct {
+	sseReader
+	done bool
+}
+
+// Recv implements receiver.
+func (r *pullReceiver) Recv() (*inferencev2.PullProgress, error) {
+	if r.done {
+		return nil, io.EOF
+	}
+
+	data, err := r.next()
+	if err != nil {
+		_ = r.body.Close()
+		return nil, err
+	}
+
+	var status httpPullStatus
+	if err := json.Unmarshal(data, &status); err != nil {
+		_ = r.body.Close()
+		return nil, fmt.Errorf("invalid event: %w", err)
+	}
+	if status.State == "" {
+		// The job is gone, e.g. pruned before the stream started.
+		_ = r.body.Close()
+		return nil, errors.New(status.Error)
+	}
+
+	progress := status.proto()
+	switch progress.State {
+	case inferencev2.PullState_PULL_STATE_COMPLETED, inferencev2.PullState_PULL_STATE_FAILED, inferencev2.PullState_PULL_STATE_CANCELED:
+		r.done = true
+		_ = r.body.Close()
+	}
+
+	return progress, nil
+}
+
+// buildHTTPGenerateRequest converts a chat request to the body of the HTTP API.
+func buildHTTPGenerateRequest(req *inferencev2.ChatRequest) *httpGenerateRequest {
+	// The sampling parameters take precedence over the passed through ones.
+	parameters := req.Parameters.AsMap()
+	maps.Copy(parameters, samplingParameters(req.Sampling))
+
+	out := &httpGenerateRequest{
+		Parameters: parameters,
+		ModelID:    req.ModelId,
+		Profile:    req.Profile,
+		Messages:   buildHTTPMessages(req.Messages),
+	}
+
+	return out
+}
+
+// buildHTTPMessages converts chat messages to the messages of the HTTP API.
+func buildHTTPMessages(msgs []*inferencev2.ChatMessage) []httpMessage {
+	messages := make([]httpMessage, len(msgs))
+	for i, msg := range msgs {
+		messages[i] = httpMessage{
+			Role:    enumName(msg.Role.String(), "ROLE_"),
+			Content: msg.Content,
+		}
+	}
+
+	return messages
+}
+
+// samplingParameters returns the backend parameters of the sampling options that
+// are set, as the gRPC server passes them on.
+func samplingParameters(p *inferencev2.SamplingParams) map[string]any {
+	parameters := map[string]any{}
+	if p == nil {
+		return parameters
+	}
+
+	if p.MaxTokens != nil {
+		parameters["n_predict"] = *p.MaxTokens
+	}
+	if p.Temperature != nil {
+		parameters["temperature"] = *p.Temperature
+	}
+	if p.TopK != nil {
+		parameters["top_k"] = *p.TopK
+	}
+	if p.TopP != nil {
+		parameters["top_p"] = *p.TopP
+	}
+	if p.MinP != nil {
+		parameters["min_p"] = *p.MinP
+	}
+	if p.RepeatPenalty != nil {
+		parameters["repeat_penalty"] = *p.RepeatPenalty
+	}
+	if p.PresencePenalty != nil {
+		parameters["presence_penalty"] = *p.PresencePenalty
+	}
+	if p.FrequencyPenalty != nil {
+		parameters["frequency_penalty"] = *p.FrequencyPenalty
+	}
+
+	return parameters
+}
+
+// buildHTTPTranscribeResponse converts a transcription of the HTTP API, with the
+// segments of the whisper.cpp response in its metadata.
+func buildHTTPTranscribeResponse(resp *httpTextResponse, language string) *inferencev2.TranscribeResponse {
+	out := &inferencev2.TranscribeResponse{
+		Text:     resp.Text,
+		Language: language,
+		Metadata: resp.Metadata.proto(),
+	}
+	if resp.Metadata == nil {
+		return out
+	}
+	if resp.Metadata.Usage != nil {
+		out.DurationSeconds = resp.Metadata.Usage.AudioSeconds
+	}
+
+	var transcription httpTranscription
+	if err := json.Unmarshal(resp.Metadata.BackendSpecific["response"], &transcription); err != nil {
+		return out
+	}
+	if transcription.DetectedLanguage != "" {
+		out.Language = transcription.DetectedLanguage
+	}
+	for _, segment := range transcription.Segments {
+		s := &inferencev2.Segment{
+			Id:           segment.ID,
+			StartSeconds: segment.Start,
+			EndSeconds:   segment.End,
+			Text:         segment.Text,
+			AvgLogprob:   segment.AvgLogprob,
+			NoSpeechProb: segment.NoSpeechProb,
+		}
+		for _, word := range segment.Words {
+			s.Words = append(s.Words, &inferencev2.Word{
+				Word:         word.Word,
+				StartSeconds: word.Start,
+				EndSeconds:   word.End,
+				Probability:  word.Probability,
+			})
+		}
+		out.Segments = append(out.Segments, s)
+	}
+
+	return out
+}
+
+// proto converts the metadata of a response to protobuf.
+func (m *httpMetadata) proto() *inferencev2.ResponseMetadata {
+	if m == nil {
+		return nil
+	}
+
+	metadata := &inferencev2.ResponseMetadata{
+		Backend:         m.Provider,
+		ModelId:         m.Model,
+		DurationSeconds: m.DurationSeconds,
+	}
+	if !m.Timestamp.IsZero() {
+		metadata.Timestamp = timestamppb.New(m.Timestamp)
+	}
+	if u := m.Usage; u != nil {
+		metadata.Usage = &inferencev2.Usage{
+			PromptTokens:    u.PromptTokens,
+			GeneratedTokens: u.GeneratedTokens,
+			TokensPerSecond: u.TokensPerSecond,
+			AudioSeconds:    u.AudioSeconds,
+			Characters:      u.Characters,
+		}
+	}
+
+	return metadata
+}
+
+// proto converts the status of a model download to protobuf.
+func (s *httpPullStatus) proto() *inferencev2.PullProgress {
+	progress := &inferencev2.PullProgress{
+		JobId:              s.ID,
+		ModelId:            s.ModelID,
+		State:              inferencev2.PullState(enumValue(inferencev2.PullState_value, "PULL_STATE_", s.State)),
+		BytesDone:          s.BytesDone,
+		BytesTotal:         s.BytesTotal,
+		RateBytesPerSecond: s.RateBytesPerSec,
+		EtaSeconds:         s.ETASeconds,
+		Error:              s.Error,
+		Files:              make([]*inferencev2.FileProgress, len(s.Files)),
+	}
+	if s.StartedAt != nil {
+		progress.StartedAt = timestamppb.New(*s.StartedAt)
+	}
+	if s.FinishedAt != nil {
+		progress.FinishedAt = timestamppb.New(*s.FinishedAt)
+	}
+	for i, f := range s.Files {
+		progress.Files[i] = &inferencev2.FileProgress{
+			Path:       f.Path,
+			State:      inferencev2.FileState(enumValue(inferencev2.FileState_value, "FILE_STATE_", f.State)),
+			BytesDone:  f.BytesDone,
+			BytesTotal: f.BytesTotal,
+		}
+	}
+
+	return progress
+}
+
+// chatCompletion returns the finish reason and timings of the llama-server
+// response in the metadata of a reply.
+func (m *httpMetadata) chatCompletion() (string, *inferencev2.Timings) {
+	if m == nil {
+		return "", nil
+	}
+
+	var completion httpChatCompletion
+	if err := json.Unmarshal(m.BackendSpecific["response"], &completion); err != nil {
+		return "", nil
+	}
+
+	var finishReason string
+	if len(completion.Choices) > 0 {
+		finishReason = completion.Choices[0].FinishReason
+	}
+
+	var timings *inferencev2.Timings
+	if len(completion.Timings) > 0 {
+		timings = &inferencev2.Timings{
+			PromptSeconds:            completion.Timings["prompt_ms"] / 1000,
+			GenerationSeconds:        completion.Timings["predicted_ms"] / 1000,
+			PromptTokensPerSecond:    completion.Timings["prompt_per_second"],
+			GeneratedTokensPerSecond: completion.Timings["predicted_per_second"],
+		}
+	}
+
+	return finishReason, timings
+}
@@ -0,0 +1,330 @@
+package relic_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	relic "github.com/ju4n97/relic/sdk-go"
+)
+
+// newHTTPClient returns a client of the HTTP API served by handler, without retries.
+func newHTTPClient(t *testing.T, handler http.Handler, opts ...relic.ClientOption) *relic.Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := relic.NewClient(server.URL, append([]relic.ClientOption{relic.WithoutRetries()}, opts...)...)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	return client
+}
+
+func TestHTTP_Chat(t *testing.T) {
+	var (
+		authorization string
+		body          struct {
+			Parameters map[string]any `json:"parameters"`
+			ModelID    string         `json:"model_id"`
+			Messages   []struct {
+				Role    string `json:"role"`
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/llm", func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		_, _ = io.WriteString(w, `{
+			"text": "Hello!",
+			"metadata": {
+				"provider": "llama.cpp",
+				"model": "qwen",
+				"usage": {"prompt_tokens": 12, "generated_tokens": 3},
+				"backend_specific": {"response": {"choices": [{"finish_reason": "length"}], "timings": {"predicted_ms": 1500, "predicted_per_second": 2}}}
+			}
+		}`)
+	})
+	client := newHTTPClient(t, mux, relic.WithAPIKey("secret"))
+
+	result, err := client.Chat(context.Background(), []relic.Message{
+		relic.NewMessage(relic.MessageRoleSystem, "Be brief."),
+		relic.NewMessage(relic.MessageRoleUser, "Hi"),
+	}, relic.WithModelID("chat"), relic.WithMaxTokens(64), relic.WithParameter("seed", 42))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Bearer secret", authorization)
+	assert.Equal(t, "chat", body.ModelID)
+	require.Len(t, body.Messages, 2)
+	assert.Equal(t, "system", body.Messages[0].Role)
+	assert.Equal(t, "user", body.Messages[1].Role)
+	assert.Equal(t, map[string]any{"n_predict": float64(64), "seed": float64(42)}, body.Parameters)
+
+	assert.Equal(t, "Hello!", result.Text)
+	assert.Equal(t, "qwen", result.Model)
+	assert.Equal(t, "length", result.FinishReason)
+	require.NotNil(t, result.Usage)
+	assert.Equal(t, 3, result.Usage.GeneratedTokens)
+	require.NotNil(t, result.Timings)
+	assert.Equal(t, 1500*time.Millisecond, result.Timings.Generation)
+}
+
+func TestHTTP_GenerateStream(t *testing.T) {
+	// Events split across writes, in the middle of lines and of frames, with
+	// comments, event types and data spread over several lines.
+	writes := []string{
+		": keep-alive\n\nevent: message\nda",
+		`ta: {"text": "Hel"}`,
+		"\n\ndata: {\"text\":\n",
+		"data: \"lo!\"}\n",
+		"\ndata: {\"done\": true, \"metadata\": {\"usage\": {\"generated_tokens\": 2}, ",
+		`"backend_specific": {"response": {"choices": [{"finish_reason": "stop"}]}}}}`,
+		"\n\n",
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/llm/stream", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, part := range writes {
+			_, _ = io.WriteString(w, part)
+			w.(http.Flusher).Flush()
+			time.Sleep(5 * time.Millisecond)
+		}
+	})
+	client := newHTTPClient(t, mux)
+
+	var (
+		text string
+		last relic.StreamChunk
+	)
+	for chunk := range client.GenerateStream(context.Background(), []relic.Message{relic.NewMessage(relic.MessageRoleUser, "Hi")}) {
+		require.NoError(t, chunk.Error)
+		text += chunk.Content
+		last = chunk
+	}
+
+	assert.Equal(t, "Hello!", text)
+	assert.True(t, last.Done)
+	assert.Equal(t, "stop", last.FinishReason)
+	require.NotNil(t, last.Usage)
+	assert.Equal(t, 2, last.Usage.GeneratedTokens)
+}
+
+func TestHTTP_GenerateStream_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		events string
+		want   string
+	}{
+		{"cut", "data: {\"text\": \"Hel\"}\n\n", io.ErrUnexpectedEOF.Error()},
+		{"error event", "data: {\"text\": \"Hel\"}\n\ndata: {\"error\": \"backend crashed\"}\n\n", "backend crashed"},
+		{"invalid event", "data: {\"text\": \"Hel\"}\n\ndata: nope\n\n", "invalid event"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newHTTPClient(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "text/event-stream")
+				_, _ = io.WriteString(w, tt.events)
+			}))
+
+			var err error
+			for chunk := range client.GenerateStream(context.Background(), []relic.Message{relic.NewMessage(relic.MessageRoleUser, "Hi")}) {
+				if chunk.Error != nil {
+					err = chunk.Error
+				}
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestHTTP_Transcribe(t *testing.T) {
+	var (
+		audio       []byte
+		filename    string
+		contentType string
+		modelID     string
+		parameters  map[string]any
+	)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/stt", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+
+		audio, _ = io.ReadAll(file)
+		filename, contentType = header.Filename, header.Header.Get("Content-Type")
+		modelID = r.FormValue("model_id")
+		_ = json.Unmarshal([]byte(r.FormValue("parameters")), &parameters)
+
+		_, _ = io.WriteString(w, `{
+			"text": "Hola mundo",
+			"metadata": {
+				"usage": {"audio_seconds": 2.5},
+				"backend_specific": {"response": {"detected_language": "es", "segments": [{
+					"id": 0, "start": 0.1, "end": 2.4, "text": "Hola mundo",
+					"words": [{"word": "Hola", "start": 0.1, "end": 0.9, "probability": 0.98}, {"word": "mundo", "start": 1.0, "end": 2.4, "probability": 0.95}]
+				}]}}
+			}
+		}`)
+	})
+	client := newHTTPClient(t, mux)
+
+	transcript, err := client.Transcribe(context.Background(), []byte("RIFF...."),
+		relic.WithModelID("whisper"), relic.WithParameter("beam_size", 5), relic.WithParameter("no_timestamps", true))
+	require.NoError(t, err)
+
+	assert.Equal(t, []byte("RIFF...."), audio)
+	assert.Equal(t, "audio.wav", filename)
+	assert.Equal(t, "audio/wav", contentType)
+	assert.Equal(t, "whisper", modelID)
+	assert.Equal(t, map[string]any{"beam_size": float64(5), "no_timestamps": true}, parameters)
+
+	assert.Equal(t, "Hola mundo", transcript.Text)
+	assert.Equal(t, "es", transcript.Language)
+	assert.Equal(t, 2500*time.Millisecond, transcript.Duration)
+	require.Len(t, transcript.Segments, 1)
+	assert.Equal(t, 2400*time.Millisecond, transcript.Segments[0].End)
+	require.Len(t, transcript.Words, 2)
+	assert.Equal(t, "mundo", transcript.Words[1].Text)
+	assert.Equal(t, time.Second, transcript.Words[1].Start)
+}
+
+func TestHTTP_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		message string
+		details []string
+	}{
+		{
+			name:    "problem",
+			status:  http.StatusUnprocessableEntity,
+			body:    `{"title": "Unprocessable Entity", "detail": "validation failed", "errors": [{"message": "expected messages"}]}`,
+			message: "validation failed",
+			details: []string{"expected messages"},
+		},
+		{"problem without detail", http.StatusNotFound, `{"title": "model not found"}`, "model not found", nil},
+		{"proxy", http.StatusBadGateway, "upstream went away\n", "upstream went away", nil},
+		{"empty", http.StatusServiceUnavailable, "", "Service Unavailable", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newHTTPClient(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = io.WriteString(w, tt.body)
+			}))
+
+			_, err := client.Generate(context.Background(), []relic.Message{relic.NewMessage(relic.MessageRoleUser, "Hi")})
+
+			var httpErr *relic.HTTPError
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, tt.status, httpErr.StatusCode)
+			assert.Equal(t, tt.message, httpErr.Message)
+			assert.Equal(t, tt.details, httpErr.Details)
+		})
+	}
+}
+
+func TestHTTP_ListModels(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/models", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `[
+			{"id": "qwen", "type": "llm", "backend": "llama.cpp", "status": "loaded", "aliases": ["chat"], "profiles": [{"name": "gpu"}, {"name": "cpu"}], "order": 1, "default": true},
+			{"id": "whisper", "type": "stt", "backend": "whisper.cpp", "status": "downloading", "pull": {"id": "job-1", "model_id": "whisper", "state": "running", "bytes_done": 10, "bytes_total": 40, "eta_seconds": 3}}
+		]`)
+	})
+	client := newHTTPClient(t, mux)
+
+	models, err := client.ListModels(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []relic.Model{
+		{ID: "qwen", Type: "llm", Backend: "llama.cpp", Status: "loaded", Aliases: []string{"chat"}, Profiles: []string{"gpu", "cpu"}},
+		{ID: "whisper", Type: "stt", Backend: "whisper.cpp", Status: "downloading", Pull: &relic.PullProgress{
+			JobID: "job-1", ModelID: "whisper", State: "running", BytesDone: 10, BytesTotal: 40, ETA: 3 * time.Second,
+		}},
+	}, models)
+}
+
+func TestHTTP_PullModel(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/models/{model_id}/pull", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = io.WriteString(w, `{"id": "job-1", "model_id": "`+r.PathValue("model_id")+`", "state": "queued"}`)
+	})
+	mux.HandleFunc("GET /v1/pulls/job-1/events", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "event: progress\ndata: {\"id\": \"job-1\", \"model_id\": \"qwen\", \"state\": \"running\", \"bytes_done\": 10, \"bytes_total\": 20}\n\n")
+		_, _ = io.WriteString(w, "event: progress\ndata: {\"id\": \"job-1\", \"model_id\": \"qwen\", \"state\": \"completed\", \"bytes_done\": 20, \"bytes_total\": 20}\n\n")
+	})
+	mux.HandleFunc("POST /v1/models/broken/pull", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `{"id": "job-2", "model_id": "broken", "state": "queued"}`)
+	})
+	mux.HandleFunc("GET /v1/pulls/job-2/events", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = io.WriteString(w, "data: {\"id\": \"job-2\", \"model_id\": \"broken\", \"state\": \"failed\", \"error\": \"disk full\"}\n\n")
+	})
+	client := newHTTPClient(t, mux)
+
+	var states []string
+	err := client.PullModel(context.Background(), "qwen", func(p relic.PullProgress) {
+		states = append(states, p.State)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"running", "completed"}, states)
+
+	err = client.PullModel(context.Background(), "broken", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "disk full")
+}
+
+func TestHTTP_RemoveModel(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("DELETE /v1/cache/{model_id}", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("model_id") != "qwen" {
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"title": "Not Found", "status": 404, "detail": "model not found"}`)
+			return
+		}
+		_, _ = io.WriteString(w, `{"removed": ["qwen.gguf"], "evicted_models": [], "freed_bytes": 1024, "dry_run": false}`)
+	})
+	client := newHTTPClient(t, mux)
+
+	freed, err := client.RemoveModel(context.Background(), "qwen")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1024), freed)
+
+	_, err = client.RemoveModel(context.Background(), "unknown")
+	assert.True(t, errors.Is(err, relic.ErrModelNotFound), err)
+}
+
+func TestHTTP_Unsupported(t *testing.T) {
+	client := newHTTPClient(t, http.NotFoundHandler())
+
+	_, err := client.Embed(context.Background(), []string{"Hello"})
+	assert.True(t, errors.Is(err, relic.ErrUnsupported), err)
+}
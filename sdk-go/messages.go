@@ -45,9 +45,13 @@ func NewToolMessage(content string) Message { return NewMessage(MessageRoleTool,
 // NewDeveloperMessage creates a new developer message.
 func NewDeveloperMessage(content string) Message { return NewMessage(MessageRoleDeveloper, content) }
 
-// StreamChunk represents a chunk of a stream response.
+// StreamChunk represents a chunk of a stream response. The last chunk, with
+// Done set, carries the usage, timings and finish reason of the reply.
 type StreamChunk struct {
-	Done    bool   `json:"done"`
-	Content string `json:"content"`
-	Error   error  `json:"error,omitempty"`
+	Done         bool     `json:"done"`
+	Content      string   `json:"content"`
+	Error        error    `json:"error,omitempty"`
+	FinishReason string   `json:"finish_reason,omitempty"`
+	Usage        *Usage   `json:"usage,omitempty"`
+	Timings      *Timings `json:"timings,omitempty"`
 }
@@ -0,0 +1,173 @@
+package relic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	inferencev2 "github.com/ju4n97/relic/sdk-go/pb/inference/v2"
+)
+
+// Model describes a model configured on the server.
+type Model struct {
+	ID       string
+	Type     string // "llm", "nlu", "stt" or "tts"
+	Backend  string
+	Status   string // e.g. "loaded", "downloading" or "not_cached"
+	Error    string
+	Aliases  []string
+	Profiles []string      // The first is the default
+	Pull     *PullProgress // Active download, if any
+}
+
+// PullProgress is the progress of a model download.
+type PullProgress struct {
+	JobID              string
+	ModelID            string
+	State              string // "queued", "running", "completed", "failed" or "canceled"
+	Error              string
+	BytesDone          int64
+	BytesTotal         int64
+	RateBytesPerSecond float64
+	ETA                time.Duration
+}
+
+// ListModels lists the models configured on the server and their status. Of the
+// options, WithTimeout and WithRetries apply.
+func (c *Client) ListModels(ctx context.Context, options ...Option) ([]Model, error) {
+	cfg := c.applyOptions(options...)
+
+	resp, err := call(ctx, c, cfg.Timeout, cfg.Retries, c.transport.listModels)
+	if err != nil {
+		return nil, fmt.Errorf("relic: failed to list models: %w", err)
+	}
+
+	models := make([]Model, len(resp.Models))
+	for i, m := range resp.Models {
+		models[i] = Model{
+			ID:      m.Id,
+			Type:    enumName(m.Type.String(), "MODEL_TYPE_"),
+			Backend: m.Backend,
+			Status:  enumName(m.Status.String(), "MODEL_STATUS_"),
+			Error:   m.Error,
+			Aliases: m.Aliases,
+		}
+		for _, profile := range m.Profiles {
+			models[i].Profiles = append(models[i].Profiles, profile.Name)
+		}
+		if m.Pull != nil {
+			progress := buildPullProgress(m.Pull)
+			models[i].Pull = &progress
+		}
+	}
+
+	return models, nil
+}
+
+// PullModel downloads a model on the server, or attaches to its running download,
+// and waits until it finishes. onProgress, which may be nil, is called with every
+// progress update.
+//
+// Of the options, WithTimeout and WithRetries apply. The default timeout of the
+// client does not, downloads take long.
+//
+// Example:
+//
+//	err := client.PullModel(ctx, "qwen", func(p relic.PullProgress) {
+//		fmt.Printf("%d/%d bytes\n", p.BytesDone, p.BytesTotal)
+//	})
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func (c *Client) PullModel(ctx context.Context, modelID string, onProgress func(PullProgress), options ...Option) error {
+	cfg := &Config{}
+	for _, option := range options {
+		option(cfg)
+	}
+
+	ctx, cancel := withTimeout(ctx, cfg.Timeout)
+	defer cancel()
+
+	req := &inferencev2.PullModelRequest{ModelId: modelID}
+	stream, err := openStream(ctx, c, cfg.Retries, func(ctx context.Context) (receiver[*inferencev2.PullProgress], error) {
+		return c.transport.pullModel(ctx, req)
+	})
+	if err != nil {
+		return fmt.Errorf("relic: failed to pull model: %w", err)
+	}
+
+	var last PullProgress
+	for {
+		update, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("relic: failed to pull model: %w", err)
+		}
+
+		last = buildPullProgress(update)
+		if onProgress != nil {
+			onProgress(last)
+		}
+	}
+
+	switch last.State {
+	case "completed":
+		return nil
+	case "failed":
+		return fmt.Errorf("relic: failed to pull model: %s", last.Error)
+	case "canceled":
+		return errors.New("relic: failed to pull model: download canceled")
+	default:
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return errors.New("relic: failed to pull model: stream closed before the download finished")
+	}
+}
+
+// RemoveModel removes the cached files of a model on the server, keeping those
+// other models use, and returns the number of bytes freed. Of the options,
+// WithTimeout and WithRetries apply.
+func (c *Client) RemoveModel(ctx context.Context, modelID string, options ...Option) (int64, error) {
+	cfg := c.applyOptions(options...)
+
+	req := &inferencev2.RemoveModelRequest{ModelId: modelID}
+	resp, err := call(ctx, c, cfg.Timeout, cfg.Retries, func(ctx context.Context) (*inferencev2.RemoveModelResponse, error) {
+		return c.transport.removeModel(ctx, req)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("relic: failed to remove model: %w", err)
+	}
+
+	return resp.FreedBytes, nil
+}
+
+// buildPullProgress converts a download progress from protobuf.
+func buildPullProgress(p *inferencev2.PullProgress) PullProgress {
+	return PullProgress{
+		JobID:              p.JobId,
+		ModelID:            p.ModelId,
+		State:              enumName(p.State.String(), "PULL_STATE_"),
+		Error:              p.Error,
+		BytesDone:          p.BytesDone,
+		BytesTotal:         p.BytesTotal,
+		RateBytesPerSecond: p.RateBytesPerSecond,
+		ETA:                seconds(p.EtaSeconds),
+	}
+}
+
+// enumName returns the lowercase name of a protobuf enum value without its prefix.
+func enumName(name, prefix string) string {
+	return strings.ToLower(strings.TrimPrefix(name, prefix))
+}
+
+// enumValue returns the protobuf enum value of a lowercase name without its
+// prefix, the unspecified value for unknown names.
+func enumValue(values map[string]int32, prefix, name string) int32 {
+	return values[prefix+strings.ToUpper(name)]
+}
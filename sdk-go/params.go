@@ -0,0 +1,361 @@
+package relic
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	inferencev2 "github.com/ju4n97/relic/sdk-go/pb/inference/v2"
+)
+
+// Backends serving the requests of the SDK.
+const (
+	backendLlama   = "llama.cpp"
+	backendWhisper = "whisper.cpp"
+	backendPiper   = "piper"
+)
+
+// messageRoles maps the message roles to protobuf.
+var messageRoles = map[MessageRole]inferencev2.Role{
+	MessageRoleSystem:    inferencev2.Role_ROLE_SYSTEM,
+	MessageRoleUser:      inferencev2.Role_ROLE_USER,
+	MessageRoleAssistant: inferencev2.Role_ROLE_ASSISTANT,
+	MessageRoleTool:      inferencev2.Role_ROLE_TOOL,
+	MessageRoleDeveloper: inferencev2.Role_ROLE_DEVELOPER,
+}
+
+// parameters reads the parameters of a request, rejecting values of the wrong
+// type. Parameters the request has no field for are passed through to the backend
+// or, for backends that take none, rejected.
+type parameters struct {
+	values map[string]any
+	used   map[string]bool
+	err    error
+}
+
+// newParameters returns the parameters of a config.
+func newParameters(values map[string]any) *parameters {
+	return &parameters{values: values, used: map[string]bool{}}
+}
+
+// int returns the integer parameter with the first of the given names that is set.
+func (p *parameters) int(names ...string) *int32 {
+	name, v, ok := p.lookup(names)
+	if !ok {
+		return nil
+	}
+
+	var n int64
+	switch v := v.(type) {
+	case int:
+		n = int64(v)
+	case int32:
+		n = int64(v)
+	case int64:
+		n = v
+	case float64:
+		if v != math.Trunc(v) {
+			p.fail(name, "an integer", v)
+			return nil
+		}
+		if v < math.MinInt32 || v > math.MaxInt32 {
+			p.outOfRange(name, v)
+			return nil
+		}
+		n = int64(v)
+	default:
+		p.fail(name, "an integer", v)
+		return nil
+	}
+
+	if n < math.MinInt32 || n > math.MaxInt32 {
+		p.outOfRange(name, n)
+		return nil
+	}
+
+	n32 := int32(n)
+	return &n32
+}
+
+// float returns the float parameter with the given name.
+func (p *parameters) float(name string) *float64 {
+	_, v, ok := p.lookup([]string{name})
+	if !ok {
+		return nil
+	}
+
+	var f float64
+	switch v := v.(type) {
+	case float64:
+		f = v
+	case float32:
+		f = float64(v)
+	case int:
+		f = float64(v)
+	default:
+		p.fail(name, "a number", v)
+		return nil
+	}
+
+	return &f
+}
+
+// string returns the string parameter with the given name.
+func (p *parameters) string(name string) string {
+	_, v, ok := p.lookup([]string{name})
+	if !ok {
+		return ""
+	}
+
+	s, ok := v.(string)
+	if !ok {
+		p.fail(name, "a string", v)
+	}
+
+	return s
+}
+
+// bool returns the boolean parameter with the given name.
+func (p *parameters) bool(name string) bool {
+	_, v, ok := p.lookup([]string{name})
+	if !ok {
+		return false
+	}
+
+	b, ok := v.(bool)
+	if !ok {
+		p.fail(name, "a boolean", v)
+	}
+
+	return b
+}
+
+// lookup returns the first of the given parameters that is set and marks them
+// all as used.
+func (p *parameters) lookup(names []string) (string, any, bool) {
+	for _, name := range names {
+		p.used[name] = true
+	}
+	for _, name := range names {
+		if v, ok := p.values[name]; ok {
+			return name, v, true
+		}
+	}
+
+	return "", nil, false
+}
+
+// fail records the first invalid parameter.
+func (p *parameters) fail(name, want string, v any) {
+	if p.err == nil {
+		p.err = fmt.Errorf("relic: parameter %s must be %s, got %T", name, want, v)
+	}
+}
+
+// outOfRange records the first integer parameter that does not fit in 32 bits.
+func (p *parameters) outOfRange(name string, v any) {
+	if p.err == nil {
+		p.err = fmt.Errorf("relic: parameter %s is out of range: %v", name, v)
+	}
+}
+
+// unused returns the names of the parameters that were not read, sorted.
+func (p *parameters) unused() []string {
+	var names []string
+	for name := range p.values {
+		if !p.used[name] {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// passThrough returns the first invalid parameter, or the parameters that were
+// not read, to be passed through to the backend as they are, e.g. seed.
+func (p *parameters) passThrough() (*structpb.Struct, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+
+	names := p.unused()
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	rest := make(map[string]any, len(names))
+	for _, name := range names {
+		rest[name] = p.values[name]
+	}
+
+	out, err := structpb.NewStruct(rest)
+	if err != nil {
+		return nil, fmt.Errorf("relic: invalid parameters: %w", err)
+	}
+
+	return out, nil
+}
+
+// done returns the first invalid parameter, or the parameters the request does
+// not support.
+func (p *parameters) done() error {
+	if p.err != nil {
+		return p.err
+	}
+
+	if unknown := p.unused(); len(unknown) > 0 {
+		return fmt.Errorf("relic: unsupported parameters: %s", strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
+
+// buildChatRequest builds the request of a chat completion.
+func buildChatRequest(messages []Message, cfg *Config) (*inferencev2.ChatRequest, error) {
+	if len(messages) == 0 {
+		return nil, errors.New("relic: messages cannot be empty")
+	}
+
+	if err := checkProvider(cfg, backendLlama); err != nil {
+		return nil, err
+	}
+
+	chatMessages, err := buildChatMessages(messages)
+	if err != nil {
+		return nil, err
+	}
+
+	p := newParameters(cfg.Parameters)
+	sampling := &inferencev2.SamplingParams{
+		// n_predict is the llama.cpp name of max_tokens.
+		MaxTokens:        p.int("max_tokens", "n_predict"),
+		Temperature:      p.float("temperature"),
+		TopK:             p.int("top_k"),
+		TopP:             p.float("top_p"),
+		MinP:             p.float("min_p"),
+		RepeatPenalty:    p.float("repeat_penalty"),
+		PresencePenalty:  p.float("presence_penalty"),
+		FrequencyPenalty: p.float("frequency_penalty"),
+	}
+	parameters, err := p.passThrough()
+	if err != nil {
+		return nil, err
+	}
+
+	return &inferencev2.ChatRequest{
+		ModelId:    cfg.ModelID,
+		Profile:    cfg.Profile,
+		Messages:   chatMessages,
+		Sampling:   sampling,
+		Parameters: parameters,
+	}, nil
+}
+
+// buildChatMessages converts messages to protobuf.
+func buildChatMessages(messages []Message) ([]*inferencev2.ChatMessage, error) {
+	chatMessages := make([]*inferencev2.ChatMessage, len(messages))
+	for i, msg := range messages {
+		role, ok := messageRoles[msg.Role]
+		if !ok {
+			return nil, fmt.Errorf("relic: unknown role %q of message %d", msg.Role, i)
+		}
+		chatMessages[i] = &inferencev2.ChatMessage{Role: role, Content: msg.Content}
+	}
+
+	return chatMessages, nil
+}
+
+// buildTokenizeRequest builds the request of a tokenization of text or messages.
+func buildTokenizeRequest(text string, messages []Message, cfg *Config) (*inferencev2.TokenizeRequest, error) {
+	if err := checkProvider(cfg, backendLlama); err != nil {
+		return nil, err
+	}
+
+	chatMessages, err := buildChatMessages(messages)
+	if err != nil {
+		return nil, err
+	}
+
+	p := newParameters(cfg.Parameters)
+	req := &inferencev2.TokenizeRequest{
+		ModelId:    cfg.ModelID,
+		Profile:    cfg.Profile,
+		Text:       text,
+		Messages:   chatMessages,
+		AddSpecial: p.bool("add_special"),
+		WithPieces: p.bool("with_pieces"),
+	}
+	if err := p.done(); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// buildTranscribeRequest builds the request of a transcription.
+func buildTranscribeRequest(audio []byte, cfg *Config) (*inferencev2.TranscribeRequest, error) {
+	if err := checkProvider(cfg, backendWhisper); err != nil {
+		return nil, err
+	}
+
+	p := newParameters(cfg.Parameters)
+	req := &inferencev2.TranscribeRequest{
+		ModelId:     cfg.ModelID,
+		Profile:     cfg.Profile,
+		Audio:       audio,
+		Format:      inferencev2.AudioFormat_AUDIO_FORMAT_WAV,
+		Language:    p.string("language"),
+		Prompt:      p.string("prompt"),
+		Translate:   p.bool("translate"),
+		Temperature: p.float("temperature"),
+		BeamSize:    p.int("beam_size"),
+		BestOf:      p.int("best_of"),
+	}
+	parameters, err := p.passThrough()
+	if err != nil {
+		return nil, err
+	}
+	req.Parameters = parameters
+
+	return req, nil
+}
+
+// buildSynthesizeRequest builds the request of a speech synthesis.
+func buildSynthesizeRequest(text string, cfg *Config) (*inferencev2.SynthesizeRequest, error) {
+	if err := checkProvider(cfg, backendPiper); err != nil {
+		return nil, err
+	}
+
+	p := newParameters(cfg.Parameters)
+	req := &inferencev2.SynthesizeRequest{
+		ModelId:         cfg.ModelID,
+		Profile:         cfg.Profile,
+		Text:            text,
+		Format:          inferencev2.AudioFormat_AUDIO_FORMAT_WAV,
+		SpeakerId:       p.int("speaker_id"),
+		LengthScale:     p.float("length_scale"),
+		NoiseScale:      p.float("noise_scale"),
+		NoiseW:          p.float("noise_w"),
+		SentenceSilence: p.float("sentence_silence"),
+	}
+	if err := p.done(); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// checkProvider returns an error if the request names a provider other than the
+// backend serving it.
+func checkProvider(cfg *Config, backend string) error {
+	if cfg.Provider != "" && cfg.Provider != backend {
+		return fmt.Errorf("relic: provider %s cannot serve the request, it is served by %s", cfg.Provider, backend)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package relic
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var hello = []Message{{Role: MessageRoleUser, Content: "Hello"}}
+
+func TestBuildChatRequest_Parameters(t *testing.T) {
+	cfg := &Config{Parameters: map[string]any{
+		"max_tokens":    64,
+		"temperature":   0.2,
+		"seed":          42,
+		"system_prompt": "Be brief.",
+		"cache_prompt":  false,
+	}}
+
+	req, err := buildChatRequest(hello, cfg)
+	require.NoError(t, err)
+
+	assert.EqualValues(t, 64, req.Sampling.GetMaxTokens())
+	assert.InDelta(t, 0.2, req.Sampling.GetTemperature(), 1e-9)
+	assert.Equal(t, map[string]any{
+		"seed":          float64(42),
+		"system_prompt": "Be brief.",
+		"cache_prompt":  false,
+	}, req.Parameters.AsMap(), "parameters without a field are passed through")
+}
+
+func TestBuildChatRequest_NoParameters(t *testing.T) {
+	req, err := buildChatRequest(hello, &Config{})
+	require.NoError(t, err)
+	assert.Nil(t, req.Parameters)
+}
+
+func TestBuildChatRequest_InvalidParameters(t *testing.T) {
+	tests := []struct {
+		name       string
+		parameters map[string]any
+		want       string
+	}{
+		{"wrong type", map[string]any{"temperature": "hot"}, "parameter temperature must be a number"},
+		{"fraction", map[string]any{"top_k": 1.5}, "parameter top_k must be an integer"},
+		{"int out of range", map[string]any{"max_tokens": math.MaxInt32 + 1}, "parameter max_tokens is out of range"},
+		{"int64 out of range", map[string]any{"n_predict": int64(math.MinInt32) - 1}, "parameter n_predict is out of range"},
+		{"float out of range", map[string]any{"max_tokens": 1e12}, "parameter max_tokens is out of range"},
+		{"unsupported pass through", map[string]any{"seed": struct{}{}}, "invalid parameters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := buildChatRequest(hello, &Config{Parameters: tt.parameters})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestBuildChatRequest_Provider(t *testing.T) {
+	_, err := buildChatRequest(hello, &Config{Provider: "llama.cpp"})
+	require.NoError(t, err)
+
+	_, err = buildChatRequest(hello, &Config{Provider: "whisper.cpp"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "provider whisper.cpp cannot serve the request")
+}
+
+func TestBuildSpeechRequests_Parameters(t *testing.T) {
+	transcribe, err := buildTranscribeRequest([]byte("RIFF"), &Config{Parameters: map[string]any{
+		"language":      "en",
+		"beam_size":     5,
+		"no_timestamps": true,
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, "en", transcribe.Language)
+	assert.EqualValues(t, 5, transcribe.GetBeamSize())
+	assert.Equal(t, map[string]any{"no_timestamps": true}, transcribe.Parameters.AsMap())
+
+	synthesize, err := buildSynthesizeRequest("Hello", &Config{Parameters: map[string]any{"speaker_id": 2}})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, synthesize.GetSpeakerId())
+
+	_, err = buildSynthesizeRequest("Hello", &Config{Parameters: map[string]any{"speaker": "amy"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported parameters: speaker", "piper takes no other parameters")
+
+	_, err = buildSynthesizeRequest("Hello", &Config{Provider: "llama.cpp"})
+	assert.Error(t, err)
+}
+
+func TestBuildTokenizeRequest_RejectsUnknownParameters(t *testing.T) {
+	req, err := buildTokenizeRequest("Hello", nil, &Config{Parameters: map[string]any{"add_special": true}})
+	require.NoError(t, err)
+	assert.True(t, req.AddSpecial)
+
+	_, err = buildTokenizeRequest("Hello", nil, &Config{Parameters: map[string]any{"seed": 42}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported parameters: seed")
+}
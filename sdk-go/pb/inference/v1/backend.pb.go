@@ -30,6 +30,7 @@ type InferenceRequest struct {
 	ModelId       string                 `protobuf:"bytes,2,opt,name=model_id,json=modelId,proto3" json:"model_id,omitempty"` // Logical model ID, e.g. "llama2-7b"
 	Input         []byte                 `protobuf:"bytes,3,opt,name=input,proto3" json:"input,omitempty"`                    // Raw input (text, audio, image, etc.)
 	Parameters    *structpb.Struct       `protobuf:"bytes,4,opt,name=parameters,proto3" json:"parameters,omitempty"`          // Backend-specific inference params
+	Profile       string                 `protobuf:"bytes,5,opt,name=profile,proto3" json:"profile,omitempty"`                // Compute profile, defaults to the first profile of the model
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -92,6 +93,13 @@ func (x *InferenceRequest) GetParameters() *structpb.Struct {
 	return nil
 }
 
+func (x *InferenceRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
 // Response for synchronous inference
 type InferenceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -303,14 +311,15 @@ var File_backend_proto protoreflect.FileDescriptor
 
 const file_backend_proto_rawDesc = "" +
 	"\n" +
-	"\rbackend.proto\x12\finference.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x98\x01\n" +
+	"\rbackend.proto\x12\finference.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb2\x01\n" +
 	"\x10InferenceRequest\x12\x1a\n" +
 	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x19\n" +
 	"\bmodel_id\x18\x02 \x01(\tR\amodelId\x12\x14\n" +
 	"\x05input\x18\x03 \x01(\fR\x05input\x127\n" +
 	"\n" +
 	"parameters\x18\x04 \x01(\v2\x17.google.protobuf.StructR\n" +
-	"parameters\"h\n" +
+	"parameters\x12\x18\n" +
+	"\aprofile\x18\x05 \x01(\tR\aprofile\"h\n" +
 	"\x11InferenceResponse\x12\x16\n" +
 	"\x06output\x18\x01 \x01(\fR\x06output\x12;\n" +
 	"\bmetadata\x18\x02 \x01(\v2\x1f.inference.v1.InferenceMetadataR\bmetadata\"\x88\x01\n" +
@@ -0,0 +1,663 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.0
+// source: v2/chat.proto
+
+package inferencev2
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Role of the author of a chat message
+type Role int32
+
+const (
+	Role_ROLE_UNSPECIFIED Role = 0
+	Role_ROLE_SYSTEM      Role = 1
+	Role_ROLE_USER        Role = 2
+	Role_ROLE_ASSISTANT   Role = 3
+	Role_ROLE_TOOL        Role = 4
+	Role_ROLE_DEVELOPER   Role = 5
+)
+
+// Enum value maps for Role.
+var (
+	Role_name = map[int32]string{
+		0: "ROLE_UNSPECIFIED",
+		1: "ROLE_SYSTEM",
+		2: "ROLE_USER",
+		3: "ROLE_ASSISTANT",
+		4: "ROLE_TOOL",
+		5: "ROLE_DEVELOPER",
+	}
+	Role_value = map[string]int32{
+		"ROLE_UNSPECIFIED": 0,
+		"ROLE_SYSTEM":      1,
+		"ROLE_USER":        2,
+		"ROLE_ASSISTANT":   3,
+		"ROLE_TOOL":        4,
+		"ROLE_DEVELOPER":   5,
+	}
+)
+
+func (x Role) Enum() *Role {
+	p := new(Role)
+	*p = x
+	return p
+}
+
+func (x Role) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Role) Descriptor() protoreflect.EnumDescriptor {
+	return file_v2_chat_proto_enumTypes[0].Descriptor()
+}
+
+func (Role) Type() protoreflect.EnumType {
+	return &file_v2_chat_proto_enumTypes[0]
+}
+
+func (x Role) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Role.Descriptor instead.
+func (Role) EnumDescriptor() ([]byte, []int) {
+	return file_v2_chat_proto_rawDescGZIP(), []int{0}
+}
+
+// Message of a chat conversation
+type ChatMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          Role                   `protobuf:"varint,1,opt,name=role,proto3,enum=inference.v2.Role" json:"role,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatMessage) Reset() {
+	*x = ChatMessage{}
+	mi := &file_v2_chat_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatMessage) ProtoMessage() {}
+
+func (x *ChatMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_chat_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatMessage.ProtoReflect.Descriptor instead.
+func (*ChatMessage) Descriptor() ([]byte, []int) {
+	return file_v2_chat_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ChatMessage) GetRole() Role {
+	if x != nil {
+		return x.Role
+	}
+	return Role_ROLE_UNSPECIFIED
+}
+
+func (x *ChatMessage) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+// Sampling parameters. Unset fields keep the backend defaults.
+type SamplingParams struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	MaxTokens        *int32                 `protobuf:"varint,1,opt,name=max_tokens,json=maxTokens,proto3,oneof" json:"max_tokens,omitempty"` // Maximum number of tokens to generate
+	Temperature      *float64               `protobuf:"fixed64,2,opt,name=temperature,proto3,oneof" json:"temperature,omitempty"`
+	TopK             *int32                 `protobuf:"varint,3,opt,name=top_k,json=topK,proto3,oneof" json:"top_k,omitempty"`
+	TopP             *float64               `protobuf:"fixed64,4,opt,name=top_p,json=topP,proto3,oneof" json:"top_p,omitempty"`
+	MinP             *float64               `protobuf:"fixed64,5,opt,name=min_p,json=minP,proto3,oneof" json:"min_p,omitempty"`
+	RepeatPenalty    *float64               `protobuf:"fixed64,6,opt,name=repeat_penalty,json=repeatPenalty,proto3,oneof" json:"repeat_penalty,omitempty"`
+	PresencePenalty  *float64               `protobuf:"fixed64,7,opt,name=presence_penalty,json=presencePenalty,proto3,oneof" json:"presence_penalty,omitempty"`
+	FrequencyPenalty *float64               `protobuf:"fixed64,8,opt,name=frequency_penalty,json=frequencyPenalty,proto3,oneof" json:"frequency_penalty,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SamplingParams) Reset() {
+	*x = SamplingParams{}
+	mi := &file_v2_chat_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SamplingParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SamplingParams) ProtoMessage() {}
+
+func (x *SamplingParams) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_chat_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SamplingParams.ProtoReflect.Descriptor instead.
+func (*SamplingParams) Descriptor() ([]byte, []int) {
+	return file_v2_chat_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SamplingParams) GetMaxTokens() int32 {
+	if x != nil && x.MaxTokens != nil {
+		return *x.MaxTokens
+	}
+	return 0
+}
+
+func (x *SamplingParams) GetTemperature() float64 {
+	if x != nil && x.Temperature != nil {
+		return *x.Temperature
+	}
+	return 0
+}
+
+func (x *SamplingParams) GetTopK() int32 {
+	if x != nil && x.TopK != nil {
+		return *x.TopK
+	}
+	return 0
+}
+
+func (x *SamplingParams) GetTopP() float64 {
+	if x != nil && x.TopP != nil {
+		return *x.TopP
+	}
+	return 0
+}
+
+func (x *SamplingParams) GetMinP() float64 {
+	if x != nil && x.MinP != nil {
+		return *x.MinP
+	}
+	return 0
+}
+
+func (x *SamplingParams) GetRepeatPenalty() float64 {
+	if x != nil && x.RepeatPenalty != nil {
+		return *x.RepeatPenalty
+	}
+	return 0
+}
+
+func (x *SamplingParams) GetPresencePenalty() float64 {
+	if x != nil && x.PresencePenalty != nil {
+		return *x.PresencePenalty
+	}
+	return 0
+}
+
+func (x *SamplingParams) GetFrequencyPenalty() float64 {
+	if x != nil && x.FrequencyPenalty != nil {
+		return *x.FrequencyPenalty
+	}
+	return 0
+}
+
+// Request to continue a chat conversation
+type ChatRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ModelId       string                 `protobuf:"bytes,1,opt,name=model_id,json=modelId,proto3" json:"model_id,omitempty"` // Model ID or alias, defaults to the LLM service default model
+	Profile       string                 `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`                // Compute profile, defaults to the first profile of the model
+	Messages      []*ChatMessage         `protobuf:"bytes,3,rep,name=messages,proto3" json:"messages,omitempty"`              // Conversation so far, at least one message
+	Sampling      *SamplingParams        `protobuf:"bytes,4,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	Parameters    *structpb.Struct       `protobuf:"bytes,5,opt,name=parameters,proto3" json:"parameters,omitempty"` // Backend parameters without a field, e.g. seed, passed through
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatRequest) Reset() {
+	*x = ChatRequest{}
+	mi := &file_v2_chat_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatRequest) ProtoMessage() {}
+
+func (x *ChatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_chat_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatRequest.ProtoReflect.Descriptor instead.
+func (*ChatRequest) Descriptor() ([]byte, []int) {
+	return file_v2_chat_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ChatRequest) GetModelId() string {
+	if x != nil {
+		return x.ModelId
+	}
+	return ""
+}
+
+func (x *ChatRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *ChatRequest) GetMessages() []*ChatMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *ChatRequest) GetSampling() *SamplingParams {
+	if x != nil {
+		return x.Sampling
+	}
+	return nil
+}
+
+func (x *ChatRequest) GetParameters() *structpb.Struct {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+// Time the backend spent on a reply
+type Timings struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	PromptSeconds            float64                `protobuf:"fixed64,1,opt,name=prompt_seconds,json=promptSeconds,proto3" json:"prompt_seconds,omitempty"`             // Processing the prompt
+	GenerationSeconds        float64                `protobuf:"fixed64,2,opt,name=generation_seconds,json=generationSeconds,proto3" json:"generation_seconds,omitempty"` // Generating the reply
+	PromptTokensPerSecond    float64                `protobuf:"fixed64,3,opt,name=prompt_tokens_per_second,json=promptTokensPerSecond,proto3" json:"prompt_tokens_per_second,omitempty"`
+	GeneratedTokensPerSecond float64                `protobuf:"fixed64,4,opt,name=generated_tokens_per_second,json=generatedTokensPerSecond,proto3" json:"generated_tokens_per_second,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *Timings) Reset() {
+	*x = Timings{}
+	mi := &file_v2_chat_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Timings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Timings) ProtoMessage() {}
+
+func (x *Timings) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_chat_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Timings.ProtoReflect.Descriptor instead.
+func (*Timings) Descriptor() ([]byte, []int) {
+	return file_v2_chat_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Timings) GetPromptSeconds() float64 {
+	if x != nil {
+		return x.PromptSeconds
+	}
+	return 0
+}
+
+func (x *Timings) GetGenerationSeconds() float64 {
+	if x != nil {
+		return x.GenerationSeconds
+	}
+	return 0
+}
+
+func (x *Timings) GetPromptTokensPerSecond() float64 {
+	if x != nil {
+		return x.PromptTokensPerSecond
+	}
+	return 0
+}
+
+func (x *Timings) GetGeneratedTokensPerSecond() float64 {
+	if x != nil {
+		return x.GeneratedTokensPerSecond
+	}
+	return 0
+}
+
+// Reply to a chat conversation
+type ChatResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       *ChatMessage           `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"` // Message generated by the assistant
+	Metadata      *ResponseMetadata      `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	FinishReason  string                 `protobuf:"bytes,3,opt,name=finish_reason,json=finishReason,proto3" json:"finish_reason,omitempty"` // Why generation stopped, e.g. "stop" or "length"
+	Timings       *Timings               `protobuf:"bytes,4,opt,name=timings,proto3" json:"timings,omitempty"`                               // Set when the backend reports them
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatResponse) Reset() {
+	*x = ChatResponse{}
+	mi := &file_v2_chat_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatResponse) ProtoMessage() {}
+
+func (x *ChatResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_chat_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatResponse.ProtoReflect.Descriptor instead.
+func (*ChatResponse) Descriptor() ([]byte, []int) {
+	return file_v2_chat_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ChatResponse) GetMessage() *ChatMessage {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *ChatResponse) GetMetadata() *ResponseMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ChatResponse) GetFinishReason() string {
+	if x != nil {
+		return x.FinishReason
+	}
+	return ""
+}
+
+func (x *ChatResponse) GetTimings() *Timings {
+	if x != nil {
+		return x.Timings
+	}
+	return nil
+}
+
+// Chunk of a streamed reply
+type ChatChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Delta         string                 `protobuf:"bytes,1,opt,name=delta,proto3" json:"delta,omitempty"`                                   // Text generated since the previous chunk
+	Done          bool                   `protobuf:"varint,2,opt,name=done,proto3" json:"done,omitempty"`                                    // Set on the last chunk
+	Metadata      *ResponseMetadata      `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`                             // Set on the last chunk
+	FinishReason  string                 `protobuf:"bytes,4,opt,name=finish_reason,json=finishReason,proto3" json:"finish_reason,omitempty"` // Set on the last chunk
+	Timings       *Timings               `protobuf:"bytes,5,opt,name=timings,proto3" json:"timings,omitempty"`                               // Set on the last chunk when the backend reports them
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatChunk) Reset() {
+	*x = ChatChunk{}
+	mi := &file_v2_chat_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatChunk) ProtoMessage() {}
+
+func (x *ChatChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_chat_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatChunk.ProtoReflect.Descriptor instead.
+func (*ChatChunk) Descriptor() ([]byte, []int) {
+	return file_v2_chat_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ChatChunk) GetDelta() string {
+	if x != nil {
+		return x.Delta
+	}
+	return ""
+}
+
+func (x *ChatChunk) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+func (x *ChatChunk) GetMetadata() *ResponseMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *ChatChunk) GetFinishReason() string {
+	if x != nil {
+		return x.FinishReason
+	}
+	return ""
+}
+
+func (x *ChatChunk) GetTimings() *Timings {
+	if x != nil {
+		return x.Timings
+	}
+	return nil
+}
+
+var File_v2_chat_proto protoreflect.FileDescriptor
+
+const file_v2_chat_proto_rawDesc = "" +
+	"\n" +
+	"\rv2/chat.proto\x12\finference.v2\x1a\x0fv2/common.proto\x1a\x1cgoogle/protobuf/struct.proto\"O\n" +
+	"\vChatMessage\x12&\n" +
+	"\x04role\x18\x01 \x01(\x0e2\x12.inference.v2.RoleR\x04role\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\"\xb2\x03\n" +
+	"\x0eSamplingParams\x12\"\n" +
+	"\n" +
+	"max_tokens\x18\x01 \x01(\x05H\x00R\tmaxTokens\x88\x01\x01\x12%\n" +
+	"\vtemperature\x18\x02 \x01(\x01H\x01R\vtemperature\x88\x01\x01\x12\x18\n" +
+	"\x05top_k\x18\x03 \x01(\x05H\x02R\x04topK\x88\x01\x01\x12\x18\n" +
+	"\x05top_p\x18\x04 \x01(\x01H\x03R\x04topP\x88\x01\x01\x12\x18\n" +
+	"\x05min_p\x18\x05 \x01(\x01H\x04R\x04minP\x88\x01\x01\x12*\n" +
+	"\x0erepeat_penalty\x18\x06 \x01(\x01H\x05R\rrepeatPenalty\x88\x01\x01\x12.\n" +
+	"\x10presence_penalty\x18\a \x01(\x01H\x06R\x0fpresencePenalty\x88\x01\x01\x120\n" +
+	"\x11frequency_penalty\x18\b \x01(\x01H\aR\x10frequencyPenalty\x88\x01\x01B\r\n" +
+	"\v_max_tokensB\x0e\n" +
+	"\f_temperatureB\b\n" +
+	"\x06_top_kB\b\n" +
+	"\x06_top_pB\b\n" +
+	"\x06_min_pB\x11\n" +
+	"\x0f_repeat_penaltyB\x13\n" +
+	"\x11_presence_penaltyB\x14\n" +
+	"\x12_frequency_penalty\"\xec\x01\n" +
+	"\vChatRequest\x12\x19\n" +
+	"\bmodel_id\x18\x01 \x01(\tR\amodelId\x12\x18\n" +
+	"\aprofile\x18\x02 \x01(\tR\aprofile\x125\n" +
+	"\bmessages\x18\x03 \x03(\v2\x19.inference.v2.ChatMessageR\bmessages\x128\n" +
+	"\bsampling\x18\x04 \x01(\v2\x1c.inference.v2.SamplingParamsR\bsampling\x127\n" +
+	"\n" +
+	"parameters\x18\x05 \x01(\v2\x17.google.protobuf.StructR\n" +
+	"parameters\"\xd7\x01\n" +
+	"\aTimings\x12%\n" +
+	"\x0eprompt_seconds\x18\x01 \x01(\x01R\rpromptSeconds\x12-\n" +
+	"\x12generation_seconds\x18\x02 \x01(\x01R\x11generationSeconds\x127\n" +
+	"\x18prompt_tokens_per_second\x18\x03 \x01(\x01R\x15promptTokensPerSecond\x12=\n" +
+	"\x1bgenerated_tokens_per_second\x18\x04 \x01(\x01R\x18generatedTokensPerSecond\"\xd5\x01\n" +
+	"\fChatResponse\x123\n" +
+	"\amessage\x18\x01 \x01(\v2\x19.inference.v2.ChatMessageR\amessage\x12:\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x1e.inference.v2.ResponseMetadataR\bmetadata\x12#\n" +
+	"\rfinish_reason\x18\x03 \x01(\tR\ffinishReason\x12/\n" +
+	"\atimings\x18\x04 \x01(\v2\x15.inference.v2.TimingsR\atimings\"\xc7\x01\n" +
+	"\tChatChunk\x12\x14\n" +
+	"\x05delta\x18\x01 \x01(\tR\x05delta\x12\x12\n" +
+	"\x04done\x18\x02 \x01(\bR\x04done\x12:\n" +
+	"\bmetadata\x18\x03 \x01(\v2\x1e.inference.v2.ResponseMetadataR\bmetadata\x12#\n" +
+	"\rfinish_reason\x18\x04 \x01(\tR\ffinishReason\x12/\n" +
+	"\atimings\x18\x05 \x01(\v2\x15.inference.v2.TimingsR\atimings*s\n" +
+	"\x04Role\x12\x14\n" +
+	"\x10ROLE_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vROLE_SYSTEM\x10\x01\x12\r\n" +
+	"\tROLE_USER\x10\x02\x12\x12\n" +
+	"\x0eROLE_ASSISTANT\x10\x03\x12\r\n" +
+	"\tROLE_TOOL\x10\x04\x12\x12\n" +
+	"\x0eROLE_DEVELOPER\x10\x052\x90\x01\n" +
+	"\vChatService\x12=\n" +
+	"\x04Chat\x12\x19.inference.v2.ChatRequest\x1a\x1a.inference.v2.ChatResponse\x12B\n" +
+	"\n" +
+	"ChatStream\x12\x19.inference.v2.ChatRequest\x1a\x17.inference.v2.ChatChunk0\x01B\x1aZ\x18inference/v2;inferencev2b\x06proto3"
+
+var (
+	file_v2_chat_proto_rawDescOnce sync.Once
+	file_v2_chat_proto_rawDescData []byte
+)
+
+func file_v2_chat_proto_rawDescGZIP() []byte {
+	file_v2_chat_proto_rawDescOnce.Do(func() {
+		file_v2_chat_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_v2_chat_proto_rawDesc), len(file_v2_chat_proto_rawDesc)))
+	})
+	return file_v2_chat_proto_rawDescData
+}
+
+var file_v2_chat_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_v2_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_v2_chat_proto_goTypes = []any{
+	(Role)(0),                // 0: inference.v2.Role
+	(*ChatMessage)(nil),      // 1: inference.v2.ChatMessage
+	(*SamplingParams)(nil),   // 2: inference.v2.SamplingParams
+	(*ChatRequest)(nil),      // 3: inference.v2.ChatRequest
+	(*Timings)(nil),          // 4: inference.v2.Timings
+	(*ChatResponse)(nil),     // 5: inference.v2.ChatResponse
+	(*ChatChunk)(nil),        // 6: inference.v2.ChatChunk
+	(*structpb.Struct)(nil),  // 7: google.protobuf.Struct
+	(*ResponseMetadata)(nil), // 8: inference.v2.ResponseMetadata
+}
+var file_v2_chat_proto_depIdxs = []int32{
+	0,  // 0: inference.v2.ChatMessage.role:type_name -> inference.v2.Role
+	1,  // 1: inference.v2.ChatRequest.messages:type_name -> inference.v2.ChatMessage
+	2,  // 2: inference.v2.ChatRequest.sampling:type_name -> inference.v2.SamplingParams
+	7,  // 3: inference.v2.ChatRequest.parameters:type_name -> google.protobuf.Struct
+	1,  // 4: inference.v2.ChatResponse.message:type_name -> inference.v2.ChatMessage
+	8,  // 5: inference.v2.ChatResponse.metadata:type_name -> inference.v2.ResponseMetadata
+	4,  // 6: inference.v2.ChatResponse.timings:type_name -> inference.v2.Timings
+	8,  // 7: inference.v2.ChatChunk.metadata:type_name -> inference.v2.ResponseMetadata
+	4,  // 8: inference.v2.ChatChunk.timings:type_name -> inference.v2.Timings
+	3,  // 9: inference.v2.ChatService.Chat:input_type -> inference.v2.ChatRequest
+	3,  // 10: inference.v2.ChatService.ChatStream:input_type -> inference.v2.ChatRequest
+	5,  // 11: inference.v2.ChatService.Chat:output_type -> inference.v2.ChatResponse
+	6,  // 12: inference.v2.ChatService.ChatStream:output_type -> inference.v2.ChatChunk
+	11, // [11:13] is the sub-list for method output_type
+	9,  // [9:11] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_v2_chat_proto_init() }
+func file_v2_chat_proto_init() {
+	if File_v2_chat_proto != nil {
+		return
+	}
+	file_v2_common_proto_init()
+	file_v2_chat_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v2_chat_proto_rawDesc), len(file_v2_chat_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_v2_chat_proto_goTypes,
+		DependencyIndexes: file_v2_chat_proto_depIdxs,
+		EnumInfos:         file_v2_chat_proto_enumTypes,
+		MessageInfos:      file_v2_chat_proto_msgTypes,
+	}.Build()
+	File_v2_chat_proto = out.File
+	file_v2_chat_proto_goTypes = nil
+	file_v2_chat_proto_depIdxs = nil
+}
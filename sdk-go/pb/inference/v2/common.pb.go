@@ -0,0 +1,249 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.0
+// source: v2/common.proto
+
+package inferencev2
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Work done to serve a request. Only the fields that apply to the service are set.
+type Usage struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PromptTokens    int32                  `protobuf:"varint,1,opt,name=prompt_tokens,json=promptTokens,proto3" json:"prompt_tokens,omitempty"`
+	GeneratedTokens int32                  `protobuf:"varint,2,opt,name=generated_tokens,json=generatedTokens,proto3" json:"generated_tokens,omitempty"`
+	TokensPerSecond float64                `protobuf:"fixed64,3,opt,name=tokens_per_second,json=tokensPerSecond,proto3" json:"tokens_per_second,omitempty"`
+	AudioSeconds    float64                `protobuf:"fixed64,4,opt,name=audio_seconds,json=audioSeconds,proto3" json:"audio_seconds,omitempty"` // Audio transcribed or produced
+	Characters      int32                  `protobuf:"varint,5,opt,name=characters,proto3" json:"characters,omitempty"`                          // Text synthesized
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Usage) Reset() {
+	*x = Usage{}
+	mi := &file_v2_common_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Usage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Usage) ProtoMessage() {}
+
+func (x *Usage) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_common_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Usage.ProtoReflect.Descriptor instead.
+func (*Usage) Descriptor() ([]byte, []int) {
+	return file_v2_common_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Usage) GetPromptTokens() int32 {
+	if x != nil {
+		return x.PromptTokens
+	}
+	return 0
+}
+
+func (x *Usage) GetGeneratedTokens() int32 {
+	if x != nil {
+		return x.GeneratedTokens
+	}
+	return 0
+}
+
+func (x *Usage) GetTokensPerSecond() float64 {
+	if x != nil {
+		return x.TokensPerSecond
+	}
+	return 0
+}
+
+func (x *Usage) GetAudioSeconds() float64 {
+	if x != nil {
+		return x.AudioSeconds
+	}
+	return 0
+}
+
+func (x *Usage) GetCharacters() int32 {
+	if x != nil {
+		return x.Characters
+	}
+	return 0
+}
+
+// Metadata describing how a request was served
+type ResponseMetadata struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Backend         string                 `protobuf:"bytes,1,opt,name=backend,proto3" json:"backend,omitempty"`                                          // Backend provider
+	ModelId         string                 `protobuf:"bytes,2,opt,name=model_id,json=modelId,proto3" json:"model_id,omitempty"`                           // Model the request was served with, aliases resolved
+	Timestamp       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                                      // Time of inference
+	DurationSeconds float64                `protobuf:"fixed64,4,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // Total processing duration
+	Usage           *Usage                 `protobuf:"bytes,5,opt,name=usage,proto3" json:"usage,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ResponseMetadata) Reset() {
+	*x = ResponseMetadata{}
+	mi := &file_v2_common_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseMetadata) ProtoMessage() {}
+
+func (x *ResponseMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_common_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseMetadata.ProtoReflect.Descriptor instead.
+func (*ResponseMetadata) Descriptor() ([]byte, []int) {
+	return file_v2_common_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ResponseMetadata) GetBackend() string {
+	if x != nil {
+		return x.Backend
+	}
+	return ""
+}
+
+func (x *ResponseMetadata) GetModelId() string {
+	if x != nil {
+		return x.ModelId
+	}
+	return ""
+}
+
+func (x *ResponseMetadata) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *ResponseMetadata) GetDurationSeconds() float64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *ResponseMetadata) GetUsage() *Usage {
+	if x != nil {
+		return x.Usage
+	}
+	return nil
+}
+
+var File_v2_common_proto protoreflect.FileDescriptor
+
+const file_v2_common_proto_rawDesc = "" +
+	"\n" +
+	"\x0fv2/common.proto\x12\finference.v2\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc8\x01\n" +
+	"\x05Usage\x12#\n" +
+	"\rprompt_tokens\x18\x01 \x01(\x05R\fpromptTokens\x12)\n" +
+	"\x10generated_tokens\x18\x02 \x01(\x05R\x0fgeneratedTokens\x12*\n" +
+	"\x11tokens_per_second\x18\x03 \x01(\x01R\x0ftokensPerSecond\x12#\n" +
+	"\raudio_seconds\x18\x04 \x01(\x01R\faudioSeconds\x12\x1e\n" +
+	"\n" +
+	"characters\x18\x05 \x01(\x05R\n" +
+	"characters\"\xd7\x01\n" +
+	"\x10ResponseMetadata\x12\x18\n" +
+	"\abackend\x18\x01 \x01(\tR\abackend\x12\x19\n" +
+	"\bmodel_id\x18\x02 \x01(\tR\amodelId\x128\n" +
+	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12)\n" +
+	"\x10duration_seconds\x18\x04 \x01(\x01R\x0fdurationSeconds\x12)\n" +
+	"\x05usage\x18\x05 \x01(\v2\x13.inference.v2.UsageR\x05usageB\x1aZ\x18inference/v2;inferencev2b\x06proto3"
+
+var (
+	file_v2_common_proto_rawDescOnce sync.Once
+	file_v2_common_proto_rawDescData []byte
+)
+
+func file_v2_common_proto_rawDescGZIP() []byte {
+	file_v2_common_proto_rawDescOnce.Do(func() {
+		file_v2_common_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_v2_common_proto_rawDesc), len(file_v2_common_proto_rawDesc)))
+	})
+	return file_v2_common_proto_rawDescData
+}
+
+var file_v2_common_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_v2_common_proto_goTypes = []any{
+	(*Usage)(nil),                 // 0: inference.v2.Usage
+	(*ResponseMetadata)(nil),      // 1: inference.v2.ResponseMetadata
+	(*timestamppb.Timestamp)(nil), // 2: google.protobuf.Timestamp
+}
+var file_v2_common_proto_depIdxs = []int32{
+	2, // 0: inference.v2.ResponseMetadata.timestamp:type_name -> google.protobuf.Timestamp
+	0, // 1: inference.v2.ResponseMetadata.usage:type_name -> inference.v2.Usage
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_v2_common_proto_init() }
+func file_v2_common_proto_init() {
+	if File_v2_common_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v2_common_proto_rawDesc), len(file_v2_common_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_v2_common_proto_goTypes,
+		DependencyIndexes: file_v2_common_proto_depIdxs,
+		MessageInfos:      file_v2_common_proto_msgTypes,
+	}.Build()
+	File_v2_common_proto = out.File
+	file_v2_common_proto_goTypes = nil
+	file_v2_common_proto_depIdxs = nil
+}
@@ -0,0 +1,1193 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.0
+// source: v2/model.proto
+
+package inferencev2
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Type of a model, which is the service it can be assigned to
+type ModelType int32
+
+const (
+	ModelType_MODEL_TYPE_UNSPECIFIED ModelType = 0
+	ModelType_MODEL_TYPE_LLM         ModelType = 1
+	ModelType_MODEL_TYPE_NLU         ModelType = 2
+	ModelType_MODEL_TYPE_STT         ModelType = 3
+	ModelType_MODEL_TYPE_TTS         ModelType = 4
+)
+
+// Enum value maps for ModelType.
+var (
+	ModelType_name = map[int32]string{
+		0: "MODEL_TYPE_UNSPECIFIED",
+		1: "MODEL_TYPE_LLM",
+		2: "MODEL_TYPE_NLU",
+		3: "MODEL_TYPE_STT",
+		4: "MODEL_TYPE_TTS",
+	}
+	ModelType_value = map[string]int32{
+		"MODEL_TYPE_UNSPECIFIED": 0,
+		"MODEL_TYPE_LLM":         1,
+		"MODEL_TYPE_NLU":         2,
+		"MODEL_TYPE_STT":         3,
+		"MODEL_TYPE_TTS":         4,
+	}
+)
+
+func (x ModelType) Enum() *ModelType {
+	p := new(ModelType)
+	*p = x
+	return p
+}
+
+func (x ModelType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ModelType) Descriptor() protoreflect.EnumDescriptor {
+	return file_v2_model_proto_enumTypes[0].Descriptor()
+}
+
+func (ModelType) Type() protoreflect.EnumType {
+	return &file_v2_model_proto_enumTypes[0]
+}
+
+func (x ModelType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ModelType.Descriptor instead.
+func (ModelType) EnumDescriptor() ([]byte, []int) {
+	return file_v2_model_proto_rawDescGZIP(), []int{0}
+}
+
+// Status of a model
+type ModelStatus int32
+
+const (
+	ModelStatus_MODEL_STATUS_UNSPECIFIED ModelStatus = 0
+	ModelStatus_MODEL_STATUS_UNLOADED    ModelStatus = 1
+	ModelStatus_MODEL_STATUS_LOADING     ModelStatus = 2
+	ModelStatus_MODEL_STATUS_LOADED      ModelStatus = 3
+	ModelStatus_MODEL_STATUS_FAILED      ModelStatus = 4
+	ModelStatus_MODEL_STATUS_UNLOADING   ModelStatus = 5
+	ModelStatus_MODEL_STATUS_DOWNLOADING ModelStatus = 6
+	ModelStatus_MODEL_STATUS_NOT_CACHED  ModelStatus = 7
+)
+
+// Enum value maps for ModelStatus.
+var (
+	ModelStatus_name = map[int32]string{
+		0: "MODEL_STATUS_UNSPECIFIED",
+		1: "MODEL_STATUS_UNLOADED",
+		2: "MODEL_STATUS_LOADING",
+		3: "MODEL_STATUS_LOADED",
+		4: "MODEL_STATUS_FAILED",
+		5: "MODEL_STATUS_UNLOADING",
+		6: "MODEL_STATUS_DOWNLOADING",
+		7: "MODEL_STATUS_NOT_CACHED",
+	}
+	ModelStatus_value = map[string]int32{
+		"MODEL_STATUS_UNSPECIFIED": 0,
+		"MODEL_STATUS_UNLOADED":    1,
+		"MODEL_STATUS_LOADING":     2,
+		"MODEL_STATUS_LOADED":      3,
+		"MODEL_STATUS_FAILED":      4,
+		"MODEL_STATUS_UNLOADING":   5,
+		"MODEL_STATUS_DOWNLOADING": 6,
+		"MODEL_STATUS_NOT_CACHED":  7,
+	}
+)
+
+func (x ModelStatus) Enum() *ModelStatus {
+	p := new(ModelStatus)
+	*p = x
+	return p
+}
+
+func (x ModelStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ModelStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_v2_model_proto_enumTypes[1].Descriptor()
+}
+
+func (ModelStatus) Type() protoreflect.EnumType {
+	return &file_v2_model_proto_enumTypes[1]
+}
+
+func (x ModelStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ModelStatus.Descriptor instead.
+func (ModelStatus) EnumDescriptor() ([]byte, []int) {
+	return file_v2_model_proto_rawDescGZIP(), []int{1}
+}
+
+// State of a download job
+type PullState int32
+
+const (
+	PullState_PULL_STATE_UNSPECIFIED PullState = 0
+	PullState_PULL_STATE_QUEUED      PullState = 1
+	PullState_PULL_STATE_RUNNING     PullState = 2
+	PullState_PULL_STATE_COMPLETED   PullState = 3
+	PullState_PULL_STATE_FAILED      PullState = 4
+	PullState_PULL_STATE_CANCELED    PullState = 5
+)
+
+// Enum value maps for PullState.
+var (
+	PullState_name = map[int32]string{
+		0: "PULL_STATE_UNSPECIFIED",
+		1: "PULL_STATE_QUEUED",
+		2: "PULL_STATE_RUNNING",
+		3: "PULL_STATE_COMPLETED",
+		4: "PULL_STATE_FAILED",
+		5: "PULL_STATE_CANCELED",
+	}
+	PullState_value = map[string]int32{
+		"PULL_STATE_UNSPECIFIED": 0,
+		"PULL_STATE_QUEUED":      1,
+		"PULL_STATE_RUNNING":     2,
+		"PULL_STATE_COMPLETED":   3,
+		"PULL_STATE_FAILED":      4,
+		"PULL_STATE_CANCELED":    5,
+	}
+)
+
+func (x PullState) Enum() *PullState {
+	p := new(PullState)
+	*p = x
+	return p
+}
+
+func (x PullState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PullState) Descriptor() protoreflect.EnumDescriptor {
+	return file_v2_model_proto_enumTypes[2].Descriptor()
+}
+
+func (PullState) Type() protoreflect.EnumType {
+	return &file_v2_model_proto_enumTypes[2]
+}
+
+func (x PullState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PullState.Descriptor instead.
+func (PullState) EnumDescriptor() ([]byte, []int) {
+	return file_v2_model_proto_rawDescGZIP(), []int{2}
+}
+
+// State of a file within a download job
+type FileState int32
+
+const (
+	FileState_FILE_STATE_UNSPECIFIED FileState = 0
+	FileState_FILE_STATE_PENDING     FileState = 1
+	FileState_FILE_STATE_DOWNLOADING FileState = 2
+	FileState_FILE_STATE_COMPLETED   FileState = 3
+)
+
+// Enum value maps for FileState.
+var (
+	FileState_name = map[int32]string{
+		0: "FILE_STATE_UNSPECIFIED",
+		1: "FILE_STATE_PENDING",
+		2: "FILE_STATE_DOWNLOADING",
+		3: "FILE_STATE_COMPLETED",
+	}
+	FileState_value = map[string]int32{
+		"FILE_STATE_UNSPECIFIED": 0,
+		"FILE_STATE_PENDING":     1,
+		"FILE_STATE_DOWNLOADING": 2,
+		"FILE_STATE_COMPLETED":   3,
+	}
+)
+
+func (x FileState) Enum() *FileState {
+	p := new(FileState)
+	*p = x
+	return p
+}
+
+func (x FileState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FileState) Descriptor() protoreflect.EnumDescriptor {
+	return file_v2_model_proto_enumTypes[3].Descriptor()
+}
+
+func (FileState) Type() protoreflect.EnumType {
+	return &file_v2_model_proto_enumTypes[3]
+}
+
+func (x FileState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FileState.Descriptor instead.
+func (FileState) EnumDescriptor() ([]byte, []int) {
+	return file_v2_model_proto_rawDescGZIP(), []int{3}
+}
+
+// Request to list configured models
+type ListModelsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          ModelType              `protobuf:"varint,1,opt,name=type,proto3,enum=inference.v2.ModelType" json:"type,omitempty"` // Only list models of this type, all when unspecified
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModelsRequest) Reset() {
+	*x = ListModelsRequest{}
+	mi := &file_v2_model_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModelsRequest) ProtoMessage() {}
+
+func (x *ListModelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_model_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModelsRequest.ProtoReflect.Descriptor instead.
+func (*ListModelsRequest) Descriptor() ([]byte, []int) {
+	return file_v2_model_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListModelsRequest) GetType() ModelType {
+	if x != nil {
+		return x.Type
+	}
+	return ModelType_MODEL_TYPE_UNSPECIFIED
+}
+
+// Response listing configured models
+type ListModelsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Models        []*Model               `protobuf:"bytes,1,rep,name=models,proto3" json:"models,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModelsResponse) Reset() {
+	*x = ListModelsResponse{}
+	mi := &file_v2_model_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModelsResponse) ProtoMessage() {}
+
+func (x *ListModelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_model_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModelsResponse.ProtoReflect.Descriptor instead.
+func (*ListModelsResponse) Descriptor() ([]byte, []int) {
+	return file_v2_model_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListModelsResponse) GetModels() []*Model {
+	if x != nil {
+		return x.Models
+	}
+	return nil
+}
+
+// Request to describe a model
+type GetModelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ModelId       string                 `protobuf:"bytes,1,opt,name=model_id,json=modelId,proto3" json:"model_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetModelRequest) Reset() {
+	*x = GetModelRequest{}
+	mi := &file_v2_model_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetModelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetModelRequest) ProtoMessage() {}
+
+func (x *GetModelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_model_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetModelRequest.ProtoReflect.Descriptor instead.
+func (*GetModelRequest) Descriptor() ([]byte, []int) {
+	return file_v2_model_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetModelRequest) GetModelId() string {
+	if x != nil {
+		return x.ModelId
+	}
+	return ""
+}
+
+// Description of a configured model and its current status
+type Model struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Logical model ID
+	Type          ModelType              `protobuf:"varint,2,opt,name=type,proto3,enum=inference.v2.ModelType" json:"type,omitempty"`
+	Backend       string                 `protobuf:"bytes,3,opt,name=backend,proto3" json:"backend,omitempty"` // Backend provider
+	Status        ModelStatus            `protobuf:"varint,4,opt,name=status,proto3,enum=inference.v2.ModelStatus" json:"status,omitempty"`
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`       // Last error, if any
+	Tags          []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`         // Tags for filtering or grouping
+	Order         int32                  `protobuf:"varint,7,opt,name=order,proto3" json:"order,omitempty"`      // Display order
+	Pull          *PullProgress          `protobuf:"bytes,8,opt,name=pull,proto3" json:"pull,omitempty"`         // Active download, if any
+	Profiles      []*Profile             `protobuf:"bytes,9,rep,name=profiles,proto3" json:"profiles,omitempty"` // Profiles the model can be served with, the first is the default
+	Aliases       []string               `protobuf:"bytes,10,rep,name=aliases,proto3" json:"aliases,omitempty"`  // Other names the model can be requested by
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Model) Reset() {
+	*x = Model{}
+	mi := &file_v2_model_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Model) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Model) ProtoMessage() {}
+
+func (x *Model) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_model_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Model.ProtoReflect.Descriptor instead.
+func (*Model) Descriptor() ([]byte, []int) {
+	return file_v2_model_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Model) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Model) GetType() ModelType {
+	if x != nil {
+		return x.Type
+	}
+	return ModelType_MODEL_TYPE_UNSPECIFIED
+}
+
+func (x *Model) GetBackend() string {
+	if x != nil {
+		return x.Backend
+	}
+	return ""
+}
+
+func (x *Model) GetStatus() ModelStatus {
+	if x != nil {
+		return x.Status
+	}
+	return ModelStatus_MODEL_STATUS_UNSPECIFIED
+}
+
+func (x *Model) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Model) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Model) GetOrder() int32 {
+	if x != nil {
+		return x.Order
+	}
+	return 0
+}
+
+func (x *Model) GetPull() *PullProgress {
+	if x != nil {
+		return x.Pull
+	}
+	return nil
+}
+
+func (x *Model) GetProfiles() []*Profile {
+	if x != nil {
+		return x.Profiles
+	}
+	return nil
+}
+
+func (x *Model) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+// Description of a compute profile a model can be served with
+type Profile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	DisplayName   string                 `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	ComputeType   string                 `protobuf:"bytes,4,opt,name=compute_type,json=computeType,proto3" json:"compute_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Profile) Reset() {
+	*x = Profile{}
+	mi := &file_v2_model_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Profile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Profile) ProtoMessage() {}
+
+func (x *Profile) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_model_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Profile.ProtoReflect.Descriptor instead.
+func (*Profile) Descriptor() ([]byte, []int) {
+	return file_v2_model_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Profile) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Profile) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *Profile) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Profile) GetComputeType() string {
+	if x != nil {
+		return x.ComputeType
+	}
+	return ""
+}
+
+// Request to download a model
+type PullModelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ModelId       string                 `protobuf:"bytes,1,opt,name=model_id,json=modelId,proto3" json:"model_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PullModelRequest) Reset() {
+	*x = PullModelRequest{}
+	mi := &file_v2_model_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullModelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullModelRequest) ProtoMessage() {}
+
+func (x *PullModelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_model_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullModelRequest.ProtoReflect.Descriptor instead.
+func (*PullModelRequest) Descriptor() ([]byte, []int) {
+	return file_v2_model_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PullModelRequest) GetModelId() string {
+	if x != nil {
+		return x.ModelId
+	}
+	return ""
+}
+
+// Request to cancel a running download
+type CancelPullRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelPullRequest) Reset() {
+	*x = CancelPullRequest{}
+	mi := &file_v2_model_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelPullRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelPullRequest) ProtoMessage() {}
+
+func (x *CancelPullRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_model_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelPullRequest.ProtoReflect.Descriptor instead.
+func (*CancelPullRequest) Descriptor() ([]byte, []int) {
+	return file_v2_model_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CancelPullRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+// Response for a canceled download
+type CancelPullResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pull          *PullProgress          `protobuf:"bytes,1,opt,name=pull,proto3" json:"pull,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelPullResponse) Reset() {
+	*x = CancelPullResponse{}
+	mi := &file_v2_model_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelPullResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelPullResponse) ProtoMessage() {}
+
+func (x *CancelPullResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_model_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelPullResponse.ProtoReflect.Descriptor instead.
+func (*CancelPullResponse) Descriptor() ([]byte, []int) {
+	return file_v2_model_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CancelPullResponse) GetPull() *PullProgress {
+	if x != nil {
+		return x.Pull
+	}
+	return nil
+}
+
+// Request to remove the cached files of a model
+type RemoveModelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ModelId       string                 `protobuf:"bytes,1,opt,name=model_id,json=modelId,proto3" json:"model_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveModelRequest) Reset() {
+	*x = RemoveModelRequest{}
+	mi := &file_v2_model_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveModelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveModelRequest) ProtoMessage() {}
+
+func (x *RemoveModelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_model_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveModelRequest.ProtoReflect.Descriptor instead.
+func (*RemoveModelRequest) Descriptor() ([]byte, []int) {
+	return file_v2_model_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RemoveModelRequest) GetModelId() string {
+	if x != nil {
+		return x.ModelId
+	}
+	return ""
+}
+
+// Response for a removed model
+type RemoveModelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RemovedFiles  []string               `protobuf:"bytes,1,rep,name=removed_files,json=removedFiles,proto3" json:"removed_files,omitempty"` // Relative to the models directory
+	FreedBytes    int64                  `protobuf:"varint,2,opt,name=freed_bytes,json=freedBytes,proto3" json:"freed_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveModelResponse) Reset() {
+	*x = RemoveModelResponse{}
+	mi := &file_v2_model_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveModelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveModelResponse) ProtoMessage() {}
+
+func (x *RemoveModelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_model_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveModelResponse.ProtoReflect.Descriptor instead.
+func (*RemoveModelResponse) Descriptor() ([]byte, []int) {
+	return file_v2_model_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RemoveModelResponse) GetRemovedFiles() []string {
+	if x != nil {
+		return x.RemovedFiles
+	}
+	return nil
+}
+
+func (x *RemoveModelResponse) GetFreedBytes() int64 {
+	if x != nil {
+		return x.FreedBytes
+	}
+	return 0
+}
+
+// Progress of a model download job
+type PullProgress struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	JobId              string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	ModelId            string                 `protobuf:"bytes,2,opt,name=model_id,json=modelId,proto3" json:"model_id,omitempty"`
+	State              PullState              `protobuf:"varint,3,opt,name=state,proto3,enum=inference.v2.PullState" json:"state,omitempty"`
+	BytesDone          int64                  `protobuf:"varint,4,opt,name=bytes_done,json=bytesDone,proto3" json:"bytes_done,omitempty"`
+	BytesTotal         int64                  `protobuf:"varint,5,opt,name=bytes_total,json=bytesTotal,proto3" json:"bytes_total,omitempty"`
+	RateBytesPerSecond float64                `protobuf:"fixed64,6,opt,name=rate_bytes_per_second,json=rateBytesPerSecond,proto3" json:"rate_bytes_per_second,omitempty"`
+	EtaSeconds         float64                `protobuf:"fixed64,7,opt,name=eta_seconds,json=etaSeconds,proto3" json:"eta_seconds,omitempty"`
+	Files              []*FileProgress        `protobuf:"bytes,8,rep,name=files,proto3" json:"files,omitempty"`
+	Error              string                 `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+	StartedAt          *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt         *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *PullProgress) Reset() {
+	*x = PullProgress{}
+	mi := &file_v2_model_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullProgress) ProtoMessage() {}
+
+func (x *PullProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_model_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullProgress.ProtoReflect.Descriptor instead.
+func (*PullProgress) Descriptor() ([]byte, []int) {
+	return file_v2_model_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PullProgress) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *PullProgress) GetModelId() string {
+	if x != nil {
+		return x.ModelId
+	}
+	return ""
+}
+
+func (x *PullProgress) GetState() PullState {
+	if x != nil {
+		return x.State
+	}
+	return PullState_PULL_STATE_UNSPECIFIED
+}
+
+func (x *PullProgress) GetBytesDone() int64 {
+	if x != nil {
+		return x.BytesDone
+	}
+	return 0
+}
+
+func (x *PullProgress) GetBytesTotal() int64 {
+	if x != nil {
+		return x.BytesTotal
+	}
+	return 0
+}
+
+func (x *PullProgress) GetRateBytesPerSecond() float64 {
+	if x != nil {
+		return x.RateBytesPerSecond
+	}
+	return 0
+}
+
+func (x *PullProgress) GetEtaSeconds() float64 {
+	if x != nil {
+		return x.EtaSeconds
+	}
+	return 0
+}
+
+func (x *PullProgress) GetFiles() []*FileProgress {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *PullProgress) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *PullProgress) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *PullProgress) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+// Progress of a single file within a download job
+type FileProgress struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	State         FileState              `protobuf:"varint,2,opt,name=state,proto3,enum=inference.v2.FileState" json:"state,omitempty"`
+	BytesDone     int64                  `protobuf:"varint,3,opt,name=bytes_done,json=bytesDone,proto3" json:"bytes_done,omitempty"`
+	BytesTotal    int64                  `protobuf:"varint,4,opt,name=bytes_total,json=bytesTotal,proto3" json:"bytes_total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileProgress) Reset() {
+	*x = FileProgress{}
+	mi := &file_v2_model_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileProgress) ProtoMessage() {}
+
+func (x *FileProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_model_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileProgress.ProtoReflect.Descriptor instead.
+func (*FileProgress) Descriptor() ([]byte, []int) {
+	return file_v2_model_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *FileProgress) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FileProgress) GetState() FileState {
+	if x != nil {
+		return x.State
+	}
+	return FileState_FILE_STATE_UNSPECIFIED
+}
+
+func (x *FileProgress) GetBytesDone() int64 {
+	if x != nil {
+		return x.BytesDone
+	}
+	return 0
+}
+
+func (x *FileProgress) GetBytesTotal() int64 {
+	if x != nil {
+		return x.BytesTotal
+	}
+	return 0
+}
+
+var File_v2_model_proto protoreflect.FileDescriptor
+
+const file_v2_model_proto_rawDesc = "" +
+	"\n" +
+	"\x0ev2/model.proto\x12\finference.v2\x1a\x1fgoogle/protobuf/timestamp.proto\"@\n" +
+	"\x11ListModelsRequest\x12+\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x17.inference.v2.ModelTypeR\x04type\"A\n" +
+	"\x12ListModelsResponse\x12+\n" +
+	"\x06models\x18\x01 \x03(\v2\x13.inference.v2.ModelR\x06models\",\n" +
+	"\x0fGetModelRequest\x12\x19\n" +
+	"\bmodel_id\x18\x01 \x01(\tR\amodelId\"\xce\x02\n" +
+	"\x05Model\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12+\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x17.inference.v2.ModelTypeR\x04type\x12\x18\n" +
+	"\abackend\x18\x03 \x01(\tR\abackend\x121\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x19.inference.v2.ModelStatusR\x06status\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x12\x14\n" +
+	"\x05order\x18\a \x01(\x05R\x05order\x12.\n" +
+	"\x04pull\x18\b \x01(\v2\x1a.inference.v2.PullProgressR\x04pull\x121\n" +
+	"\bprofiles\x18\t \x03(\v2\x15.inference.v2.ProfileR\bprofiles\x12\x18\n" +
+	"\aaliases\x18\n" +
+	" \x03(\tR\aaliases\"\x85\x01\n" +
+	"\aProfile\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12!\n" +
+	"\fdisplay_name\x18\x02 \x01(\tR\vdisplayName\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12!\n" +
+	"\fcompute_type\x18\x04 \x01(\tR\vcomputeType\"-\n" +
+	"\x10PullModelRequest\x12\x19\n" +
+	"\bmodel_id\x18\x01 \x01(\tR\amodelId\"*\n" +
+	"\x11CancelPullRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"D\n" +
+	"\x12CancelPullResponse\x12.\n" +
+	"\x04pull\x18\x01 \x01(\v2\x1a.inference.v2.PullProgressR\x04pull\"/\n" +
+	"\x12RemoveModelRequest\x12\x19\n" +
+	"\bmodel_id\x18\x01 \x01(\tR\amodelId\"[\n" +
+	"\x13RemoveModelResponse\x12#\n" +
+	"\rremoved_files\x18\x01 \x03(\tR\fremovedFiles\x12\x1f\n" +
+	"\vfreed_bytes\x18\x02 \x01(\x03R\n" +
+	"freedBytes\"\xc3\x03\n" +
+	"\fPullProgress\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x19\n" +
+	"\bmodel_id\x18\x02 \x01(\tR\amodelId\x12-\n" +
+	"\x05state\x18\x03 \x01(\x0e2\x17.inference.v2.PullStateR\x05state\x12\x1d\n" +
+	"\n" +
+	"bytes_done\x18\x04 \x01(\x03R\tbytesDone\x12\x1f\n" +
+	"\vbytes_total\x18\x05 \x01(\x03R\n" +
+	"bytesTotal\x121\n" +
+	"\x15rate_bytes_per_second\x18\x06 \x01(\x01R\x12rateBytesPerSecond\x12\x1f\n" +
+	"\veta_seconds\x18\a \x01(\x01R\n" +
+	"etaSeconds\x120\n" +
+	"\x05files\x18\b \x03(\v2\x1a.inference.v2.FileProgressR\x05files\x12\x14\n" +
+	"\x05error\x18\t \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"started_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12;\n" +
+	"\vfinished_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\"\x91\x01\n" +
+	"\fFileProgress\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12-\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x17.inference.v2.FileStateR\x05state\x12\x1d\n" +
+	"\n" +
+	"bytes_done\x18\x03 \x01(\x03R\tbytesDone\x12\x1f\n" +
+	"\vbytes_total\x18\x04 \x01(\x03R\n" +
+	"bytesTotal*w\n" +
+	"\tModelType\x12\x1a\n" +
+	"\x16MODEL_TYPE_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eMODEL_TYPE_LLM\x10\x01\x12\x12\n" +
+	"\x0eMODEL_TYPE_NLU\x10\x02\x12\x12\n" +
+	"\x0eMODEL_TYPE_STT\x10\x03\x12\x12\n" +
+	"\x0eMODEL_TYPE_TTS\x10\x04*\xe9\x01\n" +
+	"\vModelStatus\x12\x1c\n" +
+	"\x18MODEL_STATUS_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15MODEL_STATUS_UNLOADED\x10\x01\x12\x18\n" +
+	"\x14MODEL_STATUS_LOADING\x10\x02\x12\x17\n" +
+	"\x13MODEL_STATUS_LOADED\x10\x03\x12\x17\n" +
+	"\x13MODEL_STATUS_FAILED\x10\x04\x12\x1a\n" +
+	"\x16MODEL_STATUS_UNLOADING\x10\x05\x12\x1c\n" +
+	"\x18MODEL_STATUS_DOWNLOADING\x10\x06\x12\x1b\n" +
+	"\x17MODEL_STATUS_NOT_CACHED\x10\a*\xa0\x01\n" +
+	"\tPullState\x12\x1a\n" +
+	"\x16PULL_STATE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11PULL_STATE_QUEUED\x10\x01\x12\x16\n" +
+	"\x12PULL_STATE_RUNNING\x10\x02\x12\x18\n" +
+	"\x14PULL_STATE_COMPLETED\x10\x03\x12\x15\n" +
+	"\x11PULL_STATE_FAILED\x10\x04\x12\x17\n" +
+	"\x13PULL_STATE_CANCELED\x10\x05*u\n" +
+	"\tFileState\x12\x1a\n" +
+	"\x16FILE_STATE_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12FILE_STATE_PENDING\x10\x01\x12\x1a\n" +
+	"\x16FILE_STATE_DOWNLOADING\x10\x02\x12\x18\n" +
+	"\x14FILE_STATE_COMPLETED\x10\x032\x8f\x03\n" +
+	"\fModelService\x12O\n" +
+	"\n" +
+	"ListModels\x12\x1f.inference.v2.ListModelsRequest\x1a .inference.v2.ListModelsResponse\x12>\n" +
+	"\bGetModel\x12\x1d.inference.v2.GetModelRequest\x1a\x13.inference.v2.Model\x12I\n" +
+	"\tPullModel\x12\x1e.inference.v2.PullModelRequest\x1a\x1a.inference.v2.PullProgress0\x01\x12O\n" +
+	"\n" +
+	"CancelPull\x12\x1f.inference.v2.CancelPullRequest\x1a .inference.v2.CancelPullResponse\x12R\n" +
+	"\vRemoveModel\x12 .inference.v2.RemoveModelRequest\x1a!.inference.v2.RemoveModelResponseB\x1aZ\x18inference/v2;inferencev2b\x06proto3"
+
+var (
+	file_v2_model_proto_rawDescOnce sync.Once
+	file_v2_model_proto_rawDescData []byte
+)
+
+func file_v2_model_proto_rawDescGZIP() []byte {
+	file_v2_model_proto_rawDescOnce.Do(func() {
+		file_v2_model_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_v2_model_proto_rawDesc), len(file_v2_model_proto_rawDesc)))
+	})
+	return file_v2_model_proto_rawDescData
+}
+
+var file_v2_model_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_v2_model_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_v2_model_proto_goTypes = []any{
+	(ModelType)(0),                // 0: inference.v2.ModelType
+	(ModelStatus)(0),              // 1: inference.v2.ModelStatus
+	(PullState)(0),                // 2: inference.v2.PullState
+	(FileState)(0),                // 3: inference.v2.FileState
+	(*ListModelsRequest)(nil),     // 4: inference.v2.ListModelsRequest
+	(*ListModelsResponse)(nil),    // 5: inference.v2.ListModelsResponse
+	(*GetModelRequest)(nil),       // 6: inference.v2.GetModelRequest
+	(*Model)(nil),                 // 7: inference.v2.Model
+	(*Profile)(nil),               // 8: inference.v2.Profile
+	(*PullModelRequest)(nil),      // 9: inference.v2.PullModelRequest
+	(*CancelPullRequest)(nil),     // 10: inference.v2.CancelPullRequest
+	(*CancelPullResponse)(nil),    // 11: inference.v2.CancelPullResponse
+	(*RemoveModelRequest)(nil),    // 12: inference.v2.RemoveModelRequest
+	(*RemoveModelResponse)(nil),   // 13: inference.v2.RemoveModelResponse
+	(*PullProgress)(nil),          // 14: inference.v2.PullProgress
+	(*FileProgress)(nil),          // 15: inference.v2.FileProgress
+	(*timestamppb.Timestamp)(nil), // 16: google.protobuf.Timestamp
+}
+var file_v2_model_proto_depIdxs = []int32{
+	0,  // 0: inference.v2.ListModelsRequest.type:type_name -> inference.v2.ModelType
+	7,  // 1: inference.v2.ListModelsResponse.models:type_name -> inference.v2.Model
+	0,  // 2: inference.v2.Model.type:type_name -> inference.v2.ModelType
+	1,  // 3: inference.v2.Model.status:type_name -> inference.v2.ModelStatus
+	14, // 4: inference.v2.Model.pull:type_name -> inference.v2.PullProgress
+	8,  // 5: inference.v2.Model.profiles:type_name -> inference.v2.Profile
+	14, // 6: inference.v2.CancelPullResponse.pull:type_name -> inference.v2.PullProgress
+	2,  // 7: inference.v2.PullProgress.state:type_name -> inference.v2.PullState
+	15, // 8: inference.v2.PullProgress.files:type_name -> inference.v2.FileProgress
+	16, // 9: inference.v2.PullProgress.started_at:type_name -> google.protobuf.Timestamp
+	16, // 10: inference.v2.PullProgress.finished_at:type_name -> google.protobuf.Timestamp
+	3,  // 11: inference.v2.FileProgress.state:type_name -> inference.v2.FileState
+	4,  // 12: inference.v2.ModelService.ListModels:input_type -> inference.v2.ListModelsRequest
+	6,  // 13: inference.v2.ModelService.GetModel:input_type -> inference.v2.GetModelRequest
+	9,  // 14: inference.v2.ModelService.PullModel:input_type -> inference.v2.PullModelRequest
+	10, // 15: inference.v2.ModelService.CancelPull:input_type -> inference.v2.CancelPullRequest
+	12, // 16: inference.v2.ModelService.RemoveModel:input_type -> inference.v2.RemoveModelRequest
+	5,  // 17: inference.v2.ModelService.ListModels:output_type -> inference.v2.ListModelsResponse
+	7,  // 18: inference.v2.ModelService.GetModel:output_type -> inference.v2.Model
+	14, // 19: inference.v2.ModelService.PullModel:output_type -> inference.v2.PullProgress
+	11, // 20: inference.v2.ModelService.CancelPull:output_type -> inference.v2.CancelPullResponse
+	13, // 21: inference.v2.ModelService.RemoveModel:output_type -> inference.v2.RemoveModelResponse
+	17, // [17:22] is the sub-list for method output_type
+	12, // [12:17] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
+}
+
+func init() { file_v2_model_proto_init() }
+func file_v2_model_proto_init() {
+	if File_v2_model_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v2_model_proto_rawDesc), len(file_v2_model_proto_rawDesc)),
+			NumEnums:      4,
+			NumMessages:   12,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_v2_model_proto_goTypes,
+		DependencyIndexes: file_v2_model_proto_depIdxs,
+		EnumInfos:         file_v2_model_proto_enumTypes,
+		MessageInfos:      file_v2_model_proto_msgTypes,
+	}.Build()
+	File_v2_model_proto = out.File
+	file_v2_model_proto_goTypes = nil
+	file_v2_model_proto_depIdxs = nil
+}
@@ -0,0 +1,765 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.0
+// source: v2/speech.proto
+
+package inferencev2
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Encoding of audio data
+type AudioFormat int32
+
+const (
+	AudioFormat_AUDIO_FORMAT_UNSPECIFIED AudioFormat = 0 // Treated as WAV
+	AudioFormat_AUDIO_FORMAT_WAV         AudioFormat = 1
+)
+
+// Enum value maps for AudioFormat.
+var (
+	AudioFormat_name = map[int32]string{
+		0: "AUDIO_FORMAT_UNSPECIFIED",
+		1: "AUDIO_FORMAT_WAV",
+	}
+	AudioFormat_value = map[string]int32{
+		"AUDIO_FORMAT_UNSPECIFIED": 0,
+		"AUDIO_FORMAT_WAV":         1,
+	}
+)
+
+func (x AudioFormat) Enum() *AudioFormat {
+	p := new(AudioFormat)
+	*p = x
+	return p
+}
+
+func (x AudioFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AudioFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_v2_speech_proto_enumTypes[0].Descriptor()
+}
+
+func (AudioFormat) Type() protoreflect.EnumType {
+	return &file_v2_speech_proto_enumTypes[0]
+}
+
+func (x AudioFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AudioFormat.Descriptor instead.
+func (AudioFormat) EnumDescriptor() ([]byte, []int) {
+	return file_v2_speech_proto_rawDescGZIP(), []int{0}
+}
+
+// Request to transcribe audio
+type TranscribeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ModelId       string                 `protobuf:"bytes,1,opt,name=model_id,json=modelId,proto3" json:"model_id,omitempty"` // Model ID or alias, defaults to the STT service default model
+	Profile       string                 `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`                // Compute profile, defaults to the first profile of the model
+	Audio         []byte                 `protobuf:"bytes,3,opt,name=audio,proto3" json:"audio,omitempty"`
+	Format        AudioFormat            `protobuf:"varint,4,opt,name=format,proto3,enum=inference.v2.AudioFormat" json:"format,omitempty"`
+	Language      string                 `protobuf:"bytes,5,opt,name=language,proto3" json:"language,omitempty"`    // Spoken language, e.g. "en", detected when empty
+	Prompt        string                 `protobuf:"bytes,6,opt,name=prompt,proto3" json:"prompt,omitempty"`        // Text the transcription should follow, e.g. the previous sentence
+	Translate     bool                   `protobuf:"varint,7,opt,name=translate,proto3" json:"translate,omitempty"` // Translate the transcription to English
+	Temperature   *float64               `protobuf:"fixed64,8,opt,name=temperature,proto3,oneof" json:"temperature,omitempty"`
+	BeamSize      *int32                 `protobuf:"varint,9,opt,name=beam_size,json=beamSize,proto3,oneof" json:"beam_size,omitempty"`
+	BestOf        *int32                 `protobuf:"varint,10,opt,name=best_of,json=bestOf,proto3,oneof" json:"best_of,omitempty"`
+	Parameters    *structpb.Struct       `protobuf:"bytes,11,opt,name=parameters,proto3" json:"parameters,omitempty"` // Backend parameters without a field, passed through
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TranscribeRequest) Reset() {
+	*x = TranscribeRequest{}
+	mi := &file_v2_speech_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TranscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranscribeRequest) ProtoMessage() {}
+
+func (x *TranscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_speech_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranscribeRequest.ProtoReflect.Descriptor instead.
+func (*TranscribeRequest) Descriptor() ([]byte, []int) {
+	return file_v2_speech_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TranscribeRequest) GetModelId() string {
+	if x != nil {
+		return x.ModelId
+	}
+	return ""
+}
+
+func (x *TranscribeRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *TranscribeRequest) GetAudio() []byte {
+	if x != nil {
+		return x.Audio
+	}
+	return nil
+}
+
+func (x *TranscribeRequest) GetFormat() AudioFormat {
+	if x != nil {
+		return x.Format
+	}
+	return AudioFormat_AUDIO_FORMAT_UNSPECIFIED
+}
+
+func (x *TranscribeRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *TranscribeRequest) GetPrompt() string {
+	if x != nil {
+		return x.Prompt
+	}
+	return ""
+}
+
+func (x *TranscribeRequest) GetTranslate() bool {
+	if x != nil {
+		return x.Translate
+	}
+	return false
+}
+
+func (x *TranscribeRequest) GetTemperature() float64 {
+	if x != nil && x.Temperature != nil {
+		return *x.Temperature
+	}
+	return 0
+}
+
+func (x *TranscribeRequest) GetBeamSize() int32 {
+	if x != nil && x.BeamSize != nil {
+		return *x.BeamSize
+	}
+	return 0
+}
+
+func (x *TranscribeRequest) GetBestOf() int32 {
+	if x != nil && x.BestOf != nil {
+		return *x.BestOf
+	}
+	return 0
+}
+
+func (x *TranscribeRequest) GetParameters() *structpb.Struct {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+// Transcription of audio
+type TranscribeResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Text            string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Language        string                 `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`                                        // Requested or detected language
+	DurationSeconds float64                `protobuf:"fixed64,3,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // Duration of the audio
+	Segments        []*Segment             `protobuf:"bytes,4,rep,name=segments,proto3" json:"segments,omitempty"`
+	Metadata        *ResponseMetadata      `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TranscribeResponse) Reset() {
+	*x = TranscribeResponse{}
+	mi := &file_v2_speech_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TranscribeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranscribeResponse) ProtoMessage() {}
+
+func (x *TranscribeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_speech_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranscribeResponse.ProtoReflect.Descriptor instead.
+func (*TranscribeResponse) Descriptor() ([]byte, []int) {
+	return file_v2_speech_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TranscribeResponse) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *TranscribeResponse) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *TranscribeResponse) GetDurationSeconds() float64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *TranscribeResponse) GetSegments() []*Segment {
+	if x != nil {
+		return x.Segments
+	}
+	return nil
+}
+
+func (x *TranscribeResponse) GetMetadata() *ResponseMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// Segment of a transcription
+type Segment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	StartSeconds  float64                `protobuf:"fixed64,2,opt,name=start_seconds,json=startSeconds,proto3" json:"start_seconds,omitempty"`
+	EndSeconds    float64                `protobuf:"fixed64,3,opt,name=end_seconds,json=endSeconds,proto3" json:"end_seconds,omitempty"`
+	Text          string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	Words         []*Word                `protobuf:"bytes,5,rep,name=words,proto3" json:"words,omitempty"` // Set when the backend reports word timestamps
+	AvgLogprob    float64                `protobuf:"fixed64,6,opt,name=avg_logprob,json=avgLogprob,proto3" json:"avg_logprob,omitempty"`
+	NoSpeechProb  float64                `protobuf:"fixed64,7,opt,name=no_speech_prob,json=noSpeechProb,proto3" json:"no_speech_prob,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Segment) Reset() {
+	*x = Segment{}
+	mi := &file_v2_speech_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Segment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Segment) ProtoMessage() {}
+
+func (x *Segment) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_speech_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Segment.ProtoReflect.Descriptor instead.
+func (*Segment) Descriptor() ([]byte, []int) {
+	return file_v2_speech_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Segment) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Segment) GetStartSeconds() float64 {
+	if x != nil {
+		return x.StartSeconds
+	}
+	return 0
+}
+
+func (x *Segment) GetEndSeconds() float64 {
+	if x != nil {
+		return x.EndSeconds
+	}
+	return 0
+}
+
+func (x *Segment) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Segment) GetWords() []*Word {
+	if x != nil {
+		return x.Words
+	}
+	return nil
+}
+
+func (x *Segment) GetAvgLogprob() float64 {
+	if x != nil {
+		return x.AvgLogprob
+	}
+	return 0
+}
+
+func (x *Segment) GetNoSpeechProb() float64 {
+	if x != nil {
+		return x.NoSpeechProb
+	}
+	return 0
+}
+
+// Word of a transcription segment
+type Word struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Word          string                 `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+	StartSeconds  float64                `protobuf:"fixed64,2,opt,name=start_seconds,json=startSeconds,proto3" json:"start_seconds,omitempty"`
+	EndSeconds    float64                `protobuf:"fixed64,3,opt,name=end_seconds,json=endSeconds,proto3" json:"end_seconds,omitempty"`
+	Probability   float64                `protobuf:"fixed64,4,opt,name=probability,proto3" json:"probability,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Word) Reset() {
+	*x = Word{}
+	mi := &file_v2_speech_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Word) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Word) ProtoMessage() {}
+
+func (x *Word) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_speech_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Word.ProtoReflect.Descriptor instead.
+func (*Word) Descriptor() ([]byte, []int) {
+	return file_v2_speech_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Word) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *Word) GetStartSeconds() float64 {
+	if x != nil {
+		return x.StartSeconds
+	}
+	return 0
+}
+
+func (x *Word) GetEndSeconds() float64 {
+	if x != nil {
+		return x.EndSeconds
+	}
+	return 0
+}
+
+func (x *Word) GetProbability() float64 {
+	if x != nil {
+		return x.Probability
+	}
+	return 0
+}
+
+// Request to synthesize speech
+type SynthesizeRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ModelId         string                 `protobuf:"bytes,1,opt,name=model_id,json=modelId,proto3" json:"model_id,omitempty"` // Model ID or alias, defaults to the TTS service default model
+	Profile         string                 `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`                // Compute profile, defaults to the first profile of the model
+	Text            string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	Format          AudioFormat            `protobuf:"varint,4,opt,name=format,proto3,enum=inference.v2.AudioFormat" json:"format,omitempty"`       // Format of the audio to produce
+	SpeakerId       *int32                 `protobuf:"varint,5,opt,name=speaker_id,json=speakerId,proto3,oneof" json:"speaker_id,omitempty"`        // Speaker of multi-speaker voices
+	LengthScale     *float64               `protobuf:"fixed64,6,opt,name=length_scale,json=lengthScale,proto3,oneof" json:"length_scale,omitempty"` // Speaking pace, higher is slower
+	NoiseScale      *float64               `protobuf:"fixed64,7,opt,name=noise_scale,json=noiseScale,proto3,oneof" json:"noise_scale,omitempty"`
+	NoiseW          *float64               `protobuf:"fixed64,8,opt,name=noise_w,json=noiseW,proto3,oneof" json:"noise_w,omitempty"`
+	SentenceSilence *float64               `protobuf:"fixed64,9,opt,name=sentence_silence,json=sentenceSilence,proto3,oneof" json:"sentence_silence,omitempty"` // Seconds of silence after each sentence
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SynthesizeRequest) Reset() {
+	*x = SynthesizeRequest{}
+	mi := &file_v2_speech_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SynthesizeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SynthesizeRequest) ProtoMessage() {}
+
+func (x *SynthesizeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_speech_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SynthesizeRequest.ProtoReflect.Descriptor instead.
+func (*SynthesizeRequest) Descriptor() ([]byte, []int) {
+	return file_v2_speech_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SynthesizeRequest) GetModelId() string {
+	if x != nil {
+		return x.ModelId
+	}
+	return ""
+}
+
+func (x *SynthesizeRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *SynthesizeRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SynthesizeRequest) GetFormat() AudioFormat {
+	if x != nil {
+		return x.Format
+	}
+	return AudioFormat_AUDIO_FORMAT_UNSPECIFIED
+}
+
+func (x *SynthesizeRequest) GetSpeakerId() int32 {
+	if x != nil && x.SpeakerId != nil {
+		return *x.SpeakerId
+	}
+	return 0
+}
+
+func (x *SynthesizeRequest) GetLengthScale() float64 {
+	if x != nil && x.LengthScale != nil {
+		return *x.LengthScale
+	}
+	return 0
+}
+
+func (x *SynthesizeRequest) GetNoiseScale() float64 {
+	if x != nil && x.NoiseScale != nil {
+		return *x.NoiseScale
+	}
+	return 0
+}
+
+func (x *SynthesizeRequest) GetNoiseW() float64 {
+	if x != nil && x.NoiseW != nil {
+		return *x.NoiseW
+	}
+	return 0
+}
+
+func (x *SynthesizeRequest) GetSentenceSilence() float64 {
+	if x != nil && x.SentenceSilence != nil {
+		return *x.SentenceSilence
+	}
+	return 0
+}
+
+// Synthesized speech
+type SynthesizeResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Audio           []byte                 `protobuf:"bytes,1,opt,name=audio,proto3" json:"audio,omitempty"`
+	Format          AudioFormat            `protobuf:"varint,2,opt,name=format,proto3,enum=inference.v2.AudioFormat" json:"format,omitempty"`
+	DurationSeconds float64                `protobuf:"fixed64,3,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // Duration of the audio
+	Metadata        *ResponseMetadata      `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SynthesizeResponse) Reset() {
+	*x = SynthesizeResponse{}
+	mi := &file_v2_speech_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SynthesizeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SynthesizeResponse) ProtoMessage() {}
+
+func (x *SynthesizeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_speech_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SynthesizeResponse.ProtoReflect.Descriptor instead.
+func (*SynthesizeResponse) Descriptor() ([]byte, []int) {
+	return file_v2_speech_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SynthesizeResponse) GetAudio() []byte {
+	if x != nil {
+		return x.Audio
+	}
+	return nil
+}
+
+func (x *SynthesizeResponse) GetFormat() AudioFormat {
+	if x != nil {
+		return x.Format
+	}
+	return AudioFormat_AUDIO_FORMAT_UNSPECIFIED
+}
+
+func (x *SynthesizeResponse) GetDurationSeconds() float64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *SynthesizeResponse) GetMetadata() *ResponseMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+var File_v2_speech_proto protoreflect.FileDescriptor
+
+const file_v2_speech_proto_rawDesc = "" +
+	"\n" +
+	"\x0fv2/speech.proto\x12\finference.v2\x1a\x0fv2/common.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xad\x03\n" +
+	"\x11TranscribeRequest\x12\x19\n" +
+	"\bmodel_id\x18\x01 \x01(\tR\amodelId\x12\x18\n" +
+	"\aprofile\x18\x02 \x01(\tR\aprofile\x12\x14\n" +
+	"\x05audio\x18\x03 \x01(\fR\x05audio\x121\n" +
+	"\x06format\x18\x04 \x01(\x0e2\x19.inference.v2.AudioFormatR\x06format\x12\x1a\n" +
+	"\blanguage\x18\x05 \x01(\tR\blanguage\x12\x16\n" +
+	"\x06prompt\x18\x06 \x01(\tR\x06prompt\x12\x1c\n" +
+	"\ttranslate\x18\a \x01(\bR\ttranslate\x12%\n" +
+	"\vtemperature\x18\b \x01(\x01H\x00R\vtemperature\x88\x01\x01\x12 \n" +
+	"\tbeam_size\x18\t \x01(\x05H\x01R\bbeamSize\x88\x01\x01\x12\x1c\n" +
+	"\abest_of\x18\n" +
+	" \x01(\x05H\x02R\x06bestOf\x88\x01\x01\x127\n" +
+	"\n" +
+	"parameters\x18\v \x01(\v2\x17.google.protobuf.StructR\n" +
+	"parametersB\x0e\n" +
+	"\f_temperatureB\f\n" +
+	"\n" +
+	"_beam_sizeB\n" +
+	"\n" +
+	"\b_best_of\"\xde\x01\n" +
+	"\x12TranscribeResponse\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x1a\n" +
+	"\blanguage\x18\x02 \x01(\tR\blanguage\x12)\n" +
+	"\x10duration_seconds\x18\x03 \x01(\x01R\x0fdurationSeconds\x121\n" +
+	"\bsegments\x18\x04 \x03(\v2\x15.inference.v2.SegmentR\bsegments\x12:\n" +
+	"\bmetadata\x18\x05 \x01(\v2\x1e.inference.v2.ResponseMetadataR\bmetadata\"\xe4\x01\n" +
+	"\aSegment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12#\n" +
+	"\rstart_seconds\x18\x02 \x01(\x01R\fstartSeconds\x12\x1f\n" +
+	"\vend_seconds\x18\x03 \x01(\x01R\n" +
+	"endSeconds\x12\x12\n" +
+	"\x04text\x18\x04 \x01(\tR\x04text\x12(\n" +
+	"\x05words\x18\x05 \x03(\v2\x12.inference.v2.WordR\x05words\x12\x1f\n" +
+	"\vavg_logprob\x18\x06 \x01(\x01R\n" +
+	"avgLogprob\x12$\n" +
+	"\x0eno_speech_prob\x18\a \x01(\x01R\fnoSpeechProb\"\x82\x01\n" +
+	"\x04Word\x12\x12\n" +
+	"\x04word\x18\x01 \x01(\tR\x04word\x12#\n" +
+	"\rstart_seconds\x18\x02 \x01(\x01R\fstartSeconds\x12\x1f\n" +
+	"\vend_seconds\x18\x03 \x01(\x01R\n" +
+	"endSeconds\x12 \n" +
+	"\vprobability\x18\x04 \x01(\x01R\vprobability\"\xa0\x03\n" +
+	"\x11SynthesizeRequest\x12\x19\n" +
+	"\bmodel_id\x18\x01 \x01(\tR\amodelId\x12\x18\n" +
+	"\aprofile\x18\x02 \x01(\tR\aprofile\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x121\n" +
+	"\x06format\x18\x04 \x01(\x0e2\x19.inference.v2.AudioFormatR\x06format\x12\"\n" +
+	"\n" +
+	"speaker_id\x18\x05 \x01(\x05H\x00R\tspeakerId\x88\x01\x01\x12&\n" +
+	"\flength_scale\x18\x06 \x01(\x01H\x01R\vlengthScale\x88\x01\x01\x12$\n" +
+	"\vnoise_scale\x18\a \x01(\x01H\x02R\n" +
+	"noiseScale\x88\x01\x01\x12\x1c\n" +
+	"\anoise_w\x18\b \x01(\x01H\x03R\x06noiseW\x88\x01\x01\x12.\n" +
+	"\x10sentence_silence\x18\t \x01(\x01H\x04R\x0fsentenceSilence\x88\x01\x01B\r\n" +
+	"\v_speaker_idB\x0f\n" +
+	"\r_length_scaleB\x0e\n" +
+	"\f_noise_scaleB\n" +
+	"\n" +
+	"\b_noise_wB\x13\n" +
+	"\x11_sentence_silence\"\xc4\x01\n" +
+	"\x12SynthesizeResponse\x12\x14\n" +
+	"\x05audio\x18\x01 \x01(\fR\x05audio\x121\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x19.inference.v2.AudioFormatR\x06format\x12)\n" +
+	"\x10duration_seconds\x18\x03 \x01(\x01R\x0fdurationSeconds\x12:\n" +
+	"\bmetadata\x18\x04 \x01(\v2\x1e.inference.v2.ResponseMetadataR\bmetadata*A\n" +
+	"\vAudioFormat\x12\x1c\n" +
+	"\x18AUDIO_FORMAT_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10AUDIO_FORMAT_WAV\x10\x012f\n" +
+	"\x13SpeechToTextService\x12O\n" +
+	"\n" +
+	"Transcribe\x12\x1f.inference.v2.TranscribeRequest\x1a .inference.v2.TranscribeResponse2f\n" +
+	"\x13TextToSpeechService\x12O\n" +
+	"\n" +
+	"Synthesize\x12\x1f.inference.v2.SynthesizeRequest\x1a .inference.v2.SynthesizeResponseB\x1aZ\x18inference/v2;inferencev2b\x06proto3"
+
+var (
+	file_v2_speech_proto_rawDescOnce sync.Once
+	file_v2_speech_proto_rawDescData []byte
+)
+
+func file_v2_speech_proto_rawDescGZIP() []byte {
+	file_v2_speech_proto_rawDescOnce.Do(func() {
+		file_v2_speech_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_v2_speech_proto_rawDesc), len(file_v2_speech_proto_rawDesc)))
+	})
+	return file_v2_speech_proto_rawDescData
+}
+
+var file_v2_speech_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_v2_speech_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_v2_speech_proto_goTypes = []any{
+	(AudioFormat)(0),           // 0: inference.v2.AudioFormat
+	(*TranscribeRequest)(nil),  // 1: inference.v2.TranscribeRequest
+	(*TranscribeResponse)(nil), // 2: inference.v2.TranscribeResponse
+	(*Segment)(nil),            // 3: inference.v2.Segment
+	(*Word)(nil),               // 4: inference.v2.Word
+	(*SynthesizeRequest)(nil),  // 5: inference.v2.SynthesizeRequest
+	(*SynthesizeResponse)(nil), // 6: inference.v2.SynthesizeResponse
+	(*structpb.Struct)(nil),    // 7: google.protobuf.Struct
+	(*ResponseMetadata)(nil),   // 8: inference.v2.ResponseMetadata
+}
+var file_v2_speech_proto_depIdxs = []int32{
+	0,  // 0: inference.v2.TranscribeRequest.format:type_name -> inference.v2.AudioFormat
+	7,  // 1: inference.v2.TranscribeRequest.parameters:type_name -> google.protobuf.Struct
+	3,  // 2: inference.v2.TranscribeResponse.segments:type_name -> inference.v2.Segment
+	8,  // 3: inference.v2.TranscribeResponse.metadata:type_name -> inference.v2.ResponseMetadata
+	4,  // 4: inference.v2.Segment.words:type_name -> inference.v2.Word
+	0,  // 5: inference.v2.SynthesizeRequest.format:type_name -> inference.v2.AudioFormat
+	0,  // 6: inference.v2.SynthesizeResponse.format:type_name -> inference.v2.AudioFormat
+	8,  // 7: inference.v2.SynthesizeResponse.metadata:type_name -> inference.v2.ResponseMetadata
+	1,  // 8: inference.v2.SpeechToTextService.Transcribe:input_type -> inference.v2.TranscribeRequest
+	5,  // 9: inference.v2.TextToSpeechService.Synthesize:input_type -> inference.v2.SynthesizeRequest
+	2,  // 10: inference.v2.SpeechToTextService.Transcribe:output_type -> inference.v2.TranscribeResponse
+	6,  // 11: inference.v2.TextToSpeechService.Synthesize:output_type -> inference.v2.SynthesizeResponse
+	10, // [10:12] is the sub-list for method output_type
+	8,  // [8:10] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_v2_speech_proto_init() }
+func file_v2_speech_proto_init() {
+	if File_v2_speech_proto != nil {
+		return
+	}
+	file_v2_common_proto_init()
+	file_v2_speech_proto_msgTypes[0].OneofWrappers = []any{}
+	file_v2_speech_proto_msgTypes[4].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v2_speech_proto_rawDesc), len(file_v2_speech_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_v2_speech_proto_goTypes,
+		DependencyIndexes: file_v2_speech_proto_depIdxs,
+		EnumInfos:         file_v2_speech_proto_enumTypes,
+		MessageInfos:      file_v2_speech_proto_msgTypes,
+	}.Build()
+	File_v2_speech_proto = out.File
+	file_v2_speech_proto_goTypes = nil
+	file_v2_speech_proto_depIdxs = nil
+}
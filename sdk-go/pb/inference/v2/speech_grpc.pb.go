@@ -0,0 +1,235 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.33.0
+// source: v2/speech.proto
+
+package inferencev2
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SpeechToTextService_Transcribe_FullMethodName = "/inference.v2.SpeechToTextService/Transcribe"
+)
+
+// SpeechToTextServiceClient is the client API for SpeechToTextService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Speech recognition
+type SpeechToTextServiceClient interface {
+	// Transcribes audio
+	Transcribe(ctx context.Context, in *TranscribeRequest, opts ...grpc.CallOption) (*TranscribeResponse, error)
+}
+
+type speechToTextServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSpeechToTextServiceClient(cc grpc.ClientConnInterface) SpeechToTextServiceClient {
+	return &speechToTextServiceClient{cc}
+}
+
+func (c *speechToTextServiceClient) Transcribe(ctx context.Context, in *TranscribeRequest, opts ...grpc.CallOption) (*TranscribeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TranscribeResponse)
+	err := c.cc.Invoke(ctx, SpeechToTextService_Transcribe_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SpeechToTextServiceServer is the server API for SpeechToTextService service.
+// All implementations must embed UnimplementedSpeechToTextServiceServer
+// for forward compatibility.
+//
+// Speech recognition
+type SpeechToTextServiceServer interface {
+	// Transcribes audio
+	Transcribe(context.Context, *TranscribeRequest) (*TranscribeResponse, error)
+	mustEmbedUnimplementedSpeechToTextServiceServer()
+}
+
+// UnimplementedSpeechToTextServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSpeechToTextServiceServer struct{}
+
+func (UnimplementedSpeechToTextServiceServer) Transcribe(context.Context, *TranscribeRequest) (*TranscribeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Transcribe not implemented")
+}
+func (UnimplementedSpeechToTextServiceServer) mustEmbedUnimplementedSpeechToTextServiceServer() {}
+func (UnimplementedSpeechToTextServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeSpeechToTextServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SpeechToTextServiceServer will
+// result in compilation errors.
+type UnsafeSpeechToTextServiceServer interface {
+	mustEmbedUnimplementedSpeechToTextServiceServer()
+}
+
+func RegisterSpeechToTextServiceServer(s grpc.ServiceRegistrar, srv SpeechToTextServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSpeechToTextServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SpeechToTextService_ServiceDesc, srv)
+}
+
+func _SpeechToTextService_Transcribe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TranscribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SpeechToTextServiceServer).Transcribe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SpeechToTextService_Transcribe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SpeechToTextServiceServer).Transcribe(ctx, req.(*TranscribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SpeechToTextService_ServiceDesc is the grpc.ServiceDesc for SpeechToTextService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SpeechToTextService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "inference.v2.SpeechToTextService",
+	HandlerType: (*SpeechToTextServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Transcribe",
+			Handler:    _SpeechToTextService_Transcribe_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "v2/speech.proto",
+}
+
+const (
+	TextToSpeechService_Synthesize_FullMethodName = "/inference.v2.TextToSpeechService/Synthesize"
+)
+
+// TextToSpeechServiceClient is the client API for TextToSpeechService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Speech synthesis
+type TextToSpeechServiceClient interface {
+	// Synthesizes speech from text
+	Synthesize(ctx context.Context, in *SynthesizeRequest, opts ...grpc.CallOption) (*SynthesizeResponse, error)
+}
+
+type textToSpeechServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTextToSpeechServiceClient(cc grpc.ClientConnInterface) TextToSpeechServiceClient {
+	return &textToSpeechServiceClient{cc}
+}
+
+func (c *textToSpeechServiceClient) Synthesize(ctx context.Context, in *SynthesizeRequest, opts ...grpc.CallOption) (*SynthesizeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SynthesizeResponse)
+	err := c.cc.Invoke(ctx, TextToSpeechService_Synthesize_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TextToSpeechServiceServer is the server API for TextToSpeechService service.
+// All implementations must embed UnimplementedTextToSpeechServiceServer
+// for forward compatibility.
+//
+// Speech synthesis
+type TextToSpeechServiceServer interface {
+	// Synthesizes speech from text
+	Synthesize(context.Context, *SynthesizeRequest) (*SynthesizeResponse, error)
+	mustEmbedUnimplementedTextToSpeechServiceServer()
+}
+
+// UnimplementedTextToSpeechServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTextToSpeechServiceServer struct{}
+
+func (UnimplementedTextToSpeechServiceServer) Synthesize(context.Context, *SynthesizeRequest) (*SynthesizeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Synthesize not implemented")
+}
+func (UnimplementedTextToSpeechServiceServer) mustEmbedUnimplementedTextToSpeechServiceServer() {}
+func (UnimplementedTextToSpeechServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeTextToSpeechServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TextToSpeechServiceServer will
+// result in compilation errors.
+type UnsafeTextToSpeechServiceServer interface {
+	mustEmbedUnimplementedTextToSpeechServiceServer()
+}
+
+func RegisterTextToSpeechServiceServer(s grpc.ServiceRegistrar, srv TextToSpeechServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTextToSpeechServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TextToSpeechService_ServiceDesc, srv)
+}
+
+func _TextToSpeechService_Synthesize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SynthesizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TextToSpeechServiceServer).Synthesize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TextToSpeechService_Synthesize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TextToSpeechServiceServer).Synthesize(ctx, req.(*SynthesizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TextToSpeechService_ServiceDesc is the grpc.ServiceDesc for TextToSpeechService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TextToSpeechService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "inference.v2.TextToSpeechService",
+	HandlerType: (*TextToSpeechServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Synthesize",
+			Handler:    _TextToSpeechService_Synthesize_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "v2/speech.proto",
+}
@@ -0,0 +1,657 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.0
+// source: v2/tokenizer.proto
+
+package inferencev2
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Request to tokenize text, or a chat conversation
+type TokenizeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ModelId       string                 `protobuf:"bytes,1,opt,name=model_id,json=modelId,proto3" json:"model_id,omitempty"`           // Model ID or alias, defaults to the LLM service default model
+	Profile       string                 `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`                          // Compute profile, defaults to the first profile of the model
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`                                // Text to tokenize, unless messages are given
+	Messages      []*ChatMessage         `protobuf:"bytes,4,rep,name=messages,proto3" json:"messages,omitempty"`                        // Conversation, tokenized with the chat template of the model applied
+	AddSpecial    bool                   `protobuf:"varint,5,opt,name=add_special,json=addSpecial,proto3" json:"add_special,omitempty"` // Add the special tokens of the model, e.g. BOS, to text. Always set for messages
+	WithPieces    bool                   `protobuf:"varint,6,opt,name=with_pieces,json=withPieces,proto3" json:"with_pieces,omitempty"` // Return the text of each token
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenizeRequest) Reset() {
+	*x = TokenizeRequest{}
+	mi := &file_v2_tokenizer_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenizeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenizeRequest) ProtoMessage() {}
+
+func (x *TokenizeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_tokenizer_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenizeRequest.ProtoReflect.Descriptor instead.
+func (*TokenizeRequest) Descriptor() ([]byte, []int) {
+	return file_v2_tokenizer_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TokenizeRequest) GetModelId() string {
+	if x != nil {
+		return x.ModelId
+	}
+	return ""
+}
+
+func (x *TokenizeRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *TokenizeRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *TokenizeRequest) GetMessages() []*ChatMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *TokenizeRequest) GetAddSpecial() bool {
+	if x != nil {
+		return x.AddSpecial
+	}
+	return false
+}
+
+func (x *TokenizeRequest) GetWithPieces() bool {
+	if x != nil {
+		return x.WithPieces
+	}
+	return false
+}
+
+// Tokens of a text
+type TokenizeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tokens        []int32                `protobuf:"varint,1,rep,packed,name=tokens,proto3" json:"tokens,omitempty"`
+	Pieces        []string               `protobuf:"bytes,2,rep,name=pieces,proto3" json:"pieces,omitempty"`                                     // Text of each token, when requested
+	Prompt        string                 `protobuf:"bytes,3,opt,name=prompt,proto3" json:"prompt,omitempty"`                                     // Text tokenized, the chat template applied for messages
+	ContextLength int32                  `protobuf:"varint,4,opt,name=context_length,json=contextLength,proto3" json:"context_length,omitempty"` // Tokens a request can hold, prompt and reply
+	Metadata      *ResponseMetadata      `protobuf:"bytes,5,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenizeResponse) Reset() {
+	*x = TokenizeResponse{}
+	mi := &file_v2_tokenizer_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenizeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenizeResponse) ProtoMessage() {}
+
+func (x *TokenizeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_tokenizer_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenizeResponse.ProtoReflect.Descriptor instead.
+func (*TokenizeResponse) Descriptor() ([]byte, []int) {
+	return file_v2_tokenizer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TokenizeResponse) GetTokens() []int32 {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+func (x *TokenizeResponse) GetPieces() []string {
+	if x != nil {
+		return x.Pieces
+	}
+	return nil
+}
+
+func (x *TokenizeResponse) GetPrompt() string {
+	if x != nil {
+		return x.Prompt
+	}
+	return ""
+}
+
+func (x *TokenizeResponse) GetContextLength() int32 {
+	if x != nil {
+		return x.ContextLength
+	}
+	return 0
+}
+
+func (x *TokenizeResponse) GetMetadata() *ResponseMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// Request to convert tokens back to text
+type DetokenizeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ModelId       string                 `protobuf:"bytes,1,opt,name=model_id,json=modelId,proto3" json:"model_id,omitempty"` // Model ID or alias, defaults to the LLM service default model
+	Profile       string                 `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`                // Compute profile, defaults to the first profile of the model
+	Tokens        []int32                `protobuf:"varint,3,rep,packed,name=tokens,proto3" json:"tokens,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DetokenizeRequest) Reset() {
+	*x = DetokenizeRequest{}
+	mi := &file_v2_tokenizer_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetokenizeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetokenizeRequest) ProtoMessage() {}
+
+func (x *DetokenizeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_tokenizer_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetokenizeRequest.ProtoReflect.Descriptor instead.
+func (*DetokenizeRequest) Descriptor() ([]byte, []int) {
+	return file_v2_tokenizer_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DetokenizeRequest) GetModelId() string {
+	if x != nil {
+		return x.ModelId
+	}
+	return ""
+}
+
+func (x *DetokenizeRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *DetokenizeRequest) GetTokens() []int32 {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+// Text of tokens
+type DetokenizeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Metadata      *ResponseMetadata      `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DetokenizeResponse) Reset() {
+	*x = DetokenizeResponse{}
+	mi := &file_v2_tokenizer_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetokenizeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetokenizeResponse) ProtoMessage() {}
+
+func (x *DetokenizeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_tokenizer_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetokenizeResponse.ProtoReflect.Descriptor instead.
+func (*DetokenizeResponse) Descriptor() ([]byte, []int) {
+	return file_v2_tokenizer_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DetokenizeResponse) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *DetokenizeResponse) GetMetadata() *ResponseMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// Request to format a chat conversation with the chat template of a model
+type ApplyTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ModelId       string                 `protobuf:"bytes,1,opt,name=model_id,json=modelId,proto3" json:"model_id,omitempty"` // Model ID or alias, defaults to the LLM service default model
+	Profile       string                 `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`                // Compute profile, defaults to the first profile of the model
+	Messages      []*ChatMessage         `protobuf:"bytes,3,rep,name=messages,proto3" json:"messages,omitempty"`              // Conversation, at least one message
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyTemplateRequest) Reset() {
+	*x = ApplyTemplateRequest{}
+	mi := &file_v2_tokenizer_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyTemplateRequest) ProtoMessage() {}
+
+func (x *ApplyTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_tokenizer_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyTemplateRequest.ProtoReflect.Descriptor instead.
+func (*ApplyTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_v2_tokenizer_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ApplyTemplateRequest) GetModelId() string {
+	if x != nil {
+		return x.ModelId
+	}
+	return ""
+}
+
+func (x *ApplyTemplateRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *ApplyTemplateRequest) GetMessages() []*ChatMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+// Chat conversation formatted with the chat template of a model
+type ApplyTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prompt        string                 `protobuf:"bytes,1,opt,name=prompt,proto3" json:"prompt,omitempty"` // Prompt the model is given for the conversation
+	Metadata      *ResponseMetadata      `protobuf:"bytes,2,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyTemplateResponse) Reset() {
+	*x = ApplyTemplateResponse{}
+	mi := &file_v2_tokenizer_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyTemplateResponse) ProtoMessage() {}
+
+func (x *ApplyTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_tokenizer_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyTemplateResponse.ProtoReflect.Descriptor instead.
+func (*ApplyTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_v2_tokenizer_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ApplyTemplateResponse) GetPrompt() string {
+	if x != nil {
+		return x.Prompt
+	}
+	return ""
+}
+
+func (x *ApplyTemplateResponse) GetMetadata() *ResponseMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// Request to count the tokens of a chat conversation
+type CountTokensRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ModelId       string                 `protobuf:"bytes,1,opt,name=model_id,json=modelId,proto3" json:"model_id,omitempty"` // Model ID or alias, defaults to the LLM service default model
+	Profile       string                 `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`                // Compute profile, defaults to the first profile of the model
+	Messages      []*ChatMessage         `protobuf:"bytes,3,rep,name=messages,proto3" json:"messages,omitempty"`              // Conversation; none to only read the context length
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountTokensRequest) Reset() {
+	*x = CountTokensRequest{}
+	mi := &file_v2_tokenizer_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountTokensRequest) ProtoMessage() {}
+
+func (x *CountTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_tokenizer_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountTokensRequest.ProtoReflect.Descriptor instead.
+func (*CountTokensRequest) Descriptor() ([]byte, []int) {
+	return file_v2_tokenizer_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CountTokensRequest) GetModelId() string {
+	if x != nil {
+		return x.ModelId
+	}
+	return ""
+}
+
+func (x *CountTokensRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *CountTokensRequest) GetMessages() []*ChatMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+// Token count of a chat conversation
+type CountTokensResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tokens        int32                  `protobuf:"varint,1,opt,name=tokens,proto3" json:"tokens,omitempty"`                                    // Tokens of the conversation with the chat template applied
+	ContextLength int32                  `protobuf:"varint,2,opt,name=context_length,json=contextLength,proto3" json:"context_length,omitempty"` // Tokens a request can hold, prompt and reply
+	Metadata      *ResponseMetadata      `protobuf:"bytes,3,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountTokensResponse) Reset() {
+	*x = CountTokensResponse{}
+	mi := &file_v2_tokenizer_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountTokensResponse) ProtoMessage() {}
+
+func (x *CountTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_v2_tokenizer_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountTokensResponse.ProtoReflect.Descriptor instead.
+func (*CountTokensResponse) Descriptor() ([]byte, []int) {
+	return file_v2_tokenizer_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CountTokensResponse) GetTokens() int32 {
+	if x != nil {
+		return x.Tokens
+	}
+	return 0
+}
+
+func (x *CountTokensResponse) GetContextLength() int32 {
+	if x != nil {
+		return x.ContextLength
+	}
+	return 0
+}
+
+func (x *CountTokensResponse) GetMetadata() *ResponseMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+var File_v2_tokenizer_proto protoreflect.FileDescriptor
+
+const file_v2_tokenizer_proto_rawDesc = "" +
+	"\n" +
+	"\x12v2/tokenizer.proto\x12\finference.v2\x1a\rv2/chat.proto\x1a\x0fv2/common.proto\"\xd3\x01\n" +
+	"\x0fTokenizeRequest\x12\x19\n" +
+	"\bmodel_id\x18\x01 \x01(\tR\amodelId\x12\x18\n" +
+	"\aprofile\x18\x02 \x01(\tR\aprofile\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x125\n" +
+	"\bmessages\x18\x04 \x03(\v2\x19.inference.v2.ChatMessageR\bmessages\x12\x1f\n" +
+	"\vadd_special\x18\x05 \x01(\bR\n" +
+	"addSpecial\x12\x1f\n" +
+	"\vwith_pieces\x18\x06 \x01(\bR\n" +
+	"withPieces\"\xbd\x01\n" +
+	"\x10TokenizeResponse\x12\x16\n" +
+	"\x06tokens\x18\x01 \x03(\x05R\x06tokens\x12\x16\n" +
+	"\x06pieces\x18\x02 \x03(\tR\x06pieces\x12\x16\n" +
+	"\x06prompt\x18\x03 \x01(\tR\x06prompt\x12%\n" +
+	"\x0econtext_length\x18\x04 \x01(\x05R\rcontextLength\x12:\n" +
+	"\bmetadata\x18\x05 \x01(\v2\x1e.inference.v2.ResponseMetadataR\bmetadata\"`\n" +
+	"\x11DetokenizeRequest\x12\x19\n" +
+	"\bmodel_id\x18\x01 \x01(\tR\amodelId\x12\x18\n" +
+	"\aprofile\x18\x02 \x01(\tR\aprofile\x12\x16\n" +
+	"\x06tokens\x18\x03 \x03(\x05R\x06tokens\"d\n" +
+	"\x12DetokenizeResponse\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12:\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x1e.inference.v2.ResponseMetadataR\bmetadata\"\x82\x01\n" +
+	"\x14ApplyTemplateRequest\x12\x19\n" +
+	"\bmodel_id\x18\x01 \x01(\tR\amodelId\x12\x18\n" +
+	"\aprofile\x18\x02 \x01(\tR\aprofile\x125\n" +
+	"\bmessages\x18\x03 \x03(\v2\x19.inference.v2.ChatMessageR\bmessages\"k\n" +
+	"\x15ApplyTemplateResponse\x12\x16\n" +
+	"\x06prompt\x18\x01 \x01(\tR\x06prompt\x12:\n" +
+	"\bmetadata\x18\x02 \x01(\v2\x1e.inference.v2.ResponseMetadataR\bmetadata\"\x80\x01\n" +
+	"\x12CountTokensRequest\x12\x19\n" +
+	"\bmodel_id\x18\x01 \x01(\tR\amodelId\x12\x18\n" +
+	"\aprofile\x18\x02 \x01(\tR\aprofile\x125\n" +
+	"\bmessages\x18\x03 \x03(\v2\x19.inference.v2.ChatMessageR\bmessages\"\x90\x01\n" +
+	"\x13CountTokensResponse\x12\x16\n" +
+	"\x06tokens\x18\x01 \x01(\x05R\x06tokens\x12%\n" +
+	"\x0econtext_length\x18\x02 \x01(\x05R\rcontextLength\x12:\n" +
+	"\bmetadata\x18\x03 \x01(\v2\x1e.inference.v2.ResponseMetadataR\bmetadata2\xdc\x02\n" +
+	"\x10TokenizerService\x12I\n" +
+	"\bTokenize\x12\x1d.inference.v2.TokenizeRequest\x1a\x1e.inference.v2.TokenizeResponse\x12O\n" +
+	"\n" +
+	"Detokenize\x12\x1f.inference.v2.DetokenizeRequest\x1a .inference.v2.DetokenizeResponse\x12X\n" +
+	"\rApplyTemplate\x12\".inference.v2.ApplyTemplateRequest\x1a#.inference.v2.ApplyTemplateResponse\x12R\n" +
+	"\vCountTokens\x12 .inference.v2.CountTokensRequest\x1a!.inference.v2.CountTokensResponseB\x1aZ\x18inference/v2;inferencev2b\x06proto3"
+
+var (
+	file_v2_tokenizer_proto_rawDescOnce sync.Once
+	file_v2_tokenizer_proto_rawDescData []byte
+)
+
+func file_v2_tokenizer_proto_rawDescGZIP() []byte {
+	file_v2_tokenizer_proto_rawDescOnce.Do(func() {
+		file_v2_tokenizer_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_v2_tokenizer_proto_rawDesc), len(file_v2_tokenizer_proto_rawDesc)))
+	})
+	return file_v2_tokenizer_proto_rawDescData
+}
+
+var file_v2_tokenizer_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_v2_tokenizer_proto_goTypes = []any{
+	(*TokenizeRequest)(nil),       // 0: inference.v2.TokenizeRequest
+	(*TokenizeResponse)(nil),      // 1: inference.v2.TokenizeResponse
+	(*DetokenizeRequest)(nil),     // 2: inference.v2.DetokenizeRequest
+	(*DetokenizeResponse)(nil),    // 3: inference.v2.DetokenizeResponse
+	(*ApplyTemplateRequest)(nil),  // 4: inference.v2.ApplyTemplateRequest
+	(*ApplyTemplateResponse)(nil), // 5: inference.v2.ApplyTemplateResponse
+	(*CountTokensRequest)(nil),    // 6: inference.v2.CountTokensRequest
+	(*CountTokensResponse)(nil),   // 7: inference.v2.CountTokensResponse
+	(*ChatMessage)(nil),           // 8: inference.v2.ChatMessage
+	(*ResponseMetadata)(nil),      // 9: inference.v2.ResponseMetadata
+}
+var file_v2_tokenizer_proto_depIdxs = []int32{
+	8,  // 0: inference.v2.TokenizeRequest.messages:type_name -> inference.v2.ChatMessage
+	9,  // 1: inference.v2.TokenizeResponse.metadata:type_name -> inference.v2.ResponseMetadata
+	9,  // 2: inference.v2.DetokenizeResponse.metadata:type_name -> inference.v2.ResponseMetadata
+	8,  // 3: inference.v2.ApplyTemplateRequest.messages:type_name -> inference.v2.ChatMessage
+	9,  // 4: inference.v2.ApplyTemplateResponse.metadata:type_name -> inference.v2.ResponseMetadata
+	8,  // 5: inference.v2.CountTokensRequest.messages:type_name -> inference.v2.ChatMessage
+	9,  // 6: inference.v2.CountTokensResponse.metadata:type_name -> inference.v2.ResponseMetadata
+	0,  // 7: inference.v2.TokenizerService.Tokenize:input_type -> inference.v2.TokenizeRequest
+	2,  // 8: inference.v2.TokenizerService.Detokenize:input_type -> inference.v2.DetokenizeRequest
+	4,  // 9: inference.v2.TokenizerService.ApplyTemplate:input_type -> inference.v2.ApplyTemplateRequest
+	6,  // 10: inference.v2.TokenizerService.CountTokens:input_type -> inference.v2.CountTokensRequest
+	1,  // 11: inference.v2.TokenizerService.Tokenize:output_type -> inference.v2.TokenizeResponse
+	3,  // 12: inference.v2.TokenizerService.Detokenize:output_type -> inference.v2.DetokenizeResponse
+	5,  // 13: inference.v2.TokenizerService.ApplyTemplate:output_type -> inference.v2.ApplyTemplateResponse
+	7,  // 14: inference.v2.TokenizerService.CountTokens:output_type -> inference.v2.CountTokensResponse
+	11, // [11:15] is the sub-list for method output_type
+	7,  // [7:11] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_v2_tokenizer_proto_init() }
+func file_v2_tokenizer_proto_init() {
+	if File_v2_tokenizer_proto != nil {
+		return
+	}
+	file_v2_chat_proto_init()
+	file_v2_common_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_v2_tokenizer_proto_rawDesc), len(file_v2_tokenizer_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_v2_tokenizer_proto_goTypes,
+		DependencyIndexes: file_v2_tokenizer_proto_depIdxs,
+		MessageInfos:      file_v2_tokenizer_proto_msgTypes,
+	}.Build()
+	File_v2_tokenizer_proto = out.File
+	file_v2_tokenizer_proto_goTypes = nil
+	file_v2_tokenizer_proto_depIdxs = nil
+}
@@ -0,0 +1,178 @@
+package relic
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// DefaultRetryPolicy is the retry policy of a Client unless WithRetryPolicy is
+// given.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 250 * time.Millisecond,
+	MaxBackoff:     5 * time.Second,
+	Multiplier:     2,
+}
+
+// RetryPolicy configures how the calls that take WithRetries are retried when the
+// server is unavailable or overloaded, i.e. on the gRPC codes UNAVAILABLE and
+// RESOURCE_EXHAUSTED and the HTTP statuses 429, 502, 503 and 504.
+//
+// A call that failed may still have run, e.g. a generation whose backend
+// crashed after calling a tool, so retrying it is up to the caller. Streams are
+// retried until their first message, never once it was received.
+type RetryPolicy struct {
+	MaxAttempts    int           // Including the first one, 1 disables retries
+	InitialBackoff time.Duration // Before the first retry
+	MaxBackoff     time.Duration // Cap of the backoff, and of the wait the server asks for
+	Multiplier     float64       // Growth of the backoff after each retry
+}
+
+// WithRetryPolicy sets the retry policy of the calls.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(o *clientOptions) {
+		o.retryPolicy = policy
+	}
+}
+
+// WithoutRetries disables retries, every call is attempted once, even with
+// WithRetries.
+func WithoutRetries() ClientOption {
+	return WithRetryPolicy(RetryPolicy{MaxAttempts: 1})
+}
+
+// WithRetries retries a call by the retry policy of the client while the server
+// is unavailable or overloaded. Calls are attempted once otherwise.
+func WithRetries() Option {
+	return func(c *Config) {
+		c.Retries = true
+	}
+}
+
+// backoff returns the wait before a retry, growing exponentially with the
+// attempts so far, with jitter so that clients failing together do not retry
+// together.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := float64(p.InitialBackoff) * math.Pow(max(p.Multiplier, 1), float64(attempt-1))
+	if p.MaxBackoff > 0 {
+		d = min(d, float64(p.MaxBackoff))
+	}
+
+	// Equal jitter: at least half the backoff.
+	return time.Duration(d/2 + rand.Float64()*d/2)
+}
+
+// retry runs a call until it succeeds, fails with an error that is not
+// retryable, or runs out of attempts. Calls that do not take retries are
+// attempted once. It returns the classified error of the last attempt.
+func (c *Client) retry(ctx context.Context, retries bool, call func(context.Context) error) error {
+	for attempt := 1; ; attempt++ {
+		err := classify(call(ctx))
+
+		var callErr *callError
+		if err == nil || !retries || !errors.As(err, &callErr) || !callErr.retryable || attempt >= c.retryPolicy.MaxAttempts {
+			return err
+		}
+
+		wait := c.retryPolicy.backoff(attempt)
+		if callErr.retryAfter > 0 {
+			if c.retryPolicy.MaxBackoff > 0 && callErr.retryAfter > c.retryPolicy.MaxBackoff {
+				// E.g. a daily quota, not worth waiting for.
+				return err
+			}
+			wait = max(wait, callErr.retryAfter)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// call runs a unary call bounded by a timeout, retried by the retry policy if
+// it takes retries.
+func call[T any](ctx context.Context, c *Client, timeout time.Duration, retries bool, fn func(context.Context) (T, error)) (T, error) {
+	ctx, cancel := withTimeout(ctx, timeout)
+	defer cancel()
+
+	var resp T
+	err := c.retry(ctx, retries, func(ctx context.Context) error {
+		var err error
+		resp, err = fn(ctx)
+		return err
+	})
+
+	return resp, err
+}
+
+// withTimeout bounds a context by a timeout, unless it is 0.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// receiver receives the messages of a server stream. Recv returns io.EOF after
+// the last message.
+type receiver[T any] interface {
+	Recv() (T, error)
+}
+
+// openedStream is a stream whose first message was received when it was opened.
+type openedStream[T any] struct {
+	receiver[T]
+	first T
+	err   error // Of the first Recv, io.EOF for empty streams
+	read  bool
+}
+
+// Recv implements receiver.
+func (s *openedStream[T]) Recv() (T, error) {
+	if !s.read {
+		s.read = true
+		return s.first, s.err
+	}
+	if s.err != nil {
+		var zero T
+		return zero, s.err
+	}
+
+	msg, err := s.receiver.Recv()
+	return msg, classify(err)
+}
+
+// openStream opens a stream and receives its first message, retrying by the
+// retry policy if it takes retries, since gRPC only reports that a stream
+// failed to open on its first Recv.
+func openStream[T any](ctx context.Context, c *Client, retries bool, open func(context.Context) (receiver[T], error)) (receiver[T], error) {
+	var opened *openedStream[T]
+	err := c.retry(ctx, retries, func(ctx context.Context) error {
+		stream, err := open(ctx)
+		if err != nil {
+			return err
+		}
+
+		first, err := stream.Recv()
+		if err != nil && err != io.EOF {
+			return err
+		}
+		opened = &openedStream[T]{receiver: stream, first: first, err: err}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return opened, nil
+}
@@ -0,0 +1,216 @@
+package relic
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	inferencev2 "github.com/ju4n97/relic/sdk-go/pb/inference/v2"
+)
+
+// fastRetries retries at once, so that tests do not wait.
+var fastRetries = RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond, Multiplier: 2}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2}
+
+	for attempt, want := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		want *= time.Millisecond
+
+		seen := map[time.Duration]bool{}
+		for range 50 {
+			wait := policy.backoff(attempt + 1)
+			assert.GreaterOrEqual(t, wait, want/2, "attempt %d", attempt+1)
+			assert.LessOrEqual(t, wait, want, "attempt %d", attempt+1)
+			seen[wait] = true
+		}
+		assert.Greater(t, len(seen), 1, "attempt %d is jittered", attempt+1)
+	}
+}
+
+func TestClient_Retry_GRPC(t *testing.T) {
+	tests := []struct {
+		code     codes.Code
+		attempts int
+	}{
+		{codes.Unavailable, 3},
+		{codes.ResourceExhausted, 3},
+		{codes.Unknown, 1},
+		{codes.Internal, 1},
+		{codes.InvalidArgument, 1},
+		{codes.NotFound, 1},
+		{codes.DeadlineExceeded, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code.String(), func(t *testing.T) {
+			chat := &fakeChat{chat: func(int, *inferencev2.ChatRequest) (*inferencev2.ChatResponse, error) {
+				return nil, status.Error(tt.code, "failed")
+			}}
+			client := newGRPCClient(t, fastRetries, chat)
+
+			_, err := client.Generate(context.Background(), hello, WithRetries())
+			assert.Equal(t, tt.code, status.Code(err))
+			assert.EqualValues(t, tt.attempts, chat.calls.Load())
+		})
+	}
+}
+
+func TestClient_Retry_OptIn(t *testing.T) {
+	chat := &fakeChat{chat: func(int, *inferencev2.ChatRequest) (*inferencev2.ChatResponse, error) {
+		return nil, status.Error(codes.Unavailable, "loading")
+	}}
+	client := newGRPCClient(t, fastRetries, chat)
+
+	_, err := client.Generate(context.Background(), hello)
+	assert.ErrorIs(t, err, ErrBackendUnavailable)
+	assert.EqualValues(t, 1, chat.calls.Load(), "calls without WithRetries are attempted once")
+}
+
+func TestClient_Retry_Recovers(t *testing.T) {
+	chat := &fakeChat{chat: func(attempt int, _ *inferencev2.ChatRequest) (*inferencev2.ChatResponse, error) {
+		if attempt < 3 {
+			return nil, status.Error(codes.Unavailable, "loading")
+		}
+		return &inferencev2.ChatResponse{Message: &inferencev2.ChatMessage{Content: "Hello!"}}, nil
+	}}
+	client := newGRPCClient(t, fastRetries, chat)
+
+	text, err := client.Generate(context.Background(), hello, WithRetries())
+	require.NoError(t, err)
+	assert.Equal(t, "Hello!", text)
+	assert.EqualValues(t, 3, chat.calls.Load())
+}
+
+func TestClient_Retry_RetryInfo(t *testing.T) {
+	overloaded := func(delay time.Duration) error {
+		st, err := status.New(codes.ResourceExhausted, "slow down").WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)})
+		require.NoError(t, err)
+		return st.Err()
+	}
+
+	t.Run("waits as asked", func(t *testing.T) {
+		chat := &fakeChat{chat: func(attempt int, _ *inferencev2.ChatRequest) (*inferencev2.ChatResponse, error) {
+			if attempt == 1 {
+				return nil, overloaded(50 * time.Millisecond)
+			}
+			return &inferencev2.ChatResponse{}, nil
+		}}
+		client := newGRPCClient(t, RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Second}, chat)
+
+		start := time.Now()
+		_, err := client.Generate(context.Background(), hello, WithRetries())
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	})
+
+	t.Run("fails past the maximum backoff", func(t *testing.T) {
+		chat := &fakeChat{chat: func(int, *inferencev2.ChatRequest) (*inferencev2.ChatResponse, error) {
+			return nil, overloaded(time.Hour)
+		}}
+		client := newGRPCClient(t, fastRetries, chat)
+
+		_, err := client.Generate(context.Background(), hello, WithRetries())
+		assert.ErrorIs(t, err, ErrOverloaded)
+		assert.EqualValues(t, 1, chat.calls.Load())
+	})
+}
+
+func TestClient_Retry_Canceled(t *testing.T) {
+	chat := &fakeChat{chat: func(int, *inferencev2.ChatRequest) (*inferencev2.ChatResponse, error) {
+		return nil, status.Error(codes.Unavailable, "loading")
+	}}
+	client := newGRPCClient(t, RetryPolicy{MaxAttempts: 10, InitialBackoff: time.Hour, MaxBackoff: time.Hour, Multiplier: 1}, chat)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := client.Generate(ctx, hello, WithRetries())
+	assert.ErrorIs(t, err, ErrBackendUnavailable)
+	assert.EqualValues(t, 1, chat.calls.Load(), "the wait ends with the context")
+}
+
+func TestClient_Retry_Stream(t *testing.T) {
+	t.Run("until the first message", func(t *testing.T) {
+		chat := &fakeChat{chatStream: func(attempt int, stream inferencev2.ChatService_ChatStreamServer) error {
+			if attempt == 1 {
+				return status.Error(codes.Unavailable, "loading")
+			}
+			return stream.Send(&inferencev2.ChatChunk{Delta: "Hi", Done: true})
+		}}
+		client := newGRPCClient(t, fastRetries, chat)
+
+		var text string
+		for chunk := range client.GenerateStream(context.Background(), hello, WithRetries()) {
+			require.NoError(t, chunk.Error)
+			text += chunk.Content
+		}
+		assert.Equal(t, "Hi", text)
+		assert.EqualValues(t, 2, chat.calls.Load())
+	})
+
+	t.Run("never after it", func(t *testing.T) {
+		chat := &fakeChat{chatStream: func(_ int, stream inferencev2.ChatService_ChatStreamServer) error {
+			if err := stream.Send(&inferencev2.ChatChunk{Delta: "Hel"}); err != nil {
+				return err
+			}
+			return status.Error(codes.Unavailable, "crashed")
+		}}
+		client := newGRPCClient(t, fastRetries, chat)
+
+		var err error
+		for chunk := range client.GenerateStream(context.Background(), hello, WithRetries()) {
+			if chunk.Error != nil {
+				err = chunk.Error
+			}
+		}
+		assert.ErrorIs(t, err, ErrBackendUnavailable)
+		assert.EqualValues(t, 1, chat.calls.Load())
+	})
+}
+
+func TestClient_Retry_HTTP(t *testing.T) {
+	tests := []struct {
+		status   int
+		attempts int
+	}{
+		{http.StatusTooManyRequests, 3},
+		{http.StatusBadGateway, 3},
+		{http.StatusServiceUnavailable, 3},
+		{http.StatusGatewayTimeout, 3},
+		{http.StatusInternalServerError, 1},
+		{http.StatusBadRequest, 1},
+		{http.StatusNotFound, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				calls.Add(1)
+				w.WriteHeader(tt.status)
+				_, _ = io.WriteString(w, `{"title": "failed"}`)
+			}))
+			defer server.Close()
+
+			client, err := NewClient(server.URL, WithRetryPolicy(fastRetries))
+			require.NoError(t, err)
+			defer client.Close()
+
+			_, err = client.Generate(context.Background(), hello, WithRetries())
+			assert.Error(t, err)
+			assert.EqualValues(t, tt.attempts, calls.Load())
+		})
+	}
+}
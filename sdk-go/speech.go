@@ -0,0 +1,139 @@
+package relic
+
+import (
+	"bytes"
+	"encoding/binary"
+	"time"
+
+	inferencev2 "github.com/ju4n97/relic/sdk-go/pb/inference/v2"
+)
+
+// Transcript is the transcription of audio.
+type Transcript struct {
+	Text     string
+	Language string // Requested or detected language
+	Duration time.Duration
+	Segments []Segment
+	Words    []Word // Words of all segments, when the backend reports word timestamps
+}
+
+// Segment is a timed segment of a transcript.
+type Segment struct {
+	Text  string
+	Start time.Duration
+	End   time.Duration
+	Words []Word
+}
+
+// Word is a timed word of a transcript.
+type Word struct {
+	Text        string
+	Start       time.Duration
+	End         time.Duration
+	Probability float64
+}
+
+// Speech is synthesized audio.
+type Speech struct {
+	Audio      []byte
+	Format     string // Encoding of the audio, "wav"
+	SampleRate int    // Samples per second, 0 if the header does not tell
+	Duration   time.Duration
+}
+
+// buildTranscript converts a transcription from protobuf.
+func buildTranscript(resp *inferencev2.TranscribeResponse) *Transcript {
+	transcript := &Transcript{
+		Text:     resp.Text,
+		Language: resp.Language,
+		Duration: seconds(resp.DurationSeconds),
+		Segments: make([]Segment, len(resp.Segments)),
+	}
+	for i, segment := range resp.Segments {
+		transcript.Segments[i] = Segment{
+			Text:  segment.Text,
+			Start: seconds(segment.StartSeconds),
+			End:   seconds(segment.EndSeconds),
+		}
+		for _, word := range segment.Words {
+			w := Word{
+				Text:        word.Word,
+				Start:       seconds(word.StartSeconds),
+				End:         seconds(word.EndSeconds),
+				Probability: word.Probability,
+			}
+			transcript.Segments[i].Words = append(transcript.Segments[i].Words, w)
+			transcript.Words = append(transcript.Words, w)
+		}
+	}
+
+	return transcript
+}
+
+// buildSpeech converts synthesized speech from protobuf. The sample rate, and
+// the duration if the server does not report it, are read from the WAV header.
+func buildSpeech(resp *inferencev2.SynthesizeResponse) *Speech {
+	speech := &Speech{
+		Audio:    resp.Audio,
+		Format:   "wav",
+		Duration: seconds(resp.DurationSeconds),
+	}
+
+	header, ok := parseWAVHeader(resp.Audio)
+	if !ok {
+		return speech
+	}
+	speech.SampleRate = header.sampleRate
+	if speech.Duration == 0 && header.byteRate > 0 {
+		speech.Duration = seconds(float64(header.dataSize) / float64(header.byteRate))
+	}
+
+	return speech
+}
+
+// wavHeader is the part of a WAV header that describes the length of the audio.
+type wavHeader struct {
+	sampleRate int
+	byteRate   int
+	dataSize   int
+}
+
+// parseWAVHeader reads the fmt and data chunks of a WAV file. A data size that
+// overruns the file, e.g. one left unset by a streaming encoder, is replaced by
+// the size of the rest of the file.
+func parseWAVHeader(data []byte) (wavHeader, bool) {
+	var header wavHeader
+	if len(data) < 12 || !bytes.Equal(data[0:4], []byte("RIFF")) || !bytes.Equal(data[8:12], []byte("WAVE")) {
+		return header, false
+	}
+
+	foundFmt := false
+	for offset := 12; offset+8 <= len(data); {
+		id := string(data[offset : offset+4])
+		size := int(binary.LittleEndian.Uint32(data[offset+4 : offset+8]))
+		body := offset + 8
+
+		switch id {
+		case "fmt ":
+			if body+12 > len(data) {
+				return header, false
+			}
+			header.sampleRate = int(binary.LittleEndian.Uint32(data[body+4 : body+8]))
+			header.byteRate = int(binary.LittleEndian.Uint32(data[body+8 : body+12]))
+			foundFmt = true
+		case "data":
+			header.dataSize = min(size, len(data)-body)
+			return header, foundFmt
+		}
+
+		// Chunks are padded to an even size.
+		offset = body + size + size%2
+	}
+
+	return header, false
+}
+
+// seconds converts a number of seconds to a duration.
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
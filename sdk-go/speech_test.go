@@ -0,0 +1,109 @@
+package relic
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	inferencev2 "github.com/ju4n97/relic/sdk-go/pb/inference/v2"
+)
+
+// wav returns a WAV file of 16-bit mono samples at a sample rate, with a chunk
+// of the given ID and size before the data chunk, and a data chunk announcing
+// dataSize bytes but holding n.
+func wav(sampleRate int, extraID string, extraSize, dataSize, n int) []byte {
+	var b bytes.Buffer
+	le := func(v any) { _ = binary.Write(&b, binary.LittleEndian, v) }
+
+	b.WriteString("RIFF")
+	le(uint32(0))
+	b.WriteString("WAVE")
+
+	b.WriteString("fmt ")
+	le(uint32(16))
+	le(uint16(1))              // PCM
+	le(uint16(1))              // Channels
+	le(uint32(sampleRate))     // Sample rate
+	le(uint32(sampleRate * 2)) // Byte rate
+	le(uint16(2))              // Block align
+	le(uint16(16))             // Bits per sample
+
+	if extraID != "" {
+		b.WriteString(extraID)
+		le(uint32(extraSize))
+		b.Write(make([]byte, extraSize+extraSize%2))
+	}
+
+	b.WriteString("data")
+	le(uint32(dataSize))
+	b.Write(make([]byte, n))
+
+	return b.Bytes()
+}
+
+func TestBuildTranscript(t *testing.T) {
+	transcript := buildTranscript(&inferencev2.TranscribeResponse{
+		Text:            "Hola mundo. Adiós.",
+		Language:        "es",
+		DurationSeconds: 4,
+		Segments: []*inferencev2.Segment{
+			{
+				StartSeconds: 0.1, EndSeconds: 2.4, Text: "Hola mundo.",
+				Words: []*inferencev2.Word{
+					{Word: "Hola", StartSeconds: 0.1, EndSeconds: 0.9, Probability: 0.98},
+					{Word: "mundo.", StartSeconds: 1, EndSeconds: 2.4, Probability: 0.95},
+				},
+			},
+			{StartSeconds: 2.5, EndSeconds: 3.5, Text: "Adiós."},
+		},
+	})
+
+	assert.Equal(t, "Hola mundo. Adiós.", transcript.Text)
+	assert.Equal(t, "es", transcript.Language)
+	assert.Equal(t, 4*time.Second, transcript.Duration)
+
+	require.Len(t, transcript.Segments, 2)
+	assert.Equal(t, Segment{
+		Text:  "Hola mundo.",
+		Start: 100 * time.Millisecond,
+		End:   2400 * time.Millisecond,
+		Words: []Word{
+			{Text: "Hola", Start: 100 * time.Millisecond, End: 900 * time.Millisecond, Probability: 0.98},
+			{Text: "mundo.", Start: time.Second, End: 2400 * time.Millisecond, Probability: 0.95},
+		},
+	}, transcript.Segments[0])
+	assert.Empty(t, transcript.Segments[1].Words)
+	assert.Equal(t, transcript.Segments[0].Words, transcript.Words, "words of all segments")
+}
+
+func TestBuildSpeech(t *testing.T) {
+	tests := []struct {
+		name       string
+		audio      []byte
+		duration   float64 // Reported by the server
+		sampleRate int
+		want       time.Duration
+	}{
+		{"reported duration", wav(22050, "", 0, 44100, 44100), 1.5, 22050, 1500 * time.Millisecond},
+		{"duration from the header", wav(22050, "", 0, 44100, 44100), 0, 22050, time.Second},
+		{"chunk before the data", wav(16000, "LIST", 5, 16000, 16000), 0, 16000, 500 * time.Millisecond},
+		{"data size left unset", wav(16000, "", 0, 0xFFFFFFFF, 8000), 0, 16000, 250 * time.Millisecond},
+		{"not wav", []byte("ID3 not a wav file"), 0, 0, 0},
+		{"truncated header", wav(16000, "", 0, 0, 0)[:30], 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			speech := buildSpeech(&inferencev2.SynthesizeResponse{Audio: tt.audio, DurationSeconds: tt.duration})
+
+			assert.Equal(t, tt.audio, speech.Audio)
+			assert.Equal(t, "wav", speech.Format)
+			assert.Equal(t, tt.sampleRate, speech.SampleRate)
+			assert.Equal(t, tt.want, speech.Duration)
+		})
+	}
+}
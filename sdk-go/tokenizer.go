@@ -0,0 +1,183 @@
+package relic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	inferencev2 "github.com/ju4n97/relic/sdk-go/pb/inference/v2"
+)
+
+// Tokens is the tokenization of a text or a conversation.
+type Tokens struct {
+	IDs           []int
+	Pieces        []string // Text of each token, with WithTokenPieces
+	Prompt        string   // Text tokenized, the chat template applied for messages
+	ContextLength int      // Tokens a request to the model can hold, prompt and reply
+}
+
+// Tokenize tokenizes text with the tokenizer of an LLM model.
+//
+// Example:
+//
+//	tokens, err := client.Tokenize(ctx, "Hello, world!", relic.WithTokenPieces())
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	fmt.Println(tokens.IDs, tokens.Pieces)
+func (c *Client) Tokenize(ctx context.Context, text string, options ...Option) (*Tokens, error) {
+	return c.tokenize(ctx, text, nil, options)
+}
+
+// TokenizeMessages tokenizes a conversation formatted with the chat template of
+// an LLM model, as the model is given it.
+func (c *Client) TokenizeMessages(ctx context.Context, messages []Message, options ...Option) (*Tokens, error) {
+	if len(messages) == 0 {
+		return nil, errors.New("relic: messages cannot be empty")
+	}
+
+	return c.tokenize(ctx, "", messages, options)
+}
+
+// tokenize tokenizes text or messages.
+func (c *Client) tokenize(ctx context.Context, text string, messages []Message, options []Option) (*Tokens, error) {
+	cfg := c.applyOptions(options...)
+
+	req, err := buildTokenizeRequest(text, messages, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("relic: failed to build tokenize request: %w", err)
+	}
+
+	resp, err := call(ctx, c, cfg.Timeout, cfg.Retries, func(ctx context.Context) (*inferencev2.TokenizeResponse, error) {
+		return c.transport.tokenize(ctx, req)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("relic: failed to tokenize: %w", err)
+	}
+
+	tokens := &Tokens{
+		IDs:           make([]int, len(resp.Tokens)),
+		Pieces:        resp.Pieces,
+		Prompt:        resp.Prompt,
+		ContextLength: int(resp.ContextLength),
+	}
+	for i, id := range resp.Tokens {
+		tokens.IDs[i] = int(id)
+	}
+
+	return tokens, nil
+}
+
+// Detokenize converts tokens of an LLM model back to text.
+func (c *Client) Detokenize(ctx context.Context, tokens []int, options ...Option) (string, error) {
+	cfg := c.applyOptions(options...)
+
+	if len(cfg.Parameters) > 0 {
+		return "", errors.New("relic: detokenization takes no parameters")
+	}
+
+	req := &inferencev2.DetokenizeRequest{
+		ModelId: cfg.ModelID,
+		Profile: cfg.Profile,
+		Tokens:  make([]int32, len(tokens)),
+	}
+	for i, id := range tokens {
+		req.Tokens[i] = int32(id)
+	}
+
+	resp, err := call(ctx, c, cfg.Timeout, cfg.Retries, func(ctx context.Context) (*inferencev2.DetokenizeResponse, error) {
+		return c.transport.detokenize(ctx, req)
+	})
+	if err != nil {
+		return "", fmt.Errorf("relic: failed to detokenize: %w", err)
+	}
+
+	return resp.Text, nil
+}
+
+// ApplyTemplate formats a conversation with the chat template of an LLM model
+// and returns the prompt the model is given for it. Parameters are ignored, so
+// that the options of a chat can be passed as they are.
+func (c *Client) ApplyTemplate(ctx context.Context, messages []Message, options ...Option) (string, error) {
+	cfg := c.applyOptions(options...)
+
+	if len(messages) == 0 {
+		return "", errors.New("relic: messages cannot be empty")
+	}
+
+	chatMessages, err := buildChatMessages(messages)
+	if err != nil {
+		return "", fmt.Errorf("relic: failed to build apply template request: %w", err)
+	}
+
+	req := &inferencev2.ApplyTemplateRequest{
+		ModelId:  cfg.ModelID,
+		Profile:  cfg.Profile,
+		Messages: chatMessages,
+	}
+	resp, err := call(ctx, c, cfg.Timeout, cfg.Retries, func(ctx context.Context) (*inferencev2.ApplyTemplateResponse, error) {
+		return c.transport.applyTemplate(ctx, req)
+	})
+	if err != nil {
+		return "", fmt.Errorf("relic: failed to apply chat template: %w", err)
+	}
+
+	return resp.Prompt, nil
+}
+
+// CountTokens counts the tokens of a conversation with the chat template of an
+// LLM model applied. Parameters are ignored, so that the options of a chat can
+// be passed as they are. It implements TokenCounter, the one of conversations
+// unless WithTokenCounter is given.
+func (c *Client) CountTokens(ctx context.Context, messages []Message, options ...Option) (int, error) {
+	resp, err := c.countTokens(ctx, messages, options)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(resp.Tokens), nil
+}
+
+// ContextLength returns the tokens a request to an LLM model can hold, prompt
+// and reply, as the model is served with the profile of the request.
+// Conversations read it on their first turn unless WithContextWindow is given.
+//
+// Example:
+//
+//	n, err := client.ContextLength(ctx, relic.WithModelID("qwen"))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+func (c *Client) ContextLength(ctx context.Context, options ...Option) (int, error) {
+	resp, err := c.countTokens(ctx, nil, options)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(resp.ContextLength), nil
+}
+
+// countTokens counts the tokens of messages, none to only read the context length.
+func (c *Client) countTokens(ctx context.Context, messages []Message, options []Option) (*inferencev2.CountTokensResponse, error) {
+	cfg := c.applyOptions(options...)
+
+	chatMessages, err := buildChatMessages(messages)
+	if err != nil {
+		return nil, fmt.Errorf("relic: failed to build count tokens request: %w", err)
+	}
+
+	req := &inferencev2.CountTokensRequest{
+		ModelId:  cfg.ModelID,
+		Profile:  cfg.Profile,
+		Messages: chatMessages,
+	}
+	resp, err := call(ctx, c, cfg.Timeout, cfg.Retries, func(ctx context.Context) (*inferencev2.CountTokensResponse, error) {
+		return c.transport.countTokens(ctx, req)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("relic: failed to count tokens: %w", err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,26 @@
+package relic
+
+import (
+	"context"
+
+	inferencev2 "github.com/ju4n97/relic/sdk-go/pb/inference/v2"
+)
+
+// transport carries the calls of a Client to the server, over gRPC or over the
+// HTTP API. Both speak the inference.v2 messages, so that requests are built and
+// responses read the same way whichever transport is used.
+type transport interface {
+	chat(ctx context.Context, req *inferencev2.ChatRequest) (*inferencev2.ChatResponse, error)
+	chatStream(ctx context.Context, req *inferencev2.ChatRequest) (receiver[*inferencev2.ChatChunk], error)
+	transcribe(ctx context.Context, req *inferencev2.TranscribeRequest) (*inferencev2.TranscribeResponse, error)
+	synthesize(ctx context.Context, req *inferencev2.SynthesizeRequest) (*inferencev2.SynthesizeResponse, error)
+	embed(ctx context.Context, req *inferencev2.EmbedRequest) (*inferencev2.EmbedResponse, error)
+	tokenize(ctx context.Context, req *inferencev2.TokenizeRequest) (*inferencev2.TokenizeResponse, error)
+	detokenize(ctx context.Context, req *inferencev2.DetokenizeRequest) (*inferencev2.DetokenizeResponse, error)
+	applyTemplate(ctx context.Context, req *inferencev2.ApplyTemplateRequest) (*inferencev2.ApplyTemplateResponse, error)
+	countTokens(ctx context.Context, req *inferencev2.CountTokensRequest) (*inferencev2.CountTokensResponse, error)
+	listModels(ctx context.Context) (*inferencev2.ListModelsResponse, error)
+	pullModel(ctx context.Context, req *inferencev2.PullModelRequest) (receiver[*inferencev2.PullProgress], error)
+	removeModel(ctx context.Context, req *inferencev2.RemoveModelRequest) (*inferencev2.RemoveModelResponse, error)
+	close() error
+}